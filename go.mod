@@ -4,11 +4,18 @@ go 1.17
 
 require (
 	decred.org/dcrdex v0.4.3
+	decred.org/dcrwallet/v2 v2.0.2-0.20220505152146-ece5da349895
 	gioui.org v0.0.0-20220601100144-a896a467ecae
 	github.com/JohannesKaufmann/html-to-markdown v1.2.1
 	github.com/PuerkitoBio/goquery v1.6.1
 	github.com/ararog/timeago v0.0.0-20160328174124-e9969cf18b8d
+	github.com/decred/dcrd/blockchain/stake/v4 v4.0.0
+	github.com/decred/dcrd/blockchain/standalone/v2 v2.1.0
+	github.com/decred/dcrd/chaincfg/chainhash v1.0.3
+	github.com/decred/dcrd/chaincfg/v3 v3.1.1
 	github.com/decred/dcrd/dcrutil/v4 v4.0.0
+	github.com/decred/dcrd/wire v1.5.0
+	github.com/decred/politeia v1.3.1
 	github.com/decred/slog v1.2.0
 	github.com/gen2brain/beeep v0.0.0-20220402123239-6a3042f4b71a
 	github.com/gomarkdown/markdown v0.0.0-20220817224203-2206187d3406
@@ -20,13 +27,13 @@ require (
 	github.com/yeqown/go-qrcode v1.5.1
 	golang.org/x/exp v0.0.0-20210722180016-6781d3edade3
 	golang.org/x/image v0.0.0-20210628002857-a66eb6448b8d
+	golang.org/x/net v0.0.0-20220425223048-2871e0cb64e4
 	golang.org/x/text v0.3.7
 )
 
 require (
 	decred.org/cspp/v2 v2.0.0 // indirect
 	decred.org/dcrwallet v1.7.0 // indirect
-	decred.org/dcrwallet/v2 v2.0.2-0.20220505152146-ece5da349895 // indirect
 	gioui.org/cpu v0.0.0-20210817075930-8d6a761490d2 // indirect
 	gioui.org/shader v1.0.6 // indirect
 	github.com/AndreasBriese/bbloom v0.0.0-20190825152654-46b345b51c96 // indirect
@@ -54,12 +61,8 @@ require (
 	github.com/decred/base58 v1.0.4 // indirect
 	github.com/decred/dcrd/addrmgr/v2 v2.0.0 // indirect
 	github.com/decred/dcrd/blockchain/stake/v3 v3.0.0 // indirect
-	github.com/decred/dcrd/blockchain/stake/v4 v4.0.0 // indirect
-	github.com/decred/dcrd/blockchain/standalone/v2 v2.1.0 // indirect
 	github.com/decred/dcrd/blockchain/v4 v4.0.0 // indirect
 	github.com/decred/dcrd/certgen v1.1.1 // indirect
-	github.com/decred/dcrd/chaincfg/chainhash v1.0.3 // indirect
-	github.com/decred/dcrd/chaincfg/v3 v3.1.1 // indirect
 	github.com/decred/dcrd/connmgr/v3 v3.1.0 // indirect
 	github.com/decred/dcrd/crypto/blake256 v1.0.1-0.20200921185235-6d75c7ec1199 // indirect
 	github.com/decred/dcrd/crypto/ripemd160 v1.0.1 // indirect
@@ -79,11 +82,9 @@ require (
 	github.com/decred/dcrd/rpcclient/v7 v7.0.0 // indirect
 	github.com/decred/dcrd/txscript/v3 v3.0.0 // indirect
 	github.com/decred/dcrd/txscript/v4 v4.0.0 // indirect
-	github.com/decred/dcrd/wire v1.5.0 // indirect
 	github.com/decred/dcrdata/v7 v7.0.0-20211216152310-365c9dc820eb // indirect
 	github.com/decred/dcrtime v0.0.0-20191018193024-8d8b4ef0458e // indirect
 	github.com/decred/go-socks v1.1.0 // indirect
-	github.com/decred/politeia v1.3.1 // indirect
 	github.com/dgraph-io/badger v1.6.2 // indirect
 	github.com/dgraph-io/ristretto v0.0.2 // indirect
 	github.com/dustin/go-humanize v1.0.0 // indirect
@@ -121,7 +122,6 @@ require (
 	github.com/yeqown/reedsolomon v1.0.0 // indirect
 	go.etcd.io/bbolt v1.3.7-0.20220130032806-d5db64bdbfde // indirect
 	golang.org/x/crypto v0.0.0-20220427172511-eb4f295cb31f // indirect
-	golang.org/x/net v0.0.0-20220425223048-2871e0cb64e4 // indirect
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c // indirect
 	golang.org/x/sys v0.0.0-20220503163025-988cb79eb6c6 // indirect
 	golang.org/x/term v0.0.0-20220411215600-e5f449aeb171 // indirect
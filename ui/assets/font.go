@@ -12,6 +12,8 @@ import (
 	"golang.org/x/image/font/gofont/goitalic"
 	"golang.org/x/image/font/gofont/gomedium"
 	"golang.org/x/image/font/gofont/gomediumitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
 	"golang.org/x/image/font/gofont/goregular"
 )
 
@@ -59,6 +61,8 @@ func FontCollection() []text.FontFace {
 		register(text.Font{Style: text.Italic, Weight: text.Bold}, boldItalic)
 		register(text.Font{Weight: text.Medium}, semibold)
 		register(text.Font{Weight: text.Medium, Style: text.Italic}, semiboldItalic)
+		register(text.Font{Variant: "Mono"}, gomono.TTF)
+		register(text.Font{Variant: "Mono", Weight: text.Bold}, gomonobold.TTF)
 		// Ensure that any outside appends will not reuse the backing store.
 		n := len(collection)
 		collection = collection[:n:n]
@@ -206,12 +206,8 @@ func (cm *CreatePasswordModal) Handle() {
 				return
 			}
 
-			if cm.passwordsMatch(cm.passwordEditor.Editor, cm.confirmPasswordEditor.Editor) {
-
-				cm.SetLoading(true)
-				if cm.callback(cm.walletName.Editor.Text(), cm.passwordEditor.Editor.Text(), cm) {
-					cm.Dismiss()
-				}
+			if !cm.passwordsMatch(cm.passwordEditor.Editor, cm.confirmPasswordEditor.Editor) {
+				return
 			}
 		}
 
@@ -0,0 +1,131 @@
+package components
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+// chartRange returns the lowest and highest of the points, including 0 so
+// the charts start at the x axis.
+func chartRange(points []float64) (low, high float64) {
+	for _, p := range points {
+		if p < low {
+			low = p
+		}
+		if p > high {
+			high = p
+		}
+	}
+	if high == low {
+		high = low + 1
+	}
+	return low, high
+}
+
+// LineChart draws the points as a line across the width of the constraints,
+// with the x axis at the bottom.
+func LineChart(gtx C, l *load.Load, points []float64, height unit.Dp, col color.NRGBA) D {
+	size := image.Point{X: gtx.Constraints.Max.X, Y: gtx.Dp(height)}
+
+	axis := image.Rect(0, size.Y-gtx.Dp(values.MarginPadding1), size.X, size.Y)
+	paint.FillShape(gtx.Ops, l.Theme.Color.Gray2, clip.Rect(axis).Op())
+	if len(points) < 2 {
+		return D{Size: size}
+	}
+
+	low, high := chartRange(points)
+	step := float32(size.X) / float32(len(points)-1)
+	pointAt := func(i int) f32.Point {
+		y := float32((points[i] - low) / (high - low))
+		return f32.Pt(float32(i)*step, float32(size.Y)*(1-y))
+	}
+
+	var path clip.Path
+	path.Begin(gtx.Ops)
+	path.MoveTo(pointAt(0))
+	for i := 1; i < len(points); i++ {
+		path.LineTo(pointAt(i))
+	}
+	paint.FillShape(gtx.Ops, col, clip.Stroke{
+		Path:  path.End(),
+		Width: float32(gtx.Dp(values.MarginPadding2)),
+	}.Op())
+
+	return D{Size: size}
+}
+
+// BarChart draws a bar for each point across the width of the constraints,
+// with the x axis at the bottom.
+func BarChart(gtx C, l *load.Load, points []float64, height unit.Dp, col color.NRGBA) D {
+	size := image.Point{X: gtx.Constraints.Max.X, Y: gtx.Dp(height)}
+
+	axis := image.Rect(0, size.Y-gtx.Dp(values.MarginPadding1), size.X, size.Y)
+	paint.FillShape(gtx.Ops, l.Theme.Color.Gray2, clip.Rect(axis).Op())
+	if len(points) == 0 {
+		return D{Size: size}
+	}
+
+	_, high := chartRange(points)
+	slot := size.X / len(points)
+	gap := slot / 4
+	for i, p := range points {
+		if p <= 0 {
+			continue
+		}
+		top := size.Y - int(float64(size.Y)*p/high)
+		bar := image.Rect(i*slot+gap/2, top, (i+1)*slot-gap/2, size.Y)
+		paint.FillShape(gtx.Ops, col, clip.Rect(bar).Op())
+	}
+
+	return D{Size: size}
+}
+
+// FractionBar draws a bar split in parts proportional to the fractions,
+// which should add up to at most 1, using the color of each part. The rest
+// of the bar is drawn in gray.
+func FractionBar(gtx C, l *load.Load, fractions []float64, colors []color.NRGBA) D {
+	size := image.Point{X: gtx.Constraints.Max.X, Y: gtx.Dp(values.MarginPadding8)}
+	r := gtx.Dp(values.MarginPadding4)
+
+	defer clip.UniformRRect(image.Rectangle{Max: size}, r).Push(gtx.Ops).Pop()
+	paint.FillShape(gtx.Ops, l.Theme.Color.Gray2, clip.Rect{Max: size}.Op())
+
+	x := 0
+	for i, fraction := range fractions {
+		width := int(float64(size.X) * fraction)
+		if width <= 0 {
+			continue
+		}
+		part := image.Rect(x, 0, x+width, size.Y)
+		paint.FillShape(gtx.Ops, colors[i], clip.Rect(part).Op())
+		x += width
+	}
+
+	return D{Size: size}
+}
+
+// ChartLegend draws a colored dot followed by the label text.
+func ChartLegend(gtx C, l *load.Load, col color.NRGBA, label string) D {
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			size := gtx.Dp(values.MarginPadding8)
+			dot := image.Rectangle{Max: image.Point{X: size, Y: size}}
+			paint.FillShape(gtx.Ops, col, clip.Ellipse(dot).Op(gtx.Ops))
+			return D{Size: dot.Max}
+		}),
+		layout.Rigid(func(gtx C) D {
+			lbl := l.Theme.Caption(label)
+			lbl.Color = l.Theme.Color.GrayText2
+			return layout.Inset{Left: values.MarginPadding4, Right: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+		}),
+	)
+}
@@ -48,38 +48,18 @@ func (ds *DexServerSelector) Expose() {
 	ds.startDexClient()
 }
 
-// isLoadingDexClient check for Dexc start, initialized, loggedin status,
-// since Dex client UI not required for app password, IsInitialized and IsLoggedIn should be done at dcrlibwallet.
+// isLoadingDexClient is true until the DEX client is started. The known
+// servers are listed without logging in, the DEX password is requested by
+// the DEX pages.
 func (ds *DexServerSelector) isLoadingDexClient() bool {
-	return ds.Dexc().Core() == nil || !ds.Dexc().Core().IsInitialized() || !ds.Dexc().IsLoggedIn()
+	return ds.Dexc().Core() == nil
 }
 
-// startDexClient do start DEX client,
-// initialize and login to DEX,
-// since Dex client UI not required for app password, initialize and login should be done at dcrlibwallet.
+// startDexClient starts the DEX client.
 func (ds *DexServerSelector) startDexClient() {
 	_, err := ds.WL.MultiWallet.StartDexClient()
 	if err != nil {
 		ds.Toast.NotifyError(err.Error())
-		return
-	}
-
-	// TODO: move to dcrlibwallet sine bypass Dex password by DEXClientPass
-	if !ds.Dexc().Initialized() {
-		err = ds.Dexc().InitializeWithPassword([]byte(values.DEXClientPass))
-		if err != nil {
-			ds.Toast.NotifyError(err.Error())
-			return
-		}
-	}
-
-	if !ds.Dexc().IsLoggedIn() {
-		err := ds.Dexc().Login([]byte(values.DEXClientPass))
-		if err != nil {
-			// todo fix  dex password error
-			// ds.Toast.NotifyError(err.Error())
-			return
-		}
 	}
 }
 
@@ -0,0 +1,203 @@
+package components
+
+import (
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// OpenLink opens the url in the system browser. Links to domains that are
+// not trusted by the user, and suspicious links, are only opened after the
+// user confirms them.
+func OpenLink(l *load.Load, window app.WindowNavigator, url string) {
+	trusted := wallet.TrustedLinkDomains(l.WL.MultiWallet)
+	info, err := wallet.InspectLink(url, trusted)
+	if err != nil {
+		l.Toast.NotifyError(values.String(values.StrInvalidLink))
+		return
+	}
+
+	if !info.Suspicious() && wallet.IsTrustedLinkHost(trusted, info.Host) {
+		GoToURL(url)
+		return
+	}
+	window.ShowModal(newLinkModal(l, url, info))
+}
+
+// linkModal shows the decoded address of a link and warns about the tricks
+// it may use to disguise where it leads before the link is opened.
+type linkModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	url  string
+	info *wallet.LinkInfo
+
+	trustDomain decredmaterial.CheckBoxStyle
+	btnOpen     decredmaterial.Button
+	btnCopy     decredmaterial.Button
+	btnCancel   decredmaterial.Button
+
+	copyRequested bool
+}
+
+func newLinkModal(l *load.Load, url string, info *wallet.LinkInfo) *linkModal {
+	lm := &linkModal{
+		Load:  l,
+		Modal: l.Theme.ModalFloatTitle("link_modal"),
+		url:   url,
+		info:  info,
+
+		trustDomain: l.Theme.CheckBox(new(widget.Bool), values.StringF(values.StrTrustLinkDomain, info.Host)),
+		btnOpen:     l.Theme.Button(values.String(values.StrOpenLink)),
+		btnCopy:     l.Theme.OutlineButton(values.String(values.StrCopyURL)),
+		btnCancel:   l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	if info.UnsafeScheme {
+		// Links that can't be opened can only be copied.
+		lm.btnCopy = l.Theme.Button(values.String(values.StrCopyURL))
+	} else if info.Suspicious() {
+		lm.btnOpen = l.Theme.DangerButton(values.String(values.StrOpenLink))
+	}
+	lm.btnOpen.Font.Weight = text.Medium
+	lm.btnCopy.Font.Weight = text.Medium
+	lm.btnCancel.Font.Weight = text.Medium
+
+	return lm
+}
+
+func (lm *linkModal) OnResume() {}
+
+func (lm *linkModal) OnDismiss() {}
+
+// warnings returns a description of each trick found in the link.
+func (lm *linkModal) warnings() []string {
+	var warnings []string
+	if lm.info.UnsafeScheme {
+		warnings = append(warnings, values.String(values.StrLinkUnsafeScheme))
+	}
+	if lm.info.UserInfo {
+		warnings = append(warnings, values.StringF(values.StrLinkUserInfo, lm.info.Host))
+	}
+	if lm.info.Punycode || lm.info.NonASCII {
+		warnings = append(warnings, values.String(values.StrLinkInternational))
+	}
+	if lm.info.Lookalike != "" {
+		warnings = append(warnings, values.StringF(values.StrLinkLookalike, lm.info.Lookalike))
+	}
+	return warnings
+}
+
+func (lm *linkModal) Handle() {
+	for lm.btnOpen.Clicked() {
+		if lm.info.UnsafeScheme {
+			continue
+		}
+		if !lm.info.Suspicious() && lm.trustDomain.CheckBox.Value {
+			if err := wallet.TrustLinkDomain(lm.WL.MultiWallet, lm.info.Host); err != nil {
+				log.Errorf("Error trusting link domain: %v", err)
+			}
+		}
+		GoToURL(lm.url)
+		lm.Dismiss()
+	}
+
+	for lm.btnCopy.Clicked() {
+		lm.copyRequested = true
+	}
+
+	for lm.btnCancel.Clicked() {
+		lm.Dismiss()
+	}
+
+	if lm.Modal.BackdropClicked(true) {
+		lm.Dismiss()
+	}
+}
+
+func (lm *linkModal) Layout(gtx C) D {
+	if lm.copyRequested {
+		lm.copyRequested = false
+		clipboard.WriteOp{Text: lm.url}.Add(gtx.Ops)
+		lm.Toast.Notify(values.String(values.StrCopied))
+		lm.Dismiss()
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			title := lm.Theme.H6(values.String(values.StrOpenLinkTitle))
+			title.Font.Weight = text.SemiBold
+			return title.Layout(gtx)
+		},
+		func(gtx C) D {
+			body := lm.Theme.Body1(values.String(values.StrOpenLinkInfo))
+			body.Color = lm.Theme.Color.GrayText2
+			return body.Layout(gtx)
+		},
+		func(gtx C) D {
+			card := lm.Theme.Card()
+			card.Color = lm.Theme.Color.Gray4
+			return card.Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return layout.UniformInset(values.MarginPadding10).Layout(gtx, lm.Theme.Body2(lm.info.URL).Layout)
+			})
+		},
+	}
+
+	for _, warning := range lm.warnings() {
+		warning := warning
+		w = append(w, func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+						return lm.Theme.Icons.ActionInfo.Layout(gtx, lm.Theme.Color.Danger)
+					})
+				}),
+				layout.Flexed(1, func(gtx C) D {
+					lbl := lm.Theme.Body2(warning)
+					lbl.Color = lm.Theme.Color.Danger
+					return lbl.Layout(gtx)
+				}),
+			)
+		})
+	}
+
+	// Suspicious domains can't be trusted.
+	if !lm.info.Suspicious() {
+		w = append(w, func(gtx C) D {
+			lm.trustDomain.TextSize = values.TextSize14
+			lm.trustDomain.Color = lm.Theme.Color.GrayText1
+			return lm.trustDomain.Layout(gtx)
+		})
+	}
+
+	w = append(w, func(gtx C) D {
+		return layout.E.Layout(gtx, func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding5}.Layout(gtx, lm.btnCancel.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding5}.Layout(gtx, lm.btnCopy.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					// Only http and https links are opened.
+					if lm.info.UnsafeScheme {
+						return D{}
+					}
+					return lm.btnOpen.Layout(gtx)
+				}),
+			)
+		})
+	})
+
+	return lm.Modal.Layout(gtx, w)
+}
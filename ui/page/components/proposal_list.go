@@ -13,6 +13,7 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type ProposalItem struct {
@@ -20,6 +21,9 @@ type ProposalItem struct {
 	tooltip      *decredmaterial.Tooltip
 	tooltipLabel decredmaterial.Label
 	voteBar      *VoteBar
+	// updated is true if the proposal was edited since the user last
+	// opened it.
+	updated bool
 }
 
 func ProposalsList(window app.WindowNavigator, gtx C, l *load.Load, prop *ProposalItem) D {
@@ -93,6 +97,12 @@ func layoutAuthorAndDate(gtx C, l *load.Load, item *ProposalItem) D {
 					return layout.Inset{Top: values.MarginPaddingMinus22}.Layout(gtx, dotLabel.Layout)
 				}),
 				layout.Rigid(versionLabel.Layout),
+				layout.Rigid(func(gtx C) D {
+					if !item.updated {
+						return D{}
+					}
+					return layoutUpdatedBadge(gtx, l)
+				}),
 			)
 		}),
 		layout.Rigid(func(gtx C) D {
@@ -175,6 +185,21 @@ func layoutInfoTooltip(gtx C, rect image.Rectangle, item ProposalItem) {
 	})
 }
 
+// LayoutNoResultsFound displays the message shown when a search returns no
+// results.
+func LayoutNoResultsFound(gtx C, l *load.Load, searchText string) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	text := l.Theme.Body1(values.StringF(values.StrNoSearchResults, searchText))
+	text.Color = l.Theme.Color.GrayText3
+
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		return layout.Inset{
+			Top:    values.MarginPadding10,
+			Bottom: values.MarginPadding10,
+		}.Layout(gtx, text.Layout)
+	})
+}
+
 func LayoutNoProposalsFound(gtx C, l *load.Load, syncing bool, category int32) D {
 	var selectedCategory string
 	switch category {
@@ -203,16 +228,34 @@ func LayoutNoProposalsFound(gtx C, l *load.Load, syncing bool, category int32) D
 	})
 }
 
+// layoutUpdatedBadge draws the badge of a proposal edited since the user last
+// opened it.
+func layoutUpdatedBadge(gtx C, l *load.Load) D {
+	lbl := l.Theme.Caption(values.String(values.StrUpdated))
+	lbl.Color = l.Theme.Color.Primary
+	return decredmaterial.LinearLayout{
+		Background: l.Theme.Color.LightBlue,
+		Width:      decredmaterial.WrapContent,
+		Height:     decredmaterial.WrapContent,
+		Direction:  layout.Center,
+		Border:     decredmaterial.Border{Radius: decredmaterial.Radius(8)},
+		Padding:    layout.Inset{Left: values.MarginPadding8, Right: values.MarginPadding8},
+		Margin:     layout.Inset{Left: values.MarginPadding8},
+	}.Layout2(gtx, lbl.Layout)
+}
+
 func LoadProposals(category int32, newestFirst bool, l *load.Load) []*ProposalItem {
 	proposalItems := make([]*ProposalItem, 0)
 
 	proposals, err := l.WL.MultiWallet.Politeia.GetProposalsRaw(category, 0, 0, newestFirst)
 	if err == nil {
+		seenVersions := wallet.SeenProposalVersions(l.WL.MultiWallet)
 		for i := 0; i < len(proposals); i++ {
 			proposal := proposals[i]
 			item := &ProposalItem{
 				Proposal: proposals[i],
 				voteBar:  NewVoteBar(l),
+				updated:  wallet.ProposalChangedSinceSeen(seenVersions, &proposal),
 			}
 
 			if proposal.Category == dcrlibwallet.ProposalCategoryPre {
@@ -32,8 +32,8 @@ func NewAddDexModal(l *load.Load) *AddDexModal {
 	md := &AddDexModal{
 		Load:             l,
 		Modal:            l.Theme.ModalFloatTitle("add_dex_modal"),
-		dexServerAddress: l.Theme.Editor(&widget.Editor{Submit: true}, strDexAddr),
-		cert:             l.Theme.Editor(new(widget.Editor), strTLSCert),
+		dexServerAddress: l.Theme.Editor(&widget.Editor{Submit: true}, values.String(values.StrDexAddr)),
+		cert:             l.Theme.Editor(new(widget.Editor), values.String(values.StrTLSCert)),
 		addDexServerBtn:  l.Theme.Button(values.String(values.StrContinue)),
 		cancelBtn:        l.Theme.OutlineButton(values.String(values.StrCancel)),
 		materialLoader:   material.Loader(l.Theme.Base),
@@ -88,30 +88,29 @@ func (md *AddDexModal) doAddDexServer(serverAddr string) {
 		return
 	}
 
-	md.isSending = true
-	md.Modal.SetDisabled(true)
-	go func() {
+	cert := []byte(md.cert.Editor.Text())
+	withDexPass(md.Load, md.ParentWindow(), func(pw []byte) error {
+		md.isSending = true
+		md.Modal.SetDisabled(true)
 		defer func() {
 			md.isSending = false
 			md.Modal.SetDisabled(false)
 		}()
 
-		cert := []byte(md.cert.Editor.Text())
-
-		dexServer, paid, err := md.Dexc().Core().DiscoverAccount(serverAddr, []byte(DEXClientPass), cert)
+		dexServer, paid, err := md.Dexc().Core().DiscoverAccount(serverAddr, pw, cert)
 		if err != nil {
-			md.Toast.NotifyError(err.Error())
-			return
+			return err
 		}
 
 		md.Dismiss()
 		if paid {
 			md.onDexAdded()
-			return
+			return nil
 		}
 
-		md.payFeeAndRegister(dexServer, cert)
-	}()
+		md.payFeeAndRegister(dexServer, cert, pw)
+		return nil
+	})
 }
 
 func (md *AddDexModal) Layout(gtx layout.Context) D {
@@ -128,7 +127,7 @@ func (md *AddDexModal) Layout(gtx layout.Context) D {
 		},
 		md.Theme.Separator().Layout,
 		func(gtx C) D {
-			customServerText := md.Theme.Label(values.TextSize16, strCustomServer)
+			customServerText := md.Theme.Label(values.TextSize16, values.String(values.StrCustomServer))
 			customServerText.Color = md.Theme.Color.Primary
 			return customServerText.Layout(gtx)
 		},
@@ -161,7 +160,7 @@ func (md *AddDexModal) Layout(gtx layout.Context) D {
 	return md.Modal.Layout(gtx, w)
 }
 
-func (md *AddDexModal) payFeeAndRegister(dexServer *core.Exchange, cert []byte) {
+func (md *AddDexModal) payFeeAndRegister(dexServer *core.Exchange, cert, pw []byte) {
 	// Create the assetSelectorModal now, it'll remain open/visible
 	// until the fee is paid and registration is completed or the
 	// user manually closes it.
@@ -169,13 +168,13 @@ func (md *AddDexModal) payFeeAndRegister(dexServer *core.Exchange, cert []byte)
 
 	confirmAndRegister := func(feeAsset *core.SupportedAsset) {
 		infoModal := modal.NewInfoModal(md.Load).
-			Title(strConfirmReg).
+			Title(values.String(values.StrConfirmReg)).
 			Body(confirmRegisterModalDesc(dexServer, feeAsset.Symbol)).
 			SetCancelable(false).
 			NegativeButton(values.String(values.StrCancel), func() {
 				md.ParentWindow().ShowModal(assetSelectorModal)
 			}).
-			PositiveButton(strRegister, func(_ bool) bool {
+			PositiveButton(values.String(values.StrRegister), func(_ bool) bool {
 				md.ParentWindow().ShowModal(assetSelectorModal)
 				go func() {
 					assetSelectorModal.SetLoading(true)
@@ -185,7 +184,7 @@ func (md *AddDexModal) payFeeAndRegister(dexServer *core.Exchange, cert []byte)
 						cert,
 						int64(regFeeAsset.Amt),
 						int32(regFeeAsset.ID),
-						[]byte(DEXClientPass))
+						pw)
 					if err != nil {
 						assetSelectorModal.SetLoading(false)
 						assetSelectorModal.Modal.SetDisabled(false) // re-enable fee asset selection
@@ -238,14 +237,24 @@ func confirmRegisterModalDesc(dexServer *core.Exchange, selectedFeeAsset string)
 
 // saveDexServer after pay the fee success save the host and cert to db.
 func (md *AddDexModal) saveDexServer(host string, cert []byte) {
+	saveKnownDexServer(md.Load, host, cert)
+}
+
+// saveKnownDexServer saves the cert of the host to the known DEX servers, or
+// removes the host if cert is nil.
+func saveKnownDexServer(l *load.Load, host string, cert []byte) {
 	dexServer := new(components.DexServer)
-	err := md.Load.WL.MultiWallet.ReadUserConfigValue(components.KnownDexServersConfigKey, &dexServer)
+	err := l.WL.MultiWallet.ReadUserConfigValue(components.KnownDexServersConfigKey, &dexServer)
 	if err != nil {
 		return
 	}
 	if dexServer.SavedHosts == nil {
 		dexServer.SavedHosts = make(map[string][]byte)
 	}
-	dexServer.SavedHosts[host] = cert
-	md.Load.WL.MultiWallet.SaveUserConfigValue(components.KnownDexServersConfigKey, dexServer)
+	if cert == nil {
+		delete(dexServer.SavedHosts, host)
+	} else {
+		dexServer.SavedHosts[host] = cert
+	}
+	l.WL.MultiWallet.SaveUserConfigValue(components.KnownDexServersConfigKey, dexServer)
 }
@@ -2,7 +2,6 @@ package dexclient
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 
 	"decred.org/dcrdex/client/asset/btc"
@@ -48,15 +47,15 @@ func newCreateWalletModal(l *load.Load, wallInfo *walletInfoWidget) *createWalle
 	md := &createWalletModal{
 		Load:             l,
 		Modal:            l.Theme.ModalFloatTitle("dex_create_wallet_modal"),
-		walletPassword:   l.Theme.EditorPassword(&widget.Editor{Submit: true}, strWalletPassword),
-		submitBtn:        l.Theme.Button(strSubmit),
+		walletPassword:   l.Theme.EditorPassword(&widget.Editor{Submit: true}, values.String(values.StrWalletPassword)),
+		submitBtn:        l.Theme.Button(values.String(values.StrSubmit)),
 		cancelBtn:        l.Theme.OutlineButton(values.String(values.StrCancel)),
 		materialLoader:   material.Loader(l.Theme.Base),
 		walletInfoWidget: wallInfo,
 	}
 	md.submitBtn.SetEnabled(false)
 	md.sourceAccountSelector = components.NewAccountSelector(md.Load).
-		Title(strSelectAccountForDex).
+		Title(values.String(values.StrSelectAccountForDex)).
 		AccountSelected(func(selectedAccount *dcrlibwallet.Account) {}).
 		AccountValidator(func(account *dcrlibwallet.Account) bool {
 			// Filter out imported account and mixed.
@@ -135,21 +134,21 @@ func (md *createWalletModal) doCreateWallet(walletPass []byte) {
 		return
 	}
 
-	md.isSending = true
-	md.Modal.SetDisabled(true)
-	go func() {
+	coinID := md.walletInfoWidget.coinID
+	coinName := md.walletInfoWidget.coinName
+	if md.Dexc().HasWallet(int32(coinID)) {
+		md.Toast.NotifyError(values.StringF(values.StrAlreadyConnectWallet, coinName))
+		return
+	}
+
+	withDexPass(md.Load, md.ParentWindow(), func(appPass []byte) error {
+		md.isSending = true
+		md.Modal.SetDisabled(true)
 		defer func() {
 			md.isSending = false
 			md.Modal.SetDisabled(false)
 		}()
 
-		coinID := md.walletInfoWidget.coinID
-		coinName := md.walletInfoWidget.coinName
-		if md.Dexc().HasWallet(int32(coinID)) {
-			md.Toast.NotifyError(fmt.Sprintf(nStrAlreadyConnectWallet, coinName))
-			return
-		}
-
 		settings := make(map[string]string)
 		var walletType string
 		switch coinID {
@@ -164,15 +163,15 @@ func (md *createWalletModal) doCreateWallet(walletPass []byte) {
 			walletPass = nil   // Core doesn't accept wallet passwords for dex-managed spv wallets.
 		}
 
-		err := md.Dexc().AddWallet(coinID, walletType, settings, []byte(DEXClientPass), walletPass)
+		err := md.Dexc().AddWallet(coinID, walletType, settings, appPass, walletPass)
 		if err != nil {
-			md.Toast.NotifyError(err.Error())
-			return
+			return err
 		}
 
 		md.Dismiss()
 		md.walletCreated()
-	}()
+		return nil
+	})
 }
 
 func (md *createWalletModal) Layout(gtx layout.Context) D {
@@ -180,7 +179,7 @@ func (md *createWalletModal) Layout(gtx layout.Context) D {
 		func(gtx C) D {
 			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
-					return md.Load.Theme.Label(values.TextSize20, strAddA).Layout(gtx)
+					return md.Load.Theme.Label(values.TextSize20, values.String(values.StrAddA)).Layout(gtx)
 				}),
 				layout.Rigid(func(gtx C) D {
 					return layout.Inset{Left: values.MarginPadding8, Right: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
@@ -190,7 +189,7 @@ func (md *createWalletModal) Layout(gtx layout.Context) D {
 					})
 				}),
 				layout.Rigid(func(gtx C) D {
-					return md.Load.Theme.Label(values.TextSize20, fmt.Sprintf(nStrNameWallet, md.walletInfoWidget.coinName)).Layout(gtx)
+					return md.Load.Theme.Label(values.TextSize20, values.StringF(values.StrNameWallet, md.walletInfoWidget.coinName)).Layout(gtx)
 				}),
 			)
 		},
@@ -200,7 +199,7 @@ func (md *createWalletModal) Layout(gtx layout.Context) D {
 					if !md.isRegisterAction {
 						return D{}
 					}
-					return md.Load.Theme.Label(values.TextSize14, strRequireWalletPayFee).Layout(gtx)
+					return md.Load.Theme.Label(values.TextSize14, values.String(values.StrRequireWalletPayFee)).Layout(gtx)
 				}),
 				layout.Rigid(func(gtx C) D {
 					if md.walletInfoWidget.coinID == dcr.BipID {
@@ -0,0 +1,160 @@
+package dexclient
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// unlockDex creates, migrates or requests the DEX password as needed to log
+// in to the DEX client, then calls onUnlocked.
+func unlockDex(l *load.Load, window app.WindowNavigator, onUnlocked func()) {
+	switch l.WL.Wallet.DexPassState(l.Dexc()) {
+	case wallet.DexPassNotCreated:
+		title, description := values.String(values.StrCreateDexPass), values.String(values.StrCreateDexPassDesc)
+		createDexPass(l, window, title, description, func(pw []byte) error {
+			return l.WL.Wallet.CreateDexPass(l.Dexc(), pw)
+		}, onUnlocked)
+	case wallet.DexPassLegacy:
+		title, description := values.String(values.StrSetDexPass), values.String(values.StrMigrateDexPassDesc)
+		createDexPass(l, window, title, description, func(pw []byte) error {
+			err := l.WL.Wallet.MigrateDexPass(l.Dexc(), l.Dexc().Core(), pw)
+			if err == wallet.ErrDexPassNotLegacy {
+				// The database was migrated before, the password must be
+				// entered instead.
+				unlockDex(l, window, onUnlocked)
+				return nil
+			}
+			return err
+		}, onUnlocked)
+	case wallet.DexPassLocked:
+		remember := new(widget.Bool)
+		passwordModal := modal.NewPasswordModal(l).
+			Title(values.String(values.StrUnlockDex)).
+			Description(values.String(values.StrUnlockDexDesc)).
+			Hint(values.String(values.StrDexPassword)).
+			UseCustomWidget(rememberPassLayout(l, remember)).
+			NegativeButton(values.String(values.StrCancel), func() {}).
+			PositiveButton(values.String(values.StrUnlock), func(password string, pm *modal.PasswordModal) bool {
+				go func() {
+					err := l.WL.Wallet.UnlockDex(l.Dexc(), []byte(password), remember.Value)
+					if err != nil {
+						pm.SetError(err.Error())
+						pm.SetLoading(false)
+						return
+					}
+					pm.Dismiss()
+					onUnlocked()
+				}()
+				return false
+			})
+		window.ShowModal(passwordModal)
+	default:
+		onUnlocked()
+	}
+}
+
+// createDexPass requests a new DEX password and calls setPass with it.
+func createDexPass(l *load.Load, window app.WindowNavigator, title, description string, setPass func(pw []byte) error, onUnlocked func()) {
+	createPasswordModal := modal.NewCreatePasswordModal(l).
+		Title(title).
+		SetDescription(description).
+		EnableName(false).
+		PasswordHint(values.String(values.StrDexPassword)).
+		ConfirmPasswordHint(values.String(values.StrConfirmDexPassword)).
+		NegativeButton(func() {}).
+		PasswordCreated(func(_, password string, m *modal.CreatePasswordModal) bool {
+			go func() {
+				if err := setPass([]byte(password)); err != nil {
+					m.SetError(err.Error())
+					m.SetLoading(false)
+					return
+				}
+				m.Dismiss()
+				if l.Dexc().IsLoggedIn() {
+					onUnlocked()
+				}
+			}()
+			return false
+		})
+	window.ShowModal(createPasswordModal)
+}
+
+// withDexPass calls fn in the background with the DEX password cached for the
+// session, or with the password entered by the user if none is cached. The
+// errors returned by fn are shown to the user.
+func withDexPass(l *load.Load, window app.WindowNavigator, fn func(pw []byte) error) {
+	if pw := l.WL.Wallet.CachedDexPass(); pw != nil {
+		go func() {
+			if err := fn(pw); err != nil {
+				l.Toast.NotifyError(err.Error())
+			}
+		}()
+		return
+	}
+
+	remember := new(widget.Bool)
+	passwordModal := modal.NewPasswordModal(l).
+		Title(values.String(values.StrDexPassword)).
+		Hint(values.String(values.StrDexPassword)).
+		UseCustomWidget(rememberPassLayout(l, remember)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				if err := fn([]byte(password)); err != nil {
+					pm.SetError(err.Error())
+					pm.SetLoading(false)
+					return
+				}
+				if remember.Value {
+					l.WL.Wallet.CacheDexPass([]byte(password))
+				}
+				pm.Dismiss()
+			}()
+			return false
+		})
+	window.ShowModal(passwordModal)
+}
+
+// changeDexPass requests the current and a new DEX password and replaces the
+// current password. A password cached for the session is forgotten.
+func changeDexPass(l *load.Load, window app.WindowNavigator) {
+	currentPassModal := modal.NewPasswordModal(l).
+		Title(values.String(values.StrChangeDexPass)).
+		Hint(values.String(values.StrDexPassword)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(current string, pm *modal.PasswordModal) bool {
+			createPasswordModal := modal.NewCreatePasswordModal(l).
+				Title(values.String(values.StrChangeDexPass)).
+				EnableName(false).
+				PasswordHint(values.String(values.StrNewDexPassword)).
+				ConfirmPasswordHint(values.String(values.StrConfirmDexPassword)).
+				NegativeButton(func() {}).
+				PasswordCreated(func(_, password string, m *modal.CreatePasswordModal) bool {
+					go func() {
+						err := l.Dexc().Core().ChangeAppPass([]byte(current), []byte(password))
+						if err != nil {
+							m.SetError(err.Error())
+							m.SetLoading(false)
+							return
+						}
+						l.WL.Wallet.ForgetDexPass()
+						m.Dismiss()
+						l.Toast.Notify(values.String(values.StrDexPassChanged))
+					}()
+					return false
+				})
+			window.ShowModal(createPasswordModal)
+			return true
+		})
+	window.ShowModal(currentPassModal)
+}
+
+func rememberPassLayout(l *load.Load, remember *widget.Bool) layout.Widget {
+	return l.Theme.CheckBox(remember, values.String(values.StrRememberDexPass)).Layout
+}
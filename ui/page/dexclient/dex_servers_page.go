@@ -0,0 +1,398 @@
+package dexclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"decred.org/dcrdex/client/core"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const DexServersPageID = "DexServers"
+
+// serverActions are the buttons of the actions on a DEX server.
+type serverActions struct {
+	setActiveBtn decredmaterial.Button
+	disableBtn   decredmaterial.Button
+	updateCert   decredmaterial.Button
+	exportBtn    decredmaterial.Button
+	removeBtn    decredmaterial.Button
+}
+
+// DexServersPage lists the registered DEX servers and manages their accounts.
+type DexServersPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	settings wallet.DexServerSettings
+	actions  map[string]*serverActions
+
+	backButton    decredmaterial.IconButton
+	addDexBtn     decredmaterial.Button
+	importBtn     decredmaterial.Button
+	changePassBtn decredmaterial.Button
+	listContainer *widget.List
+}
+
+func NewDexServersPage(l *load.Load) *DexServersPage {
+	pg := &DexServersPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(DexServersPageID),
+		actions:          make(map[string]*serverActions),
+		addDexBtn:        l.Theme.Button(values.String(values.StrAddADex)),
+		importBtn:        l.Theme.OutlineButton(values.String(values.StrImportAccount)),
+		changePassBtn:    l.Theme.OutlineButton(values.String(values.StrChangeDexPass)),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *DexServersPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.settings = wallet.ReadDexServerSettings(pg.WL.MultiWallet)
+	pg.WL.Wallet.DexNotifier().AddListener(DexServersPageID, func(n core.Notification) {
+		if n.Type() == core.NoteTypeConnEvent || n.Type() == core.NoteTypeFeePayment {
+			pg.ParentWindow().Reload()
+		}
+	})
+}
+
+// servers returns the registered DEX servers sorted by host.
+func (pg *DexServersPage) servers() []*core.Exchange {
+	if pg.Dexc().Core() == nil {
+		return nil
+	}
+	return wallet.SortDexServers(pg.Dexc().DEXServers())
+}
+
+func (pg *DexServersPage) serverActions(host string) *serverActions {
+	actions, ok := pg.actions[host]
+	if !ok {
+		actions = &serverActions{
+			setActiveBtn: pg.Theme.Button(values.String(values.StrSetActive)),
+			disableBtn:   pg.Theme.OutlineButton(values.String(values.StrDisable)),
+			updateCert:   pg.Theme.OutlineButton(values.String(values.StrUpdateCert)),
+			exportBtn:    pg.Theme.OutlineButton(values.String(values.StrExportKeys)),
+			removeBtn:    pg.Theme.OutlineButton(values.String(values.StrRemove)),
+		}
+		actions.removeBtn.Color = pg.Theme.Color.Danger
+		pg.actions[host] = actions
+	}
+	return actions
+}
+
+func (pg *DexServersPage) saveSettings() {
+	wallet.SaveDexServerSettings(pg.WL.MultiWallet, pg.settings)
+}
+
+func (pg *DexServersPage) exportAccount(host string) {
+	withDexPass(pg.Load, pg.ParentWindow(), func(pw []byte) error {
+		path, err := pg.WL.Wallet.ExportDexAccount(pg.Dexc().Core(), pw, host)
+		if err != nil {
+			return err
+		}
+		pg.Toast.Notify(values.StringF(values.StrKeysExported, path))
+		return nil
+	})
+}
+
+func (pg *DexServersPage) showRemoveModal(host string) {
+	removeModal := modal.NewInfoModal(pg.Load).
+		Title(values.String(values.StrRemoveDex)).
+		Body(values.StringF(values.StrRemoveDexWarning, host)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		PositiveButton(values.String(values.StrRemove), func(isChecked bool) bool {
+			withDexPass(pg.Load, pg.ParentWindow(), func(pw []byte) error {
+				return pg.removeServer(pw, host)
+			})
+			return true
+		})
+	pg.ParentWindow().ShowModal(removeModal)
+}
+
+// removeServer disables the account of the server in dcrdex and forgets the
+// server. dcrdex refuses to disable an account with active orders.
+func (pg *DexServersPage) removeServer(pw []byte, host string) error {
+	if err := pg.Dexc().Core().AccountDisable(pw, host); err != nil {
+		return err
+	}
+	pg.settings.Forget(host)
+	pg.saveSettings()
+	saveKnownDexServer(pg.Load, host, nil)
+	delete(pg.actions, host)
+	pg.Toast.Notify(values.StringF(values.StrDexRemoved, host))
+	pg.ParentWindow().Reload()
+	return nil
+}
+
+func (pg *DexServersPage) showImportModal() {
+	importModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrAccountFilePath)).
+		PositiveButton(values.String(values.StrImport), func(path string, tim *modal.TextInputModal) bool {
+			withDexPass(pg.Load, pg.ParentWindow(), func(pw []byte) error {
+				host, err := wallet.ImportDexAccount(pg.Dexc().Core(), pw, strings.TrimSpace(path))
+				if err != nil {
+					return err
+				}
+				pg.Toast.Notify(values.StringF(values.StrDexImported, host))
+				pg.ParentWindow().Reload()
+				return nil
+			})
+			return true
+		})
+	importModal.Title(values.String(values.StrImportAccount)).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	pg.ParentWindow().ShowModal(importModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *DexServersPage) HandleUserInteractions() {
+	if pg.addDexBtn.Clicked() {
+		addDexModal := NewAddDexModal(pg.Load).OnDexAdded(func() {
+			pg.ParentWindow().Reload()
+		})
+		pg.ParentWindow().ShowModal(addDexModal)
+	}
+
+	if pg.importBtn.Clicked() {
+		pg.showImportModal()
+	}
+
+	if pg.changePassBtn.Clicked() {
+		changeDexPass(pg.Load, pg.ParentWindow())
+	}
+
+	for _, d := range pg.servers() {
+		host := d.Host
+		actions := pg.serverActions(host)
+		disabled := pg.settings.IsDisabled(host)
+		active := wallet.ActiveDexServer(pg.Dexc().DEXServers(), pg.settings)
+
+		actions.setActiveBtn.SetEnabled(!disabled && (active == nil || active.Host != host))
+		if actions.setActiveBtn.Clicked() {
+			pg.settings.Active = host
+			pg.saveSettings()
+		}
+
+		if actions.disableBtn.Clicked() {
+			pg.settings.SetDisabled(host, !disabled)
+			pg.saveSettings()
+		}
+
+		if actions.updateCert.Clicked() {
+			pg.ParentWindow().ShowModal(newUpdateCertModal(pg.Load, host))
+		}
+
+		if actions.exportBtn.Clicked() {
+			pg.exportAccount(host)
+		}
+
+		if actions.removeBtn.Clicked() {
+			pg.showRemoveModal(host)
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DexServersPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+	pg.WL.Wallet.DexNotifier().RemoveListener(DexServersPageID)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DexServersPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrDexServers),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *DexServersPage) layoutContent(gtx C) D {
+	servers := pg.servers()
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(pg.addDexBtn.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.importBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.changePassBtn.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			if len(servers) == 0 {
+				return pg.Theme.Label(values.TextSize14, values.String(values.StrNoDexServers)).Layout(gtx)
+			}
+			return pg.Theme.List(pg.listContainer).Layout(gtx, len(servers), func(gtx C, i int) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.serverLayout(servers[i]))
+			})
+		}),
+	)
+}
+
+// serverFeeAssets returns the assets accepted for the registration fee of the
+// server, or the asset of the fee being paid.
+func serverFeeAssets(d *core.Exchange) string {
+	if d.PendingFee != nil {
+		return fmt.Sprintf("%s (%s)", strings.ToUpper(d.PendingFee.Symbol), values.String(values.StrPending))
+	}
+	symbols := make([]string, 0, len(d.RegFees))
+	for symbol := range d.RegFees {
+		symbols = append(symbols, strings.ToUpper(symbol))
+	}
+	sort.Strings(symbols)
+	return strings.Join(symbols, ", ")
+}
+
+// serverMarkets returns the names of all the markets of the server.
+func serverMarkets(d *core.Exchange) string {
+	markets := make([]*core.Market, 0, len(d.Markets))
+	for _, mkt := range d.Markets {
+		markets = append(markets, mkt)
+	}
+	sortMarkets(markets)
+	names := make([]string, len(markets))
+	for i, mkt := range markets {
+		names[i] = marketDisplayName(mkt)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (pg *DexServersPage) serverLayout(d *core.Exchange) layout.Widget {
+	return func(gtx C) D {
+		actions := pg.serverActions(d.Host)
+		disabled := pg.settings.IsDisabled(d.Host)
+		active := wallet.ActiveDexServer(pg.Dexc().DEXServers(), pg.settings)
+		if disabled {
+			actions.disableBtn.Text = values.String(values.StrEnable)
+		} else {
+			actions.disableBtn.Text = values.String(values.StrDisable)
+		}
+
+		row := func(label, value string) layout.FlexChild {
+			return layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Flexed(.2, func(gtx C) D {
+							lbl := pg.Theme.Label(values.TextSize14, label)
+							lbl.Color = pg.Theme.Color.GrayText2
+							return lbl.Layout(gtx)
+						}),
+						layout.Flexed(.8, pg.Theme.Label(values.TextSize14, value).Layout),
+					)
+				})
+			})
+		}
+
+		status := pg.Theme.Label(values.TextSize14, values.String(values.StrDisconnected))
+		status.Color = pg.Theme.Color.Danger
+		if d.Connected {
+			status.Text, status.Color = values.String(values.StrConnected), pg.Theme.Color.Success
+		}
+		var state decredmaterial.Label
+		switch {
+		case disabled:
+			state = pg.Theme.Label(values.TextSize14, values.String(values.StrServerDisabled))
+			state.Color = pg.Theme.Color.GrayText2
+		case active != nil && active.Host == d.Host:
+			state = pg.Theme.Label(values.TextSize14, values.String(values.StrActive))
+			state.Color = pg.Theme.Color.Primary
+		}
+
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						host := pg.Theme.Label(values.TextSize16, d.Host)
+						host.Font.Weight = text.SemiBold
+						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(host.Layout),
+							layout.Rigid(func(gtx C) D {
+								if state.Text == "" {
+									return D{}
+								}
+								return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, state.Layout)
+							}),
+							layout.Flexed(1, func(gtx C) D {
+								return layout.E.Layout(gtx, status.Layout)
+							}),
+						)
+					}),
+					row(values.String(values.StrFeeAssets), serverFeeAssets(d)),
+					row(values.String(values.StrMarkets), serverMarkets(d)),
+					layout.Rigid(func(gtx C) D {
+						buttons := []*decredmaterial.Button{&actions.setActiveBtn, &actions.disableBtn, &actions.updateCert, &actions.exportBtn, &actions.removeBtn}
+						children := make([]layout.FlexChild, len(buttons))
+						for i := range buttons {
+							btn := buttons[i]
+							children[i] = layout.Rigid(func(gtx C) D {
+								return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, btn.Layout)
+							})
+						}
+						return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{}.Layout(gtx, children...)
+						})
+					}),
+				)
+			})
+		})
+	}
+}
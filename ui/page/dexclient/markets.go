@@ -7,6 +7,7 @@ import (
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/db"
 	"gioui.org/layout"
+	"gioui.org/widget"
 	"gioui.org/widget/material"
 
 	"github.com/planetdecred/godcr/app"
@@ -14,6 +15,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type (
@@ -35,17 +37,38 @@ type Page struct {
 	ctxCancel      context.CancelFunc
 	addDexBtn      decredmaterial.Button
 	syncBtn        decredmaterial.Button
+	unlockBtn      decredmaterial.Button
+	dexServersBtn  decredmaterial.Button
 	materialLoader material.LoaderStyle
+
+	market                *wallet.DexMarket
+	marketCancel          context.CancelFunc
+	marketClickables      map[string]*decredmaterial.Clickable
+	sideSwitch            *decredmaterial.SwitchButtonText
+	orderTypeSwitch       *decredmaterial.SwitchButtonText
+	qtyEditor             decredmaterial.Editor
+	rateEditor            decredmaterial.Editor
+	placeOrderBtn         decredmaterial.Button
+	orderHistoryBtn       decredmaterial.Button
+	swapMonitorBtn        decredmaterial.Button
+	portfolioBtn          decredmaterial.Button
+	placingOrder          bool
+	openOrders            []*core.Order
+	cancelOrderClickables map[string]*decredmaterial.Clickable
+	tradeList             *widget.List
 }
 
 func NewMarketPage(l *load.Load) *Page {
 	pg := &Page{
 		Load:             l,
 		GenericPageModal: app.NewGenericPageModal(MarketPageID),
-		addDexBtn:        l.Theme.Button(strAddADex),
-		syncBtn:          l.Theme.Button(strStartSyncToUse),
+		addDexBtn:        l.Theme.Button(values.String(values.StrAddADex)),
+		syncBtn:          l.Theme.Button(values.String(values.StrStartSyncToUse)),
+		unlockBtn:        l.Theme.Button(values.String(values.StrUnlockDex)),
+		dexServersBtn:    l.Theme.OutlineButton(values.String(values.StrDexServers)),
 		materialLoader:   material.Loader(l.Theme.Base),
 	}
+	pg.initTradeWidgets()
 
 	return pg
 }
@@ -58,22 +81,24 @@ func (pg *Page) Layout(gtx C) D {
 		switch {
 		case !pg.WL.MultiWallet.IsConnectedToDecredNetwork():
 			return pg.pageSections(gtx, pg.welcomeLayout(&pg.syncBtn))
-		case pg.isLoadingDexClient(): // Need start DEX client
+		case pg.Dexc().Core() == nil: // Need start DEX client
 			return pg.pageSections(gtx, pg.welcomeLayout(nil))
-		case pg.dexServer() == nil:
+		case pg.isLoadingDexClient():
+			return pg.pageSections(gtx, pg.welcomeLayout(&pg.unlockBtn))
+		case len(pg.Dexc().DEXServers()) == 0:
 			return pg.pageSections(gtx, pg.welcomeLayout(&pg.addDexBtn))
+		case pg.dexServer() == nil:
+			return pg.pageSections(gtx, pg.serverErrorLayout(values.String(values.StrAllDexDisabled)))
 		default:
 			d := pg.dexServer()
 			if !d.Connected {
-				return pg.pageSections(gtx,
-					pg.Theme.Label(values.TextSize16, fmt.Sprintf(nStrConnHostError, d.Host)).Layout)
+				return pg.pageSections(gtx, pg.serverErrorLayout(values.StringF(values.StrConnHostError, d.Host)))
 			}
 			if d.PendingFee != nil {
 				return pg.pageSections(gtx, pg.registrationStatusLayout())
 			}
 
-			// TODO: remove this and render trade UI
-			return pg.pageSections(gtx, pg.Theme.Label(values.TextSize14, "Registration fee payment successful!").Layout)
+			return pg.pageSections(gtx, pg.tradeLayout(d))
 		}
 	}
 
@@ -98,15 +123,12 @@ func (pg *Page) welcomeLayout(button *decredmaterial.Button) layout.Widget {
 					})
 				}),
 				layout.Rigid(func(gtx C) D {
-					if pg.isLoadingDexClient() {
+					if button == nil {
 						return layout.Center.Layout(gtx, func(gtx C) D {
 							gtx.Constraints.Min.X = 50
 							return pg.materialLoader.Layout(gtx)
 						})
 					}
-					if button == nil {
-						return D{}
-					}
 					return button.Layout(gtx)
 				}),
 			)
@@ -114,6 +136,19 @@ func (pg *Page) welcomeLayout(button *decredmaterial.Button) layout.Widget {
 	}
 }
 
+// serverErrorLayout shows why the active server cannot be used, with a link
+// to the DEX servers page to pick another server.
+func (pg *Page) serverErrorLayout(message string) layout.Widget {
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.Theme.Label(values.TextSize16, message).Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.dexServersBtn.Layout)
+			}),
+		)
+	}
+}
+
 func (pg *Page) registrationStatusLayout() layout.Widget {
 	return func(gtx C) D {
 		txtLabel := func(txt string) layout.Widget {
@@ -122,8 +157,8 @@ func (pg *Page) registrationStatusLayout() layout.Widget {
 		d := pg.dexServer()
 		reqConfirms, currentConfs := d.Fee.Confs, d.PendingFee.Confs
 		return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx,
-			layout.Rigid(txtLabel(strWaitingConfirms)),
-			layout.Rigid(txtLabel(fmt.Sprintf(nStrConfirmationsStatus, d.Host, reqConfirms))),
+			layout.Rigid(txtLabel(values.String(values.StrWaitingConfirms))),
+			layout.Rigid(txtLabel(values.StringF(values.StrConfirmationsStatus, d.Host, reqConfirms))),
 			layout.Rigid(txtLabel(fmt.Sprintf("%d/%d", currentConfs, reqConfirms))),
 		)
 	}
@@ -135,9 +170,15 @@ func (pg *Page) registrationStatusLayout() layout.Widget {
 // Part of the load.Page interface.
 func (pg *Page) OnNavigatedTo() {
 	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	// The market is synced again from a fresh book when it is displayed.
+	pg.market = nil
 	if pg.Dexc().Core() == nil {
 		go pg.startDexClient()
 	} else {
+		startSwapMonitor(pg.Load)
+		if !pg.Dexc().IsLoggedIn() {
+			pg.unlockDex()
+		}
 		go pg.readNotifications()
 	}
 }
@@ -166,23 +207,31 @@ func (pg *Page) HandleUserInteractions() {
 		}
 	}
 
+	if pg.unlockBtn.Clicked() {
+		pg.unlockDex()
+	}
+
 	if pg.addDexBtn.Button.Clicked() {
 		newAddDexModal := NewAddDexModal(pg.Load).OnDexAdded(func() {
 			pg.ParentWindow().Reload()
 		})
 		pg.ParentWindow().ShowModal(newAddDexModal)
 	}
+
+	if pg.dexServersBtn.Clicked() {
+		pg.ParentNavigator().Display(NewDexServersPage(pg.Load))
+	}
+
+	pg.handleTradeInteractions()
 }
 
-// isLoadingDexClient check for Dexc start, initialized, loggedin status,
-// since Dex client UI not required for app password, IsInitialized and IsLoggedIn should be done at dcrlibwallet.
+// isLoadingDexClient is true until the DEX client is started and logged in.
 func (pg *Page) isLoadingDexClient() bool {
 	return pg.Dexc().Core() == nil || !pg.Dexc().Core().IsInitialized() || !pg.Dexc().IsLoggedIn()
 }
 
-// startDexClient do start DEX client,
-// initialize and login to DEX,
-// since Dex client UI not required for app password, initialize and login should be done at dcrlibwallet.
+// startDexClient starts the DEX client and requests the DEX password to log
+// in.
 func (pg *Page) startDexClient() {
 	_, err := pg.WL.MultiWallet.StartDexClient()
 	if err != nil {
@@ -190,26 +239,15 @@ func (pg *Page) startDexClient() {
 		return
 	}
 
-	// TODO: move to dcrlibwallet sine bypass Dex password by DEXClientPass
-	if !pg.Dexc().Initialized() {
-		err = pg.Dexc().InitializeWithPassword([]byte(DEXClientPass))
-		if err != nil {
-			pg.Toast.NotifyError(err.Error())
-			return
-		}
-	}
-
-	if !pg.Dexc().IsLoggedIn() {
-		err := pg.Dexc().Login([]byte(DEXClientPass))
-		if err != nil {
-			pg.Toast.NotifyError(err.Error())
-			return
-		}
-	}
-
+	startSwapMonitor(pg.Load)
+	pg.unlockDex()
 	pg.readNotifications()
 }
 
+func (pg *Page) unlockDex() {
+	unlockDex(pg.Load, pg.ParentWindow(), pg.ParentWindow().Reload)
+}
+
 // readNotifications reads from the Core notification channel.
 func (pg *Page) readNotifications() {
 	ch := pg.Dexc().Core().NotificationFeed()
@@ -220,6 +258,10 @@ func (pg *Page) readNotifications() {
 				pg.ParentWindow().Reload()
 			}
 
+			if n.Type() == core.NoteTypeOrder {
+				pg.refreshOpenOrders()
+			}
+
 			if n.Severity() > db.Success {
 				pg.Toast.NotifyError(n.Details())
 			}
@@ -230,11 +272,9 @@ func (pg *Page) readNotifications() {
 	}
 }
 
-// dexServer return first Dex
+// dexServer returns the active DEX server, or nil if there is no enabled
+// server.
 func (pg *Page) dexServer() *core.Exchange {
-	exchanges := sortServers(pg.Dexc().DEXServers())
-	if len(exchanges) == 0 {
-		return nil
-	}
-	return exchanges[0]
+	settings := wallet.ReadDexServerSettings(pg.WL.MultiWallet)
+	return wallet.ActiveDexServer(pg.Dexc().DEXServers(), settings)
 }
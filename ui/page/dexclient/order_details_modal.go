@@ -0,0 +1,193 @@
+package dexclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/calc"
+	"gioui.org/layout"
+	"gioui.org/text"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// matchCoin is a swap, redeem or refund transaction of a match.
+type matchCoin struct {
+	label     string
+	coin      *core.Coin
+	url       string
+	clickable *decredmaterial.Clickable
+}
+
+// orderDetailsModal shows an order with the swap, redeem and refund
+// transactions of its matches.
+type orderDetailsModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	order    *core.Order
+	matches  []*core.Match
+	coins    map[*core.Match][]*matchCoin
+	closeBtn decredmaterial.Button
+}
+
+func newOrderDetailsModal(l *load.Load, ord *core.Order) *orderDetailsModal {
+	odm := &orderDetailsModal{
+		Load:     l,
+		Modal:    l.Theme.ModalFloatTitle("dex_order_details_modal"),
+		order:    ord,
+		coins:    make(map[*core.Match][]*matchCoin),
+		closeBtn: l.Theme.OutlineButton(values.String(values.StrOk)),
+	}
+
+	for _, match := range ord.Matches {
+		if match.IsCancel {
+			continue
+		}
+		odm.matches = append(odm.matches, match)
+		for _, c := range []struct {
+			label string
+			coin  *core.Coin
+		}{
+			{values.String(values.StrSwap), match.Swap},
+			{values.String(values.StrCounterSwap), match.CounterSwap},
+			{values.String(values.StrRedeem), match.Redeem},
+			{values.String(values.StrCounterRedeem), match.CounterRedeem},
+			{values.String(values.StrRefund), match.Refund},
+		} {
+			if c.coin == nil {
+				continue
+			}
+			odm.coins[match] = append(odm.coins[match], &matchCoin{
+				label:     c.label,
+				coin:      c.coin,
+				url:       l.WL.Wallet.DexCoinExplorerURL(c.coin),
+				clickable: l.Theme.NewClickable(true),
+			})
+		}
+	}
+
+	return odm
+}
+
+func (odm *orderDetailsModal) OnResume() {}
+
+func (odm *orderDetailsModal) OnDismiss() {}
+
+func (odm *orderDetailsModal) Handle() {
+	if odm.closeBtn.Clicked() {
+		odm.Dismiss()
+	}
+
+	for _, coins := range odm.coins {
+		for _, c := range coins {
+			if c.clickable.Clicked() && c.url != "" {
+				components.OpenLink(odm.Load, odm.ParentWindow(), c.url)
+			}
+		}
+	}
+}
+
+func (odm *orderDetailsModal) row(label, value string) layout.Widget {
+	return func(gtx C) D {
+		return layout.Inset{Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Flexed(.35, func(gtx C) D {
+					lbl := odm.Theme.Label(values.TextSize14, label)
+					lbl.Color = odm.Theme.Color.GrayText2
+					return lbl.Layout(gtx)
+				}),
+				layout.Flexed(.65, odm.Theme.Label(values.TextSize14, value).Layout),
+			)
+		})
+	}
+}
+
+func (odm *orderDetailsModal) matchLayout(match *core.Match) layout.Widget {
+	return func(gtx C) D {
+		ord := odm.order
+		baseUnits, quoteUnits := wallet.DexUnitInfo(ord.BaseID), wallet.DexUnitInfo(ord.QuoteID)
+		rate := strconv.FormatFloat(calc.ConventionalRate(match.Rate, baseUnits, quoteUnits), 'f', -1, 64)
+		qty := fmt.Sprintf("%s %s", formatAmount(match.Qty, &baseUnits), strings.ToUpper(ord.BaseSymbol))
+
+		rows := []layout.FlexChild{
+			layout.Rigid(odm.Theme.Separator().Layout),
+			layout.Rigid(func(gtx C) D {
+				lbl := odm.Theme.Label(values.TextSize14, fmt.Sprintf("%s %s", values.String(values.StrMatch), match.MatchID))
+				lbl.Font.Weight = text.SemiBold
+				return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+			}),
+			layout.Rigid(odm.row(values.String(values.StrStatus), match.Status.String())),
+			layout.Rigid(odm.row(values.String(values.StrSide), match.Side.String())),
+			layout.Rigid(odm.row(values.String(values.StrPrice), rate)),
+			layout.Rigid(odm.row(values.String(values.StrQuantity), qty)),
+			layout.Rigid(odm.row(values.String(values.StrTime), time.UnixMilli(int64(match.Stamp)).Format(time.RFC1123))),
+		}
+		for _, c := range odm.coins[match] {
+			c := c
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Flexed(.35, func(gtx C) D {
+						lbl := odm.Theme.Label(values.TextSize14, c.label)
+						lbl.Color = odm.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}),
+					layout.Flexed(.65, func(gtx C) D {
+						lbl := odm.Theme.Label(values.TextSize14, fmt.Sprintf("%s %s", strings.ToUpper(c.coin.Symbol), c.coin.StringID))
+						if c.url == "" {
+							return lbl.Layout(gtx)
+						}
+						lbl.Color = odm.Theme.Color.Primary
+						return c.clickable.Layout(gtx, lbl.Layout)
+					}),
+				)
+			}))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+	}
+}
+
+func (odm *orderDetailsModal) Layout(gtx layout.Context) D {
+	ord := odm.order
+	rate, qty, filled := orderAmounts(ord)
+	side := values.String(values.StrBuy)
+	if ord.Sell {
+		side = values.String(values.StrSell)
+	}
+
+	w := []layout.Widget{
+		odm.Theme.Label(values.TextSize20, values.String(values.StrOrderDetails)).Layout,
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(odm.row(values.String(values.StrOrderID), ord.ID.String())),
+				layout.Rigid(odm.row(values.String(values.StrDexAddr), ord.Host)),
+				layout.Rigid(odm.row(values.String(values.StrMarket), fmt.Sprintf("%s-%s", strings.ToUpper(ord.BaseSymbol), strings.ToUpper(ord.QuoteSymbol)))),
+				layout.Rigid(odm.row(values.String(values.StrSide), side)),
+				layout.Rigid(odm.row(values.String(values.StrType), ord.Type.String())),
+				layout.Rigid(odm.row(values.String(values.StrStatus), ord.Status.String())),
+				layout.Rigid(odm.row(values.String(values.StrTime), time.UnixMilli(int64(ord.Stamp)).Format(time.RFC1123))),
+				layout.Rigid(odm.row(values.String(values.StrPrice), rate)),
+				layout.Rigid(odm.row(values.String(values.StrQuantity), qty)),
+				layout.Rigid(odm.row(values.String(values.StrFilled), filled)),
+			)
+		},
+	}
+	if len(odm.matches) == 0 {
+		w = append(w, odm.Theme.Label(values.TextSize14, values.String(values.StrNoMatches)).Layout)
+	}
+	for _, match := range odm.matches {
+		w = append(w, odm.matchLayout(match))
+	}
+	w = append(w, func(gtx C) D {
+		return layout.E.Layout(gtx, odm.closeBtn.Layout)
+	})
+
+	return odm.Modal.Layout(gtx, w)
+}
@@ -0,0 +1,382 @@
+package dexclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const OrderHistoryPageID = "DexOrderHistory"
+
+// orderStatusFilters are the statuses selected by each item of the status
+// dropdown, after the first item which selects all statuses.
+var orderStatusFilters = [][]order.OrderStatus{
+	{order.OrderStatusEpoch, order.OrderStatusBooked},
+	{order.OrderStatusExecuted},
+	{order.OrderStatusCanceled},
+	{order.OrderStatusRevoked},
+}
+
+// orderPeriodFilters are the periods selected by each item of the period
+// dropdown, after the first item which selects all the orders.
+var orderPeriodFilters = []time.Duration{
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// OrderHistoryPage lists the orders placed on all the DEX servers, including
+// the orders placed by other clients sharing the dcrdex database.
+type OrderHistoryPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	orders          []*core.Order
+	loading         bool
+	orderClickables map[string]*decredmaterial.Clickable
+
+	// markets are the names of the markets selected by the items of the
+	// market dropdown, after the first item which selects all the markets.
+	markets        []string
+	marketDropDown *decredmaterial.DropDown
+	statusDropDown *decredmaterial.DropDown
+	sideDropDown   *decredmaterial.DropDown
+	periodDropDown *decredmaterial.DropDown
+
+	backButton     decredmaterial.IconButton
+	exportBtn      decredmaterial.Button
+	materialLoader material.LoaderStyle
+	listContainer  *widget.List
+}
+
+func NewOrderHistoryPage(l *load.Load) *OrderHistoryPage {
+	pg := &OrderHistoryPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(OrderHistoryPageID),
+		orderClickables:  make(map[string]*decredmaterial.Clickable),
+		exportBtn:        l.Theme.OutlineButton(values.String(values.StrExportCSV)),
+		materialLoader:   material.Loader(l.Theme.Base),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	marketItems := []decredmaterial.DropDownItem{{Text: values.String(values.StrAllMarkets)}}
+	markets := make(map[string]*core.Market)
+	for _, d := range l.Dexc().DEXServers() {
+		for _, mkt := range d.Markets {
+			markets[mkt.Name] = mkt
+		}
+	}
+	for name := range markets {
+		pg.markets = append(pg.markets, name)
+	}
+	sort.Strings(pg.markets)
+	for _, name := range pg.markets {
+		marketItems = append(marketItems, decredmaterial.DropDownItem{Text: marketDisplayName(markets[name])})
+	}
+
+	pg.marketDropDown = l.Theme.DropDown(marketItems, values.DexOrderHistoryDropdownGroup, 0)
+	pg.statusDropDown = l.Theme.DropDown([]decredmaterial.DropDownItem{
+		{Text: values.String(values.StrAllStatuses)},
+		{Text: values.String(values.StrOpen)},
+		{Text: values.String(values.StrExecuted)},
+		{Text: values.String(values.StrCanceled)},
+		{Text: values.String(values.StrRevoked)},
+	}, values.DexOrderHistoryDropdownGroup, 1)
+	pg.sideDropDown = l.Theme.DropDown([]decredmaterial.DropDownItem{
+		{Text: values.String(values.StrBuyAndSell)},
+		{Text: values.String(values.StrBuy)},
+		{Text: values.String(values.StrSell)},
+	}, values.DexOrderHistoryDropdownGroup, 2)
+	pg.periodDropDown = l.Theme.DropDown([]decredmaterial.DropDownItem{
+		{Text: values.String(values.StrAllTime)},
+		{Text: values.String(values.StrLast24Hours)},
+		{Text: values.String(values.StrLast7Days)},
+		{Text: values.String(values.StrLast30Days)},
+	}, values.DexOrderHistoryDropdownGroup, 3)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *OrderHistoryPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadOrders()
+	pg.WL.Wallet.DexNotifier().AddListener(OrderHistoryPageID, func(n core.Notification) {
+		if n.Type() == core.NoteTypeOrder || n.Type() == core.NoteTypeMatch {
+			pg.loadOrders()
+		}
+	})
+}
+
+// orderFilter returns the filter selected by the dropdowns.
+func (pg *OrderHistoryPage) orderFilter() wallet.DexOrderFilter {
+	var filter wallet.DexOrderFilter
+	if i := pg.marketDropDown.SelectedIndex(); i > 0 {
+		filter.Market = pg.markets[i-1]
+	}
+	if i := pg.statusDropDown.SelectedIndex(); i > 0 {
+		filter.Statuses = orderStatusFilters[i-1]
+	}
+	switch pg.sideDropDown.SelectedIndex() {
+	case 1:
+		filter.Side = wallet.DexSideBuy
+	case 2:
+		filter.Side = wallet.DexSideSell
+	}
+	if i := pg.periodDropDown.SelectedIndex(); i > 0 {
+		filter.Since = time.Now().Add(-orderPeriodFilters[i-1])
+	}
+	return filter
+}
+
+// loadOrders reads the orders selected by the filter in the background.
+func (pg *OrderHistoryPage) loadOrders() {
+	if pg.Dexc().Core() == nil {
+		return
+	}
+
+	filter := pg.orderFilter()
+	pg.loading = true
+	go func() {
+		defer func() {
+			pg.loading = false
+			pg.ParentWindow().Reload()
+		}()
+		orders, err := wallet.DexOrderHistory(pg.Dexc().Core(), filter)
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+		pg.orders = orders
+	}()
+}
+
+func (pg *OrderHistoryPage) exportOrders() {
+	path, err := pg.WL.Wallet.ExportDexOrders(pg.orders)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.Toast.Notify(values.StringF(values.StrExportedTo, path))
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *OrderHistoryPage) HandleUserInteractions() {
+	for _, dropDown := range []*decredmaterial.DropDown{pg.marketDropDown, pg.statusDropDown, pg.sideDropDown, pg.periodDropDown} {
+		for dropDown.Changed() {
+			pg.loadOrders()
+		}
+	}
+	decredmaterial.DisplayOneDropdown(pg.marketDropDown, pg.statusDropDown, pg.sideDropDown, pg.periodDropDown)
+
+	if pg.exportBtn.Clicked() && len(pg.orders) > 0 {
+		pg.exportOrders()
+	}
+
+	for _, ord := range pg.orders {
+		if cl, ok := pg.orderClickables[ord.ID.String()]; ok && cl.Clicked() {
+			pg.ParentWindow().ShowModal(newOrderDetailsModal(pg.Load, ord))
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *OrderHistoryPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+	pg.WL.Wallet.DexNotifier().RemoveListener(OrderHistoryPageID)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *OrderHistoryPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrOrderHistory),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *OrderHistoryPage) layoutContent(gtx C) D {
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutOrders)
+		}),
+		layout.Expanded(func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.E.Layout(gtx, func(gtx C) D {
+				if pg.loading {
+					return pg.materialLoader.Layout(gtx)
+				}
+				return pg.exportBtn.Layout(gtx)
+			})
+		}),
+		layout.Expanded(func(gtx C) D {
+			return pg.marketDropDown.Layout(gtx, 0, false)
+		}),
+		layout.Expanded(func(gtx C) D {
+			return pg.statusDropDown.Layout(gtx, pg.marketDropDown.Width+10, false)
+		}),
+		layout.Expanded(func(gtx C) D {
+			return pg.sideDropDown.Layout(gtx, pg.marketDropDown.Width+pg.statusDropDown.Width+20, false)
+		}),
+		layout.Expanded(func(gtx C) D {
+			return pg.periodDropDown.Layout(gtx, pg.marketDropDown.Width+pg.statusDropDown.Width+pg.sideDropDown.Width+30, false)
+		}),
+	)
+}
+
+func (pg *OrderHistoryPage) layoutOrders(gtx C) D {
+	orders := pg.orders
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+			if len(orders) == 0 {
+				if pg.loading {
+					return D{}
+				}
+				return pg.Theme.Label(values.TextSize14, values.String(values.StrNoOrders)).Layout(gtx)
+			}
+
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return orderRow(gtx, pg.grayLabels(values.String(values.StrTime), values.String(values.StrMarket),
+						values.String(values.StrSide), values.String(values.StrType), values.String(values.StrPrice),
+						values.String(values.StrQuantity), values.String(values.StrFilled), values.String(values.StrStatus))...)
+				}),
+				layout.Flexed(1, func(gtx C) D {
+					return pg.Theme.List(pg.listContainer).Layout(gtx, len(orders), func(gtx C, i int) D {
+						ord := orders[i]
+						cl, ok := pg.orderClickables[ord.ID.String()]
+						if !ok {
+							cl = pg.Theme.NewClickable(true)
+							pg.orderClickables[ord.ID.String()] = cl
+						}
+						return cl.Layout(gtx, func(gtx C) D {
+							return orderRow(gtx, orderLabels(pg.Load, ord)...)
+						})
+					})
+				}),
+			)
+		})
+	})
+}
+
+func (pg *OrderHistoryPage) grayLabels(titles ...string) []decredmaterial.Label {
+	labels := make([]decredmaterial.Label, len(titles))
+	for i, title := range titles {
+		labels[i] = pg.Theme.Label(values.TextSize12, title)
+		labels[i].Color = pg.Theme.Color.GrayText2
+	}
+	return labels
+}
+
+// orderRow lays out the columns of an order row with the same widths.
+func orderRow(gtx C, columns ...decredmaterial.Label) D {
+	children := make([]layout.FlexChild, len(columns))
+	for i, column := range columns {
+		children[i] = layout.Flexed(1/float32(len(columns)), column.Layout)
+	}
+	return layout.Inset{Top: values.MarginPadding4, Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.Flex{}.Layout(gtx, children...)
+	})
+}
+
+// orderAmounts returns the rate and the quantity and filled amount of the
+// order in conventional units.
+func orderAmounts(ord *core.Order) (rate, qty, filled string) {
+	baseUnits, quoteUnits := wallet.DexUnitInfo(ord.BaseID), wallet.DexUnitInfo(ord.QuoteID)
+	rate = "-"
+	if ord.Type == order.LimitOrderType {
+		rate = strconv.FormatFloat(calc.ConventionalRate(ord.Rate, baseUnits, quoteUnits), 'f', -1, 64)
+	}
+	// Market buys are for an amount of the quote asset.
+	qtyUnits, qtySymbol := baseUnits, ord.BaseSymbol
+	if ord.Type == order.MarketOrderType && !ord.Sell {
+		qtyUnits, qtySymbol = quoteUnits, ord.QuoteSymbol
+	}
+	qty = fmt.Sprintf("%s %s", formatAmount(ord.Qty, &qtyUnits), strings.ToUpper(qtySymbol))
+	filled = fmt.Sprintf("%s %s", formatAmount(ord.Filled, &qtyUnits), strings.ToUpper(qtySymbol))
+	return rate, qty, filled
+}
+
+func orderLabels(l *load.Load, ord *core.Order) []decredmaterial.Label {
+	side, sideColor := values.String(values.StrBuy), l.Theme.Color.Success
+	if ord.Sell {
+		side, sideColor = values.String(values.StrSell), l.Theme.Color.Danger
+	}
+	orderType := values.String(values.StrMarketOrder)
+	if ord.Type == order.LimitOrderType {
+		orderType = values.String(values.StrLimit)
+	}
+	rate, qty, filled := orderAmounts(ord)
+
+	label := func(txt string) decredmaterial.Label {
+		return l.Theme.Label(values.TextSize14, txt)
+	}
+	sideLbl := label(side)
+	sideLbl.Color = sideColor
+	return []decredmaterial.Label{
+		label(time.UnixMilli(int64(ord.Stamp)).Format("2006-01-02 15:04")),
+		label(fmt.Sprintf("%s-%s", strings.ToUpper(ord.BaseSymbol), strings.ToUpper(ord.QuoteSymbol))),
+		sideLbl,
+		label(orderType),
+		label(rate),
+		label(qty),
+		label(filled),
+		label(ord.Status.String()),
+	}
+}
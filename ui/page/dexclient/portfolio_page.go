@@ -0,0 +1,356 @@
+package dexclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"decred.org/dcrdex/client/core"
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PortfolioPageID = "DexPortfolio"
+
+// PortfolioPage shows the balances of the asset wallets of the DEX client,
+// their combined USD value and their deposit addresses.
+type PortfolioPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	// mu protects balances, usdRates and fetchingRates, which are updated
+	// in the background.
+	mu             sync.RWMutex
+	balances       []*wallet.DexAssetBalance
+	usdRates       map[uint32]float64
+	fetchingRates  bool
+	usdExchangeSet bool
+
+	copyAddressBtns map[uint32]*decredmaterial.Clickable
+	newAddressBtns  map[uint32]*decredmaterial.Button
+
+	backButton    decredmaterial.IconButton
+	listContainer *widget.List
+}
+
+func NewPortfolioPage(l *load.Load) *PortfolioPage {
+	pg := &PortfolioPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PortfolioPageID),
+		copyAddressBtns:  make(map[uint32]*decredmaterial.Clickable),
+		newAddressBtns:   make(map[uint32]*decredmaterial.Button),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PortfolioPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	if pg.Dexc().Core() == nil {
+		return
+	}
+
+	pg.loadBalances()
+	currencyExchangeValue := pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
+	pg.usdExchangeSet = currencyExchangeValue == values.USDExchangeValue
+	if pg.usdExchangeSet {
+		pg.mu.Lock()
+		pg.fetchingRates = true
+		pg.mu.Unlock()
+		go pg.fetchRates()
+	}
+
+	// Read the balances again when the client reports a change of the
+	// balance or the state of a wallet.
+	pg.WL.Wallet.DexNotifier().AddListener(PortfolioPageID, func(n core.Notification) {
+		if n.Type() == core.NoteTypeBalance || n.Type() == core.NoteTypeWalletState {
+			pg.loadBalances()
+			pg.ParentWindow().Reload()
+		}
+	})
+}
+
+func (pg *PortfolioPage) loadBalances() {
+	balances := wallet.DexPortfolio(pg.Dexc().Core())
+	pg.mu.Lock()
+	pg.balances = balances
+	pg.mu.Unlock()
+}
+
+// state returns the balances and the USD rates to display, and whether the
+// rates are being fetched.
+func (pg *PortfolioPage) state() ([]*wallet.DexAssetBalance, map[uint32]float64, bool) {
+	pg.mu.RLock()
+	defer pg.mu.RUnlock()
+	return pg.balances, pg.usdRates, pg.fetchingRates
+}
+
+func (pg *PortfolioPage) fetchRates() {
+	balances, _, _ := pg.state()
+	rates := wallet.DexPortfolioRates(pg.ctx, balances)
+	pg.mu.Lock()
+	pg.usdRates = rates
+	pg.fetchingRates = false
+	pg.mu.Unlock()
+	pg.ParentWindow().Reload()
+}
+
+func (pg *PortfolioPage) copyAddressBtn(assetID uint32) *decredmaterial.Clickable {
+	btn, ok := pg.copyAddressBtns[assetID]
+	if !ok {
+		btn = pg.Theme.NewClickable(false)
+		pg.copyAddressBtns[assetID] = btn
+	}
+	return btn
+}
+
+func (pg *PortfolioPage) newAddressBtn(assetID uint32) *decredmaterial.Button {
+	btn, ok := pg.newAddressBtns[assetID]
+	if !ok {
+		b := pg.Theme.OutlineButton(values.String(values.StrNewAddress))
+		btn = &b
+		pg.newAddressBtns[assetID] = btn
+	}
+	return btn
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PortfolioPage) HandleUserInteractions() {
+	balances, _, _ := pg.state()
+	for _, balance := range balances {
+		assetID := balance.AssetID
+		if !pg.newAddressBtn(assetID).Clicked() {
+			continue
+		}
+		go func() {
+			if _, err := pg.Dexc().Core().NewDepositAddress(assetID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return
+			}
+			pg.loadBalances()
+			pg.ParentWindow().Reload()
+		}()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PortfolioPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+	pg.WL.Wallet.DexNotifier().RemoveListener(PortfolioPageID)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PortfolioPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrPortfolio),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *PortfolioPage) layoutContent(gtx C) D {
+	balances, rates, fetchingRates := pg.state()
+	if len(balances) == 0 {
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.Theme.Label(values.TextSize14, values.String(values.StrNoDexWallets)).Layout)
+		})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			if !pg.usdExchangeSet {
+				return D{}
+			}
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.totalValueLayout(balances, rates, fetchingRates))
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			return pg.Theme.List(pg.listContainer).Layout(gtx, len(balances), func(gtx C, i int) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.assetLayout(balances[i], rates))
+			})
+		}),
+	)
+}
+
+func (pg *PortfolioPage) totalValueLayout(balances []*wallet.DexAssetBalance, rates map[uint32]float64, fetchingRates bool) layout.Widget {
+	return func(gtx C) D {
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				if fetchingRates || rates == nil {
+					return pg.Theme.Label(values.TextSize14, values.String(values.StrFetchingRates)).Layout(gtx)
+				}
+
+				total, missing := wallet.DexPortfolioUSD(balances, rates)
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrTotalValue))
+						lbl.Color = pg.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSize20, load.FormatUSDBalance(pg.Printer, total))
+						lbl.Font.Weight = text.SemiBold
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if len(missing) == 0 {
+							return D{}
+						}
+						lbl := pg.Theme.Label(values.TextSize12, values.StringF(values.StrNoRateFor, strings.ToUpper(strings.Join(missing, ", "))))
+						lbl.Color = pg.Theme.Color.GrayText2
+						return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+					}),
+				)
+			})
+		})
+	}
+}
+
+func (pg *PortfolioPage) assetLayout(balance *wallet.DexAssetBalance, rates map[uint32]float64) layout.Widget {
+	return func(gtx C) D {
+		symbol := strings.ToUpper(balance.Symbol)
+		amount := func(atoms uint64) string {
+			value := fmt.Sprintf("%s %s", formatAmount(atoms, &balance.Units), symbol)
+			if rate, ok := rates[balance.AssetID]; ok && pg.usdExchangeSet {
+				value += fmt.Sprintf(" (%s)", load.FormatUSDBalance(pg.Printer, balance.ToCoin(atoms)*rate))
+			}
+			return value
+		}
+		row := func(label, value string) layout.FlexChild {
+			return layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Flexed(.3, func(gtx C) D {
+							lbl := pg.Theme.Label(values.TextSize14, label)
+							lbl.Color = pg.Theme.Color.GrayText2
+							return lbl.Layout(gtx)
+						}),
+						layout.Flexed(.7, pg.Theme.Label(values.TextSize14, value).Layout),
+					)
+				})
+			})
+		}
+
+		state := pg.Theme.Label(values.TextSize14, values.String(values.StrRunning))
+		state.Color = pg.Theme.Color.GreenText
+		if !balance.Running {
+			state.Text = values.String(values.StrNotRunning)
+			state.Color = pg.Theme.Color.GrayText2
+		}
+
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						title := pg.Theme.Label(values.TextSize16, symbol)
+						title.Font.Weight = text.SemiBold
+						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								image := components.CoinImageBySymbol(pg.Load, balance.Symbol)
+								if image == nil {
+									return D{}
+								}
+								return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, image.Layout24dp)
+							}),
+							layout.Rigid(title.Layout),
+							layout.Flexed(1, func(gtx C) D {
+								return layout.E.Layout(gtx, state.Layout)
+							}),
+						)
+					}),
+					row(values.String(values.StrAvailable), amount(balance.Available)),
+					row(values.String(values.StrLocked), amount(balance.Locked)),
+					row(values.String(values.StrImmature), amount(balance.Immature)),
+					row(values.String(values.StrTotal), amount(balance.Total())),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+								layout.Flexed(.3, func(gtx C) D {
+									lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrDepositAddress))
+									lbl.Color = pg.Theme.Color.GrayText2
+									return lbl.Layout(gtx)
+								}),
+								layout.Flexed(.7, func(gtx C) D {
+									if balance.Address == "" {
+										return pg.Theme.Label(values.TextSize14, "-").Layout(gtx)
+									}
+									copyBtn := pg.copyAddressBtn(balance.AssetID)
+									if copyBtn.Clicked() {
+										clipboard.WriteOp{Text: balance.Address}.Add(gtx.Ops)
+										pg.Toast.Notify(values.String(values.StrCopied))
+									}
+									return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+										layout.Flexed(1, pg.Theme.Label(values.TextSize14, balance.Address).Layout),
+										layout.Rigid(func(gtx C) D {
+											return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+												return copyBtn.Layout(gtx, pg.Theme.Icons.CopyIcon.Layout24dp)
+											})
+										}),
+									)
+								}),
+							)
+						})
+					}),
+					layout.Rigid(func(gtx C) D {
+						if !balance.Running {
+							return D{}
+						}
+						return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.newAddressBtn(balance.AssetID).Layout)
+					}),
+				)
+			})
+		})
+	}
+}
@@ -80,7 +80,7 @@ func (amd *assetSelectorModal) Handle() {
 
 func (amd *assetSelectorModal) Layout(gtx layout.Context) D {
 	w := []layout.Widget{
-		amd.Load.Theme.Label(values.TextSize20, strConfirmSelectAssetPayFee).Layout,
+		amd.Load.Theme.Label(values.TextSize20, values.String(values.StrConfirmSelectAssetPayFee)).Layout,
 		amd.assetsInfoLayout(),
 		func(gtx C) D {
 			return layout.E.Layout(gtx, func(gtx C) D {
@@ -129,14 +129,14 @@ func (amd *assetSelectorModal) assetsInfoLayout() layout.Widget {
 										convertedAmountSymbol := fmt.Sprintf("%s %s", convertedAmount, asset.Info.UnitInfo.Conventional.Unit)
 										return amd.Theme.Label(values.TextSize16, strings.ToUpper(convertedAmountSymbol)).Layout(gtx)
 									}),
-									layout.Rigid(amd.Theme.Label(values.TextSize12, fmt.Sprintf(nStrNumberConfirmations, feeAsset.Confs)).Layout),
+									layout.Rigid(amd.Theme.Label(values.TextSize12, values.StringF(values.StrNumberConfirmations, feeAsset.Confs)).Layout),
 								)
 							}),
 							layout.Rigid(func(gtx C) D {
-								walletReady := amd.Theme.Label(values.TextSize12, strSetupNeeded)
+								walletReady := amd.Theme.Label(values.TextSize12, values.String(values.StrSetupNeeded))
 								walletReady.Color = amd.Theme.Color.Yellow
 								if asset.Wallet != nil {
-									walletReady.Text = strWalletReady
+									walletReady.Text = values.String(values.StrWalletReady)
 									walletReady.Color = amd.Theme.Color.Success
 								}
 								return walletReady.Layout(gtx)
@@ -0,0 +1,346 @@
+package dexclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/order"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/notification"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const SwapMonitorPageID = "DexSwapMonitor"
+
+// swapMonitorInterval is the interval of the updates of the swaps between the
+// notifications of the client, to follow the lock times.
+const swapMonitorInterval = time.Minute
+
+// startSwapMonitor starts monitoring the swaps of the DEX client and posts a
+// desktop notification for the changes of the swaps and the errors of the
+// client with the swaps.
+func startSwapMonitor(l *load.Load) {
+	notifier := l.WL.Wallet.DexNotifier()
+	notifier.Start(context.Background(), l.Dexc().Core())
+	systemNotification, _ := notification.NewSystemNotification()
+	l.WL.Wallet.DexSwapMonitor().Start(context.Background(), l.Dexc().Core(), notifier, swapMonitorInterval, func(event wallet.DexSwapEvent) {
+		message := swapEventMessage(event)
+		if message == "" {
+			return
+		}
+		if systemNotification == nil || systemNotification.Notify(message) != nil {
+			l.Toast.Notify(message)
+		}
+	})
+}
+
+func swapEventMessage(event wallet.DexSwapEvent) string {
+	if event.Type == wallet.DexSwapFailed {
+		return fmt.Sprintf("%s: %s", event.Subject, event.Details)
+	}
+
+	ord := event.Swap.Order
+	market := fmt.Sprintf("%s-%s", strings.ToUpper(ord.BaseSymbol), strings.ToUpper(ord.QuoteSymbol))
+	switch event.Type {
+	case wallet.DexSwapSent:
+		return values.StringF(values.StrSwapSentNotif, market, event.Swap.Host)
+	case wallet.DexSwapRedeemed:
+		return values.StringF(values.StrSwapRedeemedNotif, market, event.Swap.Host)
+	case wallet.DexSwapRefundDue:
+		return values.StringF(values.StrRefundDueNotif, market, event.Swap.Host)
+	case wallet.DexSwapRefundSent:
+		return values.StringF(values.StrRefundSentNotif, market, event.Swap.Host)
+	case wallet.DexSwapRevoked:
+		return values.StringF(values.StrSwapRevokedNotif, market, event.Swap.Host)
+	}
+	return ""
+}
+
+// SwapMonitorPage shows the progress of the active swaps and the refund of the
+// swaps the counterparty does not redeem.
+type SwapMonitorPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	refundBtns map[string]*decredmaterial.Button
+
+	backButton    decredmaterial.IconButton
+	listContainer *widget.List
+}
+
+func NewSwapMonitorPage(l *load.Load) *SwapMonitorPage {
+	pg := &SwapMonitorPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SwapMonitorPageID),
+		refundBtns:       make(map[string]*decredmaterial.Button),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SwapMonitorPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	go pg.refresh()
+}
+
+// refresh reloads the page every second for the lock time countdowns and the
+// swaps updated by the swap monitor.
+func (pg *SwapMonitorPage) refresh() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			pg.ParentWindow().Reload()
+		case <-pg.ctx.Done():
+			return
+		}
+	}
+}
+
+// refunder returns the DEX client if it refunds swaps on request.
+func (pg *SwapMonitorPage) refunder() (wallet.DexRefunder, bool) {
+	var c interface{} = pg.Dexc().Core()
+	refunder, ok := c.(wallet.DexRefunder)
+	return refunder, ok
+}
+
+func (pg *SwapMonitorPage) refundBtn(matchID string) *decredmaterial.Button {
+	btn, ok := pg.refundBtns[matchID]
+	if !ok {
+		b := pg.Theme.Button(values.String(values.StrRefundNow))
+		btn = &b
+		pg.refundBtns[matchID] = btn
+	}
+	return btn
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SwapMonitorPage) HandleUserInteractions() {
+	refunder, ok := pg.refunder()
+	if !ok {
+		return
+	}
+	for _, swap := range pg.WL.Wallet.DexSwapMonitor().Swaps() {
+		swap := swap
+		if !pg.refundBtn(swap.Match.MatchID.String()).Clicked() {
+			continue
+		}
+		withDexPass(pg.Load, pg.ParentWindow(), func(pw []byte) error {
+			if err := refunder.RefundMatch(pw, swap.Order.ID, swap.Match.MatchID); err != nil {
+				return err
+			}
+			pg.Toast.Notify(values.String(values.StrRefundRequested))
+			return nil
+		})
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SwapMonitorPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SwapMonitorPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrSwapMonitor),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *SwapMonitorPage) layoutContent(gtx C) D {
+	swaps := pg.WL.Wallet.DexSwapMonitor().Swaps()
+	if len(swaps) == 0 {
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.Theme.Label(values.TextSize14, values.String(values.StrNoActiveSwaps)).Layout)
+		})
+	}
+	return pg.Theme.List(pg.listContainer).Layout(gtx, len(swaps), func(gtx C, i int) D {
+		return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.swapLayout(swaps[i]))
+	})
+}
+
+// formatCountdown formats the time left until t.
+func formatCountdown(t time.Time) string {
+	left := time.Until(t).Round(time.Second)
+	if left <= 0 {
+		return values.String(values.StrExpired)
+	}
+	return fmt.Sprintf("%dh %02dm %02ds", int(left.Hours()), int(left.Minutes())%60, int(left.Seconds())%60)
+}
+
+// swapCoin returns the description of a swap coin with its confirmations.
+func swapCoin(coin *core.Coin) string {
+	if coin == nil {
+		return "-"
+	}
+	desc := fmt.Sprintf("%s %s", strings.ToUpper(coin.Symbol), coin.StringID)
+	if coin.Confs != nil {
+		desc += " " + values.StringF(values.StrConfirmationsOf, coin.Confs.Count, coin.Confs.Required)
+	}
+	return desc
+}
+
+func swapStage(swap *wallet.DexSwap) string {
+	switch swap.Stage {
+	case wallet.DexSwapSending:
+		return values.String(values.StrSendingSwap)
+	case wallet.DexSwapWaiting:
+		return values.String(values.StrWaitingCounterparty)
+	case wallet.DexSwapRedeeming:
+		return values.String(values.StrRedeemingSwap)
+	case wallet.DexSwapRefunded:
+		return values.String(values.StrRefunded)
+	default:
+		return values.String(values.StrComplete)
+	}
+}
+
+func swapRefundStatus(swap *wallet.DexSwap) string {
+	switch swap.Refund {
+	case wallet.DexRefundLocked:
+		if time.Now().Before(swap.LockTime) {
+			return values.StringF(values.StrRefundableIn, formatCountdown(swap.LockTime))
+		}
+		return values.String(values.StrRefundDue)
+	case wallet.DexRefundDue:
+		return values.String(values.StrRefundDue)
+	case wallet.DexRefunded:
+		return values.String(values.StrRefunded)
+	default:
+		return values.String(values.StrRefundNotNeeded)
+	}
+}
+
+func (pg *SwapMonitorPage) swapLayout(swap *wallet.DexSwap) layout.Widget {
+	return func(gtx C) D {
+		ord, match := swap.Order, swap.Match
+		side := values.String(values.StrBuy)
+		if ord.Sell {
+			side = values.String(values.StrSell)
+		}
+		role := values.String(values.StrMaker)
+		if match.Side == order.Taker {
+			role = values.String(values.StrTaker)
+		}
+		baseUnits := wallet.DexUnitInfo(ord.BaseID)
+		qty := fmt.Sprintf("%s %s", formatAmount(match.Qty, &baseUnits), strings.ToUpper(ord.BaseSymbol))
+
+		row := func(label, value string) layout.FlexChild {
+			return layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Flexed(.3, func(gtx C) D {
+							lbl := pg.Theme.Label(values.TextSize14, label)
+							lbl.Color = pg.Theme.Color.GrayText2
+							return lbl.Layout(gtx)
+						}),
+						layout.Flexed(.7, pg.Theme.Label(values.TextSize14, value).Layout),
+					)
+				})
+			})
+		}
+		lockTime := func(t time.Time) string {
+			return fmt.Sprintf("%s (%s)", t.Format(time.RFC1123), formatCountdown(t))
+		}
+
+		stage := pg.Theme.Label(values.TextSize14, swapStage(swap))
+		stage.Color = pg.Theme.Color.Primary
+		if swap.Refund == wallet.DexRefundDue || match.Revoked {
+			stage.Color = pg.Theme.Color.Danger
+		}
+
+		rows := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				title := pg.Theme.Label(values.TextSize16, fmt.Sprintf("%s %s (%s-%s)", side, qty,
+					strings.ToUpper(ord.BaseSymbol), strings.ToUpper(ord.QuoteSymbol)))
+				title.Font.Weight = text.SemiBold
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(title.Layout),
+					layout.Flexed(1, func(gtx C) D {
+						return layout.E.Layout(gtx, stage.Layout)
+					}),
+				)
+			}),
+			row(values.String(values.StrDexAddr), swap.Host),
+			row(values.String(values.StrMatch), match.MatchID.String()),
+			row(values.String(values.StrRole), role),
+			row(values.String(values.StrStatus), match.Status.String()),
+			row(values.String(values.StrYourSwap), swapCoin(match.Swap)),
+			row(values.String(values.StrCounterSwap), swapCoin(match.CounterSwap)),
+			row(values.String(values.StrLockTime), lockTime(swap.LockTime)),
+			row(values.String(values.StrCounterLockTime), lockTime(swap.CounterLockTime)),
+			row(values.String(values.StrRefund), swapRefundStatus(swap)),
+		}
+		if match.Revoked {
+			rows = append(rows, row(values.String(values.StrRevoked), values.String(values.StrMatchRevoked)))
+		}
+		if _, ok := pg.refunder(); ok && swap.Refund == wallet.DexRefundDue {
+			btn := pg.refundBtn(match.MatchID.String())
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, btn.Layout)
+			}))
+		}
+
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+			})
+		})
+	}
+}
@@ -0,0 +1,566 @@
+package dexclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/order"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// maxBookRows is the number of orders shown on each side of the order book.
+const maxBookRows = 10
+
+// initTradeWidgets creates the widgets of the trading screen.
+func (pg *Page) initTradeWidgets() {
+	pg.marketClickables = make(map[string]*decredmaterial.Clickable)
+	pg.cancelOrderClickables = make(map[string]*decredmaterial.Clickable)
+
+	pg.sideSwitch = pg.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrBuy)},
+		{Text: values.String(values.StrSell)},
+	})
+	pg.orderTypeSwitch = pg.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrLimit)},
+		{Text: values.String(values.StrMarketOrder)},
+	})
+
+	pg.qtyEditor = pg.Theme.Editor(new(widget.Editor), values.String(values.StrQuantity))
+	pg.qtyEditor.Editor.SingleLine = true
+	pg.rateEditor = pg.Theme.Editor(new(widget.Editor), values.String(values.StrPrice))
+	pg.rateEditor.Editor.SingleLine = true
+
+	pg.placeOrderBtn = pg.Theme.Button(values.String(values.StrPlaceOrder))
+	pg.orderHistoryBtn = pg.Theme.OutlineButton(values.String(values.StrOrderHistory))
+	pg.swapMonitorBtn = pg.Theme.OutlineButton(values.String(values.StrSwapMonitor))
+	pg.portfolioBtn = pg.Theme.OutlineButton(values.String(values.StrPortfolio))
+	pg.tradeList = &widget.List{
+		List: layout.List{Axis: layout.Vertical},
+	}
+}
+
+// selectMarket starts syncing the market and stops syncing the previously
+// selected market.
+func (pg *Page) selectMarket(host string, mkt *core.Market) {
+	market, err := wallet.NewDexMarket(pg.Dexc().Core(), host, mkt)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	if pg.marketCancel != nil {
+		pg.marketCancel()
+	}
+	var ctx context.Context
+	ctx, pg.marketCancel = context.WithCancel(pg.ctx)
+	pg.market = market
+	pg.openOrders = nil
+
+	go func() {
+		if err := market.Sync(ctx, pg.ParentWindow().Reload); err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+		pg.refreshOpenOrders()
+	}()
+}
+
+// refreshOpenOrders reads the open orders of the selected market.
+func (pg *Page) refreshOpenOrders() {
+	market := pg.market
+	if market == nil {
+		return
+	}
+	orders, err := market.OpenOrders()
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.openOrders = orders
+	pg.ParentWindow().Reload()
+}
+
+// supportedMarkets returns the markets of the DEX that can be traded.
+func supportedMarkets(d *core.Exchange) []*core.Market {
+	var markets []*core.Market
+	for _, mkt := range d.Markets {
+		if supportedMarket(mkt) {
+			markets = append(markets, mkt)
+		}
+	}
+	sortMarkets(markets)
+	return markets
+}
+
+func marketDisplayName(mkt *core.Market) string {
+	return fmt.Sprintf("%s-%s", strings.ToUpper(mkt.BaseSymbol), strings.ToUpper(mkt.QuoteSymbol))
+}
+
+// orderFormError returns the text shown for the errors of an order.
+func orderFormError(err error) string {
+	switch err {
+	case wallet.ErrInvalidQuantity:
+		return values.String(values.StrInvalidQuantity)
+	case wallet.ErrLotSize:
+		return values.String(values.StrQtyNotLotMultiple)
+	case wallet.ErrInvalidRate:
+		return values.String(values.StrInvalidPrice)
+	case wallet.ErrRateStep:
+		return values.String(values.StrPriceNotRateStepMultiple)
+	case wallet.ErrNoLiquidity:
+		return values.String(values.StrNoLiquidity)
+	case wallet.ErrMarketBuyTooSmall:
+		return values.String(values.StrMarketBuyTooSmall)
+	}
+	return err.Error()
+}
+
+// orderForm reads the order entered by the user.
+func (pg *Page) orderForm() (wallet.DexOrderForm, error) {
+	form := wallet.DexOrderForm{
+		Sell:    pg.sideSwitch.SelectedIndex() == 2,
+		IsLimit: pg.orderTypeSwitch.SelectedIndex() == 1,
+	}
+
+	qty, err := strconv.ParseFloat(pg.qtyEditor.Editor.Text(), 64)
+	if err != nil {
+		return form, wallet.ErrInvalidQuantity
+	}
+	form.Qty = qty
+
+	if form.IsLimit {
+		rate, err := strconv.ParseFloat(pg.rateEditor.Editor.Text(), 64)
+		if err != nil {
+			return form, wallet.ErrInvalidRate
+		}
+		form.Rate = rate
+	}
+	return form, nil
+}
+
+// validateOrder shows the errors of the order entered by the user and
+// returns true if it can be placed.
+func (pg *Page) validateOrder() bool {
+	pg.qtyEditor.SetError("")
+	pg.rateEditor.SetError("")
+	if pg.qtyEditor.Editor.Text() == "" {
+		return false
+	}
+
+	form, err := pg.orderForm()
+	if err == nil {
+		_, err = pg.market.TradeForm(form)
+	}
+	switch err {
+	case nil:
+		return true
+	case wallet.ErrInvalidRate, wallet.ErrRateStep:
+		if pg.rateEditor.Editor.Text() != "" {
+			pg.rateEditor.SetError(orderFormError(err))
+		}
+	default:
+		pg.qtyEditor.SetError(orderFormError(err))
+	}
+	return false
+}
+
+func (pg *Page) placeOrder() {
+	form, err := pg.orderForm()
+	if err != nil {
+		pg.Toast.NotifyError(orderFormError(err))
+		return
+	}
+
+	market := pg.market
+	withDexPass(pg.Load, pg.ParentWindow(), func(pw []byte) error {
+		pg.placingOrder = true
+		defer func() {
+			pg.placingOrder = false
+		}()
+		if _, err := market.PlaceOrder(pw, form); err != nil {
+			return errors.New(orderFormError(err))
+		}
+		pg.Toast.Notify(values.String(values.StrOrderPlaced))
+		pg.qtyEditor.Editor.SetText("")
+		pg.refreshOpenOrders()
+		return nil
+	})
+}
+
+func (pg *Page) cancelOrder(ord *core.Order) {
+	market := pg.market
+	withDexPass(pg.Load, pg.ParentWindow(), func(pw []byte) error {
+		if err := market.CancelOrder(pw, ord.ID); err != nil {
+			return err
+		}
+		pg.Toast.Notify(values.String(values.StrCancelRequested))
+		pg.refreshOpenOrders()
+		return nil
+	})
+}
+
+// handleTradeInteractions handles the user interactions of the trading
+// screen.
+func (pg *Page) handleTradeInteractions() {
+	d := pg.dexServer()
+	if pg.isLoadingDexClient() || d == nil || !d.Connected || d.PendingFee != nil {
+		return
+	}
+
+	if pg.orderHistoryBtn.Clicked() {
+		pg.ParentNavigator().Display(NewOrderHistoryPage(pg.Load))
+	}
+
+	if pg.swapMonitorBtn.Clicked() {
+		pg.ParentNavigator().Display(NewSwapMonitorPage(pg.Load))
+	}
+
+	if pg.portfolioBtn.Clicked() {
+		pg.ParentNavigator().Display(NewPortfolioPage(pg.Load))
+	}
+
+	// The market of the previous server is dropped when the active server
+	// changes.
+	if pg.market != nil && pg.market.Host() != d.Host {
+		pg.marketCancel()
+		pg.market = nil
+		pg.openOrders = nil
+	}
+
+	markets := supportedMarkets(d)
+	for _, mkt := range markets {
+		cl, ok := pg.marketClickables[mkt.Name]
+		if !ok {
+			cl = pg.Theme.NewClickable(true)
+			pg.marketClickables[mkt.Name] = cl
+		}
+		if cl.Clicked() && (pg.market == nil || pg.market.Market().Name != mkt.Name) {
+			pg.selectMarket(d.Host, mkt)
+		}
+	}
+	if pg.market == nil && len(markets) > 0 {
+		pg.selectMarket(d.Host, markets[0])
+	}
+	if pg.market == nil {
+		return
+	}
+
+	pg.placeOrderBtn.SetEnabled(pg.validateOrder() && !pg.placingOrder)
+	if pg.placeOrderBtn.Clicked() {
+		pg.placeOrder()
+	}
+
+	for _, ord := range pg.openOrders {
+		if cl, ok := pg.cancelOrderClickables[ord.ID.String()]; ok && cl.Clicked() {
+			pg.cancelOrder(ord)
+		}
+	}
+}
+
+func (pg *Page) tradeLayout(d *core.Exchange) layout.Widget {
+	return func(gtx C) D {
+		if pg.market == nil {
+			return pg.Theme.Label(values.TextSize14, values.String(values.StrNoSupportedMarkets)).Layout(gtx)
+		}
+
+		sections := []layout.Widget{
+			pg.marketSelectorLayout(d),
+			func(gtx C) D {
+				return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
+					layout.Flexed(.35, pg.orderBookLayout),
+					layout.Flexed(.35, func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding16, Right: values.MarginPadding16}.Layout(gtx, pg.orderEntryLayout)
+					}),
+					layout.Flexed(.3, pg.tradeTapeLayout),
+				)
+			},
+			pg.openOrdersLayout,
+		}
+		return pg.Theme.List(pg.tradeList).Layout(gtx, len(sections), func(gtx C, i int) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, sections[i])
+		})
+	}
+}
+
+func (pg *Page) sectionTitle(title string) layout.Widget {
+	return func(gtx C) D {
+		lbl := pg.Theme.Label(values.TextSize16, title)
+		lbl.Font.Weight = text.SemiBold
+		return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+	}
+}
+
+func (pg *Page) marketSelectorLayout(d *core.Exchange) layout.Widget {
+	return func(gtx C) D {
+		items := []layout.FlexChild{
+			layout.Rigid(pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s %s", values.String(values.StrAllMarketAt), d.Host)).Layout),
+		}
+		for _, mkt := range supportedMarkets(d) {
+			mkt := mkt
+			cl, ok := pg.marketClickables[mkt.Name]
+			if !ok {
+				continue
+			}
+			items = append(items, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					return cl.Layout(gtx, func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSize14, marketDisplayName(mkt))
+						if pg.market.Market().Name == mkt.Name {
+							lbl.Font.Weight = text.SemiBold
+							lbl.Color = pg.Theme.Color.Primary
+						}
+						return layout.UniformInset(values.MarginPadding4).Layout(gtx, lbl.Layout)
+					})
+				})
+			}))
+		}
+		items = append(items, layout.Flexed(1, func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				mkt := pg.market.Market()
+				lotSize := formatAmountUnit(mkt.BaseID, mkt.BaseSymbol, mkt.LotSize)
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s: %s", values.String(values.StrLotSize), lotSize)).Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, pg.orderHistoryBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.swapMonitorBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.portfolioBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.dexServersBtn.Layout)
+					}),
+				)
+			})
+		}))
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx, items...)
+	}
+}
+
+// tableRow lays out the columns of a table row with the same widths.
+func (pg *Page) tableRow(gtx C, columns ...decredmaterial.Label) D {
+	children := make([]layout.FlexChild, len(columns))
+	for i, column := range columns {
+		children[i] = layout.Flexed(1/float32(len(columns)), column.Layout)
+	}
+	return layout.Inset{Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx, children...)
+	})
+}
+
+func (pg *Page) tableHeader(gtx C, titles ...string) D {
+	columns := make([]decredmaterial.Label, len(titles))
+	for i, title := range titles {
+		columns[i] = pg.Theme.Label(values.TextSize12, title)
+		columns[i].Color = pg.Theme.Color.GrayText2
+	}
+	return pg.tableRow(gtx, columns...)
+}
+
+func (pg *Page) orderBookLayout(gtx C) D {
+	mkt, baseUnits := pg.market.Market(), pg.market.BaseUnits()
+	sells, buys := pg.market.Book()
+	if len(sells) > maxBookRows {
+		sells = sells[:maxBookRows]
+	}
+	if len(buys) > maxBookRows {
+		buys = buys[:maxBookRows]
+	}
+
+	bookRow := func(ord *core.MiniOrder) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			rate := pg.Theme.Label(values.TextSize14, strconv.FormatFloat(pg.market.ConventionalRate(ord.MsgRate), 'f', -1, 64))
+			rate.Color = pg.Theme.Color.Success
+			if ord.Sell {
+				rate.Color = pg.Theme.Color.Danger
+			}
+			return pg.tableRow(gtx, rate, pg.Theme.Label(values.TextSize14, formatAmount(ord.QtyAtomic, &baseUnits)))
+		})
+	}
+
+	rows := []layout.FlexChild{
+		layout.Rigid(pg.sectionTitle(values.String(values.StrOrderBook))),
+		layout.Rigid(func(gtx C) D {
+			return pg.tableHeader(gtx,
+				fmt.Sprintf("%s (%s)", values.String(values.StrPrice), strings.ToUpper(mkt.QuoteSymbol)),
+				fmt.Sprintf("%s (%s)", values.String(values.StrQuantity), strings.ToUpper(mkt.BaseSymbol)))
+		}),
+	}
+	// The best sell is shown last, next to the best buy.
+	for i := len(sells) - 1; i >= 0; i-- {
+		rows = append(rows, bookRow(sells[i]))
+	}
+	rows = append(rows, layout.Rigid(func(gtx C) D {
+		return layout.Inset{Top: values.MarginPadding4, Bottom: values.MarginPadding8}.Layout(gtx, pg.Theme.Separator().Layout)
+	}))
+	for _, ord := range buys {
+		rows = append(rows, bookRow(ord))
+	}
+	if len(sells) == 0 && len(buys) == 0 {
+		rows = append(rows, layout.Rigid(pg.Theme.Label(values.TextSize14, values.String(values.StrEmptyOrderBook)).Layout))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+func (pg *Page) orderEntryLayout(gtx C) D {
+	mkt := pg.market.Market()
+	isLimit := pg.orderTypeSwitch.SelectedIndex() == 1
+	isSell := pg.sideSwitch.SelectedIndex() == 2
+
+	// Market buys are for an amount of the quote asset.
+	qtyUnit := strings.ToUpper(mkt.BaseSymbol)
+	if !isLimit && !isSell {
+		qtyUnit = strings.ToUpper(mkt.QuoteSymbol)
+	}
+	pg.qtyEditor.Hint = fmt.Sprintf("%s (%s)", values.String(values.StrQuantity), qtyUnit)
+	pg.rateEditor.Hint = fmt.Sprintf("%s (%s)", values.String(values.StrPrice), strings.ToUpper(mkt.QuoteSymbol))
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.sectionTitle(values.String(values.StrPlaceOrder))),
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
+				layout.Rigid(pg.sideSwitch.Layout),
+				layout.Rigid(pg.orderTypeSwitch.Layout),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !isLimit {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.rateEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.qtyEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				if pg.placingOrder {
+					return layout.Center.Layout(gtx, pg.materialLoader.Layout)
+				}
+				pg.placeOrderBtn.Text = values.String(values.StrBuy)
+				if isSell {
+					pg.placeOrderBtn.Text = values.String(values.StrSell)
+				}
+				return pg.placeOrderBtn.Layout(gtx)
+			})
+		}),
+	)
+}
+
+func (pg *Page) tradeTapeLayout(gtx C) D {
+	mkt, baseUnits := pg.market.Market(), pg.market.BaseUnits()
+	trades := pg.market.Trades()
+	if len(trades) > 2*maxBookRows {
+		trades = trades[:2*maxBookRows]
+	}
+
+	rows := []layout.FlexChild{
+		layout.Rigid(pg.sectionTitle(values.String(values.StrRecentTrades))),
+		layout.Rigid(func(gtx C) D {
+			return pg.tableHeader(gtx,
+				fmt.Sprintf("%s (%s)", values.String(values.StrPrice), strings.ToUpper(mkt.QuoteSymbol)),
+				fmt.Sprintf("%s (%s)", values.String(values.StrQuantity), strings.ToUpper(mkt.BaseSymbol)),
+				values.String(values.StrTime))
+		}),
+	}
+	for _, trade := range trades {
+		trade := trade
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			rate := pg.Theme.Label(values.TextSize14, strconv.FormatFloat(pg.market.ConventionalRate(trade.Rate), 'f', -1, 64))
+			rate.Color = pg.Theme.Color.Danger
+			if trade.Up {
+				rate.Color = pg.Theme.Color.Success
+			}
+			stamp := pg.Theme.Label(values.TextSize12, formatStamp(trade.Stamp))
+			stamp.Color = pg.Theme.Color.GrayText2
+			return pg.tableRow(gtx, rate, pg.Theme.Label(values.TextSize14, formatAmount(trade.Qty, &baseUnits)), stamp)
+		}))
+	}
+	if len(trades) == 0 {
+		rows = append(rows, layout.Rigid(pg.Theme.Label(values.TextSize14, values.String(values.StrNoRecentTrades)).Layout))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+func (pg *Page) openOrdersLayout(gtx C) D {
+	baseUnits, quoteUnits := pg.market.BaseUnits(), pg.market.QuoteUnits()
+	openOrders := pg.openOrders
+
+	rows := []layout.FlexChild{
+		layout.Rigid(pg.sectionTitle(values.String(values.StrOpenOrders))),
+		layout.Rigid(func(gtx C) D {
+			return pg.tableHeader(gtx, values.String(values.StrSide), values.String(values.StrType), values.String(values.StrPrice),
+				values.String(values.StrQuantity), values.String(values.StrFilled), values.String(values.StrStatus), "")
+		}),
+	}
+	for _, ord := range openOrders {
+		ord := ord
+		cl, ok := pg.cancelOrderClickables[ord.ID.String()]
+		if !ok {
+			cl = pg.Theme.NewClickable(true)
+			pg.cancelOrderClickables[ord.ID.String()] = cl
+		}
+
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			side, sideColor := values.String(values.StrBuy), pg.Theme.Color.Success
+			if ord.Sell {
+				side, sideColor = values.String(values.StrSell), pg.Theme.Color.Danger
+			}
+			sideLbl := pg.Theme.Label(values.TextSize14, side)
+			sideLbl.Color = sideColor
+
+			orderType, rate := values.String(values.StrMarketOrder), "-"
+			if ord.Type == order.LimitOrderType {
+				orderType = values.String(values.StrLimit)
+				rate = strconv.FormatFloat(pg.market.ConventionalRate(ord.Rate), 'f', -1, 64)
+			}
+			// Market buys are for an amount of the quote asset.
+			qtyUnits := baseUnits
+			if ord.Type == order.MarketOrderType && !ord.Sell {
+				qtyUnits = quoteUnits
+			}
+
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(6.0/7, func(gtx C) D {
+					return pg.tableRow(gtx,
+						sideLbl,
+						pg.Theme.Label(values.TextSize14, orderType),
+						pg.Theme.Label(values.TextSize14, rate),
+						pg.Theme.Label(values.TextSize14, formatAmount(ord.Qty, &qtyUnits)),
+						pg.Theme.Label(values.TextSize14, formatAmount(ord.Filled, &qtyUnits)),
+						pg.Theme.Label(values.TextSize14, ord.Status.String()),
+					)
+				}),
+				layout.Flexed(1.0/7, func(gtx C) D {
+					if ord.Cancelling {
+						lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrCancelling))
+						lbl.Color = pg.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}
+					return cl.Layout(gtx, func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrCancel))
+						lbl.Color = pg.Theme.Color.Danger
+						return layout.UniformInset(values.MarginPadding4).Layout(gtx, lbl.Layout)
+					})
+				}),
+			)
+		}))
+	}
+	if len(openOrders) == 0 {
+		rows = append(rows, layout.Rigid(pg.Theme.Label(values.TextSize14, values.String(values.StrNoOpenOrders)).Layout))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
@@ -0,0 +1,119 @@
+package dexclient
+
+import (
+	"errors"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// updateCertModal replaces the TLS certificate of a DEX server, e.g. after
+// the server renewed its certificate.
+type updateCertModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	host           string
+	cert           decredmaterial.Editor
+	updateBtn      decredmaterial.Button
+	cancelBtn      decredmaterial.Button
+	isSending      bool
+	materialLoader material.LoaderStyle
+}
+
+func newUpdateCertModal(l *load.Load, host string) *updateCertModal {
+	md := &updateCertModal{
+		Load:           l,
+		Modal:          l.Theme.ModalFloatTitle("dex_update_cert_modal"),
+		host:           host,
+		cert:           l.Theme.Editor(new(widget.Editor), values.String(values.StrTLSCert)),
+		updateBtn:      l.Theme.Button(values.String(values.StrUpdate)),
+		cancelBtn:      l.Theme.OutlineButton(values.String(values.StrCancel)),
+		materialLoader: material.Loader(l.Theme.Base),
+	}
+	md.updateBtn.SetEnabled(false)
+
+	return md
+}
+
+func (md *updateCertModal) OnResume() {
+	md.cert.Editor.Focus()
+}
+
+func (md *updateCertModal) OnDismiss() {}
+
+func (md *updateCertModal) Handle() {
+	md.updateBtn.SetEnabled(md.cert.Editor.Text() != "" && !md.isSending)
+	if md.updateBtn.Clicked() && md.cert.Editor.Text() != "" {
+		md.updateCert([]byte(md.cert.Editor.Text()))
+	}
+
+	if md.cancelBtn.Clicked() && !md.isSending {
+		md.Dismiss()
+	}
+}
+
+func (md *updateCertModal) updateCert(cert []byte) {
+	if md.isSending {
+		return
+	}
+
+	withDexPass(md.Load, md.ParentWindow(), func(pw []byte) error {
+		md.isSending = true
+		md.Modal.SetDisabled(true)
+		defer func() {
+			md.isSending = false
+			md.Modal.SetDisabled(false)
+		}()
+
+		err := md.WL.Wallet.ReplaceDexCert(md.Dexc().Core(), pw, md.host, cert)
+		var backupErr *wallet.DexAccountBackupError
+		if errors.As(err, &backupErr) {
+			return errors.New(values.StringF(values.StrDexAccountBackupAt, backupErr.Err, backupErr.Path))
+		}
+		if err != nil {
+			return err
+		}
+		saveKnownDexServer(md.Load, md.host, cert)
+		md.Toast.Notify(values.String(values.StrCertUpdated))
+		md.Dismiss()
+		return nil
+	})
+}
+
+func (md *updateCertModal) Layout(gtx layout.Context) D {
+	w := []layout.Widget{
+		md.Theme.Label(values.TextSize20, values.String(values.StrUpdateCert)).Layout,
+		md.Theme.Label(values.TextSize14, md.host).Layout,
+		func(gtx C) D {
+			gtx.Constraints.Max.Y = 300
+			return md.cert.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if md.isSending {
+							return D{}
+						}
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, md.cancelBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if md.isSending {
+							return md.materialLoader.Layout(gtx)
+						}
+						return md.updateBtn.Layout(gtx)
+					}),
+				)
+			})
+		},
+	}
+
+	return md.Modal.Layout(gtx, w)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/asset/btc"
@@ -12,41 +13,6 @@ import (
 	"decred.org/dcrdex/dex"
 )
 
-// TODO: move this to the dcrlibwallet
-// DEXClientPass use for DEX since the UI not required password.
-const DEXClientPass = "DEXClientPass"
-
-// TODO: add localizable support for all these strings values
-const (
-	strLogin                    = "Login"
-	strStartSyncToUse           = "Start sync to continue"
-	strWalletPassword           = "Wallet Password"
-	strSelectAccountForDex      = "Select DCR account to use with DEX"
-	strWaitingConfirms          = "Waiting for confirmations..."
-	strDexAddr                  = "DEX Address"
-	strSubmit                   = "Submit"
-	strCustomServer             = "Custom Server"
-	strAddADex                  = "Add a dex"
-	strAddA                     = "Add a"
-	strTLSCert                  = "TLS Certificate"
-	strRegister                 = "Register"
-	strConfirmReg               = "Confirm Registration"
-	strRequireWalletPayFee      = "Your wallet is required to pay registration fees."
-	strConfirmSelectAssetPayFee = "How will you pay the registration fee?"
-	strSetupNeeded              = "Setup Needed"
-	strWalletReady              = "Wallet Ready"
-	strMarket                   = "Market"
-	strAllMarketAt              = "All markets at"
-	strLotSize                  = "Lot Size"
-	strSuccessful               = "Successfully!"
-
-	nStrNameWallet           = "%s Wallet"
-	nStrAlreadyConnectWallet = "Already connected a %s wallet"
-	nStrNumberConfirmations  = "%d confirmations"
-	nStrConnHostError        = "Connection to dex server %s failed. You can close app and try again later or wait for it to reconnect"
-	nStrConfirmationsStatus  = "In order to trade at %s, the registration fee payment needs %d confirmations."
-)
-
 // supportedMarket check supported market for app depend on dcrlibwallet.
 // TODO: update the logic or remove this when supported all markets.
 func supportedMarket(mkt *core.Market) bool {
@@ -76,6 +42,18 @@ func formatAmountUnit(assetID uint32, assetName string, amount uint64) string {
 	return fmt.Sprintf("%s %s", convertedLotSize, unitInfo.Conventional.Unit)
 }
 
+// formatStamp formats a timestamp in milliseconds as the local time of day.
+func formatStamp(stamp uint64) string {
+	return time.UnixMilli(int64(stamp)).Format("15:04:05")
+}
+
+// sortMarkets sorts markets by name
+func sortMarkets(markets []*core.Market) {
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].Name < markets[j].Name
+	})
+}
+
 // sortFeeAsset convert map FeeAsset into a sorted slice
 func sortFeeAsset(mapFeeAsset map[string]*core.FeeAsset) []*core.FeeAsset {
 	feeAssets := make([]*core.FeeAsset, 0, len(mapFeeAsset))
@@ -87,15 +65,3 @@ func sortFeeAsset(mapFeeAsset map[string]*core.FeeAsset) []*core.FeeAsset {
 	})
 	return feeAssets
 }
-
-// sortServers convert mapExchanges into a sorted slice
-func sortServers(mapExchanges map[string]*core.Exchange) []*core.Exchange {
-	exchanges := make([]*core.Exchange, 0, len(mapExchanges))
-	for _, dexServer := range mapExchanges {
-		exchanges = append(exchanges, dexServer)
-	}
-	sort.Slice(exchanges, func(i, j int) bool {
-		return exchanges[i].Host < exchanges[j].Host
-	})
-	return exchanges
-}
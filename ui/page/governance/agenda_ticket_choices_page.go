@@ -0,0 +1,494 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const AgendaTicketChoicesPageID = "AgendaTicketChoices"
+
+// ticketChoiceRow is a ticket listed with the checkbox that selects it.
+type ticketChoiceRow struct {
+	choice   *wallet.TicketAgendaChoice
+	checkBox decredmaterial.CheckBoxStyle
+}
+
+// choicesUpdate is the result of loading, syncing or submitting the choices
+// in the background, applied to the page in HandleUserInteractions.
+type choicesUpdate struct {
+	// choices replace the displayed choices unless nil.
+	choices []*wallet.TicketAgendaChoice
+	// resetAssigned clears the choices assigned on the page once they are
+	// submitted.
+	resetAssigned bool
+}
+
+// AgendaTicketChoicesPage lists the vote choice of each ticket of a wallet
+// on an agenda, and lets the user set the choice of groups of tickets.
+type AgendaTicketChoicesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	agenda *dcrlibwallet.Agenda
+	wallet *dcrlibwallet.Wallet
+
+	choices []*wallet.TicketAgendaChoice
+	rows    []*ticketChoiceRow
+	// assigned maps tickets to the choice assigned to them on this page.
+	assigned map[string]string
+	loading  bool
+
+	// updateMu protects update, which is set by the background goroutines.
+	// The other fields are only used on the UI goroutine.
+	updateMu sync.Mutex
+	update   *choicesUpdate
+
+	backButton     decredmaterial.IconButton
+	selectAll      decredmaterial.CheckBoxStyle
+	choiceBtns     []decredmaterial.Button
+	syncBtn        decredmaterial.Button
+	submitBtn      decredmaterial.Button
+	materialLoader material.LoaderStyle
+	listContainer  *widget.List
+}
+
+func NewAgendaTicketChoicesPage(l *load.Load, agenda *dcrlibwallet.Agenda, w *dcrlibwallet.Wallet) *AgendaTicketChoicesPage {
+	pg := &AgendaTicketChoicesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AgendaTicketChoicesPageID),
+		agenda:           agenda,
+		wallet:           w,
+		assigned:         make(map[string]string),
+		selectAll:        l.Theme.CheckBox(new(widget.Bool), values.String(values.StrSelectAll)),
+		syncBtn:          l.Theme.OutlineButton(values.String(values.StrSyncFromVSP)),
+		submitBtn:        l.Theme.Button(values.String(values.StrSubmit)),
+		materialLoader:   material.Loader(l.Theme.Base),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.choiceBtns = make([]decredmaterial.Button, len(agenda.Choices))
+	for i, choice := range agenda.Choices {
+		pg.choiceBtns[i] = l.Theme.OutlineButton(values.StringF(values.StrSetSelectedTo, choice.Id))
+	}
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AgendaTicketChoicesPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadChoices()
+}
+
+// loadChoices reads the choices saved in the wallet for the unspent,
+// unexpired tickets in the background.
+func (pg *AgendaTicketChoicesPage) loadChoices() {
+	pg.loading = true
+	go pg.fetchChoices(false)
+}
+
+// fetchChoices reads the choices saved in the wallet and hands them off to
+// the page. It is called from a background goroutine.
+func (pg *AgendaTicketChoicesPage) fetchChoices(resetAssigned bool) {
+	update := choicesUpdate{resetAssigned: resetAssigned}
+	defer func() {
+		pg.handOff(update)
+	}()
+
+	tickets, err := pg.wallet.UnspentUnexpiredTickets()
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	hashes := make([]string, len(tickets))
+	for i := range tickets {
+		hashes[i] = tickets[i].Hash
+	}
+
+	choices, err := wallet.TicketAgendaChoices(pg.ctx, pg.wallet, pg.agenda.AgendaID, hashes)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	update.choices = choices
+}
+
+// handOff passes the result of a background goroutine to the UI goroutine.
+func (pg *AgendaTicketChoicesPage) handOff(update choicesUpdate) {
+	pg.updateMu.Lock()
+	if pg.update != nil {
+		// An update that wasn't applied yet is merged into this one.
+		update.resetAssigned = update.resetAssigned || pg.update.resetAssigned
+		if update.choices == nil {
+			update.choices = pg.update.choices
+		}
+	}
+	pg.update = &update
+	pg.updateMu.Unlock()
+	pg.ParentWindow().Reload()
+}
+
+// applyUpdate displays the result handed off by the last background
+// goroutine, if any.
+func (pg *AgendaTicketChoicesPage) applyUpdate() {
+	pg.updateMu.Lock()
+	update := pg.update
+	pg.update = nil
+	pg.updateMu.Unlock()
+	if update == nil {
+		return
+	}
+
+	pg.loading = false
+	if update.resetAssigned {
+		pg.assigned = make(map[string]string)
+	}
+	if update.choices != nil {
+		pg.setChoices(update.choices)
+	}
+}
+
+func (pg *AgendaTicketChoicesPage) setChoices(choices []*wallet.TicketAgendaChoice) {
+	// The tickets selected remain selected when the choices are synced.
+	checkBoxes := make(map[string]decredmaterial.CheckBoxStyle, len(pg.rows))
+	for _, row := range pg.rows {
+		checkBoxes[row.choice.Ticket] = row.checkBox
+	}
+
+	rows := make([]*ticketChoiceRow, len(choices))
+	for i, choice := range choices {
+		checkBox, ok := checkBoxes[choice.Ticket]
+		if !ok {
+			checkBox = pg.Theme.CheckBox(new(widget.Bool), "")
+		}
+		rows[i] = &ticketChoiceRow{
+			choice:   choice,
+			checkBox: checkBox,
+		}
+	}
+	pg.choices = choices
+	pg.rows = rows
+	pg.selectAll.CheckBox.Value = false
+}
+
+func (pg *AgendaTicketChoicesPage) syncChoices() {
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrSyncFromVSP)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			// The displayed choices are not changed by the sync, the synced
+			// copies replace them once it's done.
+			choices := make([]*wallet.TicketAgendaChoice, len(pg.choices))
+			for i, choice := range pg.choices {
+				synced := *choice
+				choices[i] = &synced
+			}
+
+			pg.loading = true
+			go func() {
+				err := wallet.SyncTicketAgendaChoices(pg.ctx, pg.wallet, pg.agenda.AgendaID, choices, []byte(password))
+				if err != nil {
+					pg.handOff(choicesUpdate{})
+					if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
+						pm.SetError(values.String(values.StrInvalidPassphrase))
+					} else {
+						pm.Toast.NotifyError(err.Error())
+					}
+					pm.SetLoading(false)
+					return
+				}
+				pm.Toast.Notify(values.String(values.StrChoicesSynced))
+				pm.Dismiss()
+				pg.handOff(choicesUpdate{choices: choices})
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// choiceSummary describes the number of tickets that will vote each choice
+// once the changes are submitted.
+func (pg *AgendaTicketChoicesPage) choiceSummary(changes map[string]string) string {
+	lines := []string{values.StringF(values.StrTicketsToUpdate, len(changes))}
+	for _, count := range wallet.SummarizeAgendaChoices(pg.choices, pg.assigned) {
+		lines = append(lines, values.StringF(values.StrChoiceSummary, count.Choice, count.Tickets))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (pg *AgendaTicketChoicesPage) submitChoices() {
+	changes := wallet.ChangedAgendaChoices(pg.choices, pg.assigned)
+	if len(changes) == 0 {
+		pg.Toast.Notify(values.String(values.StrNoChoiceChanges))
+		return
+	}
+
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrConfirmVoteChoices)).
+		Description(pg.choiceSummary(changes)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			pg.loading = true
+			go func() {
+				failed, err := wallet.SetTicketAgendaChoices(pg.wallet, pg.agenda.AgendaID, changes, []byte(password))
+				if err != nil {
+					pm.SetError(values.String(values.StrInvalidPassphrase))
+					pm.SetLoading(false)
+					pg.handOff(choicesUpdate{})
+					return
+				}
+
+				if len(failed) > 0 {
+					pm.Toast.NotifyError(values.StringF(values.StrChoiceUpdateFailed, len(failed)))
+				} else {
+					pm.Toast.Notify(values.String(values.StrVoteUpdated))
+				}
+				pm.Dismiss()
+				pg.fetchChoices(true)
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AgendaTicketChoicesPage) HandleUserInteractions() {
+	pg.applyUpdate()
+
+	if pg.selectAll.CheckBox.Changed() {
+		for _, row := range pg.rows {
+			row.checkBox.CheckBox.Value = pg.selectAll.CheckBox.Value
+		}
+	}
+
+	for i := range pg.choiceBtns {
+		if pg.choiceBtns[i].Clicked() {
+			for _, row := range pg.rows {
+				if row.checkBox.CheckBox.Value {
+					pg.assigned[row.choice.Ticket] = pg.agenda.Choices[i].Id
+					row.checkBox.CheckBox.Value = false
+				}
+			}
+			pg.selectAll.CheckBox.Value = false
+		}
+	}
+
+	if pg.syncBtn.Clicked() && !pg.loading {
+		pg.syncChoices()
+	}
+
+	if pg.submitBtn.Clicked() && !pg.loading {
+		pg.submitChoices()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AgendaTicketChoicesPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AgendaTicketChoicesPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrTicketVoteChoices),
+			SubTitle:   fmt.Sprintf("%s · %s", pg.agenda.AgendaID, pg.wallet.Name),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.layoutActions),
+					layout.Flexed(1, pg.layoutTickets),
+				)
+			},
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *AgendaTicketChoicesPage) layoutActions(gtx C) D {
+	return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				info := pg.Theme.Body2(values.String(values.StrTicketVoteChoicesInfo))
+				info.Color = pg.Theme.Color.GrayText2
+
+				choiceBtns := make([]layout.FlexChild, len(pg.choiceBtns))
+				for i := range pg.choiceBtns {
+					btn := pg.choiceBtns[i]
+					choiceBtns[i] = layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, btn.Layout)
+					})
+				}
+
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(info.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Alignment: layout.Middle}.Layout(gtx, choiceBtns...)
+						})
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+								layout.Flexed(1, func(gtx C) D {
+									lbl := pg.Theme.Body1(pg.choiceSummary(wallet.ChangedAgendaChoices(pg.choices, pg.assigned)))
+									lbl.Font.Weight = text.Medium
+									return lbl.Layout(gtx)
+								}),
+								layout.Rigid(func(gtx C) D {
+									if pg.loading {
+										return pg.materialLoader.Layout(gtx)
+									}
+									return layout.Flex{}.Layout(gtx,
+										layout.Rigid(func(gtx C) D {
+											return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.syncBtn.Layout)
+										}),
+										layout.Rigid(pg.submitBtn.Layout),
+									)
+								}),
+							)
+						})
+					}),
+				)
+			})
+		})
+	})
+}
+
+func (pg *AgendaTicketChoicesPage) layoutTickets(gtx C) D {
+	return pg.Theme.List(pg.listContainer).Layout(gtx, 1, func(gtx C, i int) D {
+		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				if len(pg.rows) == 0 {
+					txt := pg.Theme.Body1(values.String(values.StrNoActiveTickets))
+					txt.Color = pg.Theme.Color.GrayText3
+					return layout.Center.Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+					})
+				}
+
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.selectAll.Layout)
+					}),
+					layout.Rigid(pg.Theme.Separator().Layout),
+				}
+				for _, row := range pg.rows {
+					row := row
+					children = append(children,
+						layout.Rigid(func(gtx C) D {
+							return pg.layoutTicketRow(gtx, row)
+						}),
+						layout.Rigid(pg.Theme.Separator().Layout),
+					)
+				}
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			})
+		})
+	})
+}
+
+func (pg *AgendaTicketChoicesPage) layoutTicketRow(gtx C, row *ticketChoiceRow) D {
+	choice := row.choice
+	grayText := func(txt string) decredmaterial.Label {
+		lbl := pg.Theme.Caption(txt)
+		lbl.Color = pg.Theme.Color.GrayText2
+		return lbl
+	}
+
+	vsp := choice.VSP
+	if vsp == "" {
+		vsp = values.String(values.StrNoVSP)
+	}
+
+	return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(row.checkBox.Layout),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.Theme.Body1(components.TruncateString(choice.Ticket, 24)).Layout),
+					layout.Rigid(grayText(vsp).Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical, Alignment: layout.End}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lbl := pg.Theme.Body1(choice.Choice)
+						if assigned, ok := pg.assigned[choice.Ticket]; ok && assigned != choice.Choice {
+							lbl = pg.Theme.Body1(fmt.Sprintf("%s → %s", choice.Choice, assigned))
+							lbl.Color = pg.Theme.Color.Primary
+						}
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						switch {
+						case choice.Err != nil:
+							lbl := grayText(choice.Err.Error())
+							lbl.Color = pg.Theme.Color.Danger
+							return lbl.Layout(gtx)
+						case choice.Synced:
+							lbl := grayText(values.StringF(values.StrVSPChoice, choice.VSPChoice))
+							if choice.Mismatch() {
+								lbl.Color = pg.Theme.Color.Danger
+							}
+							return lbl.Layout(gtx)
+						}
+						return D{}
+					}),
+				)
+			}),
+		)
+	})
+}
@@ -29,6 +29,9 @@ type agendaVoteModal struct {
 	modalUpdateCount int // this keeps track of the number of times the modal has been updated.
 
 	onPreferenceUpdated func()
+	// onVotePerTicket opens the page that sets the choice of each ticket of
+	// the selected wallet.
+	onVotePerTicket func(*dcrlibwallet.Wallet)
 
 	walletSelector    *WalletSelector
 	ticketSelector    *ticketSelector
@@ -39,19 +42,22 @@ type agendaVoteModal struct {
 	optionsRadioGroup *widget.Enum
 	voteBtn           decredmaterial.Button
 	cancelBtn         decredmaterial.Button
+	perTicketBtn      decredmaterial.Button
 }
 
-func newAgendaVoteModal(l *load.Load, agenda *dcrlibwallet.Agenda, onPreferenceUpdated func()) *agendaVoteModal {
+func newAgendaVoteModal(l *load.Load, agenda *dcrlibwallet.Agenda, onPreferenceUpdated func(), onVotePerTicket func(*dcrlibwallet.Wallet)) *agendaVoteModal {
 	avm := &agendaVoteModal{
 		Load:                l,
 		Modal:               l.Theme.ModalFloatTitle("input_vote_modal"),
 		agenda:              agenda,
 		onPreferenceUpdated: onPreferenceUpdated,
+		onVotePerTicket:     onVotePerTicket,
 		materialLoader:      material.Loader(material.NewTheme(gofont.Collection())),
 		optionsRadioGroup:   new(widget.Enum),
 		spendingPassword:    l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword)),
 		voteBtn:             l.Theme.Button(values.String(values.StrUpdatePreference)),
 		cancelBtn:           l.Theme.OutlineButton(values.String(values.StrCancel)),
+		perTicketBtn:        l.Theme.OutlineButton(values.String(values.StrVotePerTicket)),
 	}
 
 	avm.voteBtn.Background = l.Theme.Color.Gray3
@@ -127,6 +133,14 @@ func (avm *agendaVoteModal) Handle() {
 		avm.Dismiss()
 	}
 
+	for avm.perTicketBtn.Clicked() {
+		if avm.isVoting || avm.walletSelector.selectedWallet == nil {
+			continue
+		}
+		avm.Dismiss()
+		avm.onVotePerTicket(avm.walletSelector.selectedWallet)
+	}
+
 	_, isChanged := decredmaterial.HandleEditorEvents(avm.spendingPassword.Editor)
 	if isChanged {
 		avm.spendingPassword.SetError("")
@@ -191,6 +205,9 @@ func (avm *agendaVoteModal) Layout(gtx layout.Context) D {
 		func(gtx C) D {
 			return layout.E.Layout(gtx, func(gtx C) D {
 				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, avm.perTicketBtn.Layout)
+					}),
 					layout.Rigid(func(gtx C) D {
 						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, avm.cancelBtn.Layout)
 					}),
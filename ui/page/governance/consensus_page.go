@@ -105,8 +105,11 @@ func (pg *ConsensusPage) HandleUserInteractions() {
 
 	for i := range pg.consensusItems {
 		if pg.consensusItems[i].VoteButton.Clicked() {
-			voteModal := newAgendaVoteModal(pg.Load, &pg.consensusItems[i].Agenda, func() {
+			agenda := &pg.consensusItems[i].Agenda
+			voteModal := newAgendaVoteModal(pg.Load, agenda, func() {
 				go pg.FetchAgendas() // re-fetch agendas when modal is dismissed
+			}, func(w *dcrlibwallet.Wallet) {
+				pg.ParentNavigator().Display(NewAgendaTicketChoicesPage(pg.Load, agenda, w))
 			})
 			pg.ParentWindow().ShowModal(voteModal)
 		}
@@ -27,12 +27,22 @@ type Page struct {
 	tabCategoryList        *decredmaterial.ClickableList
 	splashScreenInfoButton decredmaterial.IconButton
 	enableGovernanceBtn    decredmaterial.Button
+	readOfflineBtn         decredmaterial.Button
+
+	// offlineProposals is the number of proposals saved for offline reading.
+	offlineProposals int
+	// readingOffline is true if the offline proposals are displayed while
+	// politeia syncing is disabled.
+	readingOffline bool
 }
 
 var governanceTabTitles = []string{
 	values.String(values.StrProposal),
 	values.String(values.StrConsensusChange),
 	values.String(values.StrTreasurySpending),
+	values.String(values.StrWatched),
+	values.String(values.StrVotingHistory),
+	values.String(values.StrOffline),
 }
 
 func NewGovernancePage(l *load.Load) *Page {
@@ -55,6 +65,14 @@ func NewGovernancePage(l *load.Load) *Page {
 // the page is displayed.
 // Part of the load.Page interface.
 func (pg *Page) OnNavigatedTo() {
+	if !pg.isGovernanceFeatureEnabled() {
+		pg.readingOffline = false
+		if proposals, err := pg.WL.Wallet.OfflineProposals(); err == nil {
+			pg.offlineProposals = len(proposals)
+		}
+		pg.readOfflineBtn.Text = values.StringF(values.StrReadOfflineProposals, pg.offlineProposals)
+	}
+
 	if activeTab := pg.CurrentPage(); activeTab != nil {
 		activeTab.OnNavigatedTo()
 	} else if pg.isGovernanceFeatureEnabled() {
@@ -66,6 +84,12 @@ func (pg *Page) isGovernanceFeatureEnabled() bool {
 	return pg.WL.MultiWallet.ReadBoolConfigValueForKey(load.FetchProposalConfigKey, false)
 }
 
+// showSplashScreen returns true if politeia syncing is disabled and the user
+// is not reading the proposals saved for offline reading.
+func (pg *Page) showSplashScreen() bool {
+	return !pg.isGovernanceFeatureEnabled() && !pg.readingOffline
+}
+
 // OnNavigatedFrom is called when the page is about to be removed from
 // the displayed window. This method should ideally be used to disable
 // features that are irrelevant when the page is NOT displayed.
@@ -94,13 +118,25 @@ func (pg *Page) HandleUserInteractions() {
 		pg.WL.MultiWallet.SaveUserConfigValue(load.FetchProposalConfigKey, true)
 	}
 
+	for pg.readOfflineBtn.Clicked() {
+		pg.readingOffline = true
+		pg.Display(NewOfflineProposalsPage(pg.Load))
+	}
+
 	if tabItemClicked, clickedTabIndex := pg.tabCategoryList.ItemClicked(); tabItemClicked {
-		if clickedTabIndex == 0 {
+		switch clickedTabIndex {
+		case 0:
 			pg.Display(NewProposalsPage(pg.Load)) // Display should do nothing if the page is already displayed.
-		} else if clickedTabIndex == 1 {
+		case 1:
 			pg.Display(NewConsensusPage(pg.Load))
-		} else {
+		case 2:
 			pg.Display(NewTreasuryPage(pg.Load))
+		case 3:
+			pg.Display(NewWatchedProposalsPage(pg.Load))
+		case 5:
+			pg.Display(NewOfflineProposalsPage(pg.Load))
+		default:
+			pg.Display(NewVotingHistoryPage(pg.Load))
 		}
 	}
 
@@ -118,14 +154,19 @@ func (pg *Page) Layout(gtx C) D {
 }
 
 func (pg *Page) layoutDesktop(gtx layout.Context) layout.Dimensions {
-	if !pg.isGovernanceFeatureEnabled() {
+	if pg.showSplashScreen() {
 		return components.UniformPadding(gtx, pg.splashScreenLayout)
 	}
 
 	return components.UniformPadding(gtx, func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(pg.layoutPageTopNav),
-			layout.Rigid(pg.layoutTabs),
+			layout.Rigid(func(gtx C) D {
+				if !pg.isGovernanceFeatureEnabled() {
+					return D{}
+				}
+				return pg.layoutTabs(gtx)
+			}),
 			layout.Rigid(pg.Theme.Separator().Layout),
 			layout.Flexed(1, func(gtx C) D {
 				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
@@ -137,13 +178,18 @@ func (pg *Page) layoutDesktop(gtx layout.Context) layout.Dimensions {
 }
 
 func (pg *Page) layoutMobile(gtx layout.Context) layout.Dimensions {
-	if !pg.isGovernanceFeatureEnabled() {
+	if pg.showSplashScreen() {
 		return components.UniformMobile(gtx, false, false, pg.splashScreenLayout)
 	}
 	return components.UniformMobile(gtx, false, true, func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(pg.layoutPageTopNav),
-			layout.Rigid(pg.layoutTabs),
+			layout.Rigid(func(gtx C) D {
+				if !pg.isGovernanceFeatureEnabled() {
+					return D{}
+				}
+				return pg.layoutTabs(gtx)
+			}),
 			layout.Rigid(pg.Theme.Separator().Layout),
 			layout.Flexed(1, func(gtx C) D {
 				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
@@ -162,6 +208,12 @@ func (pg *Page) selectedTabIndex() int {
 		return 1
 	case TreasuryPageID:
 		return 2
+	case WatchedProposalsPageID:
+		return 3
+	case VotingHistoryPageID:
+		return 4
+	case OfflineProposalsPageID:
+		return 5
 	default:
 		return -1
 	}
@@ -229,6 +281,9 @@ func (pg *Page) layoutPageTopNav(gtx C) D {
 		}),
 		layout.Flexed(1, func(gtx C) D {
 			return layout.E.Layout(gtx, func(gtx C) D {
+				if !pg.isGovernanceFeatureEnabled() {
+					return pg.enableGovernanceBtn.Layout(gtx)
+				}
 				return D{}
 				//TODO: governance syncing functionality.
 				//TODO: Split wallet sync from governance
@@ -0,0 +1,137 @@
+package governance
+
+import (
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const OfflineProposalsPageID = "OfflineProposals"
+
+// OfflineProposalsPage lists the proposals saved for offline reading. The
+// proposals are read from disk so the page works without politeia syncing.
+type OfflineProposalsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	listContainer *widget.List
+	proposalsList *decredmaterial.ClickableList
+
+	proposals []*wallet.OfflineProposal
+}
+
+func NewOfflineProposalsPage(l *load.Load) *OfflineProposalsPage {
+	pg := &OfflineProposalsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(OfflineProposalsPageID),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		proposalsList: l.Theme.NewClickableList(layout.Vertical),
+	}
+	pg.proposalsList.IsShadowEnabled = true
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *OfflineProposalsPage) OnNavigatedTo() {
+	proposals, err := pg.WL.Wallet.OfflineProposals()
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+	}
+	pg.proposals = proposals
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *OfflineProposalsPage) HandleUserInteractions() {
+	if clicked, selectedItem := pg.proposalsList.ItemClicked(); clicked {
+		selectedProposal := pg.proposals[selectedItem].Proposal
+		pg.ParentNavigator().Display(NewProposalDetailsPage(pg.Load, &selectedProposal))
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *OfflineProposalsPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *OfflineProposalsPage) Layout(gtx C) D {
+	return pg.Theme.List(pg.listContainer).Layout(gtx, 1, func(gtx C, i int) D {
+		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				if len(pg.proposals) == 0 {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					txt := pg.Theme.Body1(values.String(values.StrNoOfflineProposals))
+					txt.Color = pg.Theme.Color.GrayText3
+					return layout.Center.Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+					})
+				}
+
+				return pg.proposalsList.Layout(gtx, len(pg.proposals), func(gtx C, i int) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return pg.layoutProposal(gtx, pg.proposals[i])
+						}),
+						layout.Rigid(pg.Theme.Separator().Layout),
+					)
+				})
+			})
+		})
+	})
+}
+
+func (pg *OfflineProposalsPage) layoutProposal(gtx C, offline *wallet.OfflineProposal) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	proposal := offline.Proposal
+
+	grayText := func(txt string) decredmaterial.Label {
+		lbl := pg.Theme.Body2(txt)
+		lbl.Color = pg.Theme.Color.GrayText2
+		return lbl
+	}
+
+	return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				lbl := pg.Theme.H6(proposal.Name)
+				lbl.Font.Weight = text.SemiBold
+				return lbl.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Flexed(1, grayText(proposal.Username+" · "+values.String(values.StrVersion)+" "+proposal.Version).Layout),
+						layout.Rigid(grayText(values.StringF(values.StrSavedAgo, components.TimeAgo(offline.DownloadedAt.Unix()))).Layout),
+					)
+				})
+			}),
+		)
+	})
+}
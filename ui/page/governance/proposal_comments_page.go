@@ -0,0 +1,341 @@
+package governance
+
+import (
+	"context"
+	"sync"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/renderers"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const (
+	ProposalCommentsPageID = "proposal_comments"
+
+	// maxCommentIndent is the nesting level after which replies are no
+	// longer indented further.
+	maxCommentIndent = 6
+)
+
+// commentRow is a comment laid out at its depth in the thread.
+type commentRow struct {
+	comment *wallet.ProposalComment
+	depth   int
+}
+
+// ProposalCommentsPage shows the discussion of a proposal as nested threads.
+// The comments are cached so they can be read offline.
+type ProposalCommentsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	proposal *dcrlibwallet.Proposal
+
+	backButton    decredmaterial.IconButton
+	sortDropDown  *decredmaterial.DropDown
+	scrollbarList *widget.List
+	commentsList  *layout.List
+
+	commentsMu sync.Mutex
+	comments   *wallet.ProposalComments
+	rows       []commentRow
+	rendered   map[uint32]proposalItemWidgets
+	loading    bool
+	fetchErr   error
+}
+
+func NewProposalCommentsPage(l *load.Load, proposal *dcrlibwallet.Proposal) *ProposalCommentsPage {
+	pg := &ProposalCommentsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(ProposalCommentsPageID),
+		proposal:         proposal,
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		commentsList: &layout.List{Axis: layout.Vertical},
+		rendered:     make(map[uint32]proposalItemWidgets),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+	pg.sortDropDown = l.Theme.DropDown([]decredmaterial.DropDownItem{
+		{Text: values.String(values.StrNewest)},
+		{Text: values.String(values.StrTop)},
+	}, values.ProposalCommentsDropdownGroup, 0)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *ProposalCommentsPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+
+	cached, err := pg.WL.Wallet.CachedProposalComments(pg.proposal.Token)
+	if err != nil {
+		log.Errorf("Error reading cached proposal comments: %v", err)
+	}
+	if cached != nil {
+		pg.setComments(cached)
+	}
+
+	pg.fetchComments()
+}
+
+// fetchComments requests the latest comments from politeia in the
+// background. The cached comments remain displayed if the request fails.
+func (pg *ProposalCommentsPage) fetchComments() {
+	pg.loading = true
+	go func() {
+		comments, err := pg.WL.Wallet.FetchProposalComments(pg.ctx, pg.proposal.Token)
+		pg.loading = false
+		if err != nil {
+			log.Errorf("Error fetching proposal comments: %v", err)
+			pg.commentsMu.Lock()
+			pg.fetchErr = err
+			pg.commentsMu.Unlock()
+		} else {
+			pg.setComments(comments)
+		}
+		pg.ParentWindow().Reload()
+	}()
+}
+
+func (pg *ProposalCommentsPage) setComments(comments *wallet.ProposalComments) {
+	pg.commentsMu.Lock()
+	defer pg.commentsMu.Unlock()
+
+	pg.comments = comments
+	pg.fetchErr = nil
+	// Edited comments must be rendered again.
+	pg.rendered = make(map[uint32]proposalItemWidgets)
+	pg.sortComments()
+}
+
+// sortComments flattens the comment threads into rows in the selected
+// order. commentsMu must be held.
+func (pg *ProposalCommentsPage) sortComments() {
+	if pg.comments == nil {
+		return
+	}
+
+	sortBy := wallet.CommentSortNewest
+	if pg.sortDropDown.SelectedIndex() == 1 {
+		sortBy = wallet.CommentSortTop
+	}
+
+	var rows []commentRow
+	var addThread func(comments []*wallet.ProposalComment, depth int)
+	addThread = func(comments []*wallet.ProposalComment, depth int) {
+		for _, comment := range comments {
+			rows = append(rows, commentRow{comment: comment, depth: depth})
+			addThread(comment.Replies, depth+1)
+		}
+	}
+	addThread(wallet.CommentThreads(pg.comments.Comments, sortBy), 0)
+	pg.rows = rows
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *ProposalCommentsPage) HandleUserInteractions() {
+	for pg.sortDropDown.Changed() {
+		pg.commentsMu.Lock()
+		pg.sortComments()
+		pg.commentsMu.Unlock()
+	}
+
+	pg.commentsMu.Lock()
+	for _, item := range pg.rendered {
+		for location, clickable := range item.clickables {
+			if clickable.Clicked() {
+				components.OpenLink(pg.Load, pg.ParentWindow(), location)
+			}
+		}
+	}
+	pg.commentsMu.Unlock()
+
+	decredmaterial.DisplayOneDropdown(pg.sortDropDown)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *ProposalCommentsPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *ProposalCommentsPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      components.TruncateString(pg.proposal.Name, 40),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Stack{}.Layout(gtx,
+					layout.Expanded(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutComments)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.layoutStatus)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return pg.sortDropDown.Layout(gtx, 0, true)
+					}),
+				)
+			},
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *ProposalCommentsPage) layoutStatus(gtx C) D {
+	pg.commentsMu.Lock()
+	comments, fetchErr := pg.comments, pg.fetchErr
+	pg.commentsMu.Unlock()
+
+	var status string
+	switch {
+	case pg.loading:
+		gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding24)
+		gtx.Constraints.Max.Y = gtx.Constraints.Max.X
+		return material.Loader(pg.Theme.Base).Layout(gtx)
+	case comments != nil && fetchErr != nil:
+		status = values.StringF(values.StrCommentsOffline, components.TimeAgo(comments.FetchedAt.Unix()))
+	case comments != nil:
+		status = values.StringF(values.StrCommentsUpdated, components.TimeAgo(comments.FetchedAt.Unix()))
+	case fetchErr != nil:
+		status = fetchErr.Error()
+	}
+
+	lbl := pg.Theme.Body2(status)
+	lbl.Color = pg.Theme.Color.GrayText2
+	if fetchErr != nil {
+		lbl.Color = pg.Theme.Color.Danger
+	}
+	return lbl.Layout(gtx)
+}
+
+func (pg *ProposalCommentsPage) layoutComments(gtx C) D {
+	pg.commentsMu.Lock()
+	defer pg.commentsMu.Unlock()
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		if pg.comments != nil && len(pg.rows) == 0 {
+			txt := pg.Theme.Body1(values.String(values.StrNoComments))
+			txt.Color = pg.Theme.Color.GrayText3
+			return layout.Center.Layout(gtx, func(gtx C) D {
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+			})
+		}
+
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, 1, func(gtx C, i int) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				return pg.commentsList.Layout(gtx, len(pg.rows), func(gtx C, i int) D {
+					return pg.layoutComment(gtx, pg.rows[i])
+				})
+			})
+		})
+	})
+}
+
+// layoutComment draws a comment indented to its depth in the thread.
+// commentsMu must be held.
+func (pg *ProposalCommentsPage) layoutComment(gtx C, row commentRow) D {
+	comment := row.comment
+	depth := row.depth
+	if depth > maxCommentIndent {
+		depth = maxCommentIndent
+	}
+
+	grayText := func(txt string) decredmaterial.Label {
+		lbl := pg.Theme.Body2(txt)
+		lbl.Color = pg.Theme.Color.GrayText2
+		return lbl
+	}
+
+	header := func(gtx C) D {
+		author := pg.Theme.Body2(comment.Author)
+		author.Font.Weight = text.SemiBold
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(author.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, grayText(components.TimeAgo(comment.Timestamp)).Layout)
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.E.Layout(gtx, grayText(values.StringF(values.StrCommentPoints, comment.Score())).Layout)
+			}),
+		)
+	}
+
+	body := func(gtx C) D {
+		if comment.Deleted {
+			lbl := grayText(values.String(values.StrCommentDeleted))
+			lbl.Font.Style = text.Italic
+			return lbl.Layout(gtx)
+		}
+
+		item, ok := pg.rendered[comment.ID]
+		if !ok {
+			r := renderers.RenderMarkdown(gtx, pg.Theme, comment.Comment)
+			widgets, clickables := r.Layout()
+			item = proposalItemWidgets{widgets: widgets, clickables: clickables}
+			pg.rendered[comment.ID] = item
+		}
+
+		list := &layout.List{Axis: layout.Vertical}
+		return list.Layout(gtx, len(item.widgets), func(gtx C, i int) D {
+			return item.widgets[i](gtx)
+		})
+	}
+
+	return layout.Inset{
+		Left:   unit.Dp(float32(16 * depth)),
+		Bottom: values.MarginPadding16,
+	}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(header),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, body)
+			}),
+		)
+	})
+}
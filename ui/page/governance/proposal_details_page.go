@@ -57,13 +57,24 @@ type ProposalDetails struct {
 
 	viewInPoliteiaBtn *decredmaterial.Clickable
 	copyRedirectURL   *decredmaterial.Clickable
+	commentsBtn       *decredmaterial.Clickable
+	compareBtn        *decredmaterial.Clickable
+	offlineBtn        *decredmaterial.Clickable
+	exportMarkdownBtn *decredmaterial.Clickable
+	exportHTMLBtn     *decredmaterial.Clickable
 
 	descriptionCard decredmaterial.Card
 	vote            decredmaterial.Button
+	watchBtn        decredmaterial.Button
 	backButton      decredmaterial.IconButton
 
 	voteBar            *components.VoteBar
 	loadingDescription bool
+	// offline is the copy of the proposal saved for offline reading, nil if
+	// the proposal has not been downloaded.
+	offline     *wallet.OfflineProposal
+	downloading bool
+	exporting   bool
 }
 
 func NewProposalDetailsPage(l *load.Load, proposal *dcrlibwallet.Proposal) *ProposalDetails {
@@ -84,6 +95,11 @@ func NewProposalDetailsPage(l *load.Load, proposal *dcrlibwallet.Proposal) *Prop
 		successIcon:       l.Theme.Icons.ActionCheckCircle,
 		viewInPoliteiaBtn: l.Theme.NewClickable(true),
 		copyRedirectURL:   l.Theme.NewClickable(false),
+		commentsBtn:       l.Theme.NewClickable(true),
+		compareBtn:        l.Theme.NewClickable(true),
+		offlineBtn:        l.Theme.NewClickable(true),
+		exportMarkdownBtn: l.Theme.NewClickable(true),
+		exportHTMLBtn:     l.Theme.NewClickable(true),
 		voteBar:           components.NewVoteBar(l),
 	}
 
@@ -100,6 +116,10 @@ func NewProposalDetailsPage(l *load.Load, proposal *dcrlibwallet.Proposal) *Prop
 		Right:  values.MarginPadding12,
 	}
 
+	pg.watchBtn = l.Theme.OutlineButton(values.String(values.StrWatchProposal))
+	pg.watchBtn.TextSize = values.TextSize14
+	pg.watchBtn.Inset = pg.vote.Inset
+
 	return pg
 }
 
@@ -110,6 +130,82 @@ func NewProposalDetailsPage(l *load.Load, proposal *dcrlibwallet.Proposal) *Prop
 func (pg *ProposalDetails) OnNavigatedTo() {
 	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
 	pg.listenForSyncNotifications()
+	pg.updateWatchButton()
+	wallet.MarkProposalSeen(pg.WL.MultiWallet, pg.proposal)
+
+	offline, err := pg.WL.Wallet.OfflineProposal(pg.proposal.Token)
+	if err != nil {
+		log.Errorf("Error reading offline proposal: %v", err)
+	}
+	pg.offline = offline
+}
+
+// proposalDescription returns the markdown body of the proposal, from the
+// offline copy if it is of the current version so that downloaded proposals
+// load without politeia.
+func (pg *ProposalDetails) proposalDescription() (string, error) {
+	proposal := pg.proposal
+	if pg.offline != nil && pg.offline.Proposal.Version == proposal.Version {
+		return pg.offline.Body, nil
+	}
+	if proposal.IndexFile != "" && proposal.IndexFileVersion == proposal.Version {
+		return proposal.IndexFile, nil
+	}
+	return pg.WL.MultiWallet.Politeia.FetchProposalDescription(proposal.Token)
+}
+
+// toggleOffline removes the offline copy of the proposal if it is up to
+// date, otherwise it downloads the proposal.
+func (pg *ProposalDetails) toggleOffline() {
+	pg.downloading = true
+	go func() {
+		defer func() {
+			pg.downloading = false
+			pg.ParentWindow().Reload()
+		}()
+
+		if pg.offline != nil && pg.offline.Proposal.Version == pg.proposal.Version {
+			if err := pg.WL.Wallet.DeleteOfflineProposal(pg.proposal.Token); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return
+			}
+			pg.offline = nil
+			pg.Toast.Notify(values.String(values.StrOfflineCopyRemoved))
+			return
+		}
+
+		offline, err := pg.WL.Wallet.DownloadProposal(pg.ctx, pg.proposal)
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+		pg.offline = offline
+		pg.Toast.Notify(values.String(values.StrSavedForOffline))
+	}()
+}
+
+func (pg *ProposalDetails) exportProposal(format string) {
+	pg.exporting = true
+	go func() {
+		defer func() {
+			pg.exporting = false
+		}()
+
+		path, err := pg.WL.Wallet.ExportProposal(pg.ctx, pg.proposal, format)
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+		pg.Toast.Notify(values.StringF(values.StrExportedTo, path))
+	}()
+}
+
+func (pg *ProposalDetails) updateWatchButton() {
+	if wallet.IsProposalWatched(pg.WL.MultiWallet, pg.proposal.Token) {
+		pg.watchBtn.Text = values.String(values.StrUnwatchProposal)
+	} else {
+		pg.watchBtn.Text = values.String(values.StrWatchProposal)
+	}
 }
 
 // HandleUserInteractions is called just before Layout() to determine
@@ -121,7 +217,7 @@ func (pg *ProposalDetails) HandleUserInteractions() {
 	for token := range pg.proposalItems {
 		for location, clickable := range pg.proposalItems[token].clickables {
 			if clickable.Clicked() {
-				components.GoToURL(location)
+				components.OpenLink(pg.Load, pg.ParentWindow(), location)
 			}
 		}
 	}
@@ -130,6 +226,41 @@ func (pg *ProposalDetails) HandleUserInteractions() {
 		pg.ParentWindow().ShowModal(newVoteModal(pg.Load, pg.proposal))
 	}
 
+	for pg.compareBtn.Clicked() {
+		pg.ParentNavigator().Display(NewProposalDiffPage(pg.Load, pg.proposal))
+	}
+
+	for pg.commentsBtn.Clicked() {
+		pg.ParentNavigator().Display(NewProposalCommentsPage(pg.Load, pg.proposal))
+	}
+
+	for pg.offlineBtn.Clicked() {
+		if !pg.downloading {
+			pg.toggleOffline()
+		}
+	}
+
+	for pg.exportMarkdownBtn.Clicked() {
+		if !pg.exporting {
+			pg.exportProposal(wallet.ProposalExportMarkdown)
+		}
+	}
+
+	for pg.exportHTMLBtn.Clicked() {
+		if !pg.exporting {
+			pg.exportProposal(wallet.ProposalExportHTML)
+		}
+	}
+
+	if pg.watchBtn.Clicked() {
+		if wallet.IsProposalWatched(pg.WL.MultiWallet, pg.proposal.Token) {
+			wallet.UnwatchProposal(pg.WL.MultiWallet, pg.proposal.Token)
+		} else {
+			wallet.WatchProposal(pg.WL.MultiWallet, pg.proposal)
+		}
+		pg.updateWatchButton()
+	}
+
 	for pg.viewInPoliteiaBtn.Clicked() {
 		host := "https://proposals.decred.org/record/" + pg.proposal.Token
 		if pg.WL.MultiWallet.NetType() == dcrlibwallet.Testnet3 {
@@ -399,10 +530,20 @@ func (pg *ProposalDetails) layoutTitle(gtx C) D {
 
 	return pg.descriptionCard.Layout(gtx, func(gtx C) D {
 		return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
-			if proposal.Category == dcrlibwallet.ProposalCategoryPre {
-				return pg.layoutInDiscussionState(gtx)
-			}
-			return pg.layoutNormalTitle(gtx)
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if proposal.Category == dcrlibwallet.ProposalCategoryPre {
+						return pg.layoutInDiscussionState(gtx)
+					}
+					return pg.layoutNormalTitle(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+						return layout.E.Layout(gtx, pg.watchBtn.Layout)
+					})
+				}),
+			)
 		})
 	})
 }
@@ -468,6 +609,13 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 		w = append(w, loading)
 	}
 
+	if proposal.Version != "1" {
+		w = append(w, pg.layoutRedirect(values.String(values.StrCompareVersions), pg.Theme.Icons.Next, pg.compareBtn))
+	}
+	w = append(w, pg.layoutRedirect(values.StringF(values.StrCommentsCount, proposal.NumComments), pg.Theme.Icons.Next, pg.commentsBtn))
+	w = append(w, pg.layoutRedirect(pg.offlineText(), pg.Theme.Icons.Next, pg.offlineBtn))
+	w = append(w, pg.layoutRedirect(values.String(values.StrExportMarkdown), pg.Theme.Icons.Next, pg.exportMarkdownBtn))
+	w = append(w, pg.layoutRedirect(values.String(values.StrExportHTML), pg.Theme.Icons.Next, pg.exportHTMLBtn))
 	w = append(w, pg.layoutRedirect(values.String(values.StrViewOnPoliteia), pg.redirectIcon, pg.viewInPoliteiaBtn))
 
 	return pg.descriptionCard.Layout(gtx, func(gtx C) D {
@@ -481,6 +629,21 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 	})
 }
 
+// offlineText describes the action of the offline button and the state of
+// the offline copy.
+func (pg *ProposalDetails) offlineText() string {
+	switch {
+	case pg.downloading:
+		return values.String(values.StrDownloadingProposal)
+	case pg.offline == nil:
+		return values.String(values.StrSaveForOffline)
+	case pg.offline.Proposal.Version != pg.proposal.Version:
+		return values.String(values.StrOfflineCopyOutdated)
+	default:
+		return values.StringF(values.StrRemoveOfflineCopy, components.TimeAgo(pg.offline.DownloadedAt.Unix()))
+	}
+}
+
 func (pg *ProposalDetails) layoutRedirect(text string, icon *decredmaterial.Image, btn *decredmaterial.Clickable) layout.Widget {
 	return func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -526,18 +689,12 @@ func (pg *ProposalDetails) layoutDesktop(gtx layout.Context) layout.Dimensions {
 	if !ok && !pg.loadingDescription {
 		pg.loadingDescription = true
 		go func() {
-			var proposalDescription string
-			if proposal.IndexFile != "" && proposal.IndexFileVersion == proposal.Version {
-				proposalDescription = proposal.IndexFile
-			} else {
-				var err error
-				proposalDescription, err = pg.WL.MultiWallet.Politeia.FetchProposalDescription(proposal.Token)
-				if err != nil {
-					log.Errorf("Error loading proposal description: %v", err)
-					time.Sleep(7 * time.Second)
-					pg.loadingDescription = false
-					return
-				}
+			proposalDescription, err := pg.proposalDescription()
+			if err != nil {
+				log.Errorf("Error loading proposal description: %v", err)
+				time.Sleep(7 * time.Second)
+				pg.loadingDescription = false
+				return
 			}
 
 			r := renderers.RenderMarkdown(gtx, pg.Theme, proposalDescription)
@@ -593,18 +750,12 @@ func (pg *ProposalDetails) layoutMobile(gtx layout.Context) layout.Dimensions {
 	if !ok && !pg.loadingDescription {
 		pg.loadingDescription = true
 		go func() {
-			var proposalDescription string
-			if proposal.IndexFile != "" && proposal.IndexFileVersion == proposal.Version {
-				proposalDescription = proposal.IndexFile
-			} else {
-				var err error
-				proposalDescription, err = pg.WL.MultiWallet.Politeia.FetchProposalDescription(proposal.Token)
-				if err != nil {
-					log.Errorf("Error loading proposal description: %v", err)
-					time.Sleep(7 * time.Second)
-					pg.loadingDescription = false
-					return
-				}
+			proposalDescription, err := pg.proposalDescription()
+			if err != nil {
+				log.Errorf("Error loading proposal description: %v", err)
+				time.Sleep(7 * time.Second)
+				pg.loadingDescription = false
+				return
 			}
 
 			r := renderers.RenderMarkdown(gtx, pg.Theme, proposalDescription)
@@ -0,0 +1,264 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const ProposalDiffPageID = "proposal_diff"
+
+// ProposalDiffPage shows the line-level changes to the body of a proposal
+// between two of its versions.
+type ProposalDiffPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	proposal *dcrlibwallet.Proposal
+	// fromVersions and toVersions are the versions listed in the
+	// dropdowns, in the same order.
+	fromVersions []uint32
+	toVersions   []uint32
+
+	backButton    decredmaterial.IconButton
+	fromDropDown  *decredmaterial.DropDown
+	toDropDown    *decredmaterial.DropDown
+	scrollbarList *widget.List
+	diffList      *layout.List
+
+	diffMu  sync.Mutex
+	diff    []wallet.DiffLine
+	loading bool
+	loadErr error
+}
+
+func NewProposalDiffPage(l *load.Load, proposal *dcrlibwallet.Proposal) *ProposalDiffPage {
+	pg := &ProposalDiffPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(ProposalDiffPageID),
+		proposal:         proposal,
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		diffList: &layout.List{Axis: layout.Vertical},
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	// The latest version is compared to the previous one by default.
+	latest, _ := strconv.ParseUint(proposal.Version, 10, 32)
+	var fromItems, toItems []decredmaterial.DropDownItem
+	for v := uint32(latest); v > 1; v-- {
+		pg.toVersions = append(pg.toVersions, v)
+		toItems = append(toItems, decredmaterial.DropDownItem{
+			Text: fmt.Sprintf("%s %s %d", values.String(values.StrToVersion), values.String(values.StrVersion), v),
+		})
+		pg.fromVersions = append(pg.fromVersions, v-1)
+		fromItems = append(fromItems, decredmaterial.DropDownItem{
+			Text: fmt.Sprintf("%s %s %d", values.String(values.StrFromVersion), values.String(values.StrVersion), v-1),
+		})
+	}
+	pg.toDropDown = l.Theme.DropDown(toItems, values.ProposalVersionsDropdownGroup, 0)
+	pg.fromDropDown = l.Theme.DropDown(fromItems, values.ProposalVersionsDropdownGroup, 1)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *ProposalDiffPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadDiff()
+}
+
+// loadDiff computes the diff between the selected versions in the
+// background. Versions that haven't been fetched before are requested from
+// politeia.
+func (pg *ProposalDiffPage) loadDiff() {
+	if len(pg.toVersions) == 0 {
+		return
+	}
+
+	from := pg.fromVersions[pg.fromDropDown.SelectedIndex()]
+	to := pg.toVersions[pg.toDropDown.SelectedIndex()]
+	if from > to {
+		from, to = to, from
+	}
+
+	pg.diffMu.Lock()
+	pg.loading = true
+	pg.diffMu.Unlock()
+	go func() {
+		defer pg.ParentWindow().Reload()
+
+		var diff []wallet.DiffLine
+		fromVersion, err := pg.WL.Wallet.ProposalVersion(pg.ctx, pg.proposal.Token, from)
+		if err == nil {
+			var toVersion *wallet.ProposalVersion
+			toVersion, err = pg.WL.Wallet.ProposalVersion(pg.ctx, pg.proposal.Token, to)
+			if err == nil {
+				diff = wallet.DiffLines(fromVersion.Body, toVersion.Body)
+			}
+		}
+
+		pg.diffMu.Lock()
+		pg.diff, pg.loadErr, pg.loading = diff, err, false
+		pg.diffMu.Unlock()
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *ProposalDiffPage) HandleUserInteractions() {
+	for pg.fromDropDown.Changed() {
+		pg.loadDiff()
+	}
+
+	for pg.toDropDown.Changed() {
+		pg.loadDiff()
+	}
+
+	decredmaterial.DisplayOneDropdown(pg.toDropDown, pg.fromDropDown)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *ProposalDiffPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *ProposalDiffPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrCompareVersions),
+			SubTitle:   components.TruncateString(pg.proposal.Name, 40),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Stack{}.Layout(gtx,
+					layout.Expanded(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutDiff)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return pg.toDropDown.Layout(gtx, 0, true)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return pg.fromDropDown.Layout(gtx, pg.toDropDown.Width+10, true)
+					}),
+				)
+			},
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *ProposalDiffPage) layoutDiff(gtx C) D {
+	pg.diffMu.Lock()
+	defer pg.diffMu.Unlock()
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		var message decredmaterial.Label
+		switch {
+		case pg.loading:
+			return layout.Center.Layout(gtx, func(gtx C) D {
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, material.Loader(pg.Theme.Base).Layout)
+			})
+		case pg.loadErr != nil:
+			message = pg.Theme.Body1(pg.loadErr.Error())
+			message.Color = pg.Theme.Color.Danger
+		case !hasChanges(pg.diff):
+			message = pg.Theme.Body1(values.String(values.StrNoVersionChanges))
+			message.Color = pg.Theme.Color.GrayText3
+		default:
+			return pg.Theme.List(pg.scrollbarList).Layout(gtx, 1, func(gtx C, i int) D {
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+					return pg.diffList.Layout(gtx, len(pg.diff), func(gtx C, i int) D {
+						return pg.layoutDiffLine(gtx, pg.diff[i])
+					})
+				})
+			})
+		}
+
+		return layout.Center.Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, message.Layout)
+		})
+	})
+}
+
+func (pg *ProposalDiffPage) layoutDiffLine(gtx C, line wallet.DiffLine) D {
+	prefix := "  "
+	lbl := pg.Theme.Body2("")
+	background := pg.Theme.Color.Surface
+	switch line.Op {
+	case wallet.DiffAdded:
+		prefix = "+ "
+		lbl.Color = pg.Theme.Color.Success
+		background = pg.Theme.Color.Success
+		background.A = 0x20
+	case wallet.DiffRemoved:
+		prefix = "- "
+		lbl.Color = pg.Theme.Color.Danger
+		background = pg.Theme.Color.Danger
+		background.A = 0x20
+	}
+	lbl.Text = prefix + line.Text
+
+	return decredmaterial.LinearLayout{
+		Width:      decredmaterial.MatchParent,
+		Height:     decredmaterial.WrapContent,
+		Background: background,
+		Padding:    layout.Inset{Left: values.MarginPadding4, Right: values.MarginPadding4},
+	}.Layout2(gtx, lbl.Layout)
+}
+
+// hasChanges returns true if the diff adds or removes a line.
+func hasChanges(diff []wallet.DiffLine) bool {
+	for _, line := range diff {
+		if line.Op != wallet.DiffEqual {
+			return true
+		}
+	}
+	return false
+}
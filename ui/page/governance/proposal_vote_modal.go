@@ -15,6 +15,7 @@ import (
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type voteModal struct {
@@ -160,6 +161,7 @@ func (vm *voteModal) sendVotes() {
 					pm.SetLoading(false)
 					return
 				}
+				wallet.RecordProposalVotes(vm.walletSelector.selectedWallet, vm.proposal.Token, votes)
 				pm.Dismiss()
 				vm.Toast.Notify(values.String(values.StrVoteSent))
 				go vm.WL.MultiWallet.Politeia.Sync()
@@ -2,6 +2,7 @@ package governance
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -53,6 +54,7 @@ type ProposalsPage struct {
 	updatedIcon *decredmaterial.Icon
 
 	proposalItems []*components.ProposalItem
+	searchIndex   *wallet.SearchIndex
 
 	syncCompleted bool
 	isSyncing     bool
@@ -66,6 +68,7 @@ func NewProposalsPage(l *load.Load) *ProposalsPage {
 		listContainer: &widget.List{
 			List: layout.List{Axis: layout.Vertical},
 		},
+		searchIndex: wallet.NewSearchIndex(),
 	}
 	pg.searchEditor = l.Theme.IconEditor(new(widget.Editor), values.String(values.StrSearch), l.Theme.Icons.SearchIcon, true)
 	pg.searchEditor.Editor.SingleLine, pg.searchEditor.Editor.Submit, pg.searchEditor.Bordered = true, true, false
@@ -109,10 +112,44 @@ func NewProposalsPage(l *load.Load) *ProposalsPage {
 func (pg *ProposalsPage) OnNavigatedTo() {
 	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
 	pg.listenForSyncNotifications()
+	pg.indexProposals()
 	pg.fetchProposals()
 	pg.isSyncing = pg.multiWallet.Politeia.IsSyncing()
 }
 
+// indexProposals adds all the locally synced proposals to the search index.
+func (pg *ProposalsPage) indexProposals() {
+	proposals, err := pg.multiWallet.Politeia.GetProposalsRaw(dcrlibwallet.ProposalCategoryAll, 0, 0, true)
+	if err != nil {
+		log.Errorf("Error loading proposals for search: %v", err)
+		return
+	}
+	pg.searchIndex.AddProposals(proposals)
+}
+
+// searchProposals returns the items matching the search editor text, the
+// most relevant first.
+func (pg *ProposalsPage) searchProposals(items []*components.ProposalItem) []*components.ProposalItem {
+	query := wallet.ParseSearchQuery(pg.searchEditor.Editor.Text())
+	if query.IsEmpty() {
+		return items
+	}
+	query.Kinds = []string{wallet.SearchKindProposal}
+
+	itemsByToken := make(map[string]*components.ProposalItem, len(items))
+	for _, item := range items {
+		itemsByToken[item.Proposal.Token] = item
+	}
+
+	results := make([]*components.ProposalItem, 0)
+	for _, result := range pg.searchIndex.Search(query) {
+		if item, ok := itemsByToken[result.Token]; ok {
+			results = append(results, item)
+		}
+	}
+	return results
+}
+
 func (pg *ProposalsPage) fetchProposals() {
 	newestFirst := pg.orderDropDown.SelectedIndex() == 0
 
@@ -137,11 +174,13 @@ func (pg *ProposalsPage) fetchProposals() {
 		}
 	}
 
-	pg.proposalMu.Lock()
-	pg.proposalItems = proposalItems
 	if proposalFilter == dcrlibwallet.ProposalCategoryAll {
-		pg.proposalItems = listItems
+		proposalItems = listItems
 	}
+	proposalItems = pg.searchProposals(proposalItems)
+
+	pg.proposalMu.Lock()
+	pg.proposalItems = proposalItems
 	pg.proposalMu.Unlock()
 }
 
@@ -160,7 +199,14 @@ func (pg *ProposalsPage) HandleUserInteractions() {
 	}
 
 	pg.searchEditor.EditorIconButtonEvent = func() {
-		//TODO: Proposals search functionality
+		pg.fetchProposals()
+	}
+
+	for _, evt := range pg.searchEditor.Editor.Events() {
+		switch evt.(type) {
+		case widget.ChangeEvent, widget.SubmitEvent:
+			pg.fetchProposals()
+		}
 	}
 
 	if clicked, selectedItem := pg.proposalsList.ItemClicked(); clicked {
@@ -233,22 +279,7 @@ func (pg *ProposalsPage) layoutDesktop(gtx layout.Context) layout.Dimensions {
 					layout.Expanded(func(gtx C) D {
 						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutContent)
 					}),
-					//TODO: temp removal till after V1
-					// layout.Expanded(func(gtx C) D {
-					// 	gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding150)
-					// 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
-
-					// 	card := pg.Theme.Card()
-					// 	card.Radius = decredmaterial.Radius(8)
-					// 	return card.Layout(gtx, func(gtx C) D {
-					// 		return layout.Inset{
-					// 			Left:   values.MarginPadding10,
-					// 			Right:  values.MarginPadding10,
-					// 			Top:    values.MarginPadding2,
-					// 			Bottom: values.MarginPadding2,
-					// 		}.Layout(gtx, pg.searchEditor.Layout)
-					// 	})
-					// }),
+					layout.Expanded(pg.layoutSearchEditor),
 					layout.Expanded(func(gtx C) D {
 						gtx.Constraints.Min.X = gtx.Constraints.Max.X
 						return layout.E.Layout(gtx, func(gtx C) D {
@@ -282,22 +313,7 @@ func (pg *ProposalsPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 					layout.Expanded(func(gtx C) D {
 						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutContent)
 					}),
-					//TODO: temp removal till after V1
-					// layout.Expanded(func(gtx C) D {
-					// 	gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding150)
-					// 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
-
-					// 	card := pg.Theme.Card()
-					// 	card.Radius = decredmaterial.Radius(8)
-					// 	return card.Layout(gtx, func(gtx C) D {
-					// 		return layout.Inset{
-					// 			Left:   values.MarginPadding10,
-					// 			Right:  values.MarginPadding10,
-					// 			Top:    values.MarginPadding2,
-					// 			Bottom: values.MarginPadding2,
-					// 		}.Layout(gtx, pg.searchEditor.Layout)
-					// 	})
-					// }),
+					layout.Expanded(pg.layoutSearchEditor),
 					layout.Expanded(func(gtx C) D {
 						gtx.Constraints.Min.X = gtx.Constraints.Max.X
 						return layout.E.Layout(gtx, func(gtx C) D {
@@ -322,6 +338,22 @@ func (pg *ProposalsPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 	)
 }
 
+func (pg *ProposalsPage) layoutSearchEditor(gtx C) D {
+	gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding150)
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+
+	card := pg.Theme.Card()
+	card.Radius = decredmaterial.Radius(8)
+	return card.Layout(gtx, func(gtx C) D {
+		return layout.Inset{
+			Left:   values.MarginPadding10,
+			Right:  values.MarginPadding10,
+			Top:    values.MarginPadding2,
+			Bottom: values.MarginPadding2,
+		}.Layout(gtx, pg.searchEditor.Layout)
+	})
+}
+
 func (pg *ProposalsPage) layoutContent(gtx C) D {
 	return layout.Stack{}.Layout(gtx,
 		layout.Expanded(func(gtx C) D {
@@ -333,6 +365,9 @@ func (pg *ProposalsPage) layoutContent(gtx C) D {
 				return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
 					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
 						if len(proposalItems) == 0 {
+							if searchText := strings.TrimSpace(pg.searchEditor.Editor.Text()); searchText != "" {
+								return components.LayoutNoResultsFound(gtx, pg.Load, searchText)
+							}
 							return components.LayoutNoProposalsFound(gtx, pg.Load, pg.isSyncing, int32(pg.categoryDropDown.SelectedIndex()))
 						}
 						return pg.proposalsList.Layout(gtx, len(proposalItems), func(gtx C, i int) D {
@@ -429,6 +464,7 @@ func (pg *ProposalsPage) listenForSyncNotifications() {
 					pg.syncCompleted = true
 					pg.isSyncing = false
 
+					pg.indexProposals()
 					pg.fetchProposals()
 					pg.ParentWindow().Reload()
 				}
@@ -14,6 +14,7 @@ import (
 func (pg *Page) initSplashScreenWidgets() {
 	_, pg.splashScreenInfoButton = components.SubpageHeaderButtons(pg.Load)
 	pg.enableGovernanceBtn = pg.Theme.Button(values.String(values.StrFetchProposals))
+	pg.readOfflineBtn = pg.Theme.OutlineButton(values.String(values.StrOfflineProposals))
 }
 
 func (pg *Page) splashScreenLayout(gtx layout.Context) layout.Dimensions {
@@ -58,6 +59,16 @@ func (pg *Page) splashScreenLayout(gtx layout.Context) layout.Dimensions {
 				Right: values.MarginPadding16,
 			}.Layout(gtx, pg.enableGovernanceBtn.Layout)
 		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.offlineProposals == 0 {
+				return D{}
+			}
+			gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding350)
+			return layout.Inset{
+				Top:   values.MarginPadding12,
+				Right: values.MarginPadding16,
+			}.Layout(gtx, pg.readOfflineBtn.Layout)
+		}),
 	)
 }
 
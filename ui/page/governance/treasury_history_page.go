@@ -0,0 +1,435 @@
+package governance
+
+import (
+	"context"
+	"image/color"
+	"strconv"
+	"sync"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const TreasuryHistoryPageID = "TreasuryHistory"
+
+type treasuryHistoryFetch struct {
+	history *wallet.TreasuryHistory
+	err     error
+}
+
+// TreasuryHistoryPage shows the treasury balance history and the votes of
+// the tickets of the wallets on treasury spends.
+type TreasuryHistoryPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	history   *wallet.TreasuryHistory
+	fetchErr  error
+	fetching  bool
+	balances  []float64
+	spendings []float64
+
+	// fetchedMu protects fetched, the result of a fetch that is handed off
+	// by the fetch goroutine to be displayed in HandleUserInteractions.
+	fetchedMu sync.Mutex
+	fetched   *treasuryHistoryFetch
+
+	backButton     decredmaterial.IconButton
+	refreshBtn     decredmaterial.Button
+	exportBtn      decredmaterial.Button
+	materialLoader material.LoaderStyle
+	listContainer  *widget.List
+}
+
+func NewTreasuryHistoryPage(l *load.Load) *TreasuryHistoryPage {
+	pg := &TreasuryHistoryPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(TreasuryHistoryPageID),
+		refreshBtn:       l.Theme.Button(values.String(values.StrRefresh)),
+		exportBtn:        l.Theme.OutlineButton(values.String(values.StrExportCSV)),
+		materialLoader:   material.Loader(l.Theme.Base),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *TreasuryHistoryPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	// A fetch cancelled when the page was left doesn't block a new one.
+	pg.fetching = false
+	pg.fetchedMu.Lock()
+	pg.fetched = nil
+	pg.fetchedMu.Unlock()
+
+	history, err := pg.WL.Wallet.CachedTreasuryHistory()
+	if err != nil {
+		log.Errorf("Error reading cached treasury history: %v", err)
+	}
+	pg.setHistory(history)
+	pg.fetchHistory()
+}
+
+func (pg *TreasuryHistoryPage) setHistory(history *wallet.TreasuryHistory) {
+	pg.history = history
+	if history == nil {
+		return
+	}
+
+	pg.balances = make([]float64, len(history.BalanceHistory))
+	pg.spendings = make([]float64, len(history.BalanceHistory))
+	for i, point := range history.BalanceHistory {
+		pg.balances[i] = dcrutil.Amount(point.Balance).ToCoin()
+		pg.spendings[i] = dcrutil.Amount(point.Sent).ToCoin()
+	}
+}
+
+// fetchHistory requests the treasury history in the background. The saved
+// history remains displayed if the request fails.
+func (pg *TreasuryHistoryPage) fetchHistory() {
+	if pg.fetching {
+		return
+	}
+
+	pg.fetching = true
+	go func() {
+		history, err := pg.WL.Wallet.FetchTreasuryHistory(pg.ctx)
+		if err != nil {
+			log.Errorf("Error fetching treasury history: %v", err)
+		}
+
+		pg.fetchedMu.Lock()
+		pg.fetched = &treasuryHistoryFetch{history: history, err: err}
+		pg.fetchedMu.Unlock()
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// applyFetchedHistory displays the result of the last fetch, if any.
+func (pg *TreasuryHistoryPage) applyFetchedHistory() {
+	pg.fetchedMu.Lock()
+	fetched := pg.fetched
+	pg.fetched = nil
+	pg.fetchedMu.Unlock()
+	if fetched == nil {
+		return
+	}
+
+	pg.fetching = false
+	pg.fetchErr = fetched.err
+	if fetched.err != nil {
+		if pg.history == nil {
+			pg.Toast.NotifyError(fetched.err.Error())
+		}
+		return
+	}
+	pg.setHistory(fetched.history)
+}
+
+func (pg *TreasuryHistoryPage) exportHistory() {
+	if pg.history == nil {
+		return
+	}
+
+	path, err := pg.WL.Wallet.ExportTreasuryHistory(pg.history)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.Toast.Notify(values.StringF(values.StrExportedTo, path))
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *TreasuryHistoryPage) HandleUserInteractions() {
+	pg.applyFetchedHistory()
+
+	if pg.refreshBtn.Clicked() {
+		pg.fetchHistory()
+	}
+
+	if pg.exportBtn.Clicked() {
+		pg.exportHistory()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *TreasuryHistoryPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *TreasuryHistoryPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrTreasuryHistory),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *TreasuryHistoryPage) layoutContent(gtx C) D {
+	sections := []layout.Widget{pg.layoutSummary}
+	if pg.history != nil {
+		sections = append(sections,
+			func(gtx C) D {
+				return pg.layoutChart(gtx, values.String(values.StrTreasuryBalanceHistory), func(gtx C) D {
+					return components.LineChart(gtx, pg.Load, pg.balances, values.MarginPadding120, pg.Theme.Color.Primary)
+				})
+			},
+			func(gtx C) D {
+				return pg.layoutChart(gtx, values.String(values.StrTreasuryMonthlySpending), func(gtx C) D {
+					return components.BarChart(gtx, pg.Load, pg.spendings, values.MarginPadding120, pg.Theme.Color.Orange)
+				})
+			},
+			pg.layoutTSpends,
+		)
+	}
+
+	return pg.Theme.List(pg.listContainer).Layout(gtx, len(sections), func(gtx C, i int) D {
+		return layout.Inset{Bottom: values.MarginPadding16, Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, sections[i])
+			})
+		})
+	})
+}
+
+func (pg *TreasuryHistoryPage) grayLabel(txt string) decredmaterial.Label {
+	lbl := pg.Theme.Body2(txt)
+	lbl.Color = pg.Theme.Color.GrayText2
+	return lbl
+}
+
+func (pg *TreasuryHistoryPage) layoutSummary(gtx C) D {
+	var status string
+	switch {
+	case pg.history == nil && pg.fetching:
+		status = values.String(values.StrFetchingTreasuryHistory)
+	case pg.history == nil:
+		status = values.String(values.StrNoTreasuryHistory)
+	case pg.fetchErr != nil:
+		status = values.StringF(values.StrTreasuryOffline, components.TimeAgo(pg.history.FetchedAt.Unix()))
+	default:
+		status = values.StringF(values.StrTreasuryUpdated, components.TimeAgo(pg.history.FetchedAt.Unix()))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			if pg.history == nil {
+				return D{}
+			}
+			return layout.Flex{}.Layout(gtx,
+				layout.Flexed(1, func(gtx C) D {
+					return pg.layoutStat(gtx, values.String(values.StrBalance), dcrutil.Amount(pg.history.Balance.Balance).String())
+				}),
+				layout.Flexed(1, func(gtx C) D {
+					return pg.layoutStat(gtx, values.String(values.StrTreasurySpent), dcrutil.Amount(pg.history.Balance.Spent).String())
+				}),
+				layout.Flexed(1, func(gtx C) D {
+					return pg.layoutStat(gtx, values.String(values.StrTSpends), strconv.FormatInt(pg.history.Balance.SpendCount, 10))
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, pg.grayLabel(status).Layout),
+					layout.Rigid(func(gtx C) D {
+						if pg.history == nil {
+							return D{}
+						}
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.exportBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if pg.fetching {
+							return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.materialLoader.Layout)
+						}
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.refreshBtn.Layout)
+					}),
+				)
+			})
+		}),
+	)
+}
+
+func (pg *TreasuryHistoryPage) layoutStat(gtx C, title, value string) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.grayLabel(title).Layout),
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.H6(value)
+			lbl.Font.Weight = text.SemiBold
+			return lbl.Layout(gtx)
+		}),
+	)
+}
+
+func (pg *TreasuryHistoryPage) layoutChart(gtx C, title string, chart layout.Widget) D {
+	var first, last string
+	if points := pg.history.BalanceHistory; len(points) > 0 {
+		first = points[0].Time.Format("Jan 2006")
+		last = points[len(points)-1].Time.Format("Jan 2006")
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body1(title)
+			lbl.Font.Weight = text.SemiBold
+			return lbl.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding4}.Layout(gtx, chart)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Flexed(1, pg.grayLabel(first).Layout),
+				layout.Rigid(pg.grayLabel(last).Layout),
+			)
+		}),
+	)
+}
+
+func (pg *TreasuryHistoryPage) layoutTSpends(gtx C) D {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body1(values.String(values.StrTSpends))
+			lbl.Font.Weight = text.SemiBold
+			return lbl.Layout(gtx)
+		}),
+	}
+
+	if len(pg.history.TSpends) == 0 {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.grayLabel(values.String(values.StrNoTSpends)).Layout)
+		}))
+	}
+
+	for i := range pg.history.TSpends {
+		record := pg.history.TSpends[i]
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return pg.layoutTSpend(gtx, record)
+			})
+		}))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func (pg *TreasuryHistoryPage) layoutTSpend(gtx C, record *wallet.TSpendRecord) D {
+	status := pg.Theme.Body2(values.String(values.StrVotingInProgressOutcome))
+	status.Color = pg.Theme.Color.Primary
+	switch record.Status {
+	case wallet.TSpendStatusApproved:
+		status = pg.Theme.Body2(values.String(values.StrApproved))
+		status.Color = pg.Theme.Color.Success
+	case wallet.TSpendStatusExpired:
+		status = pg.Theme.Body2(values.String(values.StrExpired))
+		status.Color = pg.Theme.Color.GrayText2
+	}
+
+	var minedTime string
+	if !record.Time.IsZero() {
+		minedTime = record.Time.Format("Jan 2, 2006")
+	}
+
+	yes, no, abstain := record.Fractions()
+	colors := []color.NRGBA{pg.Theme.Color.Success, pg.Theme.Color.Danger, pg.Theme.Color.Gray3}
+	percent := func(f float64) string {
+		return strconv.FormatFloat(f*100, 'f', 1, 64)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.Theme.Body1(components.TruncateString(record.Hash, 24)).Layout),
+				layout.Rigid(pg.Theme.Body1(dcrutil.Amount(record.Amount).String()).Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, status.Layout)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Flexed(1, pg.grayLabel(values.StringF(values.StrTSpendTickets, record.Votes())).Layout),
+					layout.Rigid(pg.grayLabel(minedTime).Layout),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if record.Network == nil {
+				return D{}
+			}
+			tally := values.StringF(values.StrTSpendNetworkTally, record.Network.Yes, record.Network.No, percent(record.Network.YesFraction()))
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, pg.grayLabel(tally).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return components.FractionBar(gtx, pg.Load, []float64{yes, no, abstain}, colors)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return components.ChartLegend(gtx, pg.Load, colors[0], values.StringF(values.StrFraction, values.String(values.StrYes), percent(yes)))
+					}),
+					layout.Rigid(func(gtx C) D {
+						return components.ChartLegend(gtx, pg.Load, colors[1], values.StringF(values.StrFraction, values.String(values.StrNo), percent(no)))
+					}),
+					layout.Rigid(func(gtx C) D {
+						return components.ChartLegend(gtx, pg.Load, colors[2], values.StringF(values.StrFraction, values.String(values.StrAbstain), percent(abstain)))
+					}),
+				)
+			})
+		}),
+	)
+}
@@ -3,6 +3,7 @@ package governance
 import (
 	"context"
 	"encoding/hex"
+	"strings"
 	"time"
 
 	"gioui.org/io/clipboard"
@@ -16,6 +17,7 @@ import (
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const TreasuryPageID = "Treasury"
@@ -34,9 +36,14 @@ type TreasuryPage struct {
 	multiWallet   *dcrlibwallet.MultiWallet
 	wallets       []*dcrlibwallet.Wallet
 	treasuryItems []*components.TreasuryItem
+	// allTreasuryItems holds every policy while treasuryItems only holds
+	// the policies matching the search editor text.
+	allTreasuryItems []*components.TreasuryItem
+	searchIndex      *wallet.SearchIndex
 
 	listContainer      *widget.List
 	viewGovernanceKeys *decredmaterial.Clickable
+	viewHistory        *decredmaterial.Clickable
 	copyRedirectURL    *decredmaterial.Clickable
 	redirectIcon       *decredmaterial.Image
 
@@ -57,7 +64,9 @@ func NewTreasuryPage(l *load.Load) *TreasuryPage {
 		},
 		redirectIcon:       l.Theme.Icons.RedirectIcon,
 		viewGovernanceKeys: l.Theme.NewClickable(true),
+		viewHistory:        l.Theme.NewClickable(true),
 		copyRedirectURL:    l.Theme.NewClickable(false),
+		searchIndex:        wallet.NewSearchIndex(),
 	}
 
 	pg.searchEditor = l.Theme.IconEditor(new(widget.Editor), values.String(values.StrSearch), l.Theme.Icons.SearchIcon, true)
@@ -155,6 +164,10 @@ func (pg *TreasuryPage) HandleUserInteractions() {
 		pg.ParentWindow().ShowModal(info)
 	}
 
+	for pg.viewHistory.Clicked() {
+		pg.ParentNavigator().Display(NewTreasuryHistoryPage(pg.Load))
+	}
+
 	if pg.isPolicyFetchInProgress {
 		time.AfterFunc(time.Second*1, func() {
 			pg.ParentWindow().Reload()
@@ -162,10 +175,41 @@ func (pg *TreasuryPage) HandleUserInteractions() {
 	}
 
 	pg.searchEditor.EditorIconButtonEvent = func() {
-		//TODO: treasury search functionality
+		pg.searchPolicies()
+	}
+
+	for _, evt := range pg.searchEditor.Editor.Events() {
+		switch evt.(type) {
+		case widget.ChangeEvent, widget.SubmitEvent:
+			pg.searchPolicies()
+		}
 	}
 }
 
+// searchPolicies filters the treasury policies by the search editor text,
+// the most relevant first.
+func (pg *TreasuryPage) searchPolicies() {
+	query := wallet.ParseSearchQuery(pg.searchEditor.Editor.Text())
+	if query.IsEmpty() {
+		pg.treasuryItems = pg.allTreasuryItems
+		return
+	}
+	query.Kinds = []string{wallet.SearchKindTreasuryPolicy}
+
+	itemsByKey := make(map[string]*components.TreasuryItem, len(pg.allTreasuryItems))
+	for _, item := range pg.allTreasuryItems {
+		itemsByKey[item.Policy.PiKey] = item
+	}
+
+	treasuryItems := make([]*components.TreasuryItem, 0)
+	for _, result := range pg.searchIndex.Search(query) {
+		if item, ok := itemsByKey[result.Token]; ok {
+			treasuryItems = append(treasuryItems, item)
+		}
+	}
+	pg.treasuryItems = treasuryItems
+}
+
 func (pg *TreasuryPage) FetchPolicies() {
 	selectedWallet := pg.WL.SelectedWallet.Wallet
 
@@ -175,7 +219,13 @@ func (pg *TreasuryPage) FetchPolicies() {
 	// a network call. Refresh the window once the call completes.
 	key := hex.EncodeToString(pg.WL.MultiWallet.PiKeys()[0])
 	go func() {
-		pg.treasuryItems = components.LoadPolicies(pg.Load, selectedWallet, key)
+		pg.allTreasuryItems = components.LoadPolicies(pg.Load, selectedWallet, key)
+		policies := make([]*dcrlibwallet.TreasuryKeyPolicy, len(pg.allTreasuryItems))
+		for i, item := range pg.allTreasuryItems {
+			policies[i] = &item.Policy
+		}
+		pg.searchIndex.AddTreasuryPolicies(policies)
+		pg.searchPolicies()
 		pg.isPolicyFetchInProgress = true
 		pg.ParentWindow().Reload()
 	}()
@@ -196,7 +246,12 @@ func (pg *TreasuryPage) Layout(gtx C) D {
 					)
 				}),
 				layout.Flexed(1, func(gtx C) D {
-					return layout.E.Layout(gtx, pg.layoutVerifyGovernanceKeys)
+					return layout.E.Layout(gtx, func(gtx C) D {
+						return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+							layout.Rigid(pg.layoutViewHistory),
+							layout.Rigid(pg.layoutVerifyGovernanceKeys),
+						)
+					})
 				}),
 			)
 		}),
@@ -205,9 +260,10 @@ func (pg *TreasuryPage) Layout(gtx C) D {
 				return layout.Stack{}.Layout(gtx,
 					layout.Expanded(func(gtx C) D {
 						return layout.Inset{
-							Top: values.MarginPadding10,
+							Top: values.MarginPadding60,
 						}.Layout(gtx, pg.layoutContent)
 					}),
+					layout.Expanded(pg.layoutSearchEditor),
 				)
 			})
 		}),
@@ -233,8 +289,37 @@ func (pg *TreasuryPage) layoutVerifyGovernanceKeys(gtx C) D {
 	})
 }
 
+func (pg *TreasuryPage) layoutViewHistory(gtx C) D {
+	return layout.Inset{Top: values.MarginPadding5, Right: values.MarginPadding24}.Layout(gtx, func(gtx C) D {
+		return pg.viewHistory.Layout(gtx, func(gtx C) D {
+			lbl := pg.Theme.Label(values.TextSize16, values.String(values.StrTreasuryHistory))
+			lbl.Color = pg.Theme.Color.Primary
+			return layout.Inset{Top: values.MarginPaddingMinus2}.Layout(gtx, lbl.Layout)
+		})
+	})
+}
+
+func (pg *TreasuryPage) layoutSearchEditor(gtx C) D {
+	gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding150)
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+
+	card := pg.Theme.Card()
+	card.Radius = decredmaterial.Radius(8)
+	return card.Layout(gtx, func(gtx C) D {
+		return layout.Inset{
+			Left:   values.MarginPadding10,
+			Right:  values.MarginPadding10,
+			Top:    values.MarginPadding2,
+			Bottom: values.MarginPadding2,
+		}.Layout(gtx, pg.searchEditor.Layout)
+	})
+}
+
 func (pg *TreasuryPage) layoutContent(gtx C) D {
 	if len(pg.treasuryItems) == 0 {
+		if searchText := strings.TrimSpace(pg.searchEditor.Editor.Text()); searchText != "" && len(pg.allTreasuryItems) > 0 {
+			return components.LayoutNoResultsFound(gtx, pg.Load, searchText)
+		}
 		return components.LayoutNoPoliciesFound(gtx, pg.Load, pg.isPolicyFetchInProgress)
 	}
 
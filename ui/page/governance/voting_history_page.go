@@ -0,0 +1,294 @@
+package governance
+
+import (
+	"context"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const VotingHistoryPageID = "VotingHistory"
+
+// VotingHistoryPage lists the votes of the tickets of a wallet on proposals.
+// Eligible tickets that didn't vote are highlighted once the wallet's votes
+// have been audited.
+type VotingHistoryPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	walletSelector *WalletSelector
+	ticketEditor   decredmaterial.Editor
+	auditBtn       decredmaterial.Button
+	exportBtn      decredmaterial.Button
+	materialLoader material.LoaderStyle
+	listContainer  *widget.List
+	recordsList    *decredmaterial.ClickableList
+
+	records  []*wallet.VoteRecord
+	visible  []*wallet.VoteRecord
+	auditing bool
+}
+
+func NewVotingHistoryPage(l *load.Load) *VotingHistoryPage {
+	pg := &VotingHistoryPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(VotingHistoryPageID),
+		auditBtn:         l.Theme.Button(values.String(values.StrAuditVotes)),
+		exportBtn:        l.Theme.OutlineButton(values.String(values.StrExportCSV)),
+		materialLoader:   material.Loader(l.Theme.Base),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		recordsList: l.Theme.NewClickableList(layout.Vertical),
+	}
+
+	pg.ticketEditor = l.Theme.IconEditor(new(widget.Editor), values.String(values.StrFilterByTicket), l.Theme.Icons.SearchIcon, true)
+	pg.ticketEditor.Editor.SingleLine, pg.ticketEditor.Editor.Submit, pg.ticketEditor.Bordered = true, true, false
+
+	pg.walletSelector = NewWalletSelector(l).
+		Title(values.String(values.StrSelectWallet)).
+		WalletSelected(func(*dcrlibwallet.Wallet) {
+			pg.loadHistory(false)
+		})
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *VotingHistoryPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+
+	if pg.walletSelector.SelectedWallet() == nil {
+		// Selecting the wallet loads its history.
+		if err := pg.walletSelector.SelectFirstValidWallet(); err != nil {
+			pg.Toast.NotifyError(err.Error())
+		}
+		return
+	}
+	pg.loadHistory(false)
+}
+
+// loadHistory reads the vote history of the selected wallet in the
+// background. If audit is true, the votes not yet audited are requested from
+// politeia.
+func (pg *VotingHistoryPage) loadHistory(audit bool) {
+	selectedWallet := pg.walletSelector.SelectedWallet()
+	if selectedWallet == nil {
+		return
+	}
+
+	pg.auditing = audit
+	go func() {
+		records, err := wallet.ProposalVoteHistory(pg.ctx, pg.WL.MultiWallet, selectedWallet, audit)
+		pg.auditing = false
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+			pg.ParentWindow().Reload()
+			return
+		}
+
+		if audit {
+			pg.Toast.Notify(values.String(values.StrVotesAudited))
+		}
+		pg.records = records
+		pg.filterRecords()
+		pg.ParentWindow().Reload()
+	}()
+}
+
+func (pg *VotingHistoryPage) filterRecords() {
+	ticket := strings.TrimSpace(pg.ticketEditor.Editor.Text())
+	if ticket == "" {
+		pg.visible = pg.records
+		return
+	}
+
+	visible := make([]*wallet.VoteRecord, 0)
+	for _, record := range pg.records {
+		if strings.HasPrefix(record.Ticket, ticket) {
+			visible = append(visible, record)
+		}
+	}
+	pg.visible = visible
+}
+
+func (pg *VotingHistoryPage) exportHistory() {
+	path, err := pg.WL.Wallet.ExportVoteHistory(pg.walletSelector.SelectedWallet(), pg.visible)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.Toast.Notify(values.StringF(values.StrExportedTo, path))
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *VotingHistoryPage) HandleUserInteractions() {
+	for _, evt := range pg.ticketEditor.Editor.Events() {
+		switch evt.(type) {
+		case widget.ChangeEvent, widget.SubmitEvent:
+			pg.filterRecords()
+		}
+	}
+
+	// Clicking a record shows the history of its ticket.
+	if clicked, selectedItem := pg.recordsList.ItemClicked(); clicked {
+		pg.ticketEditor.Editor.SetText(pg.visible[selectedItem].Ticket)
+		pg.filterRecords()
+	}
+
+	if pg.auditBtn.Clicked() && !pg.auditing {
+		pg.loadHistory(true)
+	}
+
+	if pg.exportBtn.Clicked() {
+		pg.exportHistory()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *VotingHistoryPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *VotingHistoryPage) Layout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			if pg.walletSelector.SelectedWallet() == nil {
+				return D{}
+			}
+			return pg.walletSelector.Layout(gtx, pg.ParentWindow())
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						return pg.Theme.Card().Layout(gtx, pg.ticketEditor.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.exportBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if pg.auditing {
+							return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.materialLoader.Layout)
+						}
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.auditBtn.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			return pg.Theme.List(pg.listContainer).Layout(gtx, 1, func(gtx C, i int) D {
+				return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						if len(pg.visible) == 0 {
+							gtx.Constraints.Min.X = gtx.Constraints.Max.X
+							txt := pg.Theme.Body1(values.String(values.StrNoVotingHistory))
+							txt.Color = pg.Theme.Color.GrayText3
+							return layout.Center.Layout(gtx, func(gtx C) D {
+								return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+							})
+						}
+
+						return pg.recordsList.Layout(gtx, len(pg.visible), func(gtx C, i int) D {
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+								layout.Rigid(func(gtx C) D {
+									return pg.layoutRecord(gtx, pg.visible[i])
+								}),
+								layout.Rigid(pg.Theme.Separator().Layout),
+							)
+						})
+					})
+				})
+			})
+		}),
+	)
+}
+
+func (pg *VotingHistoryPage) layoutRecord(gtx C, record *wallet.VoteRecord) D {
+	choice := pg.Theme.Body1(values.String(values.StrNotVoted))
+	choice.Color = pg.Theme.Color.Danger
+	switch record.Choice {
+	case dcrlibwallet.VoteBitYes:
+		choice = pg.Theme.Body1(values.String(values.StrYes))
+		choice.Color = pg.Theme.Color.Success
+	case dcrlibwallet.VoteBitNo:
+		choice = pg.Theme.Body1(values.String(values.StrNo))
+	}
+
+	var outcome string
+	switch record.Outcome {
+	case wallet.VoteOutcomeVoting:
+		outcome = values.String(values.StrVotingInProgressOutcome)
+	case wallet.VoteOutcomeApproved:
+		outcome = values.String(values.StrApproved)
+	case wallet.VoteOutcomeRejected:
+		outcome = values.String(values.StrRejected)
+	}
+
+	var voteTime string
+	if !record.VoteTime.IsZero() {
+		voteTime = record.VoteTime.Format("Jan 2, 2006 15:04")
+	}
+
+	grayText := func(txt string) decredmaterial.Label {
+		lbl := pg.Theme.Caption(txt)
+		lbl.Color = pg.Theme.Color.GrayText2
+		return lbl
+	}
+
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Flexed(1, pg.Theme.Body1(record.ProposalName).Layout),
+					layout.Rigid(choice.Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Flexed(1, grayText(components.TruncateString(record.Ticket, 24)).Layout),
+						layout.Rigid(grayText(voteTime).Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, grayText(outcome).Layout)
+						}),
+					)
+				})
+			}),
+		)
+	})
+}
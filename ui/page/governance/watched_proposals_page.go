@@ -0,0 +1,118 @@
+package governance
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const WatchedProposalsPageID = "WatchedProposals"
+
+// WatchedProposalsPage lists the proposals on the user's watchlist.
+type WatchedProposalsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	listContainer *widget.List
+	proposalsList *decredmaterial.ClickableList
+
+	proposalItems []*components.ProposalItem
+}
+
+func NewWatchedProposalsPage(l *load.Load) *WatchedProposalsPage {
+	pg := &WatchedProposalsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(WatchedProposalsPageID),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		proposalsList: l.Theme.NewClickableList(layout.Vertical),
+	}
+	pg.proposalsList.IsShadowEnabled = true
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *WatchedProposalsPage) OnNavigatedTo() {
+	pg.fetchProposals()
+}
+
+func (pg *WatchedProposalsPage) fetchProposals() {
+	watched := make(map[string]bool)
+	for _, token := range wallet.WatchedProposals(pg.WL.MultiWallet) {
+		watched[token] = true
+	}
+
+	proposalItems := make([]*components.ProposalItem, 0, len(watched))
+	for _, item := range components.LoadProposals(dcrlibwallet.ProposalCategoryAll, true, pg.Load) {
+		if watched[item.Proposal.Token] {
+			proposalItems = append(proposalItems, item)
+		}
+	}
+	pg.proposalItems = proposalItems
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *WatchedProposalsPage) HandleUserInteractions() {
+	if clicked, selectedItem := pg.proposalsList.ItemClicked(); clicked {
+		selectedProposal := pg.proposalItems[selectedItem].Proposal
+		pg.ParentNavigator().Display(NewProposalDetailsPage(pg.Load, &selectedProposal))
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *WatchedProposalsPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *WatchedProposalsPage) Layout(gtx C) D {
+	return pg.Theme.List(pg.listContainer).Layout(gtx, 1, func(gtx C, i int) D {
+		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				if len(pg.proposalItems) == 0 {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					txt := pg.Theme.Body1(values.String(values.StrNoWatchedProposals))
+					txt.Color = pg.Theme.Color.GrayText3
+					return layout.Center.Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+					})
+				}
+
+				return pg.proposalsList.Layout(gtx, len(pg.proposalItems), func(gtx C, i int) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return components.ProposalsList(pg.ParentWindow(), gtx, pg.Load, pg.proposalItems[i])
+						}),
+						layout.Rigid(pg.Theme.Separator().Layout),
+					)
+				})
+			})
+		})
+	})
+}
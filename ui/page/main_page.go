@@ -21,6 +21,7 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/notification"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/page/dexclient"
 	"github.com/planetdecred/godcr/ui/page/governance"
@@ -63,12 +64,14 @@ type MainPage struct {
 	*listeners.SyncProgressListener
 	*listeners.TxAndBlockNotificationListener
 	*listeners.ProposalNotificationListener
+	*listeners.AccountMixerNotificationListener
 
 	ctx                  context.Context
 	ctxCancel            context.CancelFunc
 	drawerNav            components.NavDrawer
 	bottomNavigationBar  components.BottomNavigationBar
 	floatingActionButton components.BottomNavigationBar
+	systemNotification   *notification.SystemNotification
 
 	sendPage    *send.Page   // reuse value to keep data persistent onresume.
 	receivePage *ReceivePage // pointer to receive page. to avoid duplication.
@@ -125,9 +128,30 @@ func NewMainPage(l *load.Load) *MainPage {
 
 	mp.bottomNavigationBar.OnViewCreated()
 
+	systemNotification, err := notification.NewSystemNotification()
+	if err != nil {
+		log.Errorf("failed to initialize system notification: %v", err)
+	}
+	mp.systemNotification = systemNotification
+
+	mp.endInterruptedMixerSessions()
+
 	return mp
 }
 
+// endInterruptedMixerSessions ends the mixer sessions left running when the
+// app was last closed.
+func (mp *MainPage) endInterruptedMixerSessions() {
+	for _, wal := range mp.WL.MultiWallet.AllWallets() {
+		if wal.IsAccountMixerActive() {
+			continue
+		}
+		if err := mp.WL.Wallet.RecordMixerInterrupted(wal); err != nil {
+			log.Errorf("error ending interrupted mixer session of wallet %d: %v", wal.ID, err)
+		}
+	}
+}
+
 // ID is a unique string that identifies the page and may be used
 // to differentiate this page from other pages.
 // Part of the load.Page interface.
@@ -841,11 +865,6 @@ func (mp *MainPage) postDesktopNotification(notifier interface{}) {
 			// remove trailing zeros from amount and convert to string
 			amount := strconv.FormatFloat(dcrlibwallet.AmountCoin(t.Transaction.Amount), 'f', -1, 64)
 			notification = values.StringF(values.StrDcrReceived, amount)
-		case dcrlibwallet.TxTypeVote:
-			reward := strconv.FormatFloat(dcrlibwallet.AmountCoin(t.Transaction.VoteReward), 'f', -1, 64)
-			notification = values.StringF(values.StrTicektVoted, reward)
-		case dcrlibwallet.TxTypeRevocation:
-			notification = values.String(values.StrTicketRevoked)
 		default:
 			return
 		}
@@ -860,6 +879,42 @@ func (mp *MainPage) postDesktopNotification(notifier interface{}) {
 		}
 
 		initializeBeepNotification(notification)
+	case wallet.TicketEvent:
+		switch t.Type {
+		case wallet.TicketPurchased:
+			notification = values.String(values.StrTicketPurchasedNotif)
+		case wallet.TicketMatured:
+			notification = values.String(values.StrTicketMaturedNotif)
+		case wallet.TicketVoted:
+			reward := strconv.FormatFloat(dcrlibwallet.AmountCoin(t.Reward), 'f', -1, 64)
+			notification = values.StringF(values.StrTicektVoted, reward)
+		case wallet.TicketMissed:
+			notification = values.String(values.StrTicketMissedNotif)
+		case wallet.TicketExpired:
+			notification = values.String(values.StrTicketExpiredNotif)
+		case wallet.TicketRevoked:
+			notification = values.String(values.StrTicketRevoked)
+		case wallet.TicketVSPFeeErrored:
+			notification = values.String(values.StrVSPFeeErroredNotif)
+		default:
+			return
+		}
+
+		if mp.WL.MultiWallet.OpenedWalletsCount() > 1 {
+			wallet := mp.WL.MultiWallet.WalletWithID(t.WalletID)
+			if wallet == nil {
+				return
+			}
+
+			notification = fmt.Sprintf("[%s] %s", wallet.Name, notification)
+		}
+
+		if mp.systemNotification == nil {
+			return
+		}
+		if err := mp.systemNotification.Notify(notification); err != nil {
+			log.Info("could not initiate desktop notification, reason:", err.Error())
+		}
 	case wallet.Proposal:
 		proposalNotification := mp.WL.MultiWallet.ReadBoolConfigValueForKey(load.ProposalNotificationConfigKey, false)
 		if !proposalNotification {
@@ -876,6 +931,105 @@ func (mp *MainPage) postDesktopNotification(notifier interface{}) {
 			notification = values.StringF(values.StrNewProposalUpdate, t.Proposal.Name)
 		}
 		initializeBeepNotification(notification)
+	case wallet.ProposalEvent:
+		name := t.Proposal.Name
+		switch t.Type {
+		case wallet.ProposalVersionUpdated:
+			notification = values.StringF(values.StrProposalVersionNotif, name, t.Proposal.Version)
+		case wallet.ProposalVoteMilestone:
+			notification = values.StringF(values.StrProposalMilestoneNotif, t.Milestone, name)
+		case wallet.ProposalQuorumReached:
+			notification = values.StringF(values.StrProposalQuorumNotif, name)
+		case wallet.ProposalPassThresholdMet:
+			notification = values.StringF(values.StrProposalPassingNotif, name)
+		case wallet.ProposalPassThresholdLost:
+			notification = values.StringF(values.StrProposalFailingNotif, name)
+		case wallet.ProposalVoteResult:
+			notification = values.StringF(values.StrProposalRejectedNotif, name)
+			if t.Proposal.VoteApproved {
+				notification = values.StringF(values.StrProposalApprovedNotif, name)
+			}
+		default:
+			return
+		}
+
+		if mp.systemNotification == nil {
+			return
+		}
+		if err := mp.systemNotification.Notify(notification); err != nil {
+			log.Info("could not initiate desktop notification, reason:", err.Error())
+		}
+	}
+}
+
+// notifyWatchedProposalEvents posts a desktop notification for every change
+// to the proposals on the user's watchlist.
+func (mp *MainPage) notifyWatchedProposalEvents() {
+	// The events are read even when proposal notifications are disabled so
+	// that they are not reported once notifications are enabled again.
+	events := wallet.ProposalEvents(mp.WL.MultiWallet)
+	if !mp.WL.MultiWallet.ReadBoolConfigValueForKey(load.ProposalNotificationConfigKey, false) {
+		return
+	}
+
+	for _, event := range events {
+		mp.postDesktopNotification(event)
+	}
+}
+
+// notifyTicketEvents posts a desktop notification for every ticket lifecycle
+// event of the specified wallet that is enabled in the wallet's settings.
+// Only the tickets that can still change state and the tickets among the
+// txHashes are checked, unless checkAll is set. Ticket events are only checked
+// after the wallets are synced.
+func (mp *MainPage) notifyTicketEvents(walletID int, checkAll bool, txHashes ...string) {
+	if !mp.WL.MultiWallet.IsSynced() {
+		return
+	}
+
+	wal := mp.WL.MultiWallet.WalletWithID(walletID)
+	if wal == nil {
+		return
+	}
+
+	var events []wallet.TicketEvent
+	var err error
+	if checkAll {
+		events, err = wallet.TicketEvents(mp.WL.MultiWallet, wal)
+	} else {
+		events, err = wallet.TicketEventsForTxs(mp.WL.MultiWallet, wal, txHashes...)
+	}
+	if err != nil {
+		log.Errorf("error checking ticket events for wallet %d: %v", walletID, err)
+		return
+	}
+
+	// Ticket notifications follow the transaction notification setting
+	// until they are configured for the wallet.
+	defaultEnabled := mp.WL.MultiWallet.ReadBoolConfigValueForKey(load.TransactionNotificationConfigKey, false)
+	for _, event := range events {
+		if wal.ReadBoolConfigValueForKey(event.Type.ConfigKey(), defaultEnabled) {
+			mp.postDesktopNotification(event)
+		}
+	}
+}
+
+// recordMixerActivity adds the start or end of the account mixer of a wallet
+// to the wallet's mixer history.
+func (mp *MainPage) recordMixerActivity(n wallet.AccountMixer) {
+	wal := mp.WL.MultiWallet.WalletWithID(n.WalletID)
+	if wal == nil {
+		return
+	}
+
+	var err error
+	if n.RunStatus == wallet.MixerStarted {
+		err = mp.WL.Wallet.RecordMixerStarted(wal)
+	} else {
+		err = mp.WL.Wallet.RecordMixerEnded(wal)
+	}
+	if err != nil {
+		log.Errorf("error recording mixer activity for wallet %d: %v", n.WalletID, err)
 	}
 }
 
@@ -902,6 +1056,8 @@ func (mp *MainPage) listenForNotifications() {
 		return
 	case mp.ProposalNotificationListener != nil:
 		return
+	case mp.AccountMixerNotificationListener != nil:
+		return
 	}
 
 	mp.SyncProgressListener = listeners.NewSyncProgress()
@@ -925,6 +1081,13 @@ func (mp *MainPage) listenForNotifications() {
 		return
 	}
 
+	mp.AccountMixerNotificationListener = listeners.NewAccountMixerNotificationListener()
+	err = mp.WL.MultiWallet.AddAccountMixerNotificationListener(mp.AccountMixerNotificationListener, MainPageID)
+	if err != nil {
+		log.Errorf("Error adding account mixer notification listener: %v", err)
+		return
+	}
+
 	go func() {
 		for {
 			select {
@@ -939,6 +1102,7 @@ func (mp *MainPage) listenForNotifications() {
 						}
 						mp.postDesktopNotification(update)
 					}
+					mp.notifyTicketEvents(n.Transaction.WalletID, false, n.Transaction.Hash)
 					mp.ParentWindow().Reload()
 				case listeners.BlockAttached:
 					beep := mp.WL.MultiWallet.ReadBoolConfigValueForKey(dcrlibwallet.BeepNewBlocksConfigKey, false)
@@ -950,9 +1114,11 @@ func (mp *MainPage) listenForNotifications() {
 					}
 
 					mp.updateBalance()
+					mp.notifyTicketEvents(n.WalletID, false)
 					mp.ParentWindow().Reload()
 				case listeners.TxConfirmed:
 					mp.updateBalance()
+					mp.notifyTicketEvents(n.WalletID, false)
 					mp.ParentWindow().Reload()
 
 				}
@@ -961,23 +1127,33 @@ func (mp *MainPage) listenForNotifications() {
 				if notification.ProposalStatus != wallet.Synced {
 					mp.postDesktopNotification(notification)
 				}
+				mp.notifyWatchedProposalEvents()
+			case n := <-mp.MixerChan:
+				mp.recordMixerActivity(n)
+				mp.ParentWindow().Reload()
 			case n := <-mp.SyncStatusChan:
 				if n.Stage == wallet.SyncCompleted {
 					mp.updateBalance()
+					for _, w := range mp.WL.SortedWalletList() {
+						mp.notifyTicketEvents(w.ID, true)
+					}
 					mp.ParentWindow().Reload()
 				}
 			case <-mp.ctx.Done():
 				mp.WL.MultiWallet.RemoveSyncProgressListener(MainPageID)
 				mp.WL.MultiWallet.RemoveTxAndBlockNotificationListener(MainPageID)
 				mp.WL.MultiWallet.Politeia.RemoveNotificationListener(MainPageID)
+				mp.WL.MultiWallet.RemoveAccountMixerNotificationListener(MainPageID)
 
 				close(mp.SyncStatusChan)
 				close(mp.TxAndBlockNotifChan)
 				close(mp.ProposalNotifChan)
+				close(mp.MixerChan)
 
 				mp.SyncProgressListener = nil
 				mp.TxAndBlockNotificationListener = nil
 				mp.ProposalNotificationListener = nil
+				mp.AccountMixerNotificationListener = nil
 
 				return
 			}
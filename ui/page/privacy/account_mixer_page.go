@@ -40,7 +40,14 @@ type AccountMixerPage struct {
 	infoButton  decredmaterial.IconButton
 	toggleMixer *decredmaterial.Switch
 
+	editScheduleBtn  decredmaterial.Button
+	startScheduleBtn decredmaterial.Button
+	stopScheduleBtn  decredmaterial.Button
+
 	mixerCompleted bool
+
+	mixerHistory        *wallet.MixerHistory
+	mixerHistoryUpdates uint64
 }
 
 func NewAccountMixerPage(l *load.Load) *AccountMixerPage {
@@ -50,7 +57,11 @@ func NewAccountMixerPage(l *load.Load) *AccountMixerPage {
 		pageContainer:         layout.List{Axis: layout.Vertical},
 		toggleMixer:           l.Theme.Switch(),
 		dangerZoneCollapsible: l.Theme.Collapsible(),
+		editScheduleBtn:       l.Theme.OutlineButton(values.String(values.StrEditSchedule)),
+		startScheduleBtn:      l.Theme.Button(values.String(values.StrStartSchedule)),
+		stopScheduleBtn:       l.Theme.Button(values.String(values.StrStopSchedule)),
 	}
+	pg.stopScheduleBtn.Background = l.Theme.Color.Danger
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(l)
 
 	return pg
@@ -65,6 +76,12 @@ func (pg *AccountMixerPage) OnNavigatedTo() {
 
 	pg.listenForMixerNotifications()
 	pg.toggleMixer.SetChecked(pg.WL.SelectedWallet.Wallet.IsAccountMixerActive())
+	pg.mixerHistory = nil
+	pg.loadMixerHistory()
+
+	if scheduler := pg.mixerScheduler(); scheduler != nil {
+		scheduler.SetOnUpdate(pg.ParentWindow().Reload)
+	}
 }
 
 // Layout draws the page UI components into the provided layout context
@@ -83,21 +100,21 @@ func (pg *AccountMixerPage) Layout(gtx layout.Context) layout.Dimensions {
 			},
 			InfoTemplate: modal.PrivacyInfoTemplate,
 			Body: func(gtx layout.Context) layout.Dimensions {
+				mixedBalance := "0.00"
+				unmixedBalance := "0.00"
+				accounts, _ := pg.WL.SelectedWallet.Wallet.GetAccountsRaw()
+				for _, acct := range accounts.Acc {
+					if acct.Number == pg.WL.SelectedWallet.Wallet.MixedAccountNumber() {
+						mixedBalance = dcrutil.Amount(acct.TotalBalance).String()
+					} else if acct.Number == pg.WL.SelectedWallet.Wallet.UnmixedAccountNumber() {
+						unmixedBalance = dcrutil.Amount(acct.TotalBalance).String()
+					}
+				}
+
 				widgets := []func(gtx C) D{
 					func(gtx C) D {
 						return components.MixerInfoLayout(gtx, pg.Load, pg.WL.SelectedWallet.Wallet.IsAccountMixerActive(),
 							pg.toggleMixer.Layout, func(gtx C) D {
-								mixedBalance := "0.00"
-								unmixedBalance := "0.00"
-								accounts, _ := pg.WL.SelectedWallet.Wallet.GetAccountsRaw()
-								for _, acct := range accounts.Acc {
-									if acct.Number == pg.WL.SelectedWallet.Wallet.MixedAccountNumber() {
-										mixedBalance = dcrutil.Amount(acct.TotalBalance).String()
-									} else if acct.Number == pg.WL.SelectedWallet.Wallet.UnmixedAccountNumber() {
-										unmixedBalance = dcrutil.Amount(acct.TotalBalance).String()
-									}
-								}
-
 								return components.MixerInfoContentWrapper(gtx, pg.Load, func(gtx C) D {
 									return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 										layout.Rigid(func(gtx C) D {
@@ -131,6 +148,11 @@ func (pg *AccountMixerPage) Layout(gtx layout.Context) layout.Dimensions {
 								})
 							})
 					},
+					func(gtx C) D {
+						return pg.mixerSummaryLayout(gtx, unmixedBalance)
+					},
+					pg.mixerScheduleLayout,
+					pg.mixerTimelineLayout,
 					func(gtx C) D {
 						return pg.mixerSettingsLayout(gtx)
 					},
@@ -224,13 +246,27 @@ func (pg *AccountMixerPage) HandleUserInteractions() {
 				NegativeButton("No", func() {}).
 				PositiveButton("Yes", func(isChecked bool) bool {
 					pg.toggleMixer.SetChecked(false)
-					go pg.WL.MultiWallet.StopAccountMixer(pg.WL.SelectedWallet.Wallet.ID)
+					walletID := pg.WL.SelectedWallet.Wallet.ID
+					if scheduler := pg.mixerScheduler(); scheduler != nil && scheduler.IsRunning() {
+						// Stop the schedule too, or it restarts the mixer.
+						go scheduler.Cancel()
+						return true
+					}
+					go func() {
+						if err := pg.WL.Wallet.RecordMixerStopping(walletID, wallet.MixerStoppedByUser); err != nil {
+							log.Errorf("Error recording mixer stop: %v", err)
+						}
+						pg.WL.MultiWallet.StopAccountMixer(walletID)
+					}()
 					return true
 				})
 			pg.ParentWindow().ShowModal(info)
 		}
 	}
 
+	pg.handleScheduleInteractions()
+	pg.loadMixerHistory()
+
 	if pg.mixerCompleted {
 		pg.toggleMixer.SetChecked(false)
 		pg.mixerCompleted = false
@@ -252,6 +288,11 @@ func (pg *AccountMixerPage) showModalPasswordStartAccountMixer() {
 			go func() {
 				err := pg.WL.MultiWallet.StartAccountMixer(pg.WL.SelectedWallet.Wallet.ID, password)
 				if err != nil {
+					if err.Error() != dcrlibwallet.ErrInvalidPassphrase {
+						if recordErr := pg.WL.Wallet.RecordMixerStartFailed(pg.WL.SelectedWallet.Wallet, err); recordErr != nil {
+							log.Errorf("Error recording mixer failure: %v", recordErr)
+						}
+					}
 					pm.SetError(err.Error())
 					pm.SetLoading(false)
 					return
@@ -309,4 +350,7 @@ func (pg *AccountMixerPage) listenForMixerNotifications() {
 // Part of the load.Page interface.
 func (pg *AccountMixerPage) OnNavigatedFrom() {
 	pg.ctxCancel()
+	if scheduler := pg.mixerScheduler(); scheduler != nil {
+		scheduler.SetOnUpdate(nil)
+	}
 }
@@ -0,0 +1,181 @@
+package privacy
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// maxTimelineSessions is the number of the most recent mixer sessions shown
+// in the timeline.
+const maxTimelineSessions = 20
+
+// loadMixerHistory reads the mixer history of the selected wallet if it was
+// updated since it was last read.
+func (pg *AccountMixerPage) loadMixerHistory() {
+	updates := wallet.MixerHistoryUpdates()
+	if pg.mixerHistory != nil && updates == pg.mixerHistoryUpdates {
+		return
+	}
+
+	history, err := pg.WL.Wallet.MixerHistory(pg.WL.SelectedWallet.Wallet.ID)
+	if err != nil {
+		log.Errorf("Error reading mixer history: %v", err)
+		return
+	}
+	pg.mixerHistory = history
+	pg.mixerHistoryUpdates = updates
+}
+
+func (pg *AccountMixerPage) mixerSummaryLayout(gtx C, unmixedBalance string) D {
+	summary := pg.mixerHistory.Summary(time.Now())
+
+	row := func(label, value string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				lbl := pg.Theme.Body2(label)
+				lbl.Color = pg.Theme.Color.GrayText2
+				return components.EndToEndRow(gtx, lbl.Layout, pg.Theme.Body1(value).Layout)
+			})
+		})
+	}
+
+	rows := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body1(values.String(values.StrMixerActivity))
+			lbl.Font.Weight = text.SemiBold
+			return lbl.Layout(gtx)
+		}),
+		row(values.String(values.StrMixerSessions), fmt.Sprint(summary.Sessions)),
+		row(values.String(values.StrTimeMixing), components.TimeFormat(int(summary.Duration.Seconds()), true)),
+		row(values.String(values.StrMixedTransactions), fmt.Sprint(summary.MixedTxs)),
+		row(values.String(values.StrAmountMixed), dcrutil.Amount(summary.Amount).String()),
+	}
+	for _, denomination := range summary.Denominations {
+		rows = append(rows, row("", values.StringF(values.StrDenominationOutputs, denomination.Outputs, dcrutil.Amount(denomination.Denomination).String())))
+	}
+	rows = append(rows,
+		row(values.String(values.StrMixerFailures), fmt.Sprint(summary.Failures)),
+		row(values.String(values.StrUnmixedRemaining), unmixedBalance),
+	)
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+		})
+	})
+}
+
+func (pg *AccountMixerPage) mixerTimelineLayout(gtx C) D {
+	sessions := pg.mixerHistory.Sessions
+	items := make([]layout.FlexChild, 0, maxTimelineSessions)
+	for i := len(sessions) - 1; i >= 0 && len(items) < maxTimelineSessions; i-- {
+		session := sessions[i]
+		items = append(items, layout.Rigid(func(gtx C) D {
+			return pg.mixerSessionLayout(gtx, session)
+		}))
+	}
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+			if len(items) == 0 {
+				txt := pg.Theme.Body2(values.String(values.StrNoMixerActivity))
+				txt.Color = pg.Theme.Color.GrayText3
+				return txt.Layout(gtx)
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, items...)
+		})
+	})
+}
+
+// mixerSessionLayout draws a session as an entry of the timeline: a dot
+// colored by the outcome of the session, followed by its details.
+func (pg *AccountMixerPage) mixerSessionLayout(gtx C, session *wallet.MixerSession) D {
+	// A session that is still recorded as running while the mixer is off
+	// was interrupted by the app closing.
+	running := session.Running() && pg.WL.SelectedWallet.Wallet.IsAccountMixerActive()
+	interrupted := session.Running() && !running
+
+	dotColor := pg.Theme.Color.Success
+	var title string
+	switch {
+	case running:
+		dotColor = pg.Theme.Color.Primary
+		title = values.StringF(values.StrMixerRunningSince, components.TimeAgo(session.Start))
+	case interrupted:
+		dotColor = pg.Theme.Color.Orange
+		title = values.StringF(values.StrMixerInterrupted, components.TimeAgo(session.Start))
+	case session.FailedToStart:
+		dotColor = pg.Theme.Color.Danger
+		title = values.StringF(values.StrMixerFailedToStart, components.TimeAgo(session.Start))
+	default:
+		if session.Failure != "" {
+			// The session ended unexpectedly but may have mixed outputs.
+			dotColor = pg.Theme.Color.Orange
+		}
+		title = values.StringF(values.StrMixerRanFor, components.TimeFormat(int(session.Duration(time.Now()).Seconds()), true),
+			components.TimeAgo(session.Start))
+	}
+
+	details := []layout.FlexChild{
+		layout.Rigid(pg.Theme.Body1(title).Layout),
+	}
+	detail := func(txt string) {
+		details = append(details, layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body2(txt)
+			lbl.Color = pg.Theme.Color.GrayText2
+			return lbl.Layout(gtx)
+		}))
+	}
+
+	if !session.Running() && !session.FailedToStart {
+		detail(values.StringF(values.StrMixerSessionResult, len(session.MixedTxs), dcrutil.Amount(session.Amount()).String()))
+		detail(values.StringF(values.StrUnmixedChange, dcrutil.Amount(session.UnmixedAtStart).String(), dcrutil.Amount(session.UnmixedAtEnd).String()))
+	}
+	if session.StoppedByUser {
+		detail(values.String(values.StrMixerStoppedByUser))
+	}
+	if session.StoppedBySchedule {
+		detail(values.String(values.StrMixerStoppedBySchedule))
+	}
+	if session.Failure != "" {
+		details = append(details, layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body2(session.Failure)
+			lbl.Color = pg.Theme.Color.Danger
+			return lbl.Layout(gtx)
+		}))
+	}
+
+	return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding5, Right: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+					return timelineDot(gtx, dotColor)
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, details...)
+			}),
+		)
+	})
+}
+
+func timelineDot(gtx C, col color.NRGBA) D {
+	size := gtx.Dp(values.MarginPadding10)
+	dot := image.Rectangle{Max: image.Point{X: size, Y: size}}
+	paint.FillShape(gtx.Ops, col, clip.Ellipse(dot).Op(gtx.Ops))
+	return D{Size: dot.Max}
+}
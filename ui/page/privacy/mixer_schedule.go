@@ -0,0 +1,199 @@
+package privacy
+
+import (
+	"errors"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// mixerScheduler returns the mixer scheduler of the selected wallet.
+func (pg *AccountMixerPage) mixerScheduler() *wallet.MixerScheduler {
+	scheduler, err := pg.WL.Wallet.MixerScheduler(pg.WL.SelectedWallet.Wallet.ID)
+	if err != nil {
+		log.Errorf("Error loading mixer scheduler: %v", err)
+		return nil
+	}
+	return scheduler
+}
+
+func (pg *AccountMixerPage) handleScheduleInteractions() {
+	if pg.editScheduleBtn.Clicked() {
+		scheduleModal := newMixerScheduleModal(pg.Load).
+			OnScheduleSaved(func() {
+				pg.ParentWindow().Reload()
+			})
+		pg.ParentWindow().ShowModal(scheduleModal)
+	}
+
+	if pg.startScheduleBtn.Clicked() {
+		pg.showModalPasswordStartSchedule()
+	}
+
+	if pg.stopScheduleBtn.Clicked() {
+		if scheduler := pg.mixerScheduler(); scheduler != nil {
+			go func() {
+				scheduler.Cancel()
+				pg.ParentWindow().Reload()
+			}()
+		}
+	}
+}
+
+func (pg *AccountMixerPage) showModalPasswordStartSchedule() {
+	walletID := pg.WL.SelectedWallet.Wallet.ID
+	walletName := pg.WL.SelectedWallet.Wallet.Name
+	// The session may end while another page is displayed.
+	onAuthFailed := func() {
+		pg.Toast.NotifyError(values.StringF(values.StrScheduleAuthFailedNotif, walletName))
+		pg.ParentWindow().Reload()
+	}
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrConfirmScheduledMixing)).
+		Description(values.String(values.StrScheduledMixingDetails)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				err := pg.WL.Wallet.StartScheduledMixing(walletID, []byte(password), onAuthFailed)
+				if err != nil {
+					if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
+						err = errors.New(values.String(values.StrInvalidPassphrase))
+					}
+					pm.SetError(err.Error())
+					pm.SetLoading(false)
+					return
+				}
+				pm.Dismiss()
+				pg.ParentWindow().Reload()
+			}()
+
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// scheduleStateText describes the state of a scheduled mixing session.
+func scheduleStateText(state wallet.MixerScheduleState) string {
+	switch state {
+	case wallet.ScheduleMixing:
+		return values.String(values.StrScheduleMixing)
+	case wallet.ScheduleWaiting:
+		return values.String(values.StrScheduleWaiting)
+	case wallet.ScheduleTargetReached:
+		return values.String(values.StrScheduleTargetReached)
+	case wallet.ScheduleEmpty:
+		return values.String(values.StrScheduleEmpty)
+	case wallet.ScheduleBudgetUsed:
+		return values.String(values.StrScheduleBudgetUsed)
+	case wallet.ScheduleCancelled:
+		return values.String(values.StrScheduleCancelled)
+	case wallet.ScheduleAuthFailed:
+		return values.String(values.StrScheduleAuthFailed)
+	}
+	return ""
+}
+
+// mixerScheduleLayout draws the saved mixer schedule and the progress of the
+// scheduled session against its goal.
+func (pg *AccountMixerPage) mixerScheduleLayout(gtx C) D {
+	progress := wallet.MixerScheduleProgress{
+		Schedule: wallet.ReadMixerSchedule(pg.WL.SelectedWallet.Wallet),
+	}
+	running := false
+	if scheduler := pg.mixerScheduler(); scheduler != nil {
+		running = scheduler.IsRunning()
+		// The outcome of the last session is shown until the schedule is
+		// edited.
+		if p := scheduler.Progress(); running || p.Schedule == progress.Schedule {
+			progress = p
+		}
+	}
+	schedule := progress.Schedule
+
+	grayText := func(txt string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body2(txt)
+			lbl.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+		})
+	}
+
+	rows := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body1(values.String(values.StrScheduledMixing))
+			lbl.Font.Weight = text.SemiBold
+			return components.EndToEndRow(gtx, lbl.Layout, pg.Theme.Body2(scheduleStateText(progress.State)).Layout)
+		}),
+	}
+
+	if !schedule.HasGoal() {
+		rows = append(rows, grayText(values.String(values.StrNoSchedule)))
+	} else {
+		if progress.State != wallet.ScheduleIdle {
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					p := pg.Theme.ProgressBar(int(progress.Fraction() * 100))
+					p.Height = values.MarginPadding8
+					return p.Layout2(gtx)
+				})
+			}))
+		}
+
+		mixed := dcrutil.Amount(progress.Mixed).String()
+		if schedule.TargetAmount > 0 {
+			rows = append(rows, grayText(values.StringF(values.StrScheduleMixedOf, mixed, dcrutil.Amount(schedule.TargetAmount).String())))
+		} else {
+			rows = append(rows, grayText(values.StringF(values.StrScheduleMixed, mixed)))
+		}
+		if schedule.TimeBudget > 0 {
+			rows = append(rows, grayText(values.StringF(values.StrScheduleTimeOf,
+				components.TimeFormat(int(progress.Elapsed.Seconds()), true), components.TimeFormat(int(schedule.TimeBudget.Seconds()), true))))
+		}
+		if schedule.StopWhenEmpty {
+			rows = append(rows, grayText(values.String(values.StrStopWhenUnmixedEmpty)))
+		}
+		if schedule.HasWindow() {
+			rows = append(rows, grayText(values.StringF(values.StrScheduleWindow, schedule.WindowStart, schedule.WindowEnd)))
+		}
+		if progress.LastErr != nil {
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				lbl := pg.Theme.Body2(progress.LastErr.Error())
+				lbl.Color = pg.Theme.Color.Danger
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+			}))
+		}
+	}
+
+	rows = append(rows, layout.Rigid(func(gtx C) D {
+		return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				if running {
+					return pg.stopScheduleBtn.Layout(gtx)
+				}
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, pg.editScheduleBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						pg.startScheduleBtn.SetEnabled(schedule.HasGoal())
+						return pg.startScheduleBtn.Layout(gtx)
+					}),
+				)
+			})
+		})
+	}))
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+		})
+	})
+}
@@ -0,0 +1,203 @@
+package privacy
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// mixerScheduleModal edits the mixer schedule of the selected wallet.
+type mixerScheduleModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	scheduleSaved func()
+
+	cancel  decredmaterial.Button
+	saveBtn decredmaterial.Button
+
+	targetAmountEditor decredmaterial.Editor
+	timeBudgetEditor   decredmaterial.Editor
+	windowStartEditor  decredmaterial.Editor
+	windowEndEditor    decredmaterial.Editor
+	stopWhenEmpty      decredmaterial.CheckBoxStyle
+}
+
+func newMixerScheduleModal(l *load.Load) *mixerScheduleModal {
+	sm := &mixerScheduleModal{
+		Load:  l,
+		Modal: l.Theme.ModalFloatTitle("mixer_schedule_modal"),
+
+		cancel:        l.Theme.OutlineButton(values.String(values.StrCancel)),
+		saveBtn:       l.Theme.Button(values.String(values.StrSave)),
+		stopWhenEmpty: l.Theme.CheckBox(new(widget.Bool), values.String(values.StrStopWhenUnmixedEmpty)),
+	}
+
+	sm.targetAmountEditor = sm.scheduleEditor(values.StrMixTargetAmount)
+	sm.timeBudgetEditor = sm.scheduleEditor(values.StrMixTimeBudget)
+	sm.windowStartEditor = sm.scheduleEditor(values.StrMixFromHour)
+	sm.windowEndEditor = sm.scheduleEditor(values.StrMixUntilHour)
+
+	return sm
+}
+
+func (sm *mixerScheduleModal) scheduleEditor(hint string) decredmaterial.Editor {
+	editor := sm.Theme.Editor(new(widget.Editor), values.String(hint))
+	editor.Editor.SingleLine = true
+	return editor
+}
+
+func (sm *mixerScheduleModal) OnScheduleSaved(scheduleSaved func()) *mixerScheduleModal {
+	sm.scheduleSaved = scheduleSaved
+	return sm
+}
+
+func (sm *mixerScheduleModal) OnResume() {
+	sm.setSchedule(wallet.ReadMixerSchedule(sm.WL.SelectedWallet.Wallet))
+}
+
+// setSchedule fills the editors with the saved schedule, leaving the editors
+// of disabled conditions empty.
+func (sm *mixerScheduleModal) setSchedule(schedule wallet.MixerSchedule) {
+	if schedule.TargetAmount > 0 {
+		sm.targetAmountEditor.Editor.SetText(strconv.FormatFloat(dcrlibwallet.AmountCoin(schedule.TargetAmount), 'f', -1, 64))
+	}
+	if schedule.TimeBudget > 0 {
+		sm.timeBudgetEditor.Editor.SetText(strconv.FormatFloat(schedule.TimeBudget.Hours(), 'f', -1, 64))
+	}
+	if schedule.HasWindow() {
+		sm.windowStartEditor.Editor.SetText(strconv.Itoa(schedule.WindowStart))
+		sm.windowEndEditor.Editor.SetText(strconv.Itoa(schedule.WindowEnd))
+	}
+	sm.stopWhenEmpty.CheckBox.Value = schedule.StopWhenEmpty
+}
+
+// schedule parses the editors. Empty editors disable the corresponding
+// condition.
+func (sm *mixerScheduleModal) schedule() (wallet.MixerSchedule, error) {
+	schedule := wallet.MixerSchedule{StopWhenEmpty: sm.stopWhenEmpty.CheckBox.Value}
+
+	parseFloat := func(editor decredmaterial.Editor) (float64, error) {
+		if editor.Editor.Text() == "" {
+			return 0, nil
+		}
+		value, err := strconv.ParseFloat(editor.Editor.Text(), 64)
+		if err != nil || value < 0 {
+			return 0, errors.New(values.String(values.StrInvalidScheduleValue))
+		}
+		return value, nil
+	}
+	parseHour := func(editor decredmaterial.Editor) (int, error) {
+		if editor.Editor.Text() == "" {
+			return 0, nil
+		}
+		value, err := strconv.Atoi(editor.Editor.Text())
+		if err != nil || value < 0 || value > 23 {
+			return 0, errors.New(values.String(values.StrInvalidActiveHours))
+		}
+		return value, nil
+	}
+
+	amount, err := parseFloat(sm.targetAmountEditor)
+	if err != nil {
+		return schedule, err
+	}
+	schedule.TargetAmount = dcrlibwallet.AmountAtom(amount)
+
+	hours, err := parseFloat(sm.timeBudgetEditor)
+	if err != nil {
+		return schedule, err
+	}
+	schedule.TimeBudget = time.Duration(hours * float64(time.Hour))
+
+	if schedule.WindowStart, err = parseHour(sm.windowStartEditor); err != nil {
+		return schedule, err
+	}
+	if schedule.WindowEnd, err = parseHour(sm.windowEndEditor); err != nil {
+		return schedule, err
+	}
+
+	if !schedule.HasGoal() {
+		return schedule, errors.New(values.String(values.StrScheduleNeedsGoal))
+	}
+	return schedule, nil
+}
+
+func (sm *mixerScheduleModal) OnDismiss() {}
+
+func (sm *mixerScheduleModal) Handle() {
+	if sm.cancel.Clicked() || sm.Modal.BackdropClicked(true) {
+		sm.Dismiss()
+	}
+
+	if sm.saveBtn.Clicked() {
+		schedule, err := sm.schedule()
+		if err != nil {
+			sm.Toast.NotifyError(err.Error())
+			return
+		}
+
+		wallet.SaveMixerSchedule(sm.WL.SelectedWallet.Wallet, schedule)
+		if sm.scheduleSaved != nil {
+			sm.scheduleSaved()
+		}
+		sm.Dismiss()
+	}
+}
+
+func (sm *mixerScheduleModal) Layout(gtx layout.Context) layout.Dimensions {
+	l := []layout.Widget{
+		func(gtx C) D {
+			t := sm.Theme.H6(values.String(values.StrMixerSchedule))
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			txt := sm.Theme.Body2(values.String(values.StrScheduledMixingDesc))
+			txt.Color = sm.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		},
+		func(gtx C) D {
+			return sm.editorPair(gtx, sm.targetAmountEditor, sm.timeBudgetEditor)
+		},
+		sm.stopWhenEmpty.Layout,
+		func(gtx C) D {
+			return sm.editorPair(gtx, sm.windowStartEditor, sm.windowEndEditor)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{
+							Right: values.MarginPadding4,
+						}.Layout(gtx, sm.cancel.Layout)
+					}),
+					layout.Rigid(sm.saveBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return sm.Modal.Layout(gtx, l)
+}
+
+func (sm *mixerScheduleModal) editorPair(gtx C, left, right decredmaterial.Editor) D {
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+		layout.Flexed(.5, func(gtx C) D {
+			return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, left.Layout)
+		}),
+		layout.Flexed(.5, func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding4}.Layout(gtx, right.Layout)
+		}),
+	)
+}
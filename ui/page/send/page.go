@@ -87,6 +87,8 @@ type authoredTxData struct {
 	balanceAfterSendUSD string
 	sendAmount          string
 	sendAmountUSD       string
+	amountAtom          int64
+	sendMax             bool
 }
 
 func NewSendPage(l *load.Load) *Page {
@@ -294,6 +296,8 @@ func (pg *Page) constructTx(useDefaultParams bool) {
 	pg.destinationAddress = destinationAddress
 	pg.destinationAccount = destinationAccount
 	pg.sourceAccount = sourceAccount
+	pg.amountAtom = amountAtom
+	pg.sendMax = SendMax
 
 	if SendMax {
 		// TODO: this workaround ignores the change events from the
@@ -316,8 +320,9 @@ func (pg *Page) constructTx(useDefaultParams bool) {
 func (pg *Page) feeEstimationError(err string) {
 	if err == dcrlibwallet.ErrInsufficientBalance {
 		pg.amount.setError(values.String(values.StrInsufficentFund))
-	} else if strings.Contains(err, invalidAmountErr) {
-		pg.amount.setError(invalidAmountErr)
+	} else if strings.Contains(err, "invalid amount") {
+		// dcrlibwallet does not export its invalid amount error.
+		pg.amount.setError(values.String(values.StrInvalidAmount))
 	} else {
 		pg.amount.setError(err)
 		pg.Toast.NotifyError(values.StringF(values.StrTxEstimateErr, err))
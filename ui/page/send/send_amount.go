@@ -13,8 +13,6 @@ import (
 	"github.com/planetdecred/godcr/ui/values"
 )
 
-const invalidAmountErr = "Invalid amount" //TODO: use localized strings
-
 type sendAmount struct {
 	*load.Load
 
@@ -117,7 +115,7 @@ func (sa *sendAmount) validateDCRAmount() {
 		if err != nil {
 			// empty usd input
 			sa.usdAmountEditor.Editor.SetText("")
-			sa.amountErrorText = invalidAmountErr
+			sa.amountErrorText = values.String(values.StrInvalidAmount)
 			// todo: invalid decimal places error
 			return
 		}
@@ -143,7 +141,7 @@ func (sa *sendAmount) validateUSDAmount() bool {
 		if err != nil {
 			// empty dcr input
 			sa.dcrAmountEditor.Editor.SetText("")
-			sa.amountErrorText = invalidAmountErr
+			sa.amountErrorText = values.String(values.StrInvalidAmount)
 			return false
 		}
 
@@ -2,6 +2,7 @@ package send
 
 import (
 	"fmt"
+	"sync"
 
 	"gioui.org/layout"
 	"gioui.org/text"
@@ -26,6 +27,14 @@ type sendConfirmModal struct {
 	txSent    func()
 	isSending bool
 
+	// warningsMu protects spendWarnings, checkingWarnings and warningsErr,
+	// which are set by the privacy check.
+	warningsMu       sync.Mutex
+	spendWarnings    []*spendWarning
+	checkingWarnings bool
+	warningsErr      error
+	overrideCheckErr decredmaterial.CheckBoxStyle
+
 	*authoredTxData
 	exchangeRateSet bool
 }
@@ -45,6 +54,8 @@ func newSendConfirmModal(l *load.Load, data *authoredTxData) *sendConfirmModal {
 	scm.confirmButton.Font.Weight = text.Medium
 	scm.confirmButton.SetEnabled(false)
 
+	scm.overrideCheckErr = l.Theme.CheckBox(new(widget.Bool), values.String(values.StrSendWithoutCheck))
+
 	scm.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
 	scm.passwordEditor.Editor.SetText("")
 	scm.passwordEditor.Editor.SingleLine = true
@@ -55,13 +66,18 @@ func newSendConfirmModal(l *load.Load, data *authoredTxData) *sendConfirmModal {
 
 func (scm *sendConfirmModal) OnResume() {
 	scm.passwordEditor.Editor.Focus()
+	scm.warningsMu.Lock()
+	scm.checkingWarnings = true
+	scm.warningsErr = nil
+	scm.warningsMu.Unlock()
+	go scm.checkSpendWarnings()
 }
 
 func (scm *sendConfirmModal) OnDismiss() {}
 
 func (scm *sendConfirmModal) broadcastTransaction() {
 	password := scm.passwordEditor.Editor.Text()
-	if password == "" || scm.isSending {
+	if password == "" || scm.isSending || !scm.warningsOverridden() {
 		return
 	}
 
@@ -86,13 +102,12 @@ func (scm *sendConfirmModal) Handle() {
 	for _, evt := range scm.passwordEditor.Editor.Events() {
 		if scm.passwordEditor.Editor.Focused() {
 			switch evt.(type) {
-			case widget.ChangeEvent:
-				scm.confirmButton.SetEnabled(scm.passwordEditor.Editor.Text() != "")
 			case widget.SubmitEvent:
 				scm.broadcastTransaction()
 			}
 		}
 	}
+	scm.confirmButton.SetEnabled(scm.passwordEditor.Editor.Text() != "" && scm.warningsOverridden())
 
 	for scm.confirmButton.Clicked() {
 		scm.broadcastTransaction()
@@ -210,6 +225,7 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 				}),
 			)
 		},
+		scm.spendWarningsLayout,
 		func(gtx C) D {
 			return scm.passwordEditor.Layout(gtx)
 		},
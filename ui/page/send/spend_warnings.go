@@ -0,0 +1,147 @@
+package send
+
+import (
+	"log"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// spendWarning is a privacy risk of the transaction that the user must
+// explicitly accept before the transaction can be sent.
+type spendWarning struct {
+	wallet.SpendWarning
+	override decredmaterial.CheckBoxStyle
+}
+
+// checkSpendWarnings looks for the privacy risks of the transaction. If the
+// check fails, the transaction can only be sent once the user overrides it.
+func (scm *sendConfirmModal) checkSpendWarnings() {
+	defer scm.ParentWindow().Reload()
+
+	w := scm.WL.MultiWallet.WalletWithID(scm.sourceAccount.WalletID)
+	address := scm.destinationAddress
+	if scm.destinationAccount != nil {
+		// Transfers between accounts are not checked for address reuse or
+		// repeated payments.
+		address = ""
+	}
+
+	warnings, err := scm.WL.Wallet.SpendWarnings(w, scm.sourceAccount.Number, address, scm.amountAtom, scm.sendMax)
+	if err != nil {
+		log.Printf("Error checking transaction privacy: %v", err)
+		scm.warningsMu.Lock()
+		scm.warningsErr = err
+		scm.checkingWarnings = false
+		scm.warningsMu.Unlock()
+		return
+	}
+
+	spendWarnings := make([]*spendWarning, len(warnings))
+	for i, warning := range warnings {
+		spendWarnings[i] = &spendWarning{
+			SpendWarning: warning,
+			override:     scm.Theme.CheckBox(new(widget.Bool), values.String(values.StrSendAnyway)),
+		}
+	}
+	scm.warningsMu.Lock()
+	scm.spendWarnings = spendWarnings
+	scm.checkingWarnings = false
+	scm.warningsMu.Unlock()
+}
+
+// warningsState returns the result of the privacy check.
+func (scm *sendConfirmModal) warningsState() (warnings []*spendWarning, checking bool, err error) {
+	scm.warningsMu.Lock()
+	defer scm.warningsMu.Unlock()
+	return scm.spendWarnings, scm.checkingWarnings, scm.warningsErr
+}
+
+// warningsOverridden returns true once the privacy check succeeded and every
+// warning was accepted, or the user chose to send without the check after it
+// failed.
+func (scm *sendConfirmModal) warningsOverridden() bool {
+	warnings, checking, err := scm.warningsState()
+	if checking {
+		return false
+	}
+	if err != nil {
+		return scm.overrideCheckErr.CheckBox.Value
+	}
+	for _, warning := range warnings {
+		if !warning.override.CheckBox.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func spendWarningText(warning wallet.SpendWarning) (title, description string) {
+	switch warning.Kind {
+	case wallet.SpendMergesMixedInputs:
+		return values.String(values.StrMergesMixedInputs), values.String(values.StrMergesMixedInputsDesc)
+	case wallet.SpendTraceableChange:
+		return values.String(values.StrTraceableChange), values.String(values.StrTraceableChangeDesc)
+	case wallet.SpendAddressReused:
+		return values.String(values.StrAddressReused), values.StringF(values.StrAddressReusedDesc, components.TimeAgo(warning.Timestamp))
+	case wallet.SpendAmountRepeated:
+		return values.String(values.StrAmountRepeated), values.StringF(values.StrAmountRepeatedDesc, components.TimeAgo(warning.Timestamp))
+	}
+	return "", ""
+}
+
+func (scm *sendConfirmModal) spendWarningsLayout(gtx C) D {
+	warnings, checking, err := scm.warningsState()
+	if checking {
+		txt := scm.Theme.Body2(values.String(values.StrCheckingPrivacy))
+		txt.Color = scm.Theme.Color.GrayText2
+		return txt.Layout(gtx)
+	}
+	if err != nil {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				txt := scm.Theme.Body2(values.StringF(values.StrPrivacyCheckFailed, err))
+				txt.Color = scm.Theme.Color.Danger
+				return txt.Layout(gtx)
+			}),
+			layout.Rigid(scm.overrideCheckErr.Layout),
+		)
+	}
+	if len(warnings) == 0 {
+		return D{}
+	}
+
+	items := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			txt := scm.Theme.Body1(values.String(values.StrPrivacyWarnings))
+			txt.Font.Weight = text.SemiBold
+			txt.Color = scm.Theme.Color.Danger
+			return txt.Layout(gtx)
+		}),
+	}
+	for _, warning := range warnings {
+		warning := warning
+		title, description := spendWarningText(warning.SpendWarning)
+		items = append(items, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(scm.Theme.Body1(title).Layout),
+					layout.Rigid(func(gtx C) D {
+						txt := scm.Theme.Body2(description)
+						txt.Color = scm.Theme.Color.GrayText2
+						return txt.Layout(gtx)
+					}),
+					layout.Rigid(warning.override.Layout),
+				)
+			})
+		}))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, items...)
+}
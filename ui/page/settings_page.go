@@ -41,6 +41,7 @@ type SettingsPage struct {
 	help              *decredmaterial.Clickable
 	about             *decredmaterial.Clickable
 	appearanceMode    *decredmaterial.Clickable
+	trustedDomains    *decredmaterial.Clickable
 
 	chevronRightIcon *decredmaterial.Icon
 	backButton       decredmaterial.IconButton
@@ -78,6 +79,7 @@ func NewSettingsPage(l *load.Load) *SettingsPage {
 		help:              l.Theme.NewClickable(false),
 		about:             l.Theme.NewClickable(false),
 		appearanceMode:    l.Theme.NewClickable(false),
+		trustedDomains:    l.Theme.NewClickable(false),
 	}
 
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(l)
@@ -247,6 +249,16 @@ func (pg *SettingsPage) security() layout.Widget {
 						return pg.clickableRow(gtx, changeStartupPassRow)
 					})
 				}),
+				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					trustedDomainsRow := row{
+						title:     values.String(values.StrTrustedDomains),
+						clickable: pg.trustedDomains,
+						icon:      pg.chevronRightIcon,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, trustedDomainsRow)
+				}),
 			)
 		})
 	}
@@ -415,6 +427,10 @@ func (pg *SettingsPage) HandleUserInteractions() {
 		pg.ParentNavigator().Display(NewAboutPage(pg.Load))
 	}
 
+	if pg.trustedDomains.Clicked() {
+		pg.ParentNavigator().Display(NewTrustedDomainsPage(pg.Load))
+	}
+
 	for pg.changeStartupPass.Clicked() {
 		currentPasswordModal := modal.NewPasswordModal(pg.Load).
 			Title(values.String(values.StrConfirmStartupPass)).
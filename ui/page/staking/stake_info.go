@@ -14,6 +14,7 @@ import (
 
 func (pg *Page) initStakePriceWidget() *Page {
 	pg.stakeSettings = pg.Theme.NewClickable(false)
+	pg.manageVSPs = pg.Theme.NewClickable(false)
 	_, pg.infoButton = components.SubpageHeaderButtons(pg.Load)
 
 	pg.stake = pg.Theme.Switch()
@@ -73,6 +74,15 @@ func (pg *Page) stakePriceSection(gtx C) D {
 
 					rightWg := func(gtx C) D {
 						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{Right: values.MarginPadding24}.Layout(gtx, func(gtx C) D {
+									return pg.manageVSPs.Layout(gtx, func(gtx C) D {
+										txt := pg.Theme.Label(values.TextSize16, values.String(values.StrManageVSPs))
+										txt.Color = pg.Theme.Color.Primary
+										return txt.Layout(gtx)
+									})
+								})
+							}),
 							layout.Rigid(func(gtx C) D {
 								title := pg.Theme.Label(values.TextSize16, values.String(values.StrStake))
 								title.Color = col
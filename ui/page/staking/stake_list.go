@@ -1,16 +1,28 @@
 package staking
 
 import (
+	"context"
+
 	"gioui.org/layout"
 	"gioui.org/text"
 
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/listeners"
+	"github.com/planetdecred/godcr/ui/modal"
+	tpage "github.com/planetdecred/godcr/ui/page/transaction"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 func (pg *Page) initTicketList() {
 	pg.ticketsList = pg.Theme.NewClickableList(layout.Vertical)
+	pg.problemTicketsOnly = pg.Theme.Switch()
+	pg.checkVSPStatus = pg.Theme.OutlineButton(values.String(values.StrCheckVSPStatus))
+	pg.checkVSPStatus.Inset = layout.UniformInset(values.MarginPadding8)
+	pg.exportCSV = pg.Theme.OutlineButton(values.String(values.StrExportCSV))
+	pg.exportCSV.Inset = layout.UniformInset(values.MarginPadding8)
+	pg.exportJSON = pg.Theme.OutlineButton(values.String(values.StrExportJSON))
+	pg.exportJSON.Inset = layout.UniformInset(values.MarginPadding8)
 }
 
 func (pg *Page) listenForTxNotifications() {
@@ -58,7 +70,127 @@ func (pg *Page) fetchTickets() {
 		return
 	}
 
+	// Use the statuses last fetched from the VSPs, falling back to the
+	// status saved in the wallet database.
+	pg.ticketsMu.RLock()
+	vspStatuses := pg.vspStatuses
+	pg.ticketsMu.RUnlock()
+	w := pg.WL.SelectedWallet.Wallet
+	for _, ticket := range tickets {
+		if status, ok := vspStatuses[ticket.transaction.Hash]; ok {
+			ticket.vspStatus = status
+			continue
+		}
+		if status, err := wallet.ReadVSPTicketStatus(context.Background(), w, ticket.transaction.Hash); err == nil {
+			ticket.vspStatus = status
+		}
+	}
+
+	pg.ticketsMu.Lock()
 	pg.tickets = tickets
+	pg.ticketsMu.Unlock()
+}
+
+// visibleTickets returns the tickets to display, only the tickets with a VSP
+// problem if the problem tickets filter is on.
+func (pg *Page) visibleTickets() []*transactionItem {
+	pg.ticketsMu.RLock()
+	defer pg.ticketsMu.RUnlock()
+	if !pg.problemTicketsOnly.IsChecked() {
+		return pg.tickets
+	}
+
+	var tickets []*transactionItem
+	for _, ticket := range pg.tickets {
+		if ticket.vspStatus != nil && ticket.vspStatus.HasProblem() {
+			tickets = append(tickets, ticket)
+		}
+	}
+	return tickets
+}
+
+// fetchVSPStatuses requests the wallet passphrase and queries the VSP status
+// of the unspent tickets.
+func (pg *Page) fetchVSPStatuses() {
+	var hashes []string
+	pg.ticketsMu.RLock()
+	for _, ticket := range pg.tickets {
+		if ticket.ticketSpender == nil {
+			hashes = append(hashes, ticket.transaction.Hash)
+		}
+	}
+	pg.ticketsMu.RUnlock()
+
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrCheckVSPStatus)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				statuses, err := wallet.FetchVSPTicketStatuses(pg.ctx, pg.WL.SelectedWallet.Wallet, hashes, []byte(password))
+				if err != nil {
+					if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
+						pm.SetError(values.String(values.StrInvalidPassphrase))
+					} else {
+						pm.Toast.NotifyError(err.Error())
+					}
+					pm.SetLoading(false)
+					return
+				}
+
+				pm.Dismiss()
+				pg.ticketsMu.Lock()
+				pg.vspStatuses = statuses
+				pg.ticketsMu.Unlock()
+				pg.fetchTickets()
+				pg.Toast.Notify(values.String(values.StrVSPStatusUpdated))
+				pg.ParentWindow().Reload()
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// setVSPStatus replaces the status last fetched from the VSP of the ticket
+// with the specified hash. The map is copied as fetchTickets may be reading
+// it.
+func (pg *Page) setVSPStatus(hash string, status *wallet.VSPTicketStatus) {
+	pg.ticketsMu.Lock()
+	defer pg.ticketsMu.Unlock()
+	statuses := make(map[string]*wallet.VSPTicketStatus, len(pg.vspStatuses)+1)
+	for h, s := range pg.vspStatuses {
+		statuses[h] = s
+	}
+	statuses[hash] = status
+	pg.vspStatuses = statuses
+}
+
+// exportTickets writes the records of all the tickets of the selected wallet
+// to a file in the specified format.
+func (pg *Page) exportTickets(format string) {
+	path, err := pg.WL.Wallet.ExportTickets(pg.ctx, pg.WL.SelectedWallet.Wallet, format)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.Toast.Notify(values.StringF(values.StrExportedTo, path))
+}
+
+func (pg *Page) handleTicketClicked(ticket *transactionItem) {
+	if ticket.vspStatus == nil || !ticket.vspStatus.HasProblem() {
+		pg.ParentNavigator().Display(tpage.NewTransactionDetailsPage(pg.Load, ticket.transaction))
+		return
+	}
+
+	ticketModal := newTicketVSPModal(pg.Load, ticket).
+		OnViewDetails(func() {
+			pg.ParentNavigator().Display(tpage.NewTransactionDetailsPage(pg.Load, ticket.transaction))
+		}).
+		OnUpdated(func(status *wallet.VSPTicketStatus) {
+			pg.setVSPStatus(ticket.transaction.Hash, status)
+			pg.fetchTickets()
+			pg.ParentWindow().Reload()
+		})
+	pg.ParentWindow().ShowModal(ticketModal)
 }
 
 func (pg *Page) ticketListLayout(gtx C) D {
@@ -76,15 +208,41 @@ func (pg *Page) ticketListLayout(gtx C) D {
 					layout.Rigid(func(gtx C) D {
 						txt := pg.Theme.Body1(values.String(values.StrTickets))
 						txt.Color = pg.Theme.Color.GrayText2
-						return layout.Inset{Bottom: values.MarginPadding18}.Layout(gtx, txt.Layout)
+						return layout.Inset{Bottom: values.MarginPadding18, Right: values.MarginPadding26}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+								layout.Flexed(1, txt.Layout),
+								layout.Rigid(func(gtx C) D {
+									lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrProblemTicketsOnly))
+									lbl.Color = pg.Theme.Color.GrayText2
+									return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+								}),
+								layout.Rigid(pg.problemTicketsOnly.Layout),
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, pg.exportCSV.Layout)
+								}),
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.exportJSON.Layout)
+								}),
+								layout.Rigid(func(gtx C) D {
+									if pg.WL.SelectedWallet.Wallet.IsWatchingOnlyWallet() {
+										return D{}
+									}
+									return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, pg.checkVSPStatus.Layout)
+								}),
+							)
+						})
 					}),
 					layout.Rigid(func(gtx C) D {
-						tickets := pg.tickets
+						tickets := pg.visibleTickets()
 
 						if len(tickets) == 0 {
 							gtx.Constraints.Min.X = gtx.Constraints.Max.X
 
-							txt := pg.Theme.Body1(values.String(values.StrNoTickets))
+							noTickets := values.String(values.StrNoTickets)
+							if pg.problemTicketsOnly.IsChecked() {
+								noTickets = values.String(values.StrNoProblemTickets)
+							}
+							txt := pg.Theme.Body1(noTickets)
 							txt.Color = pg.Theme.Color.GrayText3
 							txt.Alignment = text.Middle
 							return layout.Inset{Top: values.MarginPadding15, Bottom: values.MarginPadding16}.Layout(gtx, txt.Layout)
@@ -2,6 +2,7 @@ package staking
 
 import (
 	"context"
+	"errors"
 	"strconv"
 
 	"gioui.org/layout"
@@ -13,6 +14,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type ticketBuyerModal struct {
@@ -30,6 +32,14 @@ type ticketBuyerModal struct {
 
 	balToMaintainEditor decredmaterial.Editor
 
+	maxPriceEditor          decredmaterial.Editor
+	maxPerDayEditor         decredmaterial.Editor
+	maxPerWindowEditor      decredmaterial.Editor
+	activeHoursStartEditor  decredmaterial.Editor
+	activeHoursEndEditor    decredmaterial.Editor
+	pauseAbovePercentEditor decredmaterial.Editor
+	averagePeriodEditor     decredmaterial.Editor
+
 	accountSelector *components.AccountSelector
 	vspSelector     *components.VSPSelector
 }
@@ -47,11 +57,25 @@ func newTicketBuyerModal(l *load.Load) *ticketBuyerModal {
 	tb.balToMaintainEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrBalToMaintain))
 	tb.balToMaintainEditor.Editor.SingleLine = true
 
+	tb.maxPriceEditor = tb.ruleEditor(values.StrMaxTicketPrice)
+	tb.maxPerDayEditor = tb.ruleEditor(values.StrMaxTicketsPerDay)
+	tb.maxPerWindowEditor = tb.ruleEditor(values.StrMaxTicketsPerWindow)
+	tb.activeHoursStartEditor = tb.ruleEditor(values.StrActiveHoursStart)
+	tb.activeHoursEndEditor = tb.ruleEditor(values.StrActiveHoursEnd)
+	tb.pauseAbovePercentEditor = tb.ruleEditor(values.StrPauseAbovePercent)
+	tb.averagePeriodEditor = tb.ruleEditor(values.StrAveragePeriod)
+
 	tb.saveSettingsBtn.SetEnabled(false)
 
 	return tb
 }
 
+func (tb *ticketBuyerModal) ruleEditor(hint string) decredmaterial.Editor {
+	editor := tb.Theme.Editor(new(widget.Editor), values.String(hint))
+	editor.Editor.SingleLine = true
+	return editor
+}
+
 func (tb *ticketBuyerModal) OnSettingsSaved(settingsSaved func()) *ticketBuyerModal {
 	tb.settingsSaved = settingsSaved
 	return tb
@@ -103,6 +127,89 @@ func (tb *ticketBuyerModal) OnResume() {
 			tb.Toast.NotifyError(err.Error())
 		}
 	}
+
+	tb.setRules(wallet.ReadTicketBuyerRules(tb.WL.SelectedWallet.Wallet))
+}
+
+// setRules fills the rule editors with the saved ticket buyer rules, leaving
+// the editors of disabled rules empty.
+func (tb *ticketBuyerModal) setRules(rules wallet.TicketBuyerRules) {
+	setInt := func(editor decredmaterial.Editor, value int) {
+		if value > 0 {
+			editor.Editor.SetText(strconv.Itoa(value))
+		}
+	}
+
+	if rules.MaxTicketPrice > 0 {
+		tb.maxPriceEditor.Editor.SetText(strconv.FormatFloat(dcrlibwallet.AmountCoin(rules.MaxTicketPrice), 'f', -1, 64))
+	}
+	setInt(tb.maxPerDayEditor, rules.MaxTicketsPerDay)
+	setInt(tb.maxPerWindowEditor, rules.MaxTicketsPerWindow)
+	if rules.ActiveHoursStart != rules.ActiveHoursEnd {
+		tb.activeHoursStartEditor.Editor.SetText(strconv.Itoa(rules.ActiveHoursStart))
+		tb.activeHoursEndEditor.Editor.SetText(strconv.Itoa(rules.ActiveHoursEnd))
+	}
+	if rules.PauseAbovePercent > 0 {
+		tb.pauseAbovePercentEditor.Editor.SetText(strconv.FormatFloat(rules.PauseAbovePercent, 'f', -1, 64))
+	}
+	setInt(tb.averagePeriodEditor, rules.AveragePeriod)
+}
+
+// rules parses the rule editors. Empty editors disable the corresponding
+// rule.
+func (tb *ticketBuyerModal) rules() (wallet.TicketBuyerRules, error) {
+	var rules wallet.TicketBuyerRules
+
+	parseInt := func(editor decredmaterial.Editor) (int, error) {
+		if editor.Editor.Text() == "" {
+			return 0, nil
+		}
+		value, err := strconv.Atoi(editor.Editor.Text())
+		if err != nil || value < 0 {
+			return 0, errors.New(values.String(values.StrInvalidRuleValue))
+		}
+		return value, nil
+	}
+	parseFloat := func(editor decredmaterial.Editor) (float64, error) {
+		if editor.Editor.Text() == "" {
+			return 0, nil
+		}
+		value, err := strconv.ParseFloat(editor.Editor.Text(), 64)
+		if err != nil || value < 0 {
+			return 0, errors.New(values.String(values.StrInvalidRuleValue))
+		}
+		return value, nil
+	}
+
+	maxPrice, err := parseFloat(tb.maxPriceEditor)
+	if err != nil {
+		return rules, err
+	}
+	rules.MaxTicketPrice = dcrlibwallet.AmountAtom(maxPrice)
+
+	if rules.MaxTicketsPerDay, err = parseInt(tb.maxPerDayEditor); err != nil {
+		return rules, err
+	}
+	if rules.MaxTicketsPerWindow, err = parseInt(tb.maxPerWindowEditor); err != nil {
+		return rules, err
+	}
+	if rules.ActiveHoursStart, err = parseInt(tb.activeHoursStartEditor); err != nil {
+		return rules, err
+	}
+	if rules.ActiveHoursEnd, err = parseInt(tb.activeHoursEndEditor); err != nil {
+		return rules, err
+	}
+	if rules.ActiveHoursStart > 23 || rules.ActiveHoursEnd > 23 {
+		return rules, errors.New(values.String(values.StrInvalidActiveHours))
+	}
+	if rules.PauseAbovePercent, err = parseFloat(tb.pauseAbovePercentEditor); err != nil {
+		return rules, err
+	}
+	if rules.AveragePeriod, err = parseInt(tb.averagePeriodEditor); err != nil {
+		return rules, err
+	}
+
+	return rules, nil
 }
 
 func (tb *ticketBuyerModal) Layout(gtx layout.Context) layout.Dimensions {
@@ -133,8 +240,18 @@ func (tb *ticketBuyerModal) Layout(gtx layout.Context) layout.Dimensions {
 						return tb.vspSelector.Layout(tb.ParentWindow(), gtx)
 					})
 				}),
+				layout.Rigid(func(gtx C) D {
+					vsp := tb.vspSelector.SelectedVSP()
+					if vsp == nil || !tb.WL.Wallet.VSPMonitor().IsDown(vsp.Host) {
+						return D{}
+					}
+					txt := tb.Theme.Label(values.TextSize14, values.StringF(values.StrVSPDownWarning, vsp.Host))
+					txt.Color = tb.Theme.Color.Danger
+					return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, txt.Layout)
+				}),
 			)
 		},
+		tb.rulesLayout,
 		func(gtx C) D {
 			return layout.E.Layout(gtx, func(gtx C) D {
 				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
@@ -154,6 +271,39 @@ func (tb *ticketBuyerModal) Layout(gtx layout.Context) layout.Dimensions {
 	return tb.Modal.Layout(gtx, l)
 }
 
+func (tb *ticketBuyerModal) rulesLayout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			txt := tb.Theme.Body1(values.String(values.StrPurchaseRules))
+			txt.Color = tb.Theme.Color.GrayText2
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, txt.Layout)
+		}),
+		layout.Rigid(tb.maxPriceEditor.Layout),
+		layout.Rigid(func(gtx C) D {
+			return tb.editorPair(gtx, tb.maxPerDayEditor, tb.maxPerWindowEditor)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return tb.editorPair(gtx, tb.activeHoursStartEditor, tb.activeHoursEndEditor)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return tb.editorPair(gtx, tb.pauseAbovePercentEditor, tb.averagePeriodEditor)
+		}),
+	)
+}
+
+func (tb *ticketBuyerModal) editorPair(gtx C, left, right decredmaterial.Editor) D {
+	return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+			layout.Flexed(.5, func(gtx C) D {
+				return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, left.Layout)
+			}),
+			layout.Flexed(.5, func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding4}.Layout(gtx, right.Layout)
+			}),
+		)
+	})
+}
+
 func (tb *ticketBuyerModal) canSave() bool {
 	if tb.vspSelector.SelectedVSP() == nil {
 		return false
@@ -191,6 +341,14 @@ func (tb *ticketBuyerModal) OnDismiss() {
 func (tb *ticketBuyerModal) Handle() {
 	tb.saveSettingsBtn.SetEnabled(tb.canSave())
 
+	if tb.vspSelector.Changed() {
+		vspHost := tb.vspSelector.SelectedVSP().Host
+		go func() {
+			tb.WL.Wallet.VSPMonitor().CheckHost(tb.ctx, vspHost)
+			tb.ParentWindow().Reload()
+		}()
+	}
+
 	if tb.cancel.Clicked() || tb.Modal.BackdropClicked(true) {
 		tb.onCancel()
 		tb.Dismiss()
@@ -204,10 +362,17 @@ func (tb *ticketBuyerModal) Handle() {
 			return
 		}
 
+		rules, err := tb.rules()
+		if err != nil {
+			tb.Toast.NotifyError(err.Error())
+			return
+		}
+
 		balToMaintain := dcrlibwallet.AmountAtom(amount)
 		account := tb.accountSelector.SelectedAccount()
 
 		tb.WL.SelectedWallet.Wallet.SetAutoTicketsBuyerConfig(vspHost, account.Number, balToMaintain)
+		wallet.SaveTicketBuyerRules(tb.WL.SelectedWallet.Wallet, rules)
 		tb.settingsSaved()
 		tb.Dismiss()
 	}
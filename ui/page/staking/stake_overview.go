@@ -3,6 +3,7 @@ package staking
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"gioui.org/layout"
 	"gioui.org/text"
@@ -16,8 +17,8 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
-	tpage "github.com/planetdecred/godcr/ui/page/transaction"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type (
@@ -41,17 +42,30 @@ type Page struct {
 	ctx       context.Context // page context
 	ctxCancel context.CancelFunc
 
-	tickets []*transactionItem
+	// ticketsMu protects tickets and vspStatuses, which are updated by
+	// the notification and VSP status goroutines.
+	ticketsMu sync.RWMutex
+	tickets   []*transactionItem
+	// vspStatuses holds the ticket statuses last fetched from the VSPs.
+	vspStatuses map[string]*wallet.VSPTicketStatus
 
 	ticketOverview *dcrlibwallet.StakingOverview
 
-	ticketsList   *decredmaterial.ClickableList
-	stakeSettings *decredmaterial.Clickable
-	stake         *decredmaterial.Switch
-	infoButton    decredmaterial.IconButton
+	ticketsList        *decredmaterial.ClickableList
+	problemTicketsOnly *decredmaterial.Switch
+	checkVSPStatus     decredmaterial.Button
+	exportCSV          decredmaterial.Button
+	exportJSON         decredmaterial.Button
+	stakeSettings      *decredmaterial.Clickable
+	manageVSPs         *decredmaterial.Clickable
+	stake              *decredmaterial.Switch
+	infoButton         decredmaterial.IconButton
 
 	ticketPrice  string
 	totalRewards string
+	// hasBuyerRules caches whether purchase rules are saved for the
+	// selected wallet.
+	hasBuyerRules bool
 }
 
 func NewStakingPage(l *load.Load) *Page {
@@ -84,15 +98,21 @@ func (pg *Page) OnNavigatedTo() {
 	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
 
 	pg.fetchTicketPrice()
+	pg.loadTicketBuyerRules()
 
 	pg.loadPageData() // starts go routines to refresh the display which is just about to be displayed, ok?
 
-	pg.stake.SetChecked(pg.WL.SelectedWallet.Wallet.IsAutoTicketsPurchaseActive())
+	pg.stake.SetChecked(pg.isTicketBuyerActive())
 
 	pg.setStakingButtonsState()
 
 	pg.listenForTxNotifications()
 	pg.fetchTickets()
+
+	if pg.WL.SelectedWallet.Wallet.TicketBuyerConfigIsSet() {
+		vspHost := pg.WL.SelectedWallet.Wallet.AutoTicketsBuyerConfig().VspHost
+		go pg.WL.Wallet.VSPMonitor().CheckHost(pg.ctx, vspHost)
+	}
 }
 
 // fetch ticket price only when the wallet is synced
@@ -107,6 +127,50 @@ func (pg *Page) fetchTicketPrice() {
 	}
 }
 
+// isTicketBuyerActive returns true if either the rule-based or the default
+// automatic ticket buyer is running for the selected wallet.
+func (pg *Page) isTicketBuyerActive() bool {
+	if pg.WL.SelectedWallet.Wallet.IsAutoTicketsPurchaseActive() {
+		return true
+	}
+	tb, err := pg.WL.Wallet.RuleTicketBuyer(pg.WL.SelectedWallet.Wallet.ID)
+	return err == nil && tb.IsRunning()
+}
+
+// stopTicketBuyer stops the automatic ticket buyers of the selected wallet.
+func (pg *Page) stopTicketBuyer() {
+	walletID := pg.WL.SelectedWallet.Wallet.ID
+	if pg.WL.SelectedWallet.Wallet.IsAutoTicketsPurchaseActive() {
+		if err := pg.WL.MultiWallet.StopAutoTicketsPurchase(walletID); err != nil {
+			log.Errorf("Error stopping ticket buyer: %v", err)
+		}
+	}
+	if tb, err := pg.WL.Wallet.RuleTicketBuyer(walletID); err == nil {
+		tb.Stop()
+	}
+}
+
+// startTicketBuyer starts the rule-based ticket buyer if purchase rules are
+// saved for the selected wallet, otherwise the default ticket buyer.
+func (pg *Page) startTicketBuyer(passphrase []byte) error {
+	w := pg.WL.SelectedWallet.Wallet
+	if !wallet.ReadTicketBuyerRules(w).IsSet() {
+		return w.StartTicketBuyer(passphrase)
+	}
+
+	tb, err := pg.WL.Wallet.RuleTicketBuyer(w.ID)
+	if err != nil {
+		return err
+	}
+	return tb.Start(passphrase)
+}
+
+// loadTicketBuyerRules reads whether purchase rules are saved for the
+// selected wallet.
+func (pg *Page) loadTicketBuyerRules() {
+	pg.hasBuyerRules = wallet.ReadTicketBuyerRules(pg.WL.SelectedWallet.Wallet).IsSet()
+}
+
 func (pg *Page) setStakingButtonsState() {
 	//disable auto ticket purchase if wallet is not synced
 	pg.stake.SetEnabled(pg.WL.MultiWallet.IsSynced() || !pg.WL.SelectedWallet.Wallet.IsWatchingOnlyWallet())
@@ -154,6 +218,9 @@ func (pg *Page) layoutDesktop(gtx layout.Context) layout.Dimensions {
 		func(gtx C) D {
 			return components.UniformHorizontalPadding(gtx, pg.stakePriceSection)
 		},
+		func(gtx C) D {
+			return components.UniformHorizontalPadding(gtx, pg.ticketBuyerLogLayout)
+		},
 		func(gtx C) D {
 			return components.UniformHorizontalPadding(gtx, pg.ticketListLayout)
 		},
@@ -169,6 +236,7 @@ func (pg *Page) layoutDesktop(gtx layout.Context) layout.Dimensions {
 func (pg *Page) layoutMobile(gtx layout.Context) layout.Dimensions {
 	widgets := []layout.Widget{
 		pg.stakePriceSection,
+		pg.ticketBuyerLogLayout,
 		pg.ticketListLayout,
 	}
 
@@ -217,18 +285,23 @@ func (pg *Page) HandleUserInteractions() {
 		} else {
 			pg.ticketBuyerSettingsModal()
 		}
-	} else {
-		pg.WL.MultiWallet.StopAutoTicketsPurchase(pg.WL.SelectedWallet.Wallet.ID)
+	} else if !pg.stake.IsChecked() {
+		pg.stopTicketBuyer()
+	}
+
+	if pg.manageVSPs.Clicked() {
+		pg.ParentNavigator().Display(NewVSPPage(pg.Load))
 	}
 
 	if pg.stakeSettings.Clicked() && !pg.WL.SelectedWallet.Wallet.IsWatchingOnlyWallet() {
-		if pg.WL.SelectedWallet.Wallet.IsAutoTicketsPurchaseActive() {
+		if pg.isTicketBuyerActive() {
 			pg.Toast.NotifyError(values.String(values.StrAutoTicketWarn))
 			return
 		}
 
 		ticketBuyerModal := newTicketBuyerModal(pg.Load).
 			OnSettingsSaved(func() {
+				pg.loadTicketBuyerRules()
 				pg.Toast.Notify(values.String(values.StrTicketSettingSaved))
 			}).
 			OnCancel(func() {
@@ -246,9 +319,22 @@ func (pg *Page) HandleUserInteractions() {
 		pg.fetchTicketPrice()
 	}
 
+	if pg.checkVSPStatus.Clicked() {
+		pg.fetchVSPStatuses()
+	}
+
+	if pg.exportCSV.Clicked() {
+		go pg.exportTickets(wallet.TicketExportCSV)
+	}
+
+	if pg.exportJSON.Clicked() {
+		go pg.exportTickets(wallet.TicketExportJSON)
+	}
+
 	if clicked, selectedItem := pg.ticketsList.ItemClicked(); clicked {
-		ticketTx := pg.tickets[selectedItem].transaction
-		pg.ParentNavigator().Display(tpage.NewTransactionDetailsPage(pg.Load, ticketTx))
+		ticket := pg.visibleTickets()[selectedItem]
+		ticketTx := ticket.transaction
+		pg.handleTicketClicked(ticket)
 
 		// Check if this ticket is fully registered with a VSP
 		// and log any discrepancies.
@@ -294,6 +380,7 @@ func (pg *Page) ticketBuyerSettingsModal() {
 			pg.stake.SetChecked(false)
 		}).
 		OnSettingsSaved(func() {
+			pg.loadTicketBuyerRules()
 			pg.startTicketBuyerPasswordModal()
 			pg.Toast.Notify(values.String(values.StrTicketSettingSaved))
 		})
@@ -320,6 +407,14 @@ func (pg *Page) startTicketBuyerPasswordModal() {
 					label := pg.Theme.Label(values.TextSize14, fmt.Sprintf("VSP: %s", tbConfig.VspHost))
 					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, label.Layout)
 				}),
+				layout.Rigid(func(gtx C) D {
+					if !pg.WL.Wallet.VSPMonitor().IsDown(tbConfig.VspHost) {
+						return D{}
+					}
+					label := pg.Theme.Label(values.TextSize14, values.StringF(values.StrVSPDownWarning, tbConfig.VspHost))
+					label.Color = pg.Theme.Color.Danger
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, label.Layout)
+				}),
 				layout.Rigid(func(gtx C) D {
 					return decredmaterial.LinearLayout{
 						Width:      decredmaterial.MatchParent,
@@ -359,14 +454,14 @@ func (pg *Page) startTicketBuyerPasswordModal() {
 			}
 
 			go func() {
-				err := pg.WL.SelectedWallet.Wallet.StartTicketBuyer([]byte(password))
+				err := pg.startTicketBuyer([]byte(password))
 				if err != nil {
 					pg.Toast.NotifyError(err.Error())
 					pm.SetLoading(false)
 					return
 				}
 
-				pg.stake.SetChecked(pg.WL.SelectedWallet.Wallet.IsAutoTicketsPurchaseActive())
+				pg.stake.SetChecked(pg.isTicketBuyerActive())
 				pg.ParentWindow().Reload()
 			}()
 			pm.Dismiss()
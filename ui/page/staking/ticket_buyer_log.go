@@ -0,0 +1,98 @@
+package staking
+
+import (
+	"gioui.org/layout"
+	"gioui.org/text"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// maxTicketBuyerLogEntries is the number of rule evaluations displayed on the
+// staking page.
+const maxTicketBuyerLogEntries = 10
+
+func ruleResultString(evaluation wallet.RuleEvaluation) string {
+	switch evaluation.Result {
+	case wallet.RulesPassed:
+		return values.StringF(values.StrRulesPassed, evaluation.Allowed)
+	case wallet.RuleLowBalance:
+		return values.String(values.StrRuleLowBalance)
+	case wallet.RulePriceAboveMax:
+		return values.String(values.StrRulePriceAboveMax)
+	case wallet.RuleOutsideActiveHours:
+		return values.String(values.StrRuleOutsideActiveHours)
+	case wallet.RuleDailyLimitReached:
+		return values.String(values.StrRuleDailyLimitReached)
+	case wallet.RuleWindowLimitReached:
+		return values.String(values.StrRuleWindowLimitReached)
+	case wallet.RulePriceAboveAverage:
+		return values.String(values.StrRulePriceAboveAverage)
+	default:
+		return values.String(values.StrRulePriceUnavailable)
+	}
+}
+
+// ticketBuyerLogLayout displays the most recent rule evaluations of the
+// rule-based ticket buyer. Nothing is displayed if no purchase rules are
+// saved for the selected wallet.
+func (pg *Page) ticketBuyerLogLayout(gtx C) D {
+	if !pg.hasBuyerRules {
+		return D{}
+	}
+
+	var evaluations []wallet.RuleEvaluation
+	if tb, err := pg.WL.Wallet.RuleTicketBuyer(pg.WL.SelectedWallet.Wallet.ID); err == nil {
+		evaluations = tb.Log()
+	}
+	if len(evaluations) > maxTicketBuyerLogEntries {
+		evaluations = evaluations[:maxTicketBuyerLogEntries]
+	}
+
+	return pg.pageSections(gtx, func(gtx C) D {
+		children := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				txt := pg.Theme.Body1(values.String(values.StrRuleEvaluationLog))
+				txt.Color = pg.Theme.Color.GrayText2
+				return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, txt.Layout)
+			}),
+		}
+
+		if len(evaluations) == 0 {
+			children = append(children, layout.Rigid(func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				txt := pg.Theme.Body2(values.String(values.StrNoRuleEvaluations))
+				txt.Color = pg.Theme.Color.GrayText3
+				txt.Alignment = text.Middle
+				return txt.Layout(gtx)
+			}))
+		}
+
+		for _, evaluation := range evaluations {
+			evaluation := evaluation
+			children = append(children, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							txt := pg.Theme.Body2(evaluation.Time.Format("Jan 2 15:04"))
+							txt.Color = pg.Theme.Color.GrayText3
+							return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, txt.Layout)
+						}),
+						layout.Flexed(1, pg.Theme.Body2(ruleResultString(evaluation)).Layout),
+						layout.Rigid(func(gtx C) D {
+							if evaluation.TicketPrice == 0 {
+								return D{}
+							}
+							txt := pg.Theme.Body2(dcrutil.Amount(evaluation.TicketPrice).String())
+							txt.Color = pg.Theme.Color.GrayText2
+							return txt.Layout(gtx)
+						}),
+					)
+				})
+			}))
+		}
+
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
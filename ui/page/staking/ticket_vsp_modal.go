@@ -0,0 +1,235 @@
+package staking
+
+import (
+	"context"
+	"sync"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const ticketVSPModalID = "ticket_vsp_modal"
+
+// ticketVSPModal displays the VSP status of a ticket and lets the user retry
+// a failed fee payment or register the ticket with another VSP.
+type ticketVSPModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	ticket *transactionItem
+
+	// statusMu protects status, which is refreshed by the fee payment
+	// goroutine.
+	statusMu sync.Mutex
+	status   *wallet.VSPTicketStatus
+
+	vspSelector  *components.VSPSelector
+	retryBtn     decredmaterial.Button
+	changeVSPBtn decredmaterial.Button
+	detailsBtn   decredmaterial.Button
+	closeBtn     decredmaterial.Button
+
+	onUpdated     func(*wallet.VSPTicketStatus)
+	onViewDetails func()
+}
+
+func newTicketVSPModal(l *load.Load, ticket *transactionItem) *ticketVSPModal {
+	m := &ticketVSPModal{
+		Load:   l,
+		Modal:  l.Theme.ModalFloatTitle(ticketVSPModalID),
+		ticket: ticket,
+		status: ticket.vspStatus,
+
+		vspSelector:  components.NewVSPSelector(l).Title(values.String(values.StrSelectVSP)),
+		retryBtn:     l.Theme.Button(values.String(values.StrRetryFeePayment)),
+		changeVSPBtn: l.Theme.OutlineButton(values.String(values.StrChangeVSP)),
+		detailsBtn:   l.Theme.OutlineButton(values.String(values.StrViewDetails)),
+		closeBtn:     l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+	return m
+}
+
+// OnUpdated sets the function called with the status of the ticket at its
+// VSP once a fee payment is submitted. It is called from a background
+// goroutine.
+func (m *ticketVSPModal) OnUpdated(onUpdated func(*wallet.VSPTicketStatus)) *ticketVSPModal {
+	m.onUpdated = onUpdated
+	return m
+}
+
+func (m *ticketVSPModal) OnViewDetails(onViewDetails func()) *ticketVSPModal {
+	m.onViewDetails = onViewDetails
+	return m
+}
+
+func (m *ticketVSPModal) OnResume() {}
+
+func (m *ticketVSPModal) OnDismiss() {}
+
+func (m *ticketVSPModal) vspStatus() *wallet.VSPTicketStatus {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	return m.status
+}
+
+func (m *ticketVSPModal) Handle() {
+	status := m.vspStatus()
+	selectedVSP := m.vspSelector.SelectedVSP()
+	m.retryBtn.SetEnabled(status != nil && status.HasProblem())
+	m.changeVSPBtn.SetEnabled(status != nil && selectedVSP != nil && selectedVSP.Host != status.VSP &&
+		status.FeeStatus != dcrlibwallet.VSPFeeProcessPaid && status.FeeStatus != dcrlibwallet.VSPFeeProcessConfirmed)
+
+	if m.retryBtn.Clicked() {
+		m.processTicket(func(w *dcrlibwallet.Wallet, password string) (*wallet.VSPTicketStatus, error) {
+			return wallet.RetryTicketFee(context.Background(), w, m.ticket.transaction.Hash, []byte(password))
+		})
+	}
+
+	if m.changeVSPBtn.Clicked() {
+		m.processTicket(func(w *dcrlibwallet.Wallet, password string) (*wallet.VSPTicketStatus, error) {
+			return wallet.ChangeTicketVSP(context.Background(), w, m.ticket.transaction.Hash, selectedVSP.Host, selectedVSP.PubKey, []byte(password))
+		})
+	}
+
+	if m.detailsBtn.Clicked() {
+		m.Dismiss()
+		m.onViewDetails()
+	}
+
+	if m.closeBtn.Clicked() || m.Modal.BackdropClicked(true) {
+		m.Dismiss()
+	}
+}
+
+// processTicket requests the wallet passphrase and runs process, which
+// submits the fee payment of the ticket. The status of the ticket is
+// refreshed once it succeeds.
+func (m *ticketVSPModal) processTicket(process func(w *dcrlibwallet.Wallet, password string) (*wallet.VSPTicketStatus, error)) {
+	w := m.WL.MultiWallet.WalletWithID(m.ticket.transaction.WalletID)
+	passwordModal := modal.NewPasswordModal(m.Load).
+		Title(values.String(values.StrConfirm)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				status, err := process(w, password)
+				if err != nil {
+					if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
+						pm.SetError(values.String(values.StrInvalidPassphrase))
+					} else {
+						pm.Toast.NotifyError(err.Error())
+					}
+					pm.SetLoading(false)
+					return
+				}
+
+				m.statusMu.Lock()
+				m.status = status
+				m.statusMu.Unlock()
+				pm.Dismiss()
+				m.Toast.Notify(values.String(values.StrFeePaymentSubmitted))
+				if m.onUpdated != nil {
+					m.onUpdated(status)
+				}
+				m.ParentWindow().Reload()
+			}()
+			return false
+		})
+	m.ParentWindow().ShowModal(passwordModal)
+}
+
+func feeStatusString(status dcrlibwallet.VSPFeeStatus) string {
+	switch status {
+	case dcrlibwallet.VSPFeeProcessStarted:
+		return values.String(values.StrFeeProcessStarted)
+	case dcrlibwallet.VSPFeeProcessPaid:
+		return values.String(values.StrFeePaid)
+	case dcrlibwallet.VSPFeeProcessErrored:
+		return values.String(values.StrVSPFeeErrored)
+	case dcrlibwallet.VSPFeeProcessConfirmed:
+		return values.String(values.StrFeeConfirmed)
+	default:
+		return values.String(values.StrUnknown)
+	}
+}
+
+func (m *ticketVSPModal) row(title, value string) layout.Widget {
+	return func(gtx C) D {
+		titleLabel := m.Theme.Label(values.TextSize14, title)
+		titleLabel.Color = m.Theme.Color.GrayText2
+		return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+			return components.EndToEndRow(gtx, titleLabel.Layout, m.Theme.Label(values.TextSize14, value).Layout)
+		})
+	}
+}
+
+func (m *ticketVSPModal) Layout(gtx layout.Context) layout.Dimensions {
+	status := m.vspStatus()
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := m.Theme.H6(values.String(values.StrTicketVSPStatus))
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+	}
+
+	if status == nil {
+		w = append(w, m.Theme.Label(values.TextSize14, values.String(values.StrNoVSPLoaded)).Layout)
+	} else {
+		w = append(w,
+			m.row(values.String(values.StrVsp), status.VSP),
+			m.row(values.String(values.StrFeeStatus), feeStatusString(status.FeeStatus)),
+		)
+		if status.Fetched {
+			confirmed := values.String(values.StrNotConfirmedByVSP)
+			if status.Confirmed {
+				confirmed = values.String(values.StrConfirmedByVSP)
+			}
+			w = append(w, m.row(values.String(values.StrStatus), confirmed))
+		}
+		if status.Err != nil {
+			w = append(w, func(gtx C) D {
+				lbl := m.Theme.Label(values.TextSize14, status.Err.Error())
+				lbl.Color = m.Theme.Color.Danger
+				return lbl.Layout(gtx)
+			})
+		}
+		w = append(w, func(gtx C) D {
+			return m.vspSelector.Layout(m.ParentWindow(), gtx)
+		})
+	}
+
+	w = append(w, func(gtx C) D {
+		return layout.E.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, m.closeBtn.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, m.detailsBtn.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if status == nil {
+						return D{}
+					}
+					return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, m.changeVSPBtn.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if status == nil {
+						return D{}
+					}
+					return m.retryBtn.Layout(gtx)
+				}),
+			)
+		})
+	})
+
+	return m.Modal.Layout(gtx, w)
+}
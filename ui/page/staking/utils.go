@@ -17,6 +17,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type transactionItem struct {
@@ -29,6 +30,7 @@ type transactionItem struct {
 	showTime      bool
 	purchaseTime  string
 	ticketAge     string
+	vspStatus     *wallet.VSPTicketStatus
 
 	statusTooltip     *decredmaterial.Tooltip
 	walletNameTooltip *decredmaterial.Tooltip
@@ -580,6 +582,19 @@ func ticketListLayout(gtx C, l *load.Load, ticket *transactionItem) layout.Dimen
 						})
 					}),
 					layout.Rigid(l.Theme.Label(values.TextSize18, ticket.status.Title).Layout),
+					layout.Rigid(func(gtx C) D {
+						if ticket.vspStatus == nil || !ticket.vspStatus.HasProblem() {
+							return D{}
+						}
+
+						txt := values.String(values.StrNotConfirmedByVSP)
+						if ticket.vspStatus.FeeStatus == dcrlibwallet.VSPFeeProcessErrored {
+							txt = values.String(values.StrVSPFeeErrored)
+						}
+						lbl := l.Theme.Label(values.TextSize14, txt)
+						lbl.Color = l.Theme.Color.Danger
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+					}),
 				)
 			},
 			func(gtx C) D {
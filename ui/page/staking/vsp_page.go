@@ -0,0 +1,331 @@
+package staking
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const (
+	VSPPageID = "vsp_management"
+
+	// vspPollInterval is how often the VSPs are polled while the VSP page
+	// is displayed.
+	vspPollInterval = time.Minute
+)
+
+type vspListItem struct {
+	wallet.VSPStatus
+	custom    bool
+	removeBtn decredmaterial.Button
+}
+
+// VSPPage lists the known and custom VSPs along with their fees, ticket
+// statistics and reachability.
+type VSPPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	list *widget.List
+
+	// vspsMu protects vsps, removeBtns and vspAdded, which are updated in
+	// the background.
+	vspsMu     sync.RWMutex
+	vsps       []*vspListItem
+	removeBtns map[string]decredmaterial.Button
+	// vspAdded is set once a custom VSP was saved, the input is then
+	// cleared on the UI goroutine.
+	vspAdded bool
+
+	inputVSP   decredmaterial.Editor
+	addVSP     decredmaterial.Button
+	backButton decredmaterial.IconButton
+}
+
+func NewVSPPage(l *load.Load) *VSPPage {
+	pg := &VSPPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(VSPPageID),
+		list: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		removeBtns: make(map[string]decredmaterial.Button),
+		inputVSP:   l.Theme.Editor(new(widget.Editor), values.String(values.StrAddVSP)),
+		addVSP:     l.Theme.Button(values.String(values.StrSave)),
+	}
+	pg.inputVSP.Editor.SingleLine = true
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *VSPPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadVSPs()
+
+	go func() {
+		if len(pg.WL.MultiWallet.KnownVSPs()) == 0 {
+			pg.WL.MultiWallet.ReloadVSPList(pg.ctx)
+		}
+		pg.WL.Wallet.VSPMonitor().Run(pg.ctx, vspPollInterval, func() {
+			pg.loadVSPs()
+			pg.ParentWindow().Reload()
+		})
+	}()
+}
+
+func (pg *VSPPage) loadVSPs() {
+	pg.vspsMu.Lock()
+	defer pg.vspsMu.Unlock()
+
+	custom := make(map[string]bool)
+	for _, host := range pg.WL.Wallet.CustomVSPs() {
+		custom[host] = true
+	}
+
+	statuses := pg.WL.Wallet.VSPMonitor().Statuses()
+	vsps := make([]*vspListItem, 0, len(statuses))
+	for _, status := range statuses {
+		item := &vspListItem{
+			VSPStatus: status,
+			custom:    custom[status.Host],
+		}
+		if item.custom {
+			removeBtn, ok := pg.removeBtns[status.Host]
+			if !ok {
+				removeBtn = pg.Theme.OutlineButton(values.String(values.StrRemove))
+				removeBtn.Color = pg.Theme.Color.Danger
+				removeBtn.Inset = layout.Inset{}
+				pg.removeBtns[status.Host] = removeBtn
+			}
+			item.removeBtn = removeBtn
+		}
+		vsps = append(vsps, item)
+	}
+
+	// List the cheapest VSPs first to ease fee comparison. VSPs that have
+	// never responded go last.
+	sort.SliceStable(vsps, func(i, j int) bool {
+		if vsps[i].Info == nil || vsps[j].Info == nil {
+			return vsps[j].Info == nil && vsps[i].Info != nil
+		}
+		return vsps[i].Info.FeePercentage < vsps[j].Info.FeePercentage
+	})
+	pg.vsps = vsps
+}
+
+// listedVSPs returns the VSPs to display.
+func (pg *VSPPage) listedVSPs() []*vspListItem {
+	pg.vspsMu.RLock()
+	defer pg.vspsMu.RUnlock()
+	return pg.vsps
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *VSPPage) HandleUserInteractions() {
+	pg.vspsMu.Lock()
+	if pg.vspAdded {
+		pg.vspAdded = false
+		pg.inputVSP.Editor.SetText("")
+	}
+	pg.vspsMu.Unlock()
+
+	pg.addVSP.SetEnabled(strings.TrimSpace(pg.inputVSP.Editor.Text()) != "")
+	if pg.addVSP.Clicked() {
+		host := pg.inputVSP.Editor.Text()
+		go func() {
+			if err := pg.WL.Wallet.AddCustomVSP(host); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return
+			}
+
+			pg.vspsMu.Lock()
+			pg.vspAdded = true
+			pg.vspsMu.Unlock()
+			pg.Toast.Notify(values.String(values.StrVSPAdded))
+			pg.WL.Wallet.VSPMonitor().Check(pg.ctx)
+			pg.loadVSPs()
+			pg.ParentWindow().Reload()
+		}()
+	}
+
+	for _, vsp := range pg.listedVSPs() {
+		if vsp.custom && vsp.removeBtn.Clicked() {
+			pg.WL.Wallet.RemoveCustomVSP(vsp.Host)
+			pg.loadVSPs()
+			break
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *VSPPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrManageVSPs),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return components.UniformPadding(gtx, container)
+}
+
+func (pg *VSPPage) layoutContent(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, pg.inputVSP.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.addVSP.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			vsps := pg.listedVSPs()
+			if len(vsps) == 0 {
+				txt := pg.Theme.Body1(values.String(values.StrNoVSPLoaded))
+				txt.Color = pg.Theme.Color.GrayText3
+				return txt.Layout(gtx)
+			}
+
+			return pg.Theme.List(pg.list).Layout(gtx, len(vsps), func(gtx C, i int) D {
+				return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+							return pg.vspItemLayout(gtx, vsps[i])
+						})
+					})
+				})
+			})
+		}),
+	)
+}
+
+func (pg *VSPPage) vspItemLayout(gtx C, vsp *vspListItem) D {
+	statusText, statusColor := values.String(values.StrVSPStatusChecking), pg.Theme.Color.GrayText3
+	switch {
+	case vsp.Online && vsp.Info.VspClosed:
+		statusText, statusColor = values.String(values.StrVSPClosed), pg.Theme.Color.Danger
+	case vsp.Online:
+		statusText, statusColor = values.String(values.StrVSPStatusOnline), pg.Theme.Color.Success
+	case vsp.Checked():
+		statusText, statusColor = values.String(values.StrVSPStatusOffline), pg.Theme.Color.Danger
+	}
+
+	lastSeen := values.String(values.StrNever)
+	if !vsp.LastSeen.IsZero() {
+		lastSeen = components.TimeAgo(vsp.LastSeen.Unix())
+	}
+
+	detail := func(txt string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body2(txt)
+			lbl.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+		})
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx,
+				func(gtx C) D {
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.Theme.Body1(vsp.Host).Layout),
+						layout.Rigid(func(gtx C) D {
+							if !vsp.custom {
+								return D{}
+							}
+							lbl := pg.Theme.Caption(values.String(values.StrCustomVSP))
+							lbl.Color = pg.Theme.Color.GrayText3
+							return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+						}),
+					)
+				},
+				func(gtx C) D {
+					lbl := pg.Theme.Body2(statusText)
+					lbl.Color = statusColor
+					lbl.Alignment = text.End
+					return lbl.Layout(gtx)
+				})
+		}),
+	}
+
+	if vsp.Info != nil {
+		children = append(children,
+			detail(fmt.Sprintf("%s: %v%%", values.String(values.StrFee), vsp.Info.FeePercentage)),
+			detail(values.StringF(values.StrVSPTicketStats, vsp.Info.Voting, vsp.Info.Voted, vsp.Info.Revoked)),
+			detail(fmt.Sprintf("%s: %s", values.String(values.StrNetwork), vsp.Info.Network)),
+		)
+	}
+	if vsp.Checked() {
+		children = append(children, detail(values.StringF(values.StrLastSeen, lastSeen)))
+	}
+	if vsp.Err != nil {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Caption(vsp.Err.Error())
+			lbl.Color = pg.Theme.Color.Danger
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+		}))
+	}
+	if vsp.custom {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, vsp.removeBtn.Layout)
+		}))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *VSPPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
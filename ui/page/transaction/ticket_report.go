@@ -0,0 +1,53 @@
+package transaction
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// writeTicketReport writes a printable plain text report of the ticket to
+// out.
+func writeTicketReport(out io.Writer, r *wallet.TicketRecord) error {
+	amount := func(atoms int64) string {
+		return dcrutil.Amount(atoms).String()
+	}
+
+	title := values.StringF(values.StrTicketReportTitle, r.Hash)
+	fmt.Fprintf(out, "%s\n%s\n\n", title, strings.Repeat("=", len(title)))
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	row := func(labelKey, value string) {
+		if value != "" {
+			fmt.Fprintf(tw, "%s:\t%s\n", values.String(labelKey), value)
+		}
+	}
+	row(values.StrReportWallet, r.WalletName)
+	row(values.StrStatus, values.String(r.Status))
+	row(values.StrPurchased, r.PurchaseTime.Format(time.RFC1123))
+	row(values.StrPurchaseBlock, strconv.Itoa(int(r.PurchaseHeight)))
+	row(values.StrTicketPrice, amount(r.Price))
+	row(values.StrTicketTxFee, amount(r.TxFee))
+	row(values.StrVsp, r.VSP)
+	if r.VSP != "" {
+		row(values.StrVSPFee, amount(r.VSPFee))
+		row(values.StrVSPFeeStatus, r.VSPFeeStatus)
+		row(values.StrVSPFeeTx, r.VSPFeeTxHash)
+	}
+	if r.SpenderHash != "" {
+		row(values.StrSpentBy, values.String(r.SpenderType))
+		row(values.StrSpenderTx, r.SpenderHash)
+		row(values.StrSpent, r.SpenderTime.Format(time.RFC1123))
+		row(values.StrSpenderBlock, strconv.Itoa(int(r.SpenderHeight)))
+		row(values.StrDaysToVoteOrRevoke, strconv.Itoa(int(r.DaysToVoteOrRevoke)))
+		row(values.StrReward, amount(r.Reward))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/godcr/wallet"
+)
+
+func TestWriteTicketReport(t *testing.T) {
+	voteTime := time.Date(2022, 3, 10, 8, 0, 0, 0, time.UTC)
+	voted := &wallet.TicketRecord{
+		Hash:               "aa",
+		WalletName:         "default",
+		Status:             wallet.TicketStatusVoted,
+		PurchaseTime:       time.Date(2022, 2, 1, 12, 30, 0, 0, time.UTC),
+		PurchaseHeight:     640000,
+		Price:              15000000000,
+		TxFee:              2980,
+		VSP:                "https://vsp.example.org",
+		VSPFeeTxHash:       "bb",
+		VSPFee:             1500000,
+		VSPFeeStatus:       "confirmed",
+		SpenderType:        "vote",
+		SpenderHash:        "cc",
+		SpenderTime:        &voteTime,
+		SpenderHeight:      646000,
+		Reward:             15000000,
+		DaysToVoteOrRevoke: 37,
+	}
+
+	var buf bytes.Buffer
+	if err := writeTicketReport(&buf, voted); err != nil {
+		t.Fatal(err)
+	}
+	report := buf.String()
+	for _, s := range []string{"Ticket aa", "Status:", "Voted", "Ticket Price:", "150 DCR", "VSP fee:", "0.015 DCR", "Spent by:", "Vote", "Reward:", "0.15 DCR"} {
+		if !strings.Contains(report, s) {
+			t.Errorf("expected report to contain %q:\n%s", s, report)
+		}
+	}
+
+	live := &wallet.TicketRecord{
+		Hash:         "dd",
+		WalletName:   "default",
+		Status:       "live",
+		PurchaseTime: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+		Price:        16000000000,
+	}
+	buf.Reset()
+	if err := writeTicketReport(&buf, live); err != nil {
+		t.Fatal(err)
+	}
+	if report = buf.String(); strings.Contains(report, "VSP") || strings.Contains(report, "Reward") {
+		t.Errorf("expected no VSP or spender details for unspent ticket:\n%s", report)
+	}
+}
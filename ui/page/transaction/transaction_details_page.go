@@ -1,6 +1,8 @@
 package transaction
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const TransactionDetailsPageID = "TransactionDetails"
@@ -44,6 +47,7 @@ type TxDetailsPage struct {
 	transactionInputsContainer      layout.List
 	transactionOutputsContainer     layout.List
 	associatedTicketClickable       *decredmaterial.Clickable
+	ticketReportClickable           *decredmaterial.Clickable
 	hashClickable                   *widget.Clickable
 	destAddressClickable            *widget.Clickable
 	dot                             *decredmaterial.Icon
@@ -92,6 +96,7 @@ func NewTransactionDetailsPage(l *load.Load, transaction *dcrlibwallet.Transacti
 		inputsCollapsible:  l.Theme.Collapsible(),
 
 		associatedTicketClickable: l.Theme.NewClickable(true),
+		ticketReportClickable:     l.Theme.NewClickable(true),
 		hashClickable:             new(widget.Clickable),
 		destAddressClickable:      new(widget.Clickable),
 		toDcrdata:                 l.Theme.NewClickable(true),
@@ -187,6 +192,9 @@ func (pg *TxDetailsPage) Layout(gtx layout.Context) layout.Dimensions {
 					func(gtx C) D {
 						return pg.ticketDetails(gtx)
 					},
+					func(gtx C) D {
+						return pg.ticketReport(gtx)
+					},
 					func(gtx C) D {
 						return pg.associatedTicket(gtx)
 					},
@@ -414,25 +422,7 @@ func (pg *TxDetailsPage) ticketDetails(gtx C) D {
 			}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
 					if pg.transaction.Type == dcrlibwallet.TxTypeTicketPurchase {
-						var status string
-						if pg.ticketSpender != nil {
-							if pg.ticketSpender.Type == dcrlibwallet.TxTypeVote {
-								status = values.String(values.StrVoted)
-							} else {
-								status = values.String(values.StrRevoked)
-							}
-						} else if pg.wallet.TxMatchesFilter(pg.transaction, dcrlibwallet.TxFilterLive) {
-							status = values.String(values.StrLive)
-						} else if pg.wallet.TxMatchesFilter(pg.transaction, dcrlibwallet.TxFilterImmature) {
-							status = values.String(values.StrImmature)
-						} else if pg.wallet.TxMatchesFilter(pg.transaction, dcrlibwallet.TxFilterUnmined) {
-							status = values.String(values.StrUmined)
-						} else if pg.wallet.TxMatchesFilter(pg.transaction, dcrlibwallet.TxFilterExpired) {
-							status = values.String(values.StrExpired)
-						} else {
-							status = values.String(values.StrUnknown)
-						}
-
+						status := values.String(wallet.TicketStatus(pg.wallet, pg.transaction, pg.ticketSpender))
 						return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
 							return pg.txnInfoSection(gtx, values.String(values.StrStatus), status, false, nil)
 						})
@@ -503,6 +493,65 @@ func (pg *TxDetailsPage) associatedTicket(gtx C) D {
 	)
 }
 
+func (pg *TxDetailsPage) ticketReport(gtx C) D {
+	if pg.transaction.Type != dcrlibwallet.TxTypeTicketPurchase {
+		return D{}
+	}
+
+	return layout.Flex{
+		Axis: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return pg.ticketReportClickable.Layout(gtx, func(gtx C) D {
+				return decredmaterial.LinearLayout{
+					Width:       decredmaterial.MatchParent,
+					Height:      decredmaterial.WrapContent,
+					Orientation: layout.Horizontal,
+					Padding:     layout.Inset{Left: values.MarginPadding16, Top: values.MarginPadding12, Right: values.MarginPadding16, Bottom: values.MarginPadding12},
+				}.Layout(gtx,
+					layout.Rigid(pg.Theme.Label(values.TextSize16, values.String(values.StrTicketReport)).Layout),
+					layout.Flexed(1, func(gtx C) D {
+						return layout.E.Layout(gtx, pg.Theme.Icons.Next.Layout24dp)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(pg.Theme.Separator().Layout),
+	)
+}
+
+// showTicketReport displays the printable report of the ticket and offers to
+// save it to a file.
+func (pg *TxDetailsPage) showTicketReport() {
+	record, err := wallet.ReadTicketRecord(context.Background(), pg.wallet, pg.transaction.Hash)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	var report bytes.Buffer
+	if err = writeTicketReport(&report, record); err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	reportModal := modal.NewInfoModal(pg.Load).
+		Title(values.String(values.StrTicketReport)).
+		SetCancelable(true).
+		UseCustomWidget(pg.Theme.Body2(report.String()).Layout).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrSave), func(isChecked bool) bool {
+			path, err := pg.WL.Wallet.ExportTicketReport(record.Hash, report.String())
+			if err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return false
+			}
+			pg.Toast.Notify(values.StringF(values.StrExportedTo, path))
+			return true
+		})
+	pg.ParentWindow().ShowModal(reportModal)
+}
+
 //TODO: do this at startup
 func (pg *TxDetailsPage) txConfirmations() int32 {
 	transaction := pg.transaction
@@ -797,6 +846,10 @@ func (pg *TxDetailsPage) HandleUserInteractions() {
 		}
 	}
 
+	if pg.ticketReportClickable.Clicked() {
+		pg.showTicketReport()
+	}
+
 	if pg.rebroadcastClickable.Clicked() {
 		go func() {
 			pg.rebroadcastClickable.SetEnabled(false, nil)
@@ -0,0 +1,185 @@
+package page
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const TrustedDomainsPageID = "TrustedDomains"
+
+// trustedDomain is a trusted domain listed with the button that removes it.
+type trustedDomain struct {
+	domain    string
+	removeBtn decredmaterial.IconButton
+}
+
+// TrustedDomainsPage lists the domains whose links are opened without
+// confirmation, and lets the user add and remove them.
+type TrustedDomainsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	domains       []trustedDomain
+	domainEditor  decredmaterial.Editor
+	addBtn        decredmaterial.Button
+	backButton    decredmaterial.IconButton
+	scrollbarList *widget.List
+}
+
+func NewTrustedDomainsPage(l *load.Load) *TrustedDomainsPage {
+	pg := &TrustedDomainsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(TrustedDomainsPageID),
+		domainEditor:     l.Theme.Editor(new(widget.Editor), values.String(values.StrDomainHint)),
+		addBtn:           l.Theme.Button(values.String(values.StrAddDomain)),
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.domainEditor.Editor.SingleLine = true
+	pg.domainEditor.Editor.Submit = true
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *TrustedDomainsPage) OnNavigatedTo() {
+	pg.loadDomains()
+}
+
+func (pg *TrustedDomainsPage) loadDomains() {
+	domains := wallet.TrustedLinkDomains(pg.WL.MultiWallet)
+	pg.domains = make([]trustedDomain, len(domains))
+	for i, domain := range domains {
+		removeBtn := pg.Theme.IconButton(pg.Theme.Icons.ContentClear)
+		removeBtn.Size = values.MarginPadding20
+		pg.domains[i] = trustedDomain{domain: domain, removeBtn: removeBtn}
+	}
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *TrustedDomainsPage) HandleUserInteractions() {
+	submitted := false
+	for _, evt := range pg.domainEditor.Editor.Events() {
+		if _, ok := evt.(widget.SubmitEvent); ok {
+			submitted = true
+		}
+	}
+
+	if pg.addBtn.Clicked() || submitted {
+		if err := wallet.TrustLinkDomain(pg.WL.MultiWallet, pg.domainEditor.Editor.Text()); err != nil {
+			pg.domainEditor.SetError(values.String(values.StrInvalidDomain))
+		} else {
+			pg.domainEditor.SetError("")
+			pg.domainEditor.Editor.SetText("")
+			pg.loadDomains()
+		}
+	}
+
+	for _, item := range pg.domains {
+		if item.removeBtn.Button.Clicked() {
+			wallet.UntrustLinkDomain(pg.WL.MultiWallet, item.domain)
+			pg.loadDomains()
+			break
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *TrustedDomainsPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *TrustedDomainsPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrTrustedDomains),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return pg.Theme.List(pg.scrollbarList).Layout(gtx, 1, func(gtx C, i int) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.layoutContent)
+					})
+				})
+			},
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *TrustedDomainsPage) layoutContent(gtx C) D {
+	info := pg.Theme.Body2(values.String(values.StrTrustedDomainsInfo))
+	info.Color = pg.Theme.Color.GrayText2
+
+	children := []layout.FlexChild{
+		layout.Rigid(info.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, pg.domainEditor.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.addBtn.Layout)
+					}),
+				)
+			})
+		}),
+	}
+
+	if len(pg.domains) == 0 {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body1(values.String(values.StrNoTrustedDomains))
+			lbl.Color = pg.Theme.Color.GrayText3
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		}))
+	}
+
+	for i := range pg.domains {
+		item := pg.domains[i]
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, pg.Theme.Body1(item.domain).Layout),
+					layout.Rigid(item.removeBtn.Layout),
+				)
+			})
+		}))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/page/security"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const WalletSettingsPageID = "WalletSettings"
@@ -21,6 +22,12 @@ type clickableRowData struct {
 	title     string
 }
 
+type ticketNotificationSwitch struct {
+	eventType wallet.TicketEventType
+	title     string
+	option    *decredmaterial.Switch
+}
+
 type accountData struct {
 	*dcrlibwallet.Account
 	clickable *decredmaterial.Clickable
@@ -56,6 +63,8 @@ type WalletSettingsPage struct {
 	spendUnmixedFunds *decredmaterial.Switch
 	connectToPeer     *decredmaterial.Switch
 	peerAddr          string
+
+	ticketNotifications []*ticketNotificationSwitch
 }
 
 func NewWalletSettingsPage(l *load.Load) *WalletSettingsPage {
@@ -92,6 +101,23 @@ func NewWalletSettingsPage(l *load.Load) *WalletSettingsPage {
 
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(l)
 
+	ticketEventTitles := map[wallet.TicketEventType]string{
+		wallet.TicketPurchased:     values.String(values.StrPurchased),
+		wallet.TicketMatured:       values.String(values.StrMatured),
+		wallet.TicketVoted:         values.String(values.StrVoted),
+		wallet.TicketMissed:        values.String(values.StrMissed),
+		wallet.TicketExpired:       values.String(values.StrExpired),
+		wallet.TicketRevoked:       values.String(values.StrRevoked),
+		wallet.TicketVSPFeeErrored: values.String(values.StrVSPFeeErrored),
+	}
+	for _, eventType := range wallet.TicketEventTypes {
+		pg.ticketNotifications = append(pg.ticketNotifications, &ticketNotificationSwitch{
+			eventType: eventType,
+			title:     ticketEventTitles[eventType],
+			option:    l.Theme.Switch(),
+		})
+	}
+
 	return pg
 }
 
@@ -106,6 +132,13 @@ func (pg *WalletSettingsPage) OnNavigatedTo() {
 	pg.spendUnconfirmed.SetChecked(pg.WL.SelectedWallet.Wallet.ReadBoolConfigValueForKey(dcrlibwallet.SpendUnconfirmedConfigKey, false))
 	pg.spendUnmixedFunds.SetChecked(pg.WL.SelectedWallet.Wallet.ReadBoolConfigValueForKey(load.SpendUnmixedFundsKey, false))
 
+	// ticket notifications follow the transaction notification setting until
+	// they are configured for the wallet.
+	txNotification := pg.WL.MultiWallet.ReadBoolConfigValueForKey(load.TransactionNotificationConfigKey, false)
+	for _, notif := range pg.ticketNotifications {
+		notif.option.SetChecked(pg.wallet.ReadBoolConfigValueForKey(notif.eventType.ConfigKey(), txNotification))
+	}
+
 	pg.peerAddr = pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.SpvPersistentPeerAddressesConfigKey)
 	pg.connectToPeer.SetChecked(false)
 	if pg.peerAddr != "" {
@@ -149,6 +182,7 @@ func (pg *WalletSettingsPage) Layout(gtx C) D {
 				}.Layout(gtx, pg.Theme.Label(values.TextSize20, values.String(values.StrSettings)).Layout)
 			},
 			pg.generalSection(),
+			pg.ticketNotificationSection(),
 			pg.account(),
 			pg.securityTools(),
 			pg.debug(),
@@ -213,6 +247,20 @@ func (pg *WalletSettingsPage) generalSection() layout.Widget {
 	}
 }
 
+func (pg *WalletSettingsPage) ticketNotificationSection() layout.Widget {
+	dims := func(gtx C) D {
+		children := make([]layout.FlexChild, 0, len(pg.ticketNotifications))
+		for _, notif := range pg.ticketNotifications {
+			children = append(children, layout.Rigid(pg.subSectionSwitch(notif.title, notif.option)))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	}
+
+	return func(gtx C) D {
+		return pg.pageSections(gtx, values.String(values.StrTicketNotifications), dims)
+	}
+}
+
 func (pg *WalletSettingsPage) account() layout.Widget {
 	dim := func(gtx C) D {
 		return pg.accountsList.Layout(gtx, len(pg.accounts), func(gtx C, a int) D {
@@ -695,6 +743,12 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 		pg.WL.MultiWallet.SaveUserConfigValue(load.ProposalNotificationConfigKey, pg.proposalNotif.IsChecked())
 	}
 
+	for _, notif := range pg.ticketNotifications {
+		if notif.option.Changed() {
+			pg.wallet.SetBoolConfigValueForKey(notif.eventType.ConfigKey(), notif.option.IsChecked())
+		}
+	}
+
 	if pg.resetDexData.Clicked() {
 		pg.resetDexDataModal()
 	}
@@ -9,7 +9,8 @@ const (
 	strongTagName        = "strong"
 	emphTagName          = "emph"
 	strikeTagName        = "strike"
-	blockQuoteTagName    = "blockquote"
+	codeTagName          = "code"
+	imageTagName         = "img"
 	orderedListTagName   = "ol"
 	unorderedListTagName = "ul"
 	listItemTagName      = "li"
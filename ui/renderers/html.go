@@ -8,16 +8,28 @@ import (
 
 	"gioui.org/layout"
 	"gioui.org/text"
+	"gioui.org/unit"
 	"gioui.org/widget"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gomarkdown/markdown/ast"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"golang.org/x/net/html"
 )
 
+// htmlRow is a rendered row and the items it lays out. quote is the number
+// of blockquotes the row is nested in and indent the number of lists.
+type htmlRow struct {
+	layout layout.Widget
+	items  []rowItem
+	quote  int
+	indent int
+}
+
 type HTMLProvider struct {
-	containers    []layout.Widget
+	containers    []htmlRow
 	theme         *decredmaterial.Theme
 	stringBuilder strings.Builder
 	styleGroups   []map[string]string
@@ -25,10 +37,14 @@ type HTMLProvider struct {
 	table         *table
 	isList        bool
 	prefix        string
+	listDepth     int
+	quoteDepth    int
 }
 
 var (
-	blockEls = []string{"div", "p", "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "li"}
+	// unstyledEls are the elements whose content is not wrapped in style
+	// tags: void elements, and code which is displayed as is.
+	unstyledEls = []string{"br", "hr", "img", "pre", "code"}
 )
 
 const (
@@ -56,20 +72,61 @@ func RenderHTML(html string, theme *decredmaterial.Theme) *HTMLProvider {
 }
 
 func (p *HTMLProvider) renderSoftBreak() {
+	p.render(p.theme.Body1(""))
 	p.renderEmptyLine()
 }
 
 func (p *HTMLProvider) renderHardBreak() {
+	p.render(p.theme.Body1(""))
+	p.renderEmptyLine()
+}
+
+func (p *HTMLProvider) prepareBlockQuote(node *ast.BlockQuote, entering bool) {
+	if entering {
+		p.quoteDepth++
+	} else {
+		p.quoteDepth--
+	}
+}
+
+func (p *HTMLProvider) prepareCode(node *ast.Code, entering bool) {
+	p.writeStyled(map[string]string{"font-family": "monospace"}, string(node.Literal))
+}
+
+func (p *HTMLProvider) prepareCodeBlock(node *ast.CodeBlock, entering bool) {
+	lines := codeLabels(string(node.Literal), p.theme)
+	code := renderCodeBlock(lines, p.theme)
+	p.appendRow(p.nest(code), rowItem{layout: code, labels: lines})
 	p.renderEmptyLine()
 }
 
-func (p *HTMLProvider) prepareBlockQuote(node *ast.BlockQuote, entering bool) {}
+func (p *HTMLProvider) prepareImage(node *ast.Image) {
+	style := map[string]string{"font-style": "italic", "text-color": "grayText2"}
+	p.writeStyled(style, values.StringF(values.StrImagePlaceholder, imageAltText(node)))
+}
 
-func (p *HTMLProvider) prepareCode(node *ast.Code, entering bool) {}
+// writeStyled writes the text to the builder in a style tag.
+func (p *HTMLProvider) writeStyled(style map[string]string, text string) {
+	p.stringBuilder.WriteString(openStyleTag + p.styleMapToString(style) + halfCloseStyleTag + text + closeStyleTag)
+}
 
-func (p *HTMLProvider) prepareCodeBlock(node *ast.CodeBlock, entering bool) {}
+// openOrCloseStyle opens a style tag when entering a node and closes it
+// when exiting.
+func (p *HTMLProvider) openOrCloseStyle(style map[string]string, entering bool) {
+	if entering {
+		p.stringBuilder.WriteString(openStyleTag + p.styleMapToString(style) + halfCloseStyleTag)
+	} else {
+		p.stringBuilder.WriteString(closeStyleTag)
+	}
+}
 
 func (p *HTMLProvider) prepareList(node *ast.List, entering bool) {
+	if entering {
+		p.listDepth++
+	} else {
+		p.listDepth--
+	}
+
 	if next := ast.GetNextNode(node); !entering && next != nil {
 		_, parentIsListItem := node.GetParent().(*ast.ListItem)
 		_, nextIsList := next.(*ast.List)
@@ -93,15 +150,15 @@ func (p *HTMLProvider) prepareListItem(node *ast.ListItem, entering bool) {
 				}
 				itemNumber++
 			}
-			p.prefix += fmt.Sprintf("%d. ", itemNumber)
+			p.prefix = fmt.Sprintf("%d. ", itemNumber)
 
 		// content of a definition
 		case node.ListFlags&ast.ListTypeDefinition != 0:
-			p.prefix += " "
+			p.prefix = " "
 
 		// no flags means it's the normal bullet point list
 		default:
-			p.prefix += " " + bulletUnicode + " "
+			p.prefix = bulletUnicode + " "
 		}
 	}
 }
@@ -130,16 +187,22 @@ func (p *HTMLProvider) prepareHeading(node *ast.Heading, entering bool) {
 }
 
 func (p *HTMLProvider) prepareStrong(node *ast.Strong, entering bool) {
-	label := p.theme.Body1("")
-	label.Font.Weight = text.Bold
-	p.render(label)
+	p.openOrCloseStyle(map[string]string{"font-weight": "bold"}, entering)
 }
-func (p *HTMLProvider) prepareDel(node *ast.Del, entering bool) {}
 
-// Will be taken care off by the render function
-func (p *HTMLProvider) prepareEmph(node *ast.Emph, entering bool) {}
+func (p *HTMLProvider) prepareDel(node *ast.Del, entering bool) {
+	p.openOrCloseStyle(map[string]string{"text-decoration": "line-through"}, entering)
+}
+
+func (p *HTMLProvider) prepareEmph(node *ast.Emph, entering bool) {
+	p.openOrCloseStyle(map[string]string{"font-style": "italic"}, entering)
+}
 
 func (p *HTMLProvider) prepareLink(node *ast.Link, entering bool) {
+	if entering {
+		return
+	}
+
 	dest := string(node.Destination)
 	text := string(ast.GetFirstChild(node).AsLeaf().Literal)
 
@@ -164,7 +227,10 @@ func (p *HTMLProvider) prepareLink(node *ast.Link, entering bool) {
 	p.stringBuilder.WriteString(word)
 }
 
-func (p *HTMLProvider) prepareHorizontalRule(node *ast.HorizontalRule, entering bool) {}
+func (p *HTMLProvider) prepareHorizontalRule(node *ast.HorizontalRule, entering bool) {
+	p.appendRow(renderHorizontalLine(p.theme))
+	p.renderEmptyLine()
+}
 
 func (p *HTMLProvider) prepareText(node *ast.Text, entering bool) {
 	if string(node.Literal) == "\n" {
@@ -181,7 +247,7 @@ func (p *HTMLProvider) prepareTable(node *ast.Table, entering bool) {
 	if entering {
 		p.table = newTable(p.theme)
 	} else {
-		p.containers = append(p.containers, p.table.render())
+		p.appendRow(p.table.render())
 		p.table = nil
 	}
 }
@@ -216,22 +282,43 @@ func (p *HTMLProvider) render(lbl decredmaterial.Label) {
 	content := p.stringBuilder.String()
 	p.stringBuilder.Reset()
 
+	if strings.TrimSpace(content) == "" && p.prefix == "" {
+		return
+	}
+
 	if p.prefix != "" {
 		content = p.prefix + " " + content
+		p.prefix = ""
+	}
+
+	var labels []rowItem
+	addLabel := func(text string) {
+		if strings.Trim(text, " ") == "" {
+			return
+		}
+		l := p.getLabel(lbl, text)
+		item := rowItem{
+			layout: l.Layout,
+			labels: []decredmaterial.Label{l},
+			strike: p.currentStyle()["text-decoration"] == "line-through",
+		}
+		if item.strike {
+			item.layout = renderStrike(l, p.theme)
+		}
+		labels = append(labels, item)
 	}
 
-	var labels []decredmaterial.Label
 	var inStyleBlock bool
 	var isClosingStyle bool
 	var isClosingBlock bool
 	var currStyle string
 	var currText string
-	for i := range content {
+	for i, r := range content {
 		curr := content[i]
 
 		if curr == openStyleTag[0] && getNextChar(content, i) == openStyleTag[1] {
 			inStyleBlock = true
-			labels = append(labels, p.getLabel(lbl, currText))
+			addLabel(currText)
 			currText = ""
 		}
 
@@ -244,17 +331,17 @@ func (p *HTMLProvider) render(lbl decredmaterial.Label) {
 		}
 
 		if !inStyleBlock && !isClosingBlock {
-			currStr := string(curr)
+			currStr := string(r)
 			currText += currStr
 
 			if i+1 == len(content) || currStr == "" || currStr == " " {
-				labels = append(labels, p.getLabel(lbl, currText))
+				addLabel(currText)
 				currText = ""
 			}
 		}
 
 		if isClosingBlock && curr == closeStyleTag[3] {
-			labels = append(labels, p.getLabel(lbl, currText))
+			addLabel(currText)
 			currText = ""
 			p.removeLastStyleGroup()
 			isClosingBlock = false
@@ -262,7 +349,7 @@ func (p *HTMLProvider) render(lbl decredmaterial.Label) {
 		}
 
 		if inStyleBlock && !isClosingStyle {
-			currStyle += string(curr)
+			currStyle += string(r)
 		}
 
 		if isClosingStyle && curr == halfCloseStyleTag[1] {
@@ -278,22 +365,56 @@ func (p *HTMLProvider) render(lbl decredmaterial.Label) {
 			Axis:      layout.Horizontal,
 			Alignment: layout.Start,
 		}.Layout(gtx, len(labels), func(gtx C, i int) D {
-			if strings.Trim(labels[i].Text, " ") == "" {
-				return D{}
-			}
-			return labels[i].Layout(gtx)
+			return labels[i].layout(gtx)
 		})
 	}
-	p.containers = append(p.containers, wdgt)
+	p.appendRow(p.nest(wdgt), labels...)
+}
+
+// appendRow adds a row laying out the widget, which draws the items.
+func (p *HTMLProvider) appendRow(wdgt layout.Widget, items ...rowItem) {
+	p.containers = append(p.containers, htmlRow{
+		layout: wdgt,
+		items:  items,
+		quote:  p.quoteDepth,
+		indent: p.listDepth,
+	})
+}
+
+// nest indents the widget to the current list level and draws the bars of
+// the blockquotes it is in.
+func (p *HTMLProvider) nest(wdgt layout.Widget) layout.Widget {
+	if p.listDepth > 1 {
+		content := wdgt
+		indent := unit.Dp(float32(listIndent * (p.listDepth - 1)))
+		wdgt = func(gtx C) D {
+			return layout.Inset{Left: indent}.Layout(gtx, content)
+		}
+	}
+
+	for i := 0; i < p.quoteDepth; i++ {
+		wdgt = renderBlockQuote(wdgt, p.theme)
+	}
+	return wdgt
 }
 
 func (p *HTMLProvider) getLabel(lbl decredmaterial.Label, text string) decredmaterial.Label {
 	l := lbl
 	l.Text = text
 	l = p.styleLabel(l)
+	if p.quoteDepth > 0 && l.Color == p.theme.Color.Text {
+		l.Color = p.theme.Color.GrayText2
+	}
 	return l
 }
 
+func (p *HTMLProvider) currentStyle() map[string]string {
+	if len(p.styleGroups) == 0 {
+		return nil
+	}
+	return p.styleGroups[len(p.styleGroups)-1]
+}
+
 func (p *HTMLProvider) removeLastStyleGroup() {
 	if len(p.styleGroups) > 0 {
 		p.styleGroups = p.styleGroups[:len(p.styleGroups)-1]
@@ -302,7 +423,11 @@ func (p *HTMLProvider) removeLastStyleGroup() {
 
 func (p *HTMLProvider) addStyleGroup(str string) {
 	parts := strings.Split(str, "##")
+	// Nested styles extend the style they are in.
 	styleMap := map[string]string{}
+	for key, val := range p.currentStyle() {
+		styleMap[key] = val
+	}
 
 	for i := range parts {
 		if parts[i] != " " && parts[i] != "{" {
@@ -314,9 +439,7 @@ func (p *HTMLProvider) addStyleGroup(str string) {
 		}
 	}
 
-	if len(styleMap) > 0 {
-		p.styleGroups = append(p.styleGroups, styleMap)
-	}
+	p.styleGroups = append(p.styleGroups, styleMap)
 }
 
 func (p *HTMLProvider) styleLabel(label decredmaterial.Label) decredmaterial.Label {
@@ -344,6 +467,10 @@ func (p *HTMLProvider) styleLabel(label decredmaterial.Label) decredmaterial.Lab
 		}
 	}
 
+	if style["font-family"] == "monospace" {
+		label.Font.Variant = monoVariant
+	}
+
 	return label
 }
 
@@ -395,7 +522,7 @@ func (p *HTMLProvider) renderEmptyLine() {
 		p.isList = false
 	}
 
-	p.containers = append(p.containers, func(gtx C) D {
+	p.appendRow(func(gtx C) D {
 		dims := p.theme.Body2("").Layout(gtx)
 		dims.Size.Y = dims.Size.Y + padding
 		return dims
@@ -419,18 +546,19 @@ func (p *HTMLProvider) prepare(html string) string {
 			p.prepareBold(node)
 		case "font":
 			p.prepareFont(node)
-		case "br":
-			p.prepareBreak(node)
+		case "del", "s", "strike":
+			p.prepareStrike(node)
 		}
 	})
 
-	doc.Find("body > *").Each(func(_ int, node *goquery.Selection) {
-		styleMap := p.getStyleMap(node)
-		newStyleMap := p.setNodeStyle(node, styleMap)
-		p.traverse(node, newStyleMap)
-	})
+	body := doc.Find("body")
+	p.traverse(body, map[string]string{})
 
-	return doc.Text()
+	prepared, err := body.Html()
+	if err != nil {
+		return doc.Text()
+	}
+	return prepared
 }
 
 func (p *HTMLProvider) prepareItalic(node *goquery.Selection) {
@@ -455,6 +583,17 @@ func (p *HTMLProvider) prepareBold(node *goquery.Selection) {
 	node.ReplaceWithHtml(fmt.Sprintf(`<span style="%s">%s</span>`, style, node.Text()))
 }
 
+func (p *HTMLProvider) prepareStrike(node *goquery.Selection) {
+	style, ok := node.Attr("style")
+	if ok {
+		style += "; text-decoration: line-through"
+	} else {
+		style = "text-decoration: line-through"
+	}
+
+	node.ReplaceWithHtml(fmt.Sprintf(`<span style="%s">%s</span>`, style, node.Text()))
+}
+
 func (p *HTMLProvider) prepareFont(node *goquery.Selection) {
 	style, _ := node.Attr("style")
 	if style != "" {
@@ -472,10 +611,6 @@ func (p *HTMLProvider) prepareFont(node *goquery.Selection) {
 	node.ReplaceWithHtml(fmt.Sprintf(`<span style="%s">%s</span>`, style, node.Text()))
 }
 
-func (p *HTMLProvider) prepareBreak(node *goquery.Selection) {
-	node.ReplaceWithHtml("\n\n")
-}
-
 func (p *HTMLProvider) mapToString(m map[string]string) string {
 	b := new(bytes.Buffer)
 	for key, value := range m {
@@ -511,16 +646,28 @@ func (p *HTMLProvider) styleMapToString(m map[string]string) string {
 	return str
 }
 
+// traverse wraps the text in the node in style tags with the styles of the
+// elements the text is in. The style tags are read back by render.
 func (p *HTMLProvider) traverse(node *goquery.Selection, parentStyle map[string]string) {
-	node.Children().Each(func(_ int, s *goquery.Selection) {
-		newStyle := p.setNodeStyle(s, parentStyle)
-		p.traverse(s, newStyle)
+	node.Contents().Each(func(_ int, s *goquery.Selection) {
+		if s.Get(0).Type == html.TextNode {
+			text := s.Text()
+			if strings.TrimSpace(text) != "" && len(parentStyle) > 0 {
+				styleTag := openStyleTag + p.styleMapToString(parentStyle) + halfCloseStyleTag
+				s.ReplaceWithHtml(styleTag + html.EscapeString(text) + closeStyleTag)
+			}
+			return
+		}
+
+		if !p.isUnstyledElement(goquery.NodeName(s)) {
+			p.traverse(s, p.nodeStyle(s, parentStyle))
+		}
 	})
 }
 
-func (p *HTMLProvider) isBlockElement(element string) bool {
-	for i := range blockEls {
-		if element == blockEls[i] {
+func (p *HTMLProvider) isUnstyledElement(element string) bool {
+	for i := range unstyledEls {
+		if element == unstyledEls[i] {
 			return true
 		}
 	}
@@ -528,7 +675,9 @@ func (p *HTMLProvider) isBlockElement(element string) bool {
 	return false
 }
 
-func (p *HTMLProvider) setNodeStyle(node *goquery.Selection, parentStyle map[string]string) map[string]string {
+// nodeStyle returns the style of the node, which extends the style of its
+// parent.
+func (p *HTMLProvider) nodeStyle(node *goquery.Selection, parentStyle map[string]string) map[string]string {
 	styleMap := p.getStyleMap(node)
 	for key, val := range parentStyle {
 		if _, ok := styleMap[key]; !ok {
@@ -536,19 +685,11 @@ func (p *HTMLProvider) setNodeStyle(node *goquery.Selection, parentStyle map[str
 		}
 	}
 
-	styleTag := openStyleTag + p.styleMapToString(styleMap) + halfCloseStyleTag
-	endTag := closeStyleTag
-	node = node.PrependHtml(styleTag)
-	if p.isBlockElement(goquery.NodeName(node)) {
-		endTag += " \n "
-	}
-	node.AppendHtml(endTag)
-
 	return styleMap
 }
 
 func (p *HTMLProvider) Layout(gtx C) D {
 	return (&layout.List{Axis: layout.Vertical}).Layout(gtx, len(p.containers), func(gtx C, i int) D {
-		return p.containers[i](gtx)
+		return p.containers[i].layout(gtx)
 	})
 }
@@ -1,11 +1,14 @@
 package renderers
 
 import (
+	"fmt"
+	"image"
 	"strings"
 	"unicode"
 
 	"gioui.org/layout"
 	"gioui.org/text"
+	"gioui.org/unit"
 	"gioui.org/widget"
 
 	"github.com/gomarkdown/markdown/ast"
@@ -15,6 +18,9 @@ import (
 
 const (
 	bulletUnicode = "\u2022"
+
+	// listIndent is the indentation, in dp, of each level of nested lists.
+	listIndent = 20
 )
 
 type (
@@ -22,18 +28,41 @@ type (
 	D = layout.Dimensions
 )
 
+// rowItem is a widget of a rendered row along with the labels it draws, if
+// any. strike is true if the labels are struck through and link is the
+// destination of the link the labels are the text of.
+type rowItem struct {
+	layout layout.Widget
+	labels []decredmaterial.Label
+	strike bool
+	link   string
+}
+
 type layoutRow struct {
-	widgets []layout.Widget
+	widgets []rowItem
+	// quote is the number of blockquotes the row is nested in.
+	quote int
+	// indent is the number of lists the row is nested in. marker is the
+	// bullet or number drawn before the first row of a list item.
+	indent int
+	marker *decredmaterial.Label
+}
+
+// listState is the state of a list being rendered.
+type listState struct {
+	ordered bool
+	number  int
 }
 
 type MarkdownProvider struct {
-	containers     []layoutRow
-	theme          *decredmaterial.Theme
-	listItemNumber int // should be negative when not rendering a list
-	links          map[string]*widget.Clickable
-	table          *table
-	label          *decredmaterial.Label
-	prefix         string
+	containers []layoutRow
+	theme      *decredmaterial.Theme
+	links      map[string]*widget.Clickable
+	table      *table
+	label      *decredmaterial.Label
+	link       string
+	lists      []listState
+	quoteDepth int
 
 	stringBuilder strings.Builder
 	tagStack      []string
@@ -46,9 +75,8 @@ func RenderMarkdown(gtx C, theme *decredmaterial.Theme, source string) *Markdown
 	source = strings.Replace(source, " \n*", " \n\n *", -1)
 
 	mdProvider := &MarkdownProvider{
-		theme:          theme,
-		listItemNumber: -1,
-		label:          &lbl,
+		theme: theme,
+		label: &lbl,
 	}
 	source = mdProvider.prepare(source)
 
@@ -67,52 +95,85 @@ func (*MarkdownProvider) prepare(doc string) string {
 
 func (p *MarkdownProvider) Layout() ([]layout.Widget, map[string]*widget.Clickable) {
 	w := func(gtx C) D {
-		max := gtx.Constraints.Max.X
 		rows := layout.List{Axis: layout.Vertical}
 		return rows.Layout(gtx, len(p.containers), func(gtx C, i int) D {
-			return decredmaterial.GridWrap{
-				Axis:      layout.Horizontal,
-				Alignment: layout.Start,
-			}.Layout(gtx, len(p.containers[i].widgets), func(gtx C, j int) D {
-				gtx.Constraints.Max.X = max
-				return p.containers[i].widgets[j](gtx)
-			})
+			return p.layoutRow(gtx, p.containers[i])
 		})
 	}
 
 	return []layout.Widget{w}, p.links
 }
 
+func (p *MarkdownProvider) layoutRow(gtx C, row layoutRow) D {
+	content := func(gtx C) D {
+		max := gtx.Constraints.Max.X
+		return decredmaterial.GridWrap{
+			Axis:      layout.Horizontal,
+			Alignment: layout.Start,
+		}.Layout(gtx, len(row.widgets), func(gtx C, j int) D {
+			gtx.Constraints.Max.X = max
+			return row.widgets[j].layout(gtx)
+		})
+	}
+
+	if row.indent > 0 {
+		item := content
+		content = func(gtx C) D {
+			return layout.Inset{
+				Left: unit.Dp(float32(listIndent * (row.indent - 1))),
+			}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Dp(unit.Dp(listIndent))
+						if row.marker == nil {
+							return D{Size: image.Pt(gtx.Constraints.Min.X, 0)}
+						}
+						return row.marker.Layout(gtx)
+					}),
+					layout.Flexed(1, item),
+				)
+			})
+		}
+	}
+
+	for i := 0; i < row.quote; i++ {
+		content = renderBlockQuote(content, p.theme)
+	}
+	return content(gtx)
+}
+
 func (p *MarkdownProvider) prepareBlockQuote(node *ast.BlockQuote, entering bool) {
-	p.openOrCloseTag(blockQuoteTagName, entering)
+	if entering {
+		p.quoteDepth++
+		p.createNewRow()
+		return
+	}
+
+	p.quoteDepth--
+	// The spacing after the last paragraph is not part of the quote.
+	p.containers[len(p.containers)-1].quote = p.quoteDepth
+	p.createNewRow()
 }
 
 func (p *MarkdownProvider) prepareCode(node *ast.Code, entering bool) {
-	content := string(node.Literal)
-	p.stringBuilder.WriteString(content)
+	p.openTag(codeTagName)
+	p.stringBuilder.Write(node.Literal)
+	p.closeTag()
 }
 
 func (p *MarkdownProvider) prepareCodeBlock(node *ast.CodeBlock, entering bool) {
-	content := string(node.Literal)
+	lines := codeLabels(string(node.Literal), p.theme)
 	p.createNewRow()
-	wdg := func(gtx C) D {
-		return decredmaterial.LinearLayout{
-			Orientation: layout.Vertical,
-			Width:       decredmaterial.WrapContent,
-			Height:      decredmaterial.WrapContent,
-			Background:  p.theme.Color.Background,
-			Padding:     layout.UniformInset(values.MarginPadding16),
-		}.Layout(gtx,
-			layout.Rigid(func(gtx C) D {
-				return p.theme.Body1(content).Layout(gtx)
-			}),
-		)
-	}
-
-	p.appendToLastRow(wdg)
+	p.appendToLastRow(rowItem{layout: renderCodeBlock(lines, p.theme), labels: lines})
 	p.addVerticalSpacing(15)
 }
 
+func (p *MarkdownProvider) prepareImage(node *ast.Image) {
+	p.openTag(imageTagName)
+	p.stringBuilder.WriteString(values.StringF(values.StrImagePlaceholder, imageAltText(node)))
+	p.closeTag()
+}
+
 func (p *MarkdownProvider) renderSoftBreak() {
 	p.createNewRow()
 }
@@ -126,7 +187,7 @@ func (p *MarkdownProvider) prepareStrong(node *ast.Strong, entering bool) {
 }
 
 func (p *MarkdownProvider) prepareDel(node *ast.Del, entering bool) {
-	p.openOrCloseTag(blockQuoteTagName, entering)
+	p.openOrCloseTag(strikeTagName, entering)
 }
 
 func (p *MarkdownProvider) prepareEmph(node *ast.Emph, entering bool) {
@@ -135,62 +196,59 @@ func (p *MarkdownProvider) prepareEmph(node *ast.Emph, entering bool) {
 
 func (p *MarkdownProvider) prepareHorizontalRule(node *ast.HorizontalRule, entering bool) {
 	p.drawLineRow(layout.Horizontal)
+	p.addVerticalSpacing(15)
+	p.createNewRow()
 }
 
 func (p *MarkdownProvider) prepareList(node *ast.List, entering bool) {
 	if entering {
-		p.listItemNumber = 1
-		p.prefix = bulletUnicode + " "
-	} else {
-		p.listItemNumber = -1
+		list := listState{ordered: node.ListFlags&ast.ListTypeOrdered != 0}
+		if node.Start > 0 {
+			list.number = node.Start - 1
+		}
+		p.lists = append(p.lists, list)
+		return
 	}
 
-	if next := ast.GetNextNode(node); !entering && next != nil {
-		_, parentIsListItem := node.GetParent().(*ast.ListItem)
-		_, nextIsList := next.(*ast.List)
-		if !nextIsList && !parentIsListItem {
-			p.listItemNumber = -1
-		}
+	p.lists = p.lists[:len(p.lists)-1]
+	if len(p.lists) == 0 {
+		p.createNewRow()
+		p.addVerticalSpacing(15)
 	}
 }
 
-func (p *MarkdownProvider) renderListItem(content string) {
-	if strings.Trim(content, " ") == "" {
+func (p *MarkdownProvider) prepareListItem(node *ast.ListItem, entering bool) {
+	if !entering {
+		p.renderBlock()
 		return
 	}
 
-	w := func(gtx C) D {
-		lbl := p.getLabel()
-		strongLabel := p.getLabel()
-		strongLabel.Font.Weight = text.Bold
-
-		return layout.Flex{}.Layout(gtx,
-			layout.Flexed(0.02, func(gtx C) D {
-				strongLabel.Text = ""
-				return strongLabel.Layout(gtx)
-			}),
-			layout.Flexed(0.05, func(gtx C) D {
-				strongLabel.Text = p.prefix
-				return strongLabel.Layout(gtx)
-			}),
-			layout.Flexed(1, func(gtx C) D {
-				lbl.Text = content
-				return lbl.Layout(gtx)
-			}),
-		)
-	}
-
 	p.createNewRow()
-	p.appendToLastRow(w)
-}
+	if node.ListFlags&(ast.ListTypeTerm|ast.ListTypeDefinition) != 0 {
+		return
+	}
 
-func (p *MarkdownProvider) prepareListItem(node *ast.ListItem, entering bool) {
+	list := &p.lists[len(p.lists)-1]
+	list.number++
+	marker := p.theme.Body1(bulletUnicode)
+	if list.ordered {
+		marker.Text = fmt.Sprintf("%d.", list.number)
+	}
+	marker.Font.Weight = text.Bold
+	if p.quoteDepth > 0 {
+		marker.Color = p.theme.Color.GrayText2
+	}
 
+	p.containers[len(p.containers)-1].marker = &marker
 }
 
 func (p *MarkdownProvider) prepareParagraph(node *ast.Paragraph, entering bool) {
 	if !entering {
 		p.renderBlock()
+		// Items in a list are not spaced like paragraphs.
+		if len(p.lists) > 0 {
+			return
+		}
 		p.createNewRow()
 		p.addVerticalSpacing(15)
 	}
@@ -201,7 +259,8 @@ func (p *MarkdownProvider) prepareHeading(node *ast.Heading, entering bool) {
 		content := p.stringBuilder.String()
 		p.stringBuilder.Reset()
 		p.createNewRow()
-		p.appendToLastRow(getHeading(content, node.Level, p.theme).Layout)
+		heading := getHeading(content, node.Level, p.theme)
+		p.appendToLastRow(rowItem{layout: heading.Layout, labels: []decredmaterial.Label{heading}})
 		p.addVerticalSpacing(8)
 		if node.Level == 1 {
 			p.drawLineRow(layout.Horizontal)
@@ -211,116 +270,140 @@ func (p *MarkdownProvider) prepareHeading(node *ast.Heading, entering bool) {
 }
 
 func (p *MarkdownProvider) prepareLink(node *ast.Link, entering bool) {
+	// The text before the link is rendered first so that only the text of
+	// the link is clickable.
 	p.renderBlock()
+	if !entering {
+		p.link = ""
+		return
+	}
+
+	p.link = string(node.Destination)
+	if p.links == nil {
+		p.links = map[string]*widget.Clickable{}
+	}
+	if _, ok := p.links[p.link]; !ok {
+		p.links[p.link] = new(widget.Clickable)
+	}
 }
 
 func (p *MarkdownProvider) renderBlock() {
 	content := p.stringBuilder.String()
 	p.stringBuilder.Reset()
 
-	var inBlock bool
-	var isGettingTagName bool
-	var isClosingBlock bool
-	var currentTag string
 	currText := new(strings.Builder)
-	for i := range content {
-		curr := content[i]
-
-		if curr == openTagPrefix[0] && getNextChar(content, i) == openTagPrefix[1] {
-			p.render(currText)
-			isGettingTagName = true
-			inBlock = true
-			currentTag = string(curr)
-			continue
-		} else if !inBlock {
-			currText.WriteByte(curr)
-			if i+1 == len(content) || curr == openTagPrefix[0] || curr == closeTag[0] {
-				p.render(currText)
-			}
-			continue
-		}
-
-		if isGettingTagName {
-			currentTag += string(curr)
-			if curr == openTagSuffix[1] {
-				isGettingTagName = false
-				currentTag = strings.Replace(currentTag, openTagPrefix, "", -1)
-				currentTag = strings.Replace(currentTag, openTagSuffix, "", -1)
-				p.pushTag(currentTag)
-				currentTag = ""
-			}
-			continue
-		}
-
-		if curr == closeTag[0] && getNextChar(content, i) == closeTag[1] {
-			isClosingBlock = true
-			continue
-		}
-
-		if isClosingBlock {
-			if curr == closeTag[2] {
-				p.render(currText)
-				p.popTag()
-				isClosingBlock = false
-				inBlock = false
+	for i := 0; i < len(content); {
+		switch {
+		case strings.HasPrefix(content[i:], openTagPrefix):
+			end := strings.Index(content[i:], openTagSuffix)
+			if end < 0 {
+				currText.WriteString(content[i:])
+				i = len(content)
+				continue
 			}
-			continue
+			p.render(currText)
+			p.pushTag(content[i+len(openTagPrefix) : i+end])
+			i += end + len(openTagSuffix)
+		case strings.HasPrefix(content[i:], closeTag):
+			p.render(currText)
+			p.popTag()
+			i += len(closeTag)
+		default:
+			currText.WriteByte(content[i])
+			i++
 		}
-		currText.WriteByte(curr)
 	}
+	p.render(currText)
 }
 
 func (p *MarkdownProvider) getLabel() decredmaterial.Label {
 	lbl := p.theme.Body1("")
-	if len(p.tagStack) > 0 {
-		for i := range p.tagStack {
-			switch p.tagStack[i] {
-			case strongTagName:
-				setWeight(&lbl, "bold")
-			case emphTagName:
-				setStyle(&lbl, "italic")
-			case listItemTagName:
-				//p.createNewRow()
-			}
+	if p.quoteDepth > 0 {
+		lbl.Color = p.theme.Color.GrayText2
+	}
+
+	for i := range p.tagStack {
+		switch p.tagStack[i] {
+		case strongTagName:
+			setWeight(&lbl, "bold")
+		case emphTagName:
+			setStyle(&lbl, "italic")
+		case codeTagName:
+			lbl.Font.Variant = monoVariant
+		case imageTagName:
+			setStyle(&lbl, "italic")
+			lbl.Color = p.theme.Color.GrayText2
 		}
 	}
 
 	return lbl
 }
 
+func (p *MarkdownProvider) isStruck() bool {
+	for i := range p.tagStack {
+		if p.tagStack[i] == strikeTagName {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *MarkdownProvider) render(content *strings.Builder) {
 	lbl := p.getLabel()
-	words := strings.Fields(content.String())
+	strike := p.isStruck()
+	str := content.String()
+	words := strings.Fields(str)
 	content.Reset()
 
+	var clickable *widget.Clickable
+	if p.link != "" {
+		clickable = p.links[p.link]
+		lbl.Color = p.theme.Color.Primary
+	}
+
 	for index := range words {
 		lbl.Text = words[index] + " "
-		p.appendToLastRow(lbl.Layout)
+		// The words at the ends are kept next to the text around them, such
+		// as punctuation after emphasis, unless a space separates them.
+		if index == len(words)-1 && !unicode.IsSpace(rune(str[len(str)-1])) {
+			lbl.Text = words[index]
+		}
+		if index == 0 && unicode.IsSpace(rune(str[0])) {
+			lbl.Text = " " + lbl.Text
+		}
+		wdgt := lbl.Layout
+		if strike {
+			wdgt = renderStrike(lbl, p.theme)
+		}
+		if clickable != nil {
+			wdgt = renderLink(clickable, wdgt)
+		}
+		p.appendToLastRow(rowItem{layout: wdgt, labels: []decredmaterial.Label{lbl}, strike: strike, link: p.link})
 	}
 }
 
 func (p *MarkdownProvider) addVerticalSpacing(height int) {
-	p.appendToLastRow(func(gtx C) D {
+	p.appendToLastRow(rowItem{layout: func(gtx C) D {
 		dims := p.theme.Caption(" ").Layout(gtx)
 		dims.Size.X = gtx.Constraints.Max.X
 		dims.Size.Y = height
 		return dims
-	})
+	}})
 }
 
 func (p *MarkdownProvider) createNewRow() {
-	row := layoutRow{}
+	row := layoutRow{quote: p.quoteDepth, indent: len(p.lists)}
 	p.containers = append(p.containers, row)
 }
 
-func (p *MarkdownProvider) appendToLastRow(wdgt layout.Widget) {
+func (p *MarkdownProvider) appendToLastRow(item rowItem) {
 	if len(p.containers) == 0 {
 		p.createNewRow()
 	}
 
 	l := len(p.containers)
 	lastRow := p.containers[l-1]
-	lastRow.widgets = append(lastRow.widgets, wdgt)
+	lastRow.widgets = append(lastRow.widgets, item)
 	p.containers[l-1] = lastRow
 }
 
@@ -334,7 +417,7 @@ func (p *MarkdownProvider) drawLineRow(axis layout.Axis) {
 	}
 
 	p.createNewRow()
-	p.appendToLastRow(l.Layout)
+	p.appendToLastRow(rowItem{layout: l.Layout})
 }
 
 func (p *MarkdownProvider) prepareText(node *ast.Text, entering bool) {
@@ -347,11 +430,6 @@ func (p *MarkdownProvider) prepareText(node *ast.Text, entering bool) {
 		content = removeLineBreak(content)
 	}
 
-	if p.listItemNumber > -1 {
-		p.renderListItem(content)
-		return
-	}
-
 	p.stringBuilder.WriteString(content)
 }
 
@@ -360,7 +438,7 @@ func (p *MarkdownProvider) prepareTable(node *ast.Table, entering bool) {
 		p.table = newTable(p.theme)
 	} else {
 		p.createNewRow()
-		p.appendToLastRow(p.table.render())
+		p.appendToLastRow(rowItem{layout: p.table.render()})
 		p.table = nil
 	}
 }
@@ -2,6 +2,8 @@ package renderers
 
 import (
 	"io"
+	"path"
+	"strings"
 
 	md "github.com/gomarkdown/markdown"
 	"github.com/gomarkdown/markdown/ast"
@@ -25,6 +27,7 @@ type renderer interface {
 	prepareTableCell(node *ast.TableCell, entering bool)
 	prepareTableRow(node *ast.TableRow, entering bool)
 	prepareHorizontalRule(node *ast.HorizontalRule, entering bool)
+	prepareImage(node *ast.Image)
 	renderSoftBreak()
 	renderHardBreak()
 }
@@ -92,10 +95,15 @@ func (nw *nodeWalker) RenderNode(w io.Writer, node ast.Node, entering bool) ast.
 	case *ast.Emph:
 		nw.renderer.prepareEmph(node, entering)
 	case *ast.Link:
+		nw.renderer.prepareLink(node, entering)
 		if !entering {
-			nw.renderer.prepareLink(node, entering)
 			return ast.SkipChildren
 		}
+	case *ast.Image:
+		if entering {
+			nw.renderer.prepareImage(node)
+		}
+		return ast.SkipChildren
 	case *ast.Softbreak:
 		nw.renderer.renderSoftBreak()
 	case *ast.Hardbreak:
@@ -119,3 +127,18 @@ func (nw *nodeWalker) RenderNode(w io.Writer, node ast.Node, entering bool) ast.
 func (*nodeWalker) RenderHeader(w io.Writer, node ast.Node) {}
 
 func (*nodeWalker) RenderFooter(w io.Writer, node ast.Node) {}
+
+// imageAltText returns the alternative text of the image, or the name of the
+// image file if it has none.
+func imageAltText(node *ast.Image) string {
+	var alt strings.Builder
+	for _, child := range node.GetChildren() {
+		if leaf := child.AsLeaf(); leaf != nil {
+			alt.Write(leaf.Literal)
+		}
+	}
+	if alt.Len() == 0 {
+		return path.Base(string(node.Destination))
+	}
+	return alt.String()
+}
@@ -0,0 +1,190 @@
+package renderers
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gioui.org/font/gofont"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/text"
+	"gioui.org/unit"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+var markdownTests = []struct {
+	name   string
+	source string
+}{
+	{
+		name:   "inline",
+		source: "Some **bold**, *italic*, ~~struck~~ and `inline code` text.",
+	},
+	{
+		name:   "blockquote",
+		source: "Before\n\n> A quoted **line**\n>\n> > nested quote\n\nAfter",
+	},
+	{
+		name:   "code_block",
+		source: "```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```\n",
+	},
+	{
+		name:   "lists",
+		source: "1. first\n2. second\n    - nested one\n    - nested two\n3. third\n\n- bullet\n",
+	},
+	{
+		name:   "links",
+		source: "Read [the **proposal**](https://proposals.decred.org/record/abc) or https://decred.org.",
+	},
+	{
+		name:   "rule_and_image",
+		source: "Above\n\n---\n\n![Decred logo](https://decred.org/logo.png) and ![](https://decred.org/chart.svg)",
+	},
+}
+
+var htmlTests = []struct {
+	name   string
+	source string
+}{
+	{
+		name:   "spans",
+		source: `<span style="text-color: gray">Plain <span style="font-weight: bold">bold</span> text.<br>Next line.</span>`,
+	},
+	{
+		name:   "inline",
+		source: `<p>Use <code>dcrctl</code>, <del>old</del> and <em>emphasis</em>.</p>`,
+	},
+	{
+		name: "blocks",
+		source: `<blockquote><p>Quoted <b>text</b></p></blockquote><pre><code>line one
+    line two</code></pre><hr><p><img alt="chart" src="chart.png"></p>`,
+	},
+	{
+		name:   "lists",
+		source: `<ol><li>one<ul><li>nested</li></ul></li><li>two</li></ol>`,
+	},
+}
+
+func testTheme() *decredmaterial.Theme {
+	// The theme requires the expand and collapse icons.
+	icon := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	icons := map[string]image.Image{"expand_icon": icon, "collapse_icon": icon}
+	return decredmaterial.NewTheme(gofont.Collection(), icons, false)
+}
+
+// testContext returns a context laying out widgets at most 400px wide.
+func testContext() layout.Context {
+	return layout.Context{
+		Ops:         new(op.Ops),
+		Constraints: layout.Exact(image.Pt(400, 10000)),
+		Metric:      unit.Metric{PxPerDp: 1, PxPerSp: 1},
+	}
+}
+
+// describeLabel returns the text and font of the label.
+func describeLabel(lbl decredmaterial.Label) string {
+	style := "regular"
+	if lbl.Font.Style == text.Italic {
+		style = "italic"
+	}
+	// Weights are relative to the normal CSS weight.
+	return fmt.Sprintf("%q variant=%q style=%s weight=%d", lbl.Text, lbl.Font.Variant, style, lbl.Font.Weight+400)
+}
+
+// describeItems writes a line for each label drawn by the items and for each
+// item without labels.
+func describeItems(b *strings.Builder, items []rowItem) {
+	for _, item := range items {
+		if len(item.labels) == 0 {
+			b.WriteString("  widget\n")
+			continue
+		}
+		for _, lbl := range item.labels {
+			fmt.Fprintf(b, "  label %s", describeLabel(lbl))
+			if item.strike {
+				b.WriteString(" strike")
+			}
+			if item.link != "" {
+				fmt.Fprintf(b, " link=%s", item.link)
+			}
+			b.WriteString("\n")
+		}
+	}
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+	theme := testTheme()
+	for _, test := range markdownTests {
+		provider := RenderMarkdown(layout.Context{}, theme, test.source)
+
+		var b strings.Builder
+		for _, row := range provider.containers {
+			fmt.Fprintf(&b, "row quote=%d indent=%d", row.quote, row.indent)
+			if row.marker != nil {
+				fmt.Fprintf(&b, " marker=%s", describeLabel(*row.marker))
+			}
+			b.WriteString("\n")
+			describeItems(&b, row.widgets)
+		}
+		checkGolden(t, "markdown_"+test.name, b.String())
+
+		// The document is laid out to catch rendering panics.
+		widgets, _ := provider.Layout()
+		widgets[0](testContext())
+	}
+}
+
+func TestMarkdownLinks(t *testing.T) {
+	source := "Read [the proposal](https://proposals.decred.org/record/abc) or https://decred.org."
+	provider := RenderMarkdown(layout.Context{}, testTheme(), source)
+	_, links := provider.Layout()
+	for _, link := range []string{"https://proposals.decred.org/record/abc", "https://decred.org"} {
+		if links[link] == nil {
+			t.Errorf("expected a clickable for %s", link)
+		}
+	}
+}
+
+func TestHTMLRenderer(t *testing.T) {
+	theme := testTheme()
+	for _, test := range htmlTests {
+		provider := RenderHTML(test.source, theme)
+
+		var b strings.Builder
+		for _, row := range provider.containers {
+			fmt.Fprintf(&b, "row quote=%d indent=%d\n", row.quote, row.indent)
+			describeItems(&b, row.items)
+		}
+		checkGolden(t, "html_"+test.name, b.String())
+
+		// The document is laid out to catch rendering panics.
+		provider.Layout(testContext())
+	}
+}
+
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s: unexpected output\ngot:\n%s\nwant:\n%s", name, got, want)
+	}
+}
@@ -6,10 +6,15 @@ import (
 	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
 )
 
+// monoVariant is the font variant used for code.
+const monoVariant text.Variant = "Mono"
+
 func getLabel(lbl decredmaterial.Label) decredmaterial.Label {
 	return lbl
 }
@@ -90,41 +95,60 @@ func renderStrike(lbl decredmaterial.Label, theme *decredmaterial.Theme) layout.
 	}
 }
 
-func renderBlockQuote(lbl decredmaterial.Label, theme *decredmaterial.Theme) layout.Widget {
-	words := strings.Fields(lbl.Text)
-
+// renderBlockQuote draws a bar along the left edge of the quoted content.
+func renderBlockQuote(content layout.Widget, theme *decredmaterial.Theme) layout.Widget {
 	return func(gtx C) D {
-		var dims D
-
-		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
-			layout.Flexed(1, func(gtx C) D {
-				l := theme.SeparatorVertical(dims.Size.Y, 10)
+		return layout.Stack{}.Layout(gtx,
+			layout.Stacked(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding12}.Layout(gtx, content)
+			}),
+			layout.Expanded(func(gtx C) D {
+				l := theme.SeparatorVertical(gtx.Constraints.Min.Y, gtx.Dp(values.MarginPadding4))
 				l.Color = theme.Color.Gray2
 				return l.Layout(gtx)
 			}),
-			layout.Rigid(func(gtx C) D {
-				dims = layout.Inset{
-					Left: unit.Dp(4),
-				}.Layout(gtx, func(gtx C) D {
-					return decredmaterial.GridWrap{
-						Axis:      layout.Horizontal,
-						Alignment: layout.Start,
-					}.Layout(gtx, len(words), func(gtx C, i int) D {
-						lbl.Text = words[i] + " "
-						return lbl.Layout(gtx)
-					})
-				})
-
-				return dims
-			}),
 		)
 	}
 }
 
+// codeLabels returns a monospace label for each line of the code block.
+func codeLabels(code string, theme *decredmaterial.Theme) []decredmaterial.Label {
+	code = strings.TrimSuffix(strings.Replace(code, "\t", "    ", -1), "\n")
+	lines := strings.Split(code, "\n")
+	labels := make([]decredmaterial.Label, len(lines))
+	for i := range lines {
+		labels[i] = theme.Body2(lines[i])
+		labels[i].Font.Variant = monoVariant
+	}
+	return labels
+}
+
+func renderCodeBlock(lines []decredmaterial.Label, theme *decredmaterial.Theme) layout.Widget {
+	children := make([]layout.FlexChild, len(lines))
+	for i := range lines {
+		children[i] = layout.Rigid(lines[i].Layout)
+	}
+
+	return func(gtx C) D {
+		return decredmaterial.LinearLayout{
+			Orientation: layout.Vertical,
+			Width:       decredmaterial.MatchParent,
+			Height:      decredmaterial.WrapContent,
+			Background:  theme.Color.Gray4,
+			Padding:     layout.UniformInset(values.MarginPadding12),
+		}.Layout(gtx, children...)
+	}
+}
+
+// renderLink makes the widget open the link when clicked.
+func renderLink(clickable *widget.Clickable, wdgt layout.Widget) layout.Widget {
+	return func(gtx C) D {
+		return material.Clickable(gtx, clickable, wdgt)
+	}
+}
+
 func renderHorizontalLine(theme *decredmaterial.Theme) layout.Widget {
-	l := theme.Separator()
-	l.Width = 1
-	return l.Layout
+	return theme.Separator().Layout
 }
 
 func renderEmptyLine(theme *decredmaterial.Theme, isList bool) layout.Widget {
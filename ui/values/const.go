@@ -5,8 +5,7 @@ const (
 	StakingDropdownGroup
 	ProposalDropdownGroup
 	ConsensusDropdownGroup
+	ProposalCommentsDropdownGroup
+	ProposalVersionsDropdownGroup
+	DexOrderHistoryDropdownGroup
 )
-
-// TODO: move this to the dcrlibwallet
-// DEXClientPass use for DEX since the UI not required password.
-const DEXClientPass = "DEXClientPass"
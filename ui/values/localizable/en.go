@@ -507,4 +507,365 @@ unlockWithPassword = "Unlock with password"
 "confirmUmixedSpending" = "Confirm to allow spending from unmixed accounts"
 "ok" = "OK"
 "accountMixer" = "AccountMixer"
+"ticketNotifications" = "Ticket notifications"
+"matured" = "Matured"
+"missed" = "Missed"
+"vspFeeErrored" = "VSP fee payment failed"
+"ticketPurchasedNotif" = "A ticket was purchased"
+"ticketMaturedNotif" = "A ticket has matured and is now live"
+"ticketMissedNotif" = "A ticket missed its vote"
+"ticketExpiredNotif" = "A ticket has expired"
+"vspFeeErroredNotif" = "The VSP fee payment for a ticket failed"
+"purchaseRules" = "Purchase rules (optional)"
+"maxTicketPrice" = "Max ticket price (DCR)"
+"maxTicketsPerDay" = "Max tickets per day"
+"maxTicketsPerWindow" = "Max tickets per window"
+"activeHoursStart" = "Active from (hour)"
+"activeHoursEnd" = "Active until (hour)"
+"pauseAbovePercent" = "Pause above average (%)"
+"averagePeriod" = "Average period (windows)"
+"invalidActiveHours" = "Active hours must be between 0 and 23"
+"invalidRuleValue" = "Purchase rules must be positive numbers"
+"ruleEvaluationLog" = "Ticket buyer log"
+"noRuleEvaluations" = "No rule evaluations yet"
+"rulesPassed" = "%d tickets allowed"
+"ruleLowBalance" = "Insufficient balance"
+"rulePriceAboveMax" = "Ticket price above max price"
+"ruleOutsideActiveHours" = "Outside active hours"
+"ruleDailyLimitReached" = "Daily ticket limit reached"
+"ruleWindowLimitReached" = "Window ticket limit reached"
+"rulePriceAboveAverage" = "Ticket price above moving average"
+"rulePriceUnavailable" = "Ticket price unavailable"
+"manageVSPs" = "Manage VSPs"
+"vspStatusOnline" = "Online"
+"vspStatusOffline" = "Offline"
+"vspStatusChecking" = "Checking..."
+"vspClosed" = "Closed"
+"lastSeen" = "Last seen: %s"
+"never" = "Never"
+"vspTicketStats" = "Voting: %d, voted: %d, revoked: %d"
+"customVSP" = "Custom"
+"vspDownWarning" = "The VSP %s is not reachable. Tickets purchased now may not be registered with the VSP."
+"vspAdded" = "VSP added"
+"problemTicketsOnly" = "Problem tickets only"
+"checkVSPStatus" = "Check VSP status"
+"noProblemTickets" = "No problem tickets"
+"ticketVSPStatus" = "Ticket VSP status"
+"feeStatus" = "Fee status"
+"confirmedByVSP" = "Confirmed by VSP"
+"notConfirmedByVSP" = "Not confirmed by VSP"
+"vspStatusUpdated" = "VSP status updated"
+"feeProcessStarted" = "Fee payment started"
+"feePaid" = "Fee paid"
+"feeConfirmed" = "Fee confirmed"
+"exportCSV" = "Export CSV"
+"exportJSON" = "Export JSON"
+"exportedTo" = "Exported to %s"
+"ticketReport" = "Ticket report"
+"noSearchResults" = "No results found for %s"
+"watched" = "Watched"
+"watchProposal" = "Watch"
+"unwatchProposal" = "Unwatch"
+"noWatchedProposals" = "No watched proposals. Open a proposal and click Watch to be notified of its updates."
+"proposalVersionNotif" = "Proposal %s was updated to version %s"
+"proposalMilestoneNotif" = "%d%% of eligible tickets have voted on proposal %s"
+"proposalQuorumNotif" = "Proposal %s reached quorum"
+"proposalPassingNotif" = "Proposal %s is above the pass threshold"
+"proposalFailingNotif" = "Proposal %s fell below the pass threshold"
+"proposalApprovedNotif" = "Proposal %s was approved"
+"proposalRejectedNotif" = "Proposal %s was rejected"
+"votingHistory" = "Voting history"
+"auditVotes" = "Audit votes"
+"notVoted" = "Not voted"
+"noVotingHistory" = "No votes found. Audit votes to include the votes cast outside this wallet and the tickets that did not vote."
+"filterByTicket" = "Filter by ticket hash"
+"votingInProgressOutcome" = "Voting"
+"votesAudited" = "Votes audited"
+"comments" = "Comments"
+"commentsCount" = "Comments (%d)"
+"top" = "Top"
+"noComments" = "No comments yet"
+"commentDeleted" = "This comment was deleted"
+"commentPoints" = "%d points"
+"commentsUpdated" = "Updated %s"
+"commentsOffline" = "Unable to fetch comments, showing comments saved %s"
+"compareVersions" = "Compare versions"
+"versionChanges" = "Changes"
+"fromVersion" = "From"
+"toVersion" = "To"
+"noVersionChanges" = "The versions are identical"
+"imagePlaceholder" = "[Image: %s]"
+"openLinkTitle" = "Open external link?"
+"openLinkInfo" = "This link leads outside the app. Check the full address before opening it."
+"openLink" = "Open link"
+"copyURL" = "Copy link"
+"trustLinkDomain" = "Always open links to %s without asking"
+"invalidLink" = "The link is not valid"
+"linkUnsafeScheme" = "This is not a web link, it can only be copied. Only use it if you trust where it came from."
+"linkUserInfo" = "The text before the @ sign hides the real address. This link leads to %s."
+"linkInternational" = "The domain has international characters that can imitate other letters."
+"linkLookalike" = "The domain looks like %s but is a different website."
+"trustedDomains" = "Trusted link domains"
+"trustedDomainsInfo" = "Links to these domains and their subdomains open without confirmation."
+"noTrustedDomains" = "No trusted domains"
+"addDomain" = "Add domain"
+"domainHint" = "Domain, e.g. decred.org"
+"invalidDomain" = "Enter a valid domain name"
+"treasuryHistory" = "Treasury history"
+"treasuryBalanceHistory" = "Balance history"
+"treasuryMonthlySpending" = "Monthly spending"
+"treasurySpent" = "Total spent"
+"tspends" = "Treasury spends"
+"tspendTickets" = "%d of your tickets voted during the voting window"
+"noTSpends" = "No treasury spends found"
+"noTreasuryHistory" = "Treasury history has not been fetched yet"
+"treasuryUpdated" = "Updated %s"
+"treasuryOffline" = "Unable to fetch treasury history, showing data saved %s"
+"fraction" = "%s %s%%"
+"refresh" = "Refresh"
+"fetchingTreasuryHistory" = "Fetching treasury history..."
+"votePerTicket" = "Vote per ticket"
+"ticketVoteChoices" = "Ticket vote choices"
+"ticketVoteChoicesInfo" = "Select tickets and set their vote choice. Sync from VSP to check the choices registered with your VSPs."
+"selectAll" = "Select all"
+"setSelectedTo" = "Set selected to %s"
+"syncFromVSP" = "Sync from VSP"
+"vspChoice" = "VSP: %s"
+"noVSP" = "No VSP"
+"choiceSummary" = "%s: %d tickets"
+"ticketsToUpdate" = "%d tickets will be updated"
+"noChoiceChanges" = "No vote choices were changed"
+"choicesSynced" = "Vote choices synced from VSPs"
+"choiceUpdateFailed" = "%d tickets could not be updated"
+"confirmVoteChoices" = "Confirm vote choices"
+"saveForOffline" = "Save for offline reading"
+"removeOfflineCopy" = "Remove offline copy (saved %s)"
+"offlineCopyOutdated" = "Update outdated offline copy"
+"downloadingProposal" = "Downloading proposal..."
+"savedForOffline" = "Proposal saved for offline reading"
+"offlineCopyRemoved" = "Offline copy removed"
+"exportMarkdown" = "Export as Markdown"
+"exportHTML" = "Export as HTML"
+"offlineProposals" = "Offline proposals"
+"readOfflineProposals" = "Read offline proposals (%d)"
+"noOfflineProposals" = "No proposals saved for offline reading. Open a proposal and save it to read it here without syncing."
+"savedAgo" = "Saved %s"
+"mixerActivity" = "Mixer activity"
+"mixerSessions" = "Sessions"
+"timeMixing" = "Time mixing"
+"mixedTransactions" = "Mixed transactions"
+"amountMixed" = "Amount mixed"
+"denominationOutputs" = "%d × %s"
+"mixerFailures" = "Failures"
+"unmixedRemaining" = "Unmixed balance remaining"
+"noMixerActivity" = "The mixer has not run on this wallet yet."
+"mixerRunningSince" = "Mixing since %s"
+"mixerRanFor" = "Mixed for %s, %s"
+"mixerFailedToStart" = "Mixer failed to start, %s"
+"mixerSessionResult" = "%d mixed transactions, %s mixed"
+"unmixedChange" = "Unmixed balance: %s → %s"
+"mixerStoppedByUser" = "Stopped by you"
+"mixerInterrupted" = "Mixer interrupted when the app closed, started %s"
+"scheduledMixing" = "Scheduled mixing"
+"scheduledMixingDesc" = "Mix until a goal is reached, optionally only during set hours of the day."
+"mixerSchedule" = "Mixer schedule"
+"mixTargetAmount" = "Amount to mix (DCR)"
+"mixTimeBudget" = "Time budget (hours)"
+"stopWhenUnmixedEmpty" = "Stop when the unmixed account is empty"
+"mixFromHour" = "Mix from (hour)"
+"mixUntilHour" = "Mix until (hour)"
+"invalidScheduleValue" = "Schedule values must be positive numbers"
+"scheduleNeedsGoal" = "Set an amount, a time budget or stop when the unmixed account is empty"
+"editSchedule" = "Edit schedule"
+"startSchedule" = "Start schedule"
+"stopSchedule" = "Stop schedule"
+"confirmScheduledMixing" = "Confirm to start scheduled mixing"
+"scheduledMixingDetails" = "The passphrase is kept in memory until the schedule ends so the mixer can restart in each mixing window."
+"scheduleMixing" = "Mixing"
+"scheduleWaiting" = "Waiting for the mixing window"
+"scheduleTargetReached" = "Target amount mixed"
+"scheduleEmpty" = "Unmixed account empty"
+"scheduleBudgetUsed" = "Time budget used"
+"scheduleCancelled" = "Schedule stopped"
+"scheduleMixedOf" = "%s of %s mixed"
+"scheduleMixed" = "%s mixed"
+"scheduleTimeOf" = "%s of %s mixing time"
+"scheduleWindow" = "Mixing between %d:00 and %d:00"
+"noSchedule" = "No schedule set"
+"privacyWarnings" = "Privacy warnings"
+"checkingPrivacy" = "Checking transaction privacy..."
+"mergesMixedInputs" = "Mixed and unmixed funds may be combined"
+"mergesMixedInputsDesc" = "The sending account holds both mixed and unmixed outputs. Spending them together in one transaction links your mixed funds to their unmixed history."
+"traceableChange" = "Change output can be traced"
+"traceableChangeDesc" = "This payment leaves change that can be told apart from the payment, linking the rest of your funds to it. Sending the whole balance or a less round amount avoids this."
+"addressReused" = "Address already used"
+"addressReusedDesc" = "This address was used by a transaction %s. Sending to it again lets anyone link both transactions. Ask the recipient for a new address."
+"amountRepeated" = "Same amount as a previous payment"
+"amountRepeatedDesc" = "You sent exactly this amount %s. Repeating an amount makes payments to the same recipient easy to link."
+"sendAnyway" = "I understand, send anyway"
+"login" = "Login"
+"startSyncToUse" = "Start sync to continue"
+"walletPassword" = "Wallet Password"
+"selectAccountForDex" = "Select DCR account to use with DEX"
+"waitingConfirms" = "Waiting for confirmations..."
+"dexAddr" = "DEX Address"
+"customServer" = "Custom Server"
+"addADex" = "Add a dex"
+"addA" = "Add a"
+"tlsCert" = "TLS Certificate"
+"register" = "Register"
+"confirmReg" = "Confirm Registration"
+"requireWalletPayFee" = "Your wallet is required to pay registration fees."
+"confirmSelectAssetPayFee" = "How will you pay the registration fee?"
+"setupNeeded" = "Setup Needed"
+"walletReady" = "Wallet Ready"
+"market" = "Market"
+"allMarketAt" = "All markets at"
+"lotSize" = "Lot Size"
+"successful" = "Successfully!"
+"buy" = "Buy"
+"sell" = "Sell"
+"limit" = "Limit"
+"marketOrder" = "Market"
+"quantity" = "Quantity"
+"price" = "Price"
+"placeOrder" = "Place Order"
+"orderBook" = "Order Book"
+"emptyOrderBook" = "The order book is empty"
+"recentTrades" = "Recent Trades"
+"noRecentTrades" = "No recent trades"
+"openOrders" = "Open Orders"
+"noOpenOrders" = "No open orders"
+"noSupportedMarkets" = "This DEX has no supported markets"
+"side" = "Side"
+"filled" = "Filled"
+"time" = "Time"
+"cancelling" = "Cancelling"
+"orderPlaced" = "Order placed"
+"cancelRequested" = "Order cancellation requested"
+"invalidQuantity" = "Enter a valid quantity"
+"qtyNotLotMultiple" = "Quantity must be a multiple of the lot size"
+"invalidPrice" = "Enter a valid price"
+"priceNotRateStepMultiple" = "Price must be a multiple of the rate step"
+"noLiquidity" = "There are no orders to match on the other side of the book"
+"marketBuyTooSmall" = "Amount is too small to buy a lot at the best price"
+"orderHistory" = "Order History"
+"noOrders" = "No orders found"
+"allMarkets" = "All markets"
+"allStatuses" = "All statuses"
+"open" = "Open"
+"executed" = "Executed"
+"canceled" = "Canceled"
+"buyAndSell" = "Buy and sell"
+"allTime" = "All time"
+"last24Hours" = "Last 24 hours"
+"last7Days" = "Last 7 days"
+"last30Days" = "Last 30 days"
+"orderDetails" = "Order Details"
+"orderID" = "Order ID"
+"match" = "Match"
+"noMatches" = "This order has no matches"
+"swap" = "Swap"
+"counterSwap" = "Counterparty swap"
+"redeem" = "Redeem"
+"counterRedeem" = "Counterparty redeem"
+"refund" = "Refund"
+"dexServers" = "DEX Servers"
+"connected" = "Connected"
+"disconnected" = "Disconnected"
+"active" = "Active"
+"serverDisabled" = "Disabled"
+"feeAssets" = "Fee assets"
+"markets" = "Markets"
+"setActive" = "Set active"
+"enable" = "Enable"
+"updateCert" = "Update TLS certificate"
+"update" = "Update"
+"certUpdated" = "TLS certificate updated"
+"exportKeys" = "Export keys"
+"removeDex" = "Remove DEX server"
+"importAccount" = "Import account"
+"accountFilePath" = "Account keys file path"
+"noDexServers" = "No DEX servers registered"
+"allDexDisabled" = "All DEX servers are disabled"
+"dexPassword" = "DEX password"
+"confirmDexPassword" = "Confirm DEX password"
+"createDexPass" = "Create DEX password"
+"createDexPassDesc" = "The DEX password protects your DEX accounts and is required to trade."
+"setDexPass" = "Set a DEX password"
+"migrateDexPassDesc" = "Your DEX accounts were protected by a built-in password. Create a DEX password to protect them."
+"unlockDex" = "Unlock DEX"
+"unlockDexDesc" = "Enter your DEX password to start trading."
+"rememberDexPass" = "Remember the password until the app is closed"
+"changeDexPass" = "Change DEX password"
+"newDexPassword" = "New DEX password"
+"dexPassChanged" = "DEX password changed"
+"swapMonitor" = "Swap Monitor"
+"noActiveSwaps" = "No active swaps"
+"sendingSwap" = "Sending swap"
+"waitingCounterparty" = "Waiting for counterparty"
+"redeemingSwap" = "Redeeming"
+"complete" = "Complete"
+"refunded" = "Refunded"
+"refundDue" = "Lock time expired, the swap is refunded automatically"
+"refundNotNeeded" = "Not needed"
+"refundNow" = "Attempt refund now"
+"refundRequested" = "Refund requested"
+"maker" = "Maker"
+"taker" = "Taker"
+"role" = "Role"
+"yourSwap" = "Your swap"
+"lockTime" = "Lock time"
+"counterLockTime" = "Counterparty lock time"
+"matchRevoked" = "The match was revoked by the server"
+"portfolio" = "Portfolio"
+"noDexWallets" = "No DEX wallets"
+"totalValue" = "Total value"
+"fetchingRates" = "Fetching exchange rates..."
+"available" = "Available"
+"running" = "Running"
+"notRunning" = "Not running"
+"depositAddress" = "Deposit address"
+"newAddress" = "New address"
+"nameWallet" = "%s Wallet"
+"alreadyConnectWallet" = "Already connected a %s wallet"
+"numberConfirmations" = "%d confirmations"
+"connHostError" = "Connection to dex server %s failed. You can close app and try again later or wait for it to reconnect"
+"confirmationsStatus" = "In order to trade at %s, the registration fee payment needs %d confirmations."
+"keysExported" = "Account keys exported to %s. Keep this file private, it gives full control of the account."
+"removeDexWarning" = "Removing %s disables its account. Orders can no longer be placed on this server and the account can only be recovered by importing its exported keys. Export the keys before removing the server."
+"dexRemoved" = "%s removed"
+"dexImported" = "Account for %s imported"
+"confirmationsOf" = "(%d/%d confirmations)"
+"refundableIn" = "Refundable in %s if the counterparty does not redeem"
+"swapSentNotif" = "Swap sent for a %s match on %s"
+"swapRedeemedNotif" = "Swap redeemed for a %s match on %s"
+"refundDueNotif" = "The lock time of your %s swap on %s expired, it will be refunded"
+"refundSentNotif" = "Your %s swap on %s was refunded"
+"swapRevokedNotif" = "A %s match on %s was revoked"
+"noRateFor" = "Excludes %s, no exchange rate available"
+"invalidAmount" = "Invalid amount"
+"vsp" = "VSP"
+"dexAccountBackupAt" = "%v. The account keys are saved in %s, import them from the DEX servers page."
+"privacyCheckFailed" = "The privacy of the transaction could not be checked: %v"
+"sendWithoutCheck" = "Send without the privacy check"
+"ticketReportTitle" = "Ticket %s"
+"reportWallet" = "Wallet"
+"purchaseBlock" = "Purchase block"
+"ticketTxFee" = "Transaction fee"
+"vspFee" = "VSP fee"
+"vspFeeStatus" = "VSP fee status"
+"vspFeeTx" = "VSP fee transaction"
+"spentBy" = "Spent by"
+"spenderTx" = "Spender transaction"
+"spent" = "Spent"
+"spenderBlock" = "Spender block"
+"daysToVoteOrRevoke" = "Days to vote or revoke"
+"tspendNetworkTally" = "Network: %d yes, %d no (%s%% yes)"
+"scheduleAuthFailed" = "Stopped, the passphrase was rejected"
+"scheduleAuthFailedNotif" = "Scheduled mixing of %s stopped because the passphrase was rejected, start it again to continue mixing"
+"mixerStoppedBySchedule" = "Stopped by the schedule"
+"retryFeePayment" = "Retry fee payment"
+"changeVSP" = "Change VSP"
+"feePaymentSubmitted" = "Fee payment submitted"
 `
@@ -487,4 +487,146 @@ syncCompTime" = "Est. Sync completion time"
 "confirmUmixedSpending" = "Confirm to allow spending from unmixed accounts"
 "ok" = "OK"
 "accountMixer" = "AccountMixer"
+"login" = "Iniciar sesión"
+"startSyncToUse" = "Inicie la sincronización para continuar"
+"walletPassword" = "Contraseña del monedero"
+"selectAccountForDex" = "Seleccione la cuenta DCR a usar con el DEX"
+"waitingConfirms" = "Esperando confirmaciones..."
+"dexAddr" = "Dirección del DEX"
+"customServer" = "Servidor personalizado"
+"addADex" = "Añadir un DEX"
+"addA" = "Añadir un"
+"tlsCert" = "Certificado TLS"
+"register" = "Registrarse"
+"confirmReg" = "Confirmar el registro"
+"requireWalletPayFee" = "Se necesita su monedero para pagar la tarifa de registro."
+"confirmSelectAssetPayFee" = "¿Cómo pagará la tarifa de registro?"
+"setupNeeded" = "Configuración necesaria"
+"walletReady" = "Monedero listo"
+"market" = "Mercado"
+"allMarketAt" = "Todos los mercados en"
+"lotSize" = "Tamaño del lote"
+"successful" = "¡Completado!"
+"buy" = "Comprar"
+"sell" = "Vender"
+"limit" = "Límite"
+"marketOrder" = "Mercado"
+"quantity" = "Cantidad"
+"price" = "Precio"
+"placeOrder" = "Colocar orden"
+"orderBook" = "Libro de órdenes"
+"emptyOrderBook" = "El libro de órdenes está vacío"
+"recentTrades" = "Operaciones recientes"
+"noRecentTrades" = "No hay operaciones recientes"
+"openOrders" = "Órdenes abiertas"
+"noOpenOrders" = "No hay órdenes abiertas"
+"noSupportedMarkets" = "Este DEX no tiene mercados compatibles"
+"side" = "Lado"
+"filled" = "Ejecutado"
+"time" = "Hora"
+"cancelling" = "Cancelando"
+"orderPlaced" = "Orden colocada"
+"cancelRequested" = "Cancelación de la orden solicitada"
+"invalidQuantity" = "Introduzca una cantidad válida"
+"qtyNotLotMultiple" = "La cantidad debe ser un múltiplo del tamaño del lote"
+"invalidPrice" = "Introduzca un precio válido"
+"priceNotRateStepMultiple" = "El precio debe ser un múltiplo del incremento de precio"
+"noLiquidity" = "No hay órdenes con las que emparejar al otro lado del libro"
+"marketBuyTooSmall" = "La cantidad es demasiado pequeña para comprar un lote al mejor precio"
+"orderHistory" = "Historial de órdenes"
+"noOrders" = "No se encontraron órdenes"
+"allMarkets" = "Todos los mercados"
+"allStatuses" = "Todos los estados"
+"open" = "Abierta"
+"executed" = "Ejecutada"
+"canceled" = "Cancelada"
+"buyAndSell" = "Compra y venta"
+"allTime" = "Todo el tiempo"
+"last24Hours" = "Últimas 24 horas"
+"last7Days" = "Últimos 7 días"
+"last30Days" = "Últimos 30 días"
+"orderDetails" = "Detalles de la orden"
+"orderID" = "ID de la orden"
+"match" = "Emparejamiento"
+"noMatches" = "Esta orden no tiene emparejamientos"
+"swap" = "Intercambio"
+"counterSwap" = "Intercambio de la contraparte"
+"redeem" = "Canje"
+"counterRedeem" = "Canje de la contraparte"
+"refund" = "Reembolso"
+"dexServers" = "Servidores DEX"
+"connected" = "Conectado"
+"disconnected" = "Desconectado"
+"active" = "Activo"
+"serverDisabled" = "Desactivado"
+"feeAssets" = "Activos de tarifa"
+"markets" = "Mercados"
+"setActive" = "Establecer como activo"
+"enable" = "Activar"
+"updateCert" = "Actualizar el certificado TLS"
+"update" = "Actualizar"
+"certUpdated" = "Certificado TLS actualizado"
+"exportKeys" = "Exportar las claves"
+"removeDex" = "Eliminar el servidor DEX"
+"importAccount" = "Importar una cuenta"
+"accountFilePath" = "Ruta del archivo de claves de la cuenta"
+"noDexServers" = "No hay servidores DEX registrados"
+"allDexDisabled" = "Todos los servidores DEX están desactivados"
+"dexPassword" = "Contraseña del DEX"
+"confirmDexPassword" = "Confirmar la contraseña del DEX"
+"createDexPass" = "Crear una contraseña del DEX"
+"createDexPassDesc" = "La contraseña del DEX protege sus cuentas DEX y es necesaria para operar."
+"setDexPass" = "Establecer una contraseña del DEX"
+"migrateDexPassDesc" = "Sus cuentas DEX estaban protegidas por una contraseña integrada. Cree una contraseña del DEX para protegerlas."
+"unlockDex" = "Desbloquear el DEX"
+"unlockDexDesc" = "Introduzca su contraseña del DEX para empezar a operar."
+"rememberDexPass" = "Recordar la contraseña hasta cerrar la aplicación"
+"changeDexPass" = "Cambiar la contraseña del DEX"
+"newDexPassword" = "Nueva contraseña del DEX"
+"dexPassChanged" = "Contraseña del DEX cambiada"
+"swapMonitor" = "Monitor de intercambios"
+"noActiveSwaps" = "No hay intercambios activos"
+"sendingSwap" = "Enviando el intercambio"
+"waitingCounterparty" = "Esperando a la contraparte"
+"redeemingSwap" = "Canjeando"
+"complete" = "Completado"
+"refunded" = "Reembolsado"
+"refundDue" = "Tiempo de bloqueo vencido, el intercambio se reembolsa automáticamente"
+"refundNotNeeded" = "No necesario"
+"refundNow" = "Intentar el reembolso ahora"
+"refundRequested" = "Reembolso solicitado"
+"maker" = "Creador"
+"taker" = "Tomador"
+"role" = "Rol"
+"yourSwap" = "Su intercambio"
+"lockTime" = "Tiempo de bloqueo"
+"counterLockTime" = "Tiempo de bloqueo de la contraparte"
+"matchRevoked" = "El emparejamiento fue revocado por el servidor"
+"portfolio" = "Cartera"
+"noDexWallets" = "No hay monederos DEX"
+"totalValue" = "Valor total"
+"fetchingRates" = "Obteniendo los tipos de cambio..."
+"available" = "Disponible"
+"running" = "En ejecución"
+"notRunning" = "Detenido"
+"depositAddress" = "Dirección de depósito"
+"newAddress" = "Nueva dirección"
+"nameWallet" = "Monedero %s"
+"alreadyConnectWallet" = "Ya hay un monedero %s conectado"
+"numberConfirmations" = "%d confirmaciones"
+"connHostError" = "La conexión con el servidor DEX %s falló. Puede cerrar la aplicación e intentarlo más tarde o esperar a que se reconecte"
+"confirmationsStatus" = "Para operar en %s, el pago de la tarifa de registro necesita %d confirmaciones."
+"keysExported" = "Claves de la cuenta exportadas a %s. Mantenga este archivo privado, otorga el control total de la cuenta."
+"removeDexWarning" = "Eliminar %s desactiva su cuenta. Ya no se podrán colocar órdenes en este servidor y la cuenta solo podrá recuperarse importando sus claves exportadas. Exporte las claves antes de eliminar el servidor."
+"dexRemoved" = "%s eliminado"
+"dexImported" = "Cuenta de %s importada"
+"confirmationsOf" = "(%d/%d confirmaciones)"
+"refundableIn" = "Reembolsable en %s si la contraparte no canjea"
+"swapSentNotif" = "Intercambio enviado para un emparejamiento %s en %s"
+"swapRedeemedNotif" = "Intercambio canjeado para un emparejamiento %s en %s"
+"refundDueNotif" = "El tiempo de bloqueo de su intercambio %s en %s venció, será reembolsado"
+"refundSentNotif" = "Su intercambio %s en %s fue reembolsado"
+"swapRevokedNotif" = "Un emparejamiento %s en %s fue revocado"
+"noRateFor" = "Excluye %s, no hay tipo de cambio disponible"
+"invalidAmount" = "Cantidad no válida"
 `
@@ -464,4 +464,146 @@ const FR = `
 "confirmUmixedSpending" = "Confirm to allow spending from unmixed accounts"
 "ok" = "OK"
 "accountMixer" = "AccountMixer"
+"login" = "Connexion"
+"startSyncToUse" = "Lancez la synchronisation pour continuer"
+"walletPassword" = "Mot de passe du portefeuille"
+"selectAccountForDex" = "Sélectionnez le compte DCR à utiliser avec le DEX"
+"waitingConfirms" = "En attente de confirmations..."
+"dexAddr" = "Adresse du DEX"
+"customServer" = "Serveur personnalisé"
+"addADex" = "Ajouter un DEX"
+"addA" = "Ajouter un"
+"tlsCert" = "Certificat TLS"
+"register" = "S'inscrire"
+"confirmReg" = "Confirmer l'inscription"
+"requireWalletPayFee" = "Votre portefeuille est nécessaire pour payer les frais d'inscription."
+"confirmSelectAssetPayFee" = "Comment allez-vous payer les frais d'inscription ?"
+"setupNeeded" = "Configuration requise"
+"walletReady" = "Portefeuille prêt"
+"market" = "Marché"
+"allMarketAt" = "Tous les marchés sur"
+"lotSize" = "Taille du lot"
+"successful" = "Réussi !"
+"buy" = "Acheter"
+"sell" = "Vendre"
+"limit" = "Limite"
+"marketOrder" = "Marché"
+"quantity" = "Quantité"
+"price" = "Prix"
+"placeOrder" = "Passer l'ordre"
+"orderBook" = "Carnet d'ordres"
+"emptyOrderBook" = "Le carnet d'ordres est vide"
+"recentTrades" = "Échanges récents"
+"noRecentTrades" = "Aucun échange récent"
+"openOrders" = "Ordres ouverts"
+"noOpenOrders" = "Aucun ordre ouvert"
+"noSupportedMarkets" = "Ce DEX n'a aucun marché pris en charge"
+"side" = "Sens"
+"filled" = "Exécuté"
+"time" = "Heure"
+"cancelling" = "Annulation"
+"orderPlaced" = "Ordre passé"
+"cancelRequested" = "Annulation de l'ordre demandée"
+"invalidQuantity" = "Saisissez une quantité valide"
+"qtyNotLotMultiple" = "La quantité doit être un multiple de la taille du lot"
+"invalidPrice" = "Saisissez un prix valide"
+"priceNotRateStepMultiple" = "Le prix doit être un multiple du pas de cotation"
+"noLiquidity" = "Il n'y a aucun ordre de l'autre côté du carnet"
+"marketBuyTooSmall" = "Le montant est trop faible pour acheter un lot au meilleur prix"
+"orderHistory" = "Historique des ordres"
+"noOrders" = "Aucun ordre trouvé"
+"allMarkets" = "Tous les marchés"
+"allStatuses" = "Tous les statuts"
+"open" = "Ouvert"
+"executed" = "Exécuté"
+"canceled" = "Annulé"
+"buyAndSell" = "Achat et vente"
+"allTime" = "Toute la période"
+"last24Hours" = "Dernières 24 heures"
+"last7Days" = "7 derniers jours"
+"last30Days" = "30 derniers jours"
+"orderDetails" = "Détails de l'ordre"
+"orderID" = "ID de l'ordre"
+"match" = "Correspondance"
+"noMatches" = "Cet ordre n'a aucune correspondance"
+"swap" = "Swap"
+"counterSwap" = "Swap de la contrepartie"
+"redeem" = "Rachat"
+"counterRedeem" = "Rachat de la contrepartie"
+"refund" = "Remboursement"
+"dexServers" = "Serveurs DEX"
+"connected" = "Connecté"
+"disconnected" = "Déconnecté"
+"active" = "Actif"
+"serverDisabled" = "Désactivé"
+"feeAssets" = "Actifs de frais"
+"markets" = "Marchés"
+"setActive" = "Définir comme actif"
+"enable" = "Activer"
+"updateCert" = "Mettre à jour le certificat TLS"
+"update" = "Mettre à jour"
+"certUpdated" = "Certificat TLS mis à jour"
+"exportKeys" = "Exporter les clés"
+"removeDex" = "Supprimer le serveur DEX"
+"importAccount" = "Importer un compte"
+"accountFilePath" = "Chemin du fichier des clés du compte"
+"noDexServers" = "Aucun serveur DEX enregistré"
+"allDexDisabled" = "Tous les serveurs DEX sont désactivés"
+"dexPassword" = "Mot de passe DEX"
+"confirmDexPassword" = "Confirmer le mot de passe DEX"
+"createDexPass" = "Créer un mot de passe DEX"
+"createDexPassDesc" = "Le mot de passe DEX protège vos comptes DEX et est nécessaire pour échanger."
+"setDexPass" = "Définir un mot de passe DEX"
+"migrateDexPassDesc" = "Vos comptes DEX étaient protégés par un mot de passe intégré. Créez un mot de passe DEX pour les protéger."
+"unlockDex" = "Déverrouiller le DEX"
+"unlockDexDesc" = "Saisissez votre mot de passe DEX pour commencer à échanger."
+"rememberDexPass" = "Mémoriser le mot de passe jusqu'à la fermeture de l'application"
+"changeDexPass" = "Changer le mot de passe DEX"
+"newDexPassword" = "Nouveau mot de passe DEX"
+"dexPassChanged" = "Mot de passe DEX modifié"
+"swapMonitor" = "Suivi des swaps"
+"noActiveSwaps" = "Aucun swap actif"
+"sendingSwap" = "Envoi du swap"
+"waitingCounterparty" = "En attente de la contrepartie"
+"redeemingSwap" = "Rachat en cours"
+"complete" = "Terminé"
+"refunded" = "Remboursé"
+"refundDue" = "Délai de verrouillage expiré, le swap est remboursé automatiquement"
+"refundNotNeeded" = "Non nécessaire"
+"refundNow" = "Tenter le remboursement maintenant"
+"refundRequested" = "Remboursement demandé"
+"maker" = "Maker"
+"taker" = "Taker"
+"role" = "Rôle"
+"yourSwap" = "Votre swap"
+"lockTime" = "Délai de verrouillage"
+"counterLockTime" = "Délai de verrouillage de la contrepartie"
+"matchRevoked" = "La correspondance a été révoquée par le serveur"
+"portfolio" = "Portefeuille d'actifs"
+"noDexWallets" = "Aucun portefeuille DEX"
+"totalValue" = "Valeur totale"
+"fetchingRates" = "Récupération des taux de change..."
+"available" = "Disponible"
+"running" = "En cours d'exécution"
+"notRunning" = "Arrêté"
+"depositAddress" = "Adresse de dépôt"
+"newAddress" = "Nouvelle adresse"
+"nameWallet" = "Portefeuille %s"
+"alreadyConnectWallet" = "Un portefeuille %s est déjà connecté"
+"numberConfirmations" = "%d confirmations"
+"connHostError" = "La connexion au serveur DEX %s a échoué. Vous pouvez fermer l'application et réessayer plus tard ou attendre la reconnexion"
+"confirmationsStatus" = "Pour échanger sur %s, le paiement des frais d'inscription nécessite %d confirmations."
+"keysExported" = "Clés du compte exportées vers %s. Gardez ce fichier privé, il donne le contrôle total du compte."
+"removeDexWarning" = "La suppression de %s désactive son compte. Aucun ordre ne pourra plus être passé sur ce serveur et le compte ne pourra être récupéré qu'en important ses clés exportées. Exportez les clés avant de supprimer le serveur."
+"dexRemoved" = "%s supprimé"
+"dexImported" = "Compte de %s importé"
+"confirmationsOf" = "(%d/%d confirmations)"
+"refundableIn" = "Remboursable dans %s si la contrepartie ne rachète pas"
+"swapSentNotif" = "Swap envoyé pour une correspondance %s sur %s"
+"swapRedeemedNotif" = "Swap racheté pour une correspondance %s sur %s"
+"refundDueNotif" = "Le délai de verrouillage de votre swap %s sur %s a expiré, il sera remboursé"
+"refundSentNotif" = "Votre swap %s sur %s a été remboursé"
+"swapRevokedNotif" = "Une correspondance %s sur %s a été révoquée"
+"noRateFor" = "Hors %s, aucun taux de change disponible"
+"invalidAmount" = "Montant invalide"
 `
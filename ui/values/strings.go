@@ -613,4 +613,365 @@ const (
 	StrConfirmUmixedSpending           = "confirmUmixedSpending"
 	StrOK                              = "ok"
 	StrAccountMixer                    = "accountMixer"
+	StrTicketNotifications             = "ticketNotifications"
+	StrMatured                         = "matured"
+	StrMissed                          = "missed"
+	StrVSPFeeErrored                   = "vspFeeErrored"
+	StrTicketPurchasedNotif            = "ticketPurchasedNotif"
+	StrTicketMaturedNotif              = "ticketMaturedNotif"
+	StrTicketMissedNotif               = "ticketMissedNotif"
+	StrTicketExpiredNotif              = "ticketExpiredNotif"
+	StrVSPFeeErroredNotif              = "vspFeeErroredNotif"
+	StrPurchaseRules                   = "purchaseRules"
+	StrMaxTicketPrice                  = "maxTicketPrice"
+	StrMaxTicketsPerDay                = "maxTicketsPerDay"
+	StrMaxTicketsPerWindow             = "maxTicketsPerWindow"
+	StrActiveHoursStart                = "activeHoursStart"
+	StrActiveHoursEnd                  = "activeHoursEnd"
+	StrPauseAbovePercent               = "pauseAbovePercent"
+	StrAveragePeriod                   = "averagePeriod"
+	StrInvalidActiveHours              = "invalidActiveHours"
+	StrInvalidRuleValue                = "invalidRuleValue"
+	StrRuleEvaluationLog               = "ruleEvaluationLog"
+	StrNoRuleEvaluations               = "noRuleEvaluations"
+	StrRulesPassed                     = "rulesPassed"
+	StrRuleLowBalance                  = "ruleLowBalance"
+	StrRulePriceAboveMax               = "rulePriceAboveMax"
+	StrRuleOutsideActiveHours          = "ruleOutsideActiveHours"
+	StrRuleDailyLimitReached           = "ruleDailyLimitReached"
+	StrRuleWindowLimitReached          = "ruleWindowLimitReached"
+	StrRulePriceAboveAverage           = "rulePriceAboveAverage"
+	StrRulePriceUnavailable            = "rulePriceUnavailable"
+	StrManageVSPs                      = "manageVSPs"
+	StrVSPStatusOnline                 = "vspStatusOnline"
+	StrVSPStatusOffline                = "vspStatusOffline"
+	StrVSPStatusChecking               = "vspStatusChecking"
+	StrVSPClosed                       = "vspClosed"
+	StrLastSeen                        = "lastSeen"
+	StrNever                           = "never"
+	StrVSPTicketStats                  = "vspTicketStats"
+	StrCustomVSP                       = "customVSP"
+	StrVSPDownWarning                  = "vspDownWarning"
+	StrVSPAdded                        = "vspAdded"
+	StrProblemTicketsOnly              = "problemTicketsOnly"
+	StrCheckVSPStatus                  = "checkVSPStatus"
+	StrNoProblemTickets                = "noProblemTickets"
+	StrTicketVSPStatus                 = "ticketVSPStatus"
+	StrFeeStatus                       = "feeStatus"
+	StrConfirmedByVSP                  = "confirmedByVSP"
+	StrNotConfirmedByVSP               = "notConfirmedByVSP"
+	StrVSPStatusUpdated                = "vspStatusUpdated"
+	StrFeeProcessStarted               = "feeProcessStarted"
+	StrFeePaid                         = "feePaid"
+	StrFeeConfirmed                    = "feeConfirmed"
+	StrExportCSV                       = "exportCSV"
+	StrExportJSON                      = "exportJSON"
+	StrExportedTo                      = "exportedTo"
+	StrTicketReport                    = "ticketReport"
+	StrNoSearchResults                 = "noSearchResults"
+	StrWatched                         = "watched"
+	StrWatchProposal                   = "watchProposal"
+	StrUnwatchProposal                 = "unwatchProposal"
+	StrNoWatchedProposals              = "noWatchedProposals"
+	StrProposalVersionNotif            = "proposalVersionNotif"
+	StrProposalMilestoneNotif          = "proposalMilestoneNotif"
+	StrProposalQuorumNotif             = "proposalQuorumNotif"
+	StrProposalPassingNotif            = "proposalPassingNotif"
+	StrProposalFailingNotif            = "proposalFailingNotif"
+	StrProposalApprovedNotif           = "proposalApprovedNotif"
+	StrProposalRejectedNotif           = "proposalRejectedNotif"
+	StrVotingHistory                   = "votingHistory"
+	StrAuditVotes                      = "auditVotes"
+	StrNotVoted                        = "notVoted"
+	StrNoVotingHistory                 = "noVotingHistory"
+	StrFilterByTicket                  = "filterByTicket"
+	StrVotingInProgressOutcome         = "votingInProgressOutcome"
+	StrVotesAudited                    = "votesAudited"
+	StrComments                        = "comments"
+	StrCommentsCount                   = "commentsCount"
+	StrTop                             = "top"
+	StrNoComments                      = "noComments"
+	StrCommentDeleted                  = "commentDeleted"
+	StrCommentPoints                   = "commentPoints"
+	StrCommentsUpdated                 = "commentsUpdated"
+	StrCommentsOffline                 = "commentsOffline"
+	StrCompareVersions                 = "compareVersions"
+	StrVersionChanges                  = "versionChanges"
+	StrFromVersion                     = "fromVersion"
+	StrToVersion                       = "toVersion"
+	StrNoVersionChanges                = "noVersionChanges"
+	StrImagePlaceholder                = "imagePlaceholder"
+	StrOpenLinkTitle                   = "openLinkTitle"
+	StrOpenLinkInfo                    = "openLinkInfo"
+	StrOpenLink                        = "openLink"
+	StrCopyURL                         = "copyURL"
+	StrTrustLinkDomain                 = "trustLinkDomain"
+	StrInvalidLink                     = "invalidLink"
+	StrLinkUnsafeScheme                = "linkUnsafeScheme"
+	StrLinkUserInfo                    = "linkUserInfo"
+	StrLinkInternational               = "linkInternational"
+	StrLinkLookalike                   = "linkLookalike"
+	StrTrustedDomains                  = "trustedDomains"
+	StrTrustedDomainsInfo              = "trustedDomainsInfo"
+	StrNoTrustedDomains                = "noTrustedDomains"
+	StrAddDomain                       = "addDomain"
+	StrDomainHint                      = "domainHint"
+	StrInvalidDomain                   = "invalidDomain"
+	StrTreasuryHistory                 = "treasuryHistory"
+	StrTreasuryBalanceHistory          = "treasuryBalanceHistory"
+	StrTreasuryMonthlySpending         = "treasuryMonthlySpending"
+	StrTreasurySpent                   = "treasurySpent"
+	StrTSpends                         = "tspends"
+	StrTSpendTickets                   = "tspendTickets"
+	StrNoTSpends                       = "noTSpends"
+	StrNoTreasuryHistory               = "noTreasuryHistory"
+	StrTreasuryUpdated                 = "treasuryUpdated"
+	StrTreasuryOffline                 = "treasuryOffline"
+	StrFraction                        = "fraction"
+	StrRefresh                         = "refresh"
+	StrFetchingTreasuryHistory         = "fetchingTreasuryHistory"
+	StrVotePerTicket                   = "votePerTicket"
+	StrTicketVoteChoices               = "ticketVoteChoices"
+	StrTicketVoteChoicesInfo           = "ticketVoteChoicesInfo"
+	StrSelectAll                       = "selectAll"
+	StrSetSelectedTo                   = "setSelectedTo"
+	StrSyncFromVSP                     = "syncFromVSP"
+	StrVSPChoice                       = "vspChoice"
+	StrNoVSP                           = "noVSP"
+	StrChoiceSummary                   = "choiceSummary"
+	StrTicketsToUpdate                 = "ticketsToUpdate"
+	StrNoChoiceChanges                 = "noChoiceChanges"
+	StrChoicesSynced                   = "choicesSynced"
+	StrChoiceUpdateFailed              = "choiceUpdateFailed"
+	StrConfirmVoteChoices              = "confirmVoteChoices"
+	StrSaveForOffline                  = "saveForOffline"
+	StrRemoveOfflineCopy               = "removeOfflineCopy"
+	StrOfflineCopyOutdated             = "offlineCopyOutdated"
+	StrDownloadingProposal             = "downloadingProposal"
+	StrSavedForOffline                 = "savedForOffline"
+	StrOfflineCopyRemoved              = "offlineCopyRemoved"
+	StrExportMarkdown                  = "exportMarkdown"
+	StrExportHTML                      = "exportHTML"
+	StrOfflineProposals                = "offlineProposals"
+	StrReadOfflineProposals            = "readOfflineProposals"
+	StrNoOfflineProposals              = "noOfflineProposals"
+	StrSavedAgo                        = "savedAgo"
+	StrMixerActivity                   = "mixerActivity"
+	StrMixerSessions                   = "mixerSessions"
+	StrTimeMixing                      = "timeMixing"
+	StrMixedTransactions               = "mixedTransactions"
+	StrAmountMixed                     = "amountMixed"
+	StrDenominationOutputs             = "denominationOutputs"
+	StrMixerFailures                   = "mixerFailures"
+	StrUnmixedRemaining                = "unmixedRemaining"
+	StrNoMixerActivity                 = "noMixerActivity"
+	StrMixerRunningSince               = "mixerRunningSince"
+	StrMixerRanFor                     = "mixerRanFor"
+	StrMixerFailedToStart              = "mixerFailedToStart"
+	StrMixerSessionResult              = "mixerSessionResult"
+	StrUnmixedChange                   = "unmixedChange"
+	StrMixerStoppedByUser              = "mixerStoppedByUser"
+	StrMixerInterrupted                = "mixerInterrupted"
+	StrScheduledMixing                 = "scheduledMixing"
+	StrScheduledMixingDesc             = "scheduledMixingDesc"
+	StrMixerSchedule                   = "mixerSchedule"
+	StrMixTargetAmount                 = "mixTargetAmount"
+	StrMixTimeBudget                   = "mixTimeBudget"
+	StrStopWhenUnmixedEmpty            = "stopWhenUnmixedEmpty"
+	StrMixFromHour                     = "mixFromHour"
+	StrMixUntilHour                    = "mixUntilHour"
+	StrInvalidScheduleValue            = "invalidScheduleValue"
+	StrScheduleNeedsGoal               = "scheduleNeedsGoal"
+	StrEditSchedule                    = "editSchedule"
+	StrStartSchedule                   = "startSchedule"
+	StrStopSchedule                    = "stopSchedule"
+	StrConfirmScheduledMixing          = "confirmScheduledMixing"
+	StrScheduledMixingDetails          = "scheduledMixingDetails"
+	StrScheduleMixing                  = "scheduleMixing"
+	StrScheduleWaiting                 = "scheduleWaiting"
+	StrScheduleTargetReached           = "scheduleTargetReached"
+	StrScheduleEmpty                   = "scheduleEmpty"
+	StrScheduleBudgetUsed              = "scheduleBudgetUsed"
+	StrScheduleCancelled               = "scheduleCancelled"
+	StrScheduleMixedOf                 = "scheduleMixedOf"
+	StrScheduleMixed                   = "scheduleMixed"
+	StrScheduleTimeOf                  = "scheduleTimeOf"
+	StrScheduleWindow                  = "scheduleWindow"
+	StrNoSchedule                      = "noSchedule"
+	StrPrivacyWarnings                 = "privacyWarnings"
+	StrCheckingPrivacy                 = "checkingPrivacy"
+	StrMergesMixedInputs               = "mergesMixedInputs"
+	StrMergesMixedInputsDesc           = "mergesMixedInputsDesc"
+	StrTraceableChange                 = "traceableChange"
+	StrTraceableChangeDesc             = "traceableChangeDesc"
+	StrAddressReused                   = "addressReused"
+	StrAddressReusedDesc               = "addressReusedDesc"
+	StrAmountRepeated                  = "amountRepeated"
+	StrAmountRepeatedDesc              = "amountRepeatedDesc"
+	StrSendAnyway                      = "sendAnyway"
+	StrLogin                           = "login"
+	StrStartSyncToUse                  = "startSyncToUse"
+	StrWalletPassword                  = "walletPassword"
+	StrSelectAccountForDex             = "selectAccountForDex"
+	StrWaitingConfirms                 = "waitingConfirms"
+	StrDexAddr                         = "dexAddr"
+	StrCustomServer                    = "customServer"
+	StrAddADex                         = "addADex"
+	StrAddA                            = "addA"
+	StrTLSCert                         = "tlsCert"
+	StrRegister                        = "register"
+	StrConfirmReg                      = "confirmReg"
+	StrRequireWalletPayFee             = "requireWalletPayFee"
+	StrConfirmSelectAssetPayFee        = "confirmSelectAssetPayFee"
+	StrSetupNeeded                     = "setupNeeded"
+	StrWalletReady                     = "walletReady"
+	StrMarket                          = "market"
+	StrAllMarketAt                     = "allMarketAt"
+	StrLotSize                         = "lotSize"
+	StrSuccessful                      = "successful"
+	StrBuy                             = "buy"
+	StrSell                            = "sell"
+	StrLimit                           = "limit"
+	StrMarketOrder                     = "marketOrder"
+	StrQuantity                        = "quantity"
+	StrPrice                           = "price"
+	StrPlaceOrder                      = "placeOrder"
+	StrOrderBook                       = "orderBook"
+	StrEmptyOrderBook                  = "emptyOrderBook"
+	StrRecentTrades                    = "recentTrades"
+	StrNoRecentTrades                  = "noRecentTrades"
+	StrOpenOrders                      = "openOrders"
+	StrNoOpenOrders                    = "noOpenOrders"
+	StrNoSupportedMarkets              = "noSupportedMarkets"
+	StrSide                            = "side"
+	StrFilled                          = "filled"
+	StrTime                            = "time"
+	StrCancelling                      = "cancelling"
+	StrOrderPlaced                     = "orderPlaced"
+	StrCancelRequested                 = "cancelRequested"
+	StrInvalidQuantity                 = "invalidQuantity"
+	StrQtyNotLotMultiple               = "qtyNotLotMultiple"
+	StrInvalidPrice                    = "invalidPrice"
+	StrPriceNotRateStepMultiple        = "priceNotRateStepMultiple"
+	StrNoLiquidity                     = "noLiquidity"
+	StrMarketBuyTooSmall               = "marketBuyTooSmall"
+	StrOrderHistory                    = "orderHistory"
+	StrNoOrders                        = "noOrders"
+	StrAllMarkets                      = "allMarkets"
+	StrAllStatuses                     = "allStatuses"
+	StrOpen                            = "open"
+	StrExecuted                        = "executed"
+	StrCanceled                        = "canceled"
+	StrBuyAndSell                      = "buyAndSell"
+	StrAllTime                         = "allTime"
+	StrLast24Hours                     = "last24Hours"
+	StrLast7Days                       = "last7Days"
+	StrLast30Days                      = "last30Days"
+	StrOrderDetails                    = "orderDetails"
+	StrOrderID                         = "orderID"
+	StrMatch                           = "match"
+	StrNoMatches                       = "noMatches"
+	StrSwap                            = "swap"
+	StrCounterSwap                     = "counterSwap"
+	StrRedeem                          = "redeem"
+	StrCounterRedeem                   = "counterRedeem"
+	StrRefund                          = "refund"
+	StrDexServers                      = "dexServers"
+	StrConnected                       = "connected"
+	StrDisconnected                    = "disconnected"
+	StrActive                          = "active"
+	StrServerDisabled                  = "serverDisabled"
+	StrFeeAssets                       = "feeAssets"
+	StrMarkets                         = "markets"
+	StrSetActive                       = "setActive"
+	StrEnable                          = "enable"
+	StrUpdateCert                      = "updateCert"
+	StrUpdate                          = "update"
+	StrCertUpdated                     = "certUpdated"
+	StrExportKeys                      = "exportKeys"
+	StrRemoveDex                       = "removeDex"
+	StrImportAccount                   = "importAccount"
+	StrAccountFilePath                 = "accountFilePath"
+	StrNoDexServers                    = "noDexServers"
+	StrAllDexDisabled                  = "allDexDisabled"
+	StrDexPassword                     = "dexPassword"
+	StrConfirmDexPassword              = "confirmDexPassword"
+	StrCreateDexPass                   = "createDexPass"
+	StrCreateDexPassDesc               = "createDexPassDesc"
+	StrSetDexPass                      = "setDexPass"
+	StrMigrateDexPassDesc              = "migrateDexPassDesc"
+	StrUnlockDex                       = "unlockDex"
+	StrUnlockDexDesc                   = "unlockDexDesc"
+	StrRememberDexPass                 = "rememberDexPass"
+	StrChangeDexPass                   = "changeDexPass"
+	StrNewDexPassword                  = "newDexPassword"
+	StrDexPassChanged                  = "dexPassChanged"
+	StrSwapMonitor                     = "swapMonitor"
+	StrNoActiveSwaps                   = "noActiveSwaps"
+	StrSendingSwap                     = "sendingSwap"
+	StrWaitingCounterparty             = "waitingCounterparty"
+	StrRedeemingSwap                   = "redeemingSwap"
+	StrComplete                        = "complete"
+	StrRefunded                        = "refunded"
+	StrRefundDue                       = "refundDue"
+	StrRefundNotNeeded                 = "refundNotNeeded"
+	StrRefundNow                       = "refundNow"
+	StrRefundRequested                 = "refundRequested"
+	StrMaker                           = "maker"
+	StrTaker                           = "taker"
+	StrRole                            = "role"
+	StrYourSwap                        = "yourSwap"
+	StrLockTime                        = "lockTime"
+	StrCounterLockTime                 = "counterLockTime"
+	StrMatchRevoked                    = "matchRevoked"
+	StrPortfolio                       = "portfolio"
+	StrNoDexWallets                    = "noDexWallets"
+	StrTotalValue                      = "totalValue"
+	StrFetchingRates                   = "fetchingRates"
+	StrAvailable                       = "available"
+	StrRunning                         = "running"
+	StrNotRunning                      = "notRunning"
+	StrDepositAddress                  = "depositAddress"
+	StrNewAddress                      = "newAddress"
+	StrNameWallet                      = "nameWallet"
+	StrAlreadyConnectWallet            = "alreadyConnectWallet"
+	StrNumberConfirmations             = "numberConfirmations"
+	StrConnHostError                   = "connHostError"
+	StrConfirmationsStatus             = "confirmationsStatus"
+	StrKeysExported                    = "keysExported"
+	StrRemoveDexWarning                = "removeDexWarning"
+	StrDexRemoved                      = "dexRemoved"
+	StrDexImported                     = "dexImported"
+	StrConfirmationsOf                 = "confirmationsOf"
+	StrRefundableIn                    = "refundableIn"
+	StrSwapSentNotif                   = "swapSentNotif"
+	StrSwapRedeemedNotif               = "swapRedeemedNotif"
+	StrRefundDueNotif                  = "refundDueNotif"
+	StrRefundSentNotif                 = "refundSentNotif"
+	StrSwapRevokedNotif                = "swapRevokedNotif"
+	StrNoRateFor                       = "noRateFor"
+	StrInvalidAmount                   = "invalidAmount"
+	StrVsp                             = "vsp"
+	StrDexAccountBackupAt              = "dexAccountBackupAt"
+	StrPrivacyCheckFailed              = "privacyCheckFailed"
+	StrSendWithoutCheck                = "sendWithoutCheck"
+	StrTicketReportTitle               = "ticketReportTitle"
+	StrReportWallet                    = "reportWallet"
+	StrPurchaseBlock                   = "purchaseBlock"
+	StrTicketTxFee                     = "ticketTxFee"
+	StrVSPFee                          = "vspFee"
+	StrVSPFeeStatus                    = "vspFeeStatus"
+	StrVSPFeeTx                        = "vspFeeTx"
+	StrSpentBy                         = "spentBy"
+	StrSpenderTx                       = "spenderTx"
+	StrSpent                           = "spent"
+	StrSpenderBlock                    = "spenderBlock"
+	StrDaysToVoteOrRevoke              = "daysToVoteOrRevoke"
+	StrTSpendNetworkTally              = "tspendNetworkTally"
+	StrScheduleAuthFailed              = "scheduleAuthFailed"
+	StrScheduleAuthFailedNotif         = "scheduleAuthFailedNotif"
+	StrMixerStoppedBySchedule          = "mixerStoppedBySchedule"
+	StrRetryFeePayment                 = "retryFeePayment"
+	StrChangeVSP                       = "changeVSP"
+	StrFeePaymentSubmitted             = "feePaymentSubmitted"
 )
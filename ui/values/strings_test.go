@@ -0,0 +1,77 @@
+package values
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// identifierValue matches the string values that are identifiers rather than
+// text shown to the user, such as "myWallet" or "LIVE".
+var identifierValue = regexp.MustCompile(`^([a-z][A-Za-z0-9_]*|[A-Z0-9_]+)$`)
+
+// untranslatedPageConsts are the page string constants that are shown to the
+// user but are not translated.
+var untranslatedPageConsts = map[string]bool{
+	"license": true, // the license text is only published in English
+}
+
+// stringConsts returns the string constants declared in the Go files under
+// dir, excluding tests, as "path:name" keys of their values.
+func stringConsts(t *testing.T, dir string) map[string]string {
+	consts := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for i, value := range valueSpec.Values {
+					lit, ok := value.(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					str, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						return err
+					}
+					consts[path+":"+valueSpec.Names[i].Name] = str
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return consts
+}
+
+// TestPageStringsLocalized fails when a page declares a string constant shown
+// to the user instead of a localizable string. Page IDs and config keys are
+// identifiers and are not checked.
+func TestPageStringsLocalized(t *testing.T) {
+	for key, value := range stringConsts(t, filepath.Join("..", "page")) {
+		name := key[strings.LastIndex(key, ":")+1:]
+		if strings.HasSuffix(name, "ID") || strings.HasSuffix(name, "Key") ||
+			identifierValue.MatchString(value) || untranslatedPageConsts[name] {
+			continue
+		}
+		t.Errorf("%s = %q is not localizable, add it to the values strings", key, value)
+	}
+}
@@ -0,0 +1,176 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// defaultAgendaChoice is the choice of tickets that have no choice saved
+// for an agenda.
+const defaultAgendaChoice = "abstain"
+
+// TicketAgendaChoice is the vote choice of a ticket on an agenda.
+type TicketAgendaChoice struct {
+	Ticket string
+	// Choice is the choice saved in the wallet database.
+	Choice string
+	// VSP is the host of the VSP the ticket is registered with, empty if
+	// the ticket is not registered with a VSP.
+	VSP string
+	// VSPChoice is the choice reported by the VSP. It is only meaningful if
+	// Synced is true.
+	VSPChoice string
+	Synced    bool
+	// Err is the error returned by the VSP, if any.
+	Err error
+}
+
+// Mismatch returns true if the VSP reported a choice that differs from the
+// choice saved in the wallet.
+func (c *TicketAgendaChoice) Mismatch() bool {
+	return c.Synced && c.VSPChoice != c.Choice
+}
+
+// TicketAgendaChoices returns the choice saved in the wallet database for
+// the agenda of each ticket in hashes.
+func TicketAgendaChoices(ctx context.Context, w *dcrlibwallet.Wallet, agendaID string, hashes []string) ([]*TicketAgendaChoice, error) {
+	choices := make([]*TicketAgendaChoice, 0, len(hashes))
+	for _, hash := range hashes {
+		ticketHash, err := chainhash.NewHashFromStr(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		agendaChoices, _, err := w.Internal().AgendaChoices(ctx, ticketHash)
+		if err != nil {
+			return nil, err
+		}
+		choice := &TicketAgendaChoice{Ticket: hash, Choice: defaultAgendaChoice}
+		for _, agendaChoice := range agendaChoices {
+			if agendaChoice.AgendaID == agendaID {
+				choice.Choice = agendaChoice.ChoiceID
+				break
+			}
+		}
+
+		if ticketInfo, err := w.Internal().VSPTicketInfo(ctx, ticketHash); err == nil {
+			choice.VSP = ticketInfo.Host
+		}
+		choices = append(choices, choice)
+	}
+	return choices, nil
+}
+
+// SyncTicketAgendaChoices unlocks the wallet and requests the choice of each
+// ticket on the agenda from the VSP the ticket is registered with. Tickets
+// that are not registered with a VSP are skipped.
+func SyncTicketAgendaChoices(ctx context.Context, w *dcrlibwallet.Wallet, agendaID string, choices []*TicketAgendaChoice, passphrase []byte) error {
+	if err := w.UnlockWallet(passphrase); err != nil {
+		return err
+	}
+	defer w.LockWallet()
+
+	for _, choice := range choices {
+		if choice.VSP == "" {
+			continue
+		}
+
+		ticketHash, err := chainhash.NewHashFromStr(choice.Ticket)
+		if err != nil {
+			return err
+		}
+		ticketInfo, err := w.Internal().VSPTicketInfo(ctx, ticketHash)
+		if err != nil {
+			choice.Err = err
+			continue
+		}
+		client, err := w.VSPClient(ticketInfo.Host, ticketInfo.PubKey)
+		if err != nil {
+			choice.Err = err
+			continue
+		}
+		status, err := client.TicketStatus(ctx, ticketHash)
+		if err != nil {
+			choice.Err = err
+			continue
+		}
+
+		choice.Err = nil
+		choice.Synced = true
+		choice.VSPChoice = defaultAgendaChoice
+		if vspChoice, ok := status.VoteChoices[agendaID]; ok {
+			choice.VSPChoice = vspChoice
+		}
+	}
+	return nil
+}
+
+// ChangedAgendaChoices returns the tickets whose assigned choice differs
+// from their current choice, mapped to the assigned choice. Tickets whose
+// VSP reported a different choice are included so the VSP is updated.
+func ChangedAgendaChoices(current []*TicketAgendaChoice, assigned map[string]string) map[string]string {
+	changed := make(map[string]string)
+	for _, choice := range current {
+		newChoice, ok := assigned[choice.Ticket]
+		if !ok {
+			continue
+		}
+		if newChoice != choice.Choice || (choice.Synced && newChoice != choice.VSPChoice) {
+			changed[choice.Ticket] = newChoice
+		}
+	}
+	return changed
+}
+
+// AgendaChoiceCount is the number of tickets that will vote a choice.
+type AgendaChoiceCount struct {
+	Choice  string
+	Tickets int
+}
+
+// SummarizeAgendaChoices counts the tickets that will vote each choice once
+// the assigned choices are set, sorted by the number of tickets.
+func SummarizeAgendaChoices(current []*TicketAgendaChoice, assigned map[string]string) []AgendaChoiceCount {
+	counts := make(map[string]int)
+	for _, choice := range current {
+		if newChoice, ok := assigned[choice.Ticket]; ok {
+			counts[newChoice]++
+		} else {
+			counts[choice.Choice]++
+		}
+	}
+
+	summary := make([]AgendaChoiceCount, 0, len(counts))
+	for choice, tickets := range counts {
+		summary = append(summary, AgendaChoiceCount{Choice: choice, Tickets: tickets})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Tickets != summary[j].Tickets {
+			return summary[i].Tickets > summary[j].Tickets
+		}
+		return summary[i].Choice < summary[j].Choice
+	})
+	return summary
+}
+
+// SetTicketAgendaChoices saves the choice of each ticket in changes and
+// updates the VSP the ticket is registered with. All tickets are tried and
+// the tickets that failed are returned with their errors.
+func SetTicketAgendaChoices(w *dcrlibwallet.Wallet, agendaID string, changes map[string]string, passphrase []byte) (map[string]error, error) {
+	failed := make(map[string]error)
+	for ticket, choice := range changes {
+		err := w.SetVoteChoice(agendaID, choice, ticket, passphrase)
+		if err != nil {
+			if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
+				return nil, err
+			}
+			log.Errorf("[%d] Error setting vote choice of ticket %s: %v", w.ID, ticket, err)
+			failed[ticket] = fmt.Errorf("ticket %s: %v", ticket, err)
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testTicketAgendaChoices() []*TicketAgendaChoice {
+	return []*TicketAgendaChoice{
+		{Ticket: "aa", Choice: "yes"},
+		{Ticket: "bb", Choice: "abstain"},
+		{Ticket: "cc", Choice: "no", Synced: true, VSPChoice: "abstain"},
+		{Ticket: "dd", Choice: "no", Synced: true, VSPChoice: "no"},
+	}
+}
+
+func TestTicketAgendaChoiceMismatch(t *testing.T) {
+	choices := testTicketAgendaChoices()
+	for i, want := range []bool{false, false, true, false} {
+		if got := choices[i].Mismatch(); got != want {
+			t.Errorf("ticket %s: expected mismatch %v, got %v", choices[i].Ticket, want, got)
+		}
+	}
+}
+
+func TestChangedAgendaChoices(t *testing.T) {
+	assigned := map[string]string{
+		// Unchanged.
+		"aa": "yes",
+		// Changed.
+		"bb": "no",
+		// Unchanged in the wallet but the VSP must be updated.
+		"cc": "no",
+		"dd": "no",
+		// Not a current ticket.
+		"ee": "yes",
+	}
+
+	changed := ChangedAgendaChoices(testTicketAgendaChoices(), assigned)
+	want := map[string]string{"bb": "no", "cc": "no"}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected %v, got %v", want, changed)
+	}
+}
+
+func TestSummarizeAgendaChoices(t *testing.T) {
+	summary := SummarizeAgendaChoices(testTicketAgendaChoices(), map[string]string{"bb": "no", "aa": "abstain"})
+	want := []AgendaChoiceCount{
+		{Choice: "no", Tickets: 3},
+		{Choice: "abstain", Tickets: 1},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("expected %v, got %v", want, summary)
+	}
+
+	summary = SummarizeAgendaChoices(testTicketAgendaChoices(), nil)
+	want = []AgendaChoiceCount{
+		{Choice: "no", Tickets: 2},
+		{Choice: "abstain", Tickets: 1},
+		{Choice: "yes", Tickets: 1},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("expected %v, got %v", want, summary)
+	}
+}
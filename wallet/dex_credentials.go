@@ -0,0 +1,144 @@
+package wallet
+
+import (
+	"errors"
+)
+
+// dexLegacyPass is the password of the DEX client database created by the
+// releases without a DEX password. It is only used to migrate the database
+// to a password chosen by the user.
+const dexLegacyPass = "DEXClientPass"
+
+// dexPassSetConfigKey is the config key set once the DEX client database is
+// protected by a password chosen by the user.
+const dexPassSetConfigKey = "dex_pass_set"
+
+// ErrDexPassNotLegacy is returned when migrating a DEX client database that
+// is not protected by the legacy password.
+var ErrDexPassNotLegacy = errors.New("the DEX database is already protected by a password")
+
+// DexAuth is the part of the DEX client used to set up and unlock the DEX
+// client database. It is satisfied by *dcrlibwallet.DexClient.
+type DexAuth interface {
+	Initialized() bool
+	IsLoggedIn() bool
+	InitializeWithPassword(pw []byte) error
+	Login(pw []byte) error
+}
+
+// DexPassChanger changes the password of the DEX client database. It is
+// satisfied by *core.Core.
+type DexPassChanger interface {
+	ChangeAppPass(appPW, newAppPW []byte) error
+}
+
+// DexPassState is the state of the password of the DEX client database.
+type DexPassState int
+
+const (
+	// DexPassNotCreated is the state of a DEX client that is not
+	// initialized, a password must be created.
+	DexPassNotCreated DexPassState = iota
+	// DexPassLegacy is the state of a DEX client database protected by the
+	// legacy password, a password must be created to replace it.
+	DexPassLegacy
+	// DexPassLocked is the state of a DEX client that is not logged in.
+	DexPassLocked
+	// DexPassUnlocked is the state of a DEX client that is logged in.
+	DexPassUnlocked
+)
+
+// dexPassState returns the password state of the DEX client. passSet is true
+// if the database is known to be protected by a password chosen by the user.
+func dexPassState(c DexAuth, passSet bool) DexPassState {
+	switch {
+	case !c.Initialized():
+		return DexPassNotCreated
+	case !passSet:
+		return DexPassLegacy
+	case !c.IsLoggedIn():
+		return DexPassLocked
+	default:
+		return DexPassUnlocked
+	}
+}
+
+// DexPassState returns the password state of the DEX client.
+func (wal *Wallet) DexPassState(c DexAuth) DexPassState {
+	return dexPassState(c, wal.multi.ReadBoolConfigValueForKey(dexPassSetConfigKey, false))
+}
+
+func (wal *Wallet) setDexPassSet() {
+	wal.multi.SaveUserConfigValue(dexPassSetConfigKey, true)
+}
+
+// CreateDexPass initializes the DEX client with the password.
+func (wal *Wallet) CreateDexPass(c DexAuth, pw []byte) error {
+	if err := c.InitializeWithPassword(pw); err != nil {
+		return err
+	}
+	wal.setDexPassSet()
+	return nil
+}
+
+// migrateDexPass replaces the legacy password of the DEX client database
+// with pw. The client is logged in with the legacy password first, which
+// fails if the database is protected by another password.
+func migrateDexPass(c DexAuth, changer DexPassChanger, pw []byte) error {
+	if err := c.Login([]byte(dexLegacyPass)); err != nil {
+		return ErrDexPassNotLegacy
+	}
+	return changer.ChangeAppPass([]byte(dexLegacyPass), pw)
+}
+
+// MigrateDexPass replaces the legacy password of the DEX client database with
+// pw and logs in. ErrDexPassNotLegacy is returned if the database is not
+// protected by the legacy password, which happens if the app config was
+// reset, the password must then be entered to log in.
+func (wal *Wallet) MigrateDexPass(c DexAuth, changer DexPassChanger, pw []byte) error {
+	err := migrateDexPass(c, changer, pw)
+	if err == nil || err == ErrDexPassNotLegacy {
+		wal.setDexPassSet()
+	}
+	return err
+}
+
+// UnlockDex logs in to the DEX client with the password, which is cached
+// for the session if cache is true.
+func (wal *Wallet) UnlockDex(c DexAuth, pw []byte, cache bool) error {
+	if err := c.Login(pw); err != nil {
+		return err
+	}
+	if cache {
+		wal.CacheDexPass(pw)
+	}
+	return nil
+}
+
+// CacheDexPass keeps the DEX password in memory until ForgetDexPass is called
+// or the app exits, so that it is not requested for every DEX operation.
+func (wal *Wallet) CacheDexPass(pw []byte) {
+	wal.dexPassMu.Lock()
+	defer wal.dexPassMu.Unlock()
+	wal.dexPass = append([]byte(nil), pw...)
+}
+
+// CachedDexPass returns the DEX password cached for the session, or nil.
+func (wal *Wallet) CachedDexPass() []byte {
+	wal.dexPassMu.Lock()
+	defer wal.dexPassMu.Unlock()
+	if wal.dexPass == nil {
+		return nil
+	}
+	return append([]byte(nil), wal.dexPass...)
+}
+
+// ForgetDexPass clears the DEX password cached for the session.
+func (wal *Wallet) ForgetDexPass() {
+	wal.dexPassMu.Lock()
+	defer wal.dexPassMu.Unlock()
+	for i := range wal.dexPass {
+		wal.dexPass[i] = 0
+	}
+	wal.dexPass = nil
+}
@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type testDexAuth struct {
+	pass     string
+	loggedIn bool
+}
+
+func (c *testDexAuth) Initialized() bool {
+	return c.pass != ""
+}
+
+func (c *testDexAuth) IsLoggedIn() bool {
+	return c.loggedIn
+}
+
+func (c *testDexAuth) InitializeWithPassword(pw []byte) error {
+	c.pass, c.loggedIn = string(pw), true
+	return nil
+}
+
+func (c *testDexAuth) Login(pw []byte) error {
+	if string(pw) != c.pass {
+		return errors.New("incorrect password")
+	}
+	c.loggedIn = true
+	return nil
+}
+
+func (c *testDexAuth) ChangeAppPass(appPW, newAppPW []byte) error {
+	if string(appPW) != c.pass {
+		return errors.New("old password error")
+	}
+	c.pass = string(newAppPW)
+	return nil
+}
+
+func TestDexPassState(t *testing.T) {
+	c := new(testDexAuth)
+	if state := dexPassState(c, false); state != DexPassNotCreated {
+		t.Errorf("expected a password to be created, got %d", state)
+	}
+
+	c.pass = dexLegacyPass
+	if state := dexPassState(c, false); state != DexPassLegacy {
+		t.Errorf("expected the legacy password, got %d", state)
+	}
+	if state := dexPassState(c, true); state != DexPassLocked {
+		t.Errorf("expected the client to be locked, got %d", state)
+	}
+
+	c.loggedIn = true
+	if state := dexPassState(c, true); state != DexPassUnlocked {
+		t.Errorf("expected the client to be unlocked, got %d", state)
+	}
+}
+
+func TestMigrateDexPass(t *testing.T) {
+	c := &testDexAuth{pass: dexLegacyPass}
+	if err := migrateDexPass(c, c, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	if c.pass != "new" || !c.loggedIn {
+		t.Errorf("expected the password to be replaced and the client logged in, got %+v", c)
+	}
+
+	c = &testDexAuth{pass: "user"}
+	if err := migrateDexPass(c, c, []byte("new")); err != ErrDexPassNotLegacy {
+		t.Errorf("expected ErrDexPassNotLegacy, got %v", err)
+	}
+	if c.pass != "user" || c.loggedIn {
+		t.Errorf("expected the password to be kept, got %+v", c)
+	}
+}
+
+func TestCacheDexPass(t *testing.T) {
+	wal := new(Wallet)
+	if pw := wal.CachedDexPass(); pw != nil {
+		t.Fatalf("expected no cached password, got %s", pw)
+	}
+
+	pw := []byte("pass")
+	wal.CacheDexPass(pw)
+	pw[0] = 'x'
+	if cached := wal.CachedDexPass(); !bytes.Equal(cached, []byte("pass")) {
+		t.Errorf("expected a copy of the password to be cached, got %s", cached)
+	}
+
+	wal.ForgetDexPass()
+	if pw := wal.CachedDexPass(); pw != nil {
+		t.Errorf("expected the cached password to be cleared, got %s", pw)
+	}
+}
@@ -0,0 +1,213 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// dexOrdersPageSize is the number of orders read from the dcrdex database at
+// a time.
+const dexOrdersPageSize = 100
+
+const (
+	dcrAssetID = 42
+	btcAssetID = 0
+)
+
+// DexOrderSide filters orders by side.
+type DexOrderSide int
+
+const (
+	DexSideAny DexOrderSide = iota
+	DexSideBuy
+	DexSideSell
+)
+
+// DexOrderFilter selects orders of the order history. The zero value selects
+// all the orders.
+type DexOrderFilter struct {
+	// Market is the name of the market of the orders, e.g. dcr_btc.
+	Market   string
+	Statuses []order.OrderStatus
+	Side     DexOrderSide
+	// Since excludes the orders placed before it.
+	Since time.Time
+}
+
+// match returns true if the order is selected by the filter.
+func (f *DexOrderFilter) match(ord *core.Order) bool {
+	if f.Market != "" && ord.MarketID != f.Market {
+		return false
+	}
+	if f.Side == DexSideBuy && ord.Sell || f.Side == DexSideSell && !ord.Sell {
+		return false
+	}
+	if !f.Since.IsZero() && dexStampTime(ord.Stamp).Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// dexStampTime converts a dcrdex timestamp in milliseconds to a time.
+func dexStampTime(stamp uint64) time.Time {
+	return time.UnixMilli(int64(stamp))
+}
+
+// DexOrderHistory returns the orders of all the DEX servers selected by the
+// filter, newest first.
+func DexOrderHistory(c DexCore, filter DexOrderFilter) ([]*core.Order, error) {
+	var orders []*core.Order
+	var offset dex.Bytes
+	for {
+		page, err := c.Orders(&core.OrderFilter{
+			N:        dexOrdersPageSize,
+			Offset:   offset,
+			Statuses: filter.Statuses,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ord := range page {
+			// Orders are read newest first, the orders left are all too old.
+			if !filter.Since.IsZero() && dexStampTime(ord.Stamp).Before(filter.Since) {
+				return orders, nil
+			}
+			if filter.match(ord) {
+				orders = append(orders, ord)
+			}
+		}
+
+		if len(page) < dexOrdersPageSize {
+			return orders, nil
+		}
+		offset = page[len(page)-1].ID
+	}
+}
+
+// dexCoinTxHash returns the hash of the transaction of a swap, redeem or
+// refund coin, which are identified by the transaction hash and an output
+// or input index.
+func dexCoinTxHash(coin *core.Coin) string {
+	return strings.Split(coin.StringID, ":")[0]
+}
+
+// DexCoinExplorerURL returns the block explorer URL of the transaction of a
+// swap, redeem or refund coin, or an empty string for assets without a
+// known block explorer.
+func (wal *Wallet) DexCoinExplorerURL(coin *core.Coin) string {
+	txHash := dexCoinTxHash(coin)
+	switch coin.AssetID {
+	case dcrAssetID:
+		return wal.GetBlockExplorerURL(txHash)
+	case btcAssetID:
+		if wal.Net == dcrlibwallet.Testnet3 {
+			return "https://blockstream.info/testnet/tx/" + txHash
+		}
+		return "https://blockstream.info/tx/" + txHash
+	default:
+		return ""
+	}
+}
+
+// DexUnitInfo returns the unit info of the asset. Assets without a
+// registered wallet driver are assumed to have 1e8 atoms per coin.
+func DexUnitInfo(assetID uint32) dex.UnitInfo {
+	info, err := asset.Info(assetID)
+	if err != nil {
+		return dex.UnitInfo{Conventional: dex.Denomination{ConversionFactor: 1e8}}
+	}
+	return info.UnitInfo
+}
+
+var dexOrdersCSVHeader = []string{
+	"order_id", "host", "market", "type", "side", "status", "time", "rate", "quantity", "filled",
+	"match_id", "match_status", "match_side", "match_time", "match_rate", "match_quantity",
+	"swap", "counter_swap", "redeem", "counter_redeem", "refund",
+}
+
+// WriteDexOrdersCSV writes the orders to out in CSV format after a header
+// row. Each match of an order is written on a row of its own, repeating the
+// order columns, and orders without matches are written on a single row.
+// Quantities are in the atoms of the base asset, except for market buys for
+// which they are in the atoms of the quote asset. Rates are in quote asset
+// per base asset.
+func WriteDexOrdersCSV(out io.Writer, orders []*core.Order) error {
+	coinID := func(coin *core.Coin) string {
+		if coin == nil {
+			return ""
+		}
+		return coin.StringID
+	}
+
+	writer := csv.NewWriter(out)
+	if err := writer.Write(dexOrdersCSVHeader); err != nil {
+		return err
+	}
+	for _, ord := range orders {
+		baseUnits, quoteUnits := DexUnitInfo(ord.BaseID), DexUnitInfo(ord.QuoteID)
+		rate := func(msgRate uint64) string {
+			if msgRate == 0 {
+				return ""
+			}
+			return strconv.FormatFloat(calc.ConventionalRate(msgRate, baseUnits, quoteUnits), 'f', -1, 64)
+		}
+		side := "buy"
+		if ord.Sell {
+			side = "sell"
+		}
+		orderRow := []string{
+			ord.ID.String(), ord.Host, ord.MarketID, ord.Type.String(), side, ord.Status.String(),
+			dexStampTime(ord.Stamp).UTC().Format(time.RFC3339), rate(ord.Rate),
+			strconv.FormatUint(ord.Qty, 10), strconv.FormatUint(ord.Filled, 10),
+		}
+
+		var matches []*core.Match
+		for _, match := range ord.Matches {
+			if !match.IsCancel {
+				matches = append(matches, match)
+			}
+		}
+		if len(matches) == 0 {
+			if err := writer.Write(append(orderRow, make([]string, len(dexOrdersCSVHeader)-len(orderRow))...)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, match := range matches {
+			row := append(append([]string(nil), orderRow...),
+				match.MatchID.String(), match.Status.String(), match.Side.String(),
+				dexStampTime(match.Stamp).UTC().Format(time.RFC3339), rate(match.Rate),
+				strconv.FormatUint(match.Qty, 10),
+				coinID(match.Swap), coinID(match.CounterSwap), coinID(match.Redeem),
+				coinID(match.CounterRedeem), coinID(match.Refund),
+			)
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportDexOrders writes the orders to a CSV file in the exports directory
+// and returns the file path.
+func (wal *Wallet) ExportDexOrders(orders []*core.Order) (string, error) {
+	name := fmt.Sprintf("dex-orders-%s.csv", time.Now().Format("20060102-150405"))
+	return wal.writeExport(name, func(out io.Writer) error {
+		return WriteDexOrdersCSV(out, orders)
+	})
+}
@@ -0,0 +1,137 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+)
+
+// testDexOrders returns orders placed an hour apart, newest first.
+func testDexOrders(n int, now time.Time) []*core.Order {
+	orders := make([]*core.Order, n)
+	for i := range orders {
+		orders[i] = &core.Order{
+			ID:       dex.Bytes{byte(i >> 8), byte(i)},
+			Host:     "dex.test",
+			MarketID: "dcr_btc",
+			BaseID:   testDcrID,
+			QuoteID:  testBtcID,
+			Type:     order.LimitOrderType,
+			Sell:     i%2 == 0,
+			Status:   order.OrderStatusExecuted,
+			Stamp:    uint64(now.Add(-time.Duration(i) * time.Hour).UnixMilli()),
+			Qty:      1e8,
+			Rate:     1e6,
+		}
+	}
+	return orders
+}
+
+func TestDexOrderHistory(t *testing.T) {
+	now := time.Now()
+	c := &testDexCore{orders: testDexOrders(2*dexOrdersPageSize+10, now)}
+	c.orders[3].MarketID = "btc_usdc"
+
+	orders, err := DexOrderHistory(c, DexOrderFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != len(c.orders) || c.ordersCalls != 3 {
+		t.Fatalf("expected all %d orders in 3 pages, got %d orders in %d pages", len(c.orders), len(orders), c.ordersCalls)
+	}
+
+	orders, err = DexOrderHistory(c, DexOrderFilter{Market: "dcr_btc", Side: DexSideSell, Statuses: []order.OrderStatus{order.OrderStatusExecuted}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != dexOrdersPageSize+5 {
+		t.Errorf("expected %d sell orders, got %d", dexOrdersPageSize+5, len(orders))
+	}
+	for _, ord := range orders {
+		if !ord.Sell || ord.MarketID != "dcr_btc" {
+			t.Fatalf("unexpected order %+v", ord)
+		}
+	}
+	if len(c.filter.Statuses) != 1 {
+		t.Errorf("expected the statuses to be queried, got %+v", c.filter)
+	}
+
+	// Paging stops at the first order older than the date filter.
+	c.ordersCalls = 0
+	orders, err = DexOrderHistory(c, DexOrderFilter{Since: now.Add(-10*time.Hour - time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 11 || c.ordersCalls != 1 {
+		t.Errorf("expected 11 orders in 1 page, got %d orders in %d pages", len(orders), c.ordersCalls)
+	}
+}
+
+func TestWriteDexOrdersCSV(t *testing.T) {
+	orders := testDexOrders(2, time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC))
+	orders[0].Matches = []*core.Match{
+		{
+			MatchID: dex.Bytes{0xaa},
+			Status:  order.MatchComplete,
+			Side:    order.Maker,
+			Rate:    1e6,
+			Qty:     5e7,
+			Swap:    &core.Coin{StringID: "swap:0"},
+			Redeem:  &core.Coin{StringID: "redeem:1"},
+		},
+		{MatchID: dex.Bytes{0xbb}, Status: order.MakerSwapCast, Qty: 5e7, Refund: &core.Coin{StringID: "refund:0"}},
+		{MatchID: dex.Bytes{0xcc}, IsCancel: true},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDexOrdersCSV(&buf, orders); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected header, 2 match rows and 1 order row, got %d rows", len(rows))
+	}
+
+	row := func(i int) map[string]string {
+		r := make(map[string]string)
+		for j, column := range rows[0] {
+			r[column] = rows[i][j]
+		}
+		return r
+	}
+	expected := []map[string]string{
+		{"order_id": "0000", "side": "sell", "time": "2022-08-01T12:00:00Z", "rate": "0.01", "match_id": "aa",
+			"match_quantity": "50000000", "swap": "swap:0", "redeem": "redeem:1", "refund": ""},
+		{"order_id": "0000", "match_id": "bb", "refund": "refund:0"},
+		{"order_id": "0001", "side": "buy", "time": "2022-08-01T11:00:00Z", "quantity": "100000000", "match_id": ""},
+	}
+	for i, columns := range expected {
+		r := row(i + 1)
+		for column, value := range columns {
+			if r[column] != value {
+				t.Errorf("row %d: expected %s %q, got %q", i+1, column, value, r[column])
+			}
+		}
+	}
+}
+
+func TestDexCoinExplorerURL(t *testing.T) {
+	wal := &Wallet{Net: "mainnet"}
+	if url := wal.DexCoinExplorerURL(&core.Coin{AssetID: dcrAssetID, StringID: "abcd:1"}); url != "https://explorer.dcrdata.org/tx/abcd" {
+		t.Errorf("unexpected DCR explorer URL %q", url)
+	}
+	if url := wal.DexCoinExplorerURL(&core.Coin{AssetID: btcAssetID, StringID: "abcd:0"}); url != "https://blockstream.info/tx/abcd" {
+		t.Errorf("unexpected BTC explorer URL %q", url)
+	}
+	if url := wal.DexCoinExplorerURL(&core.Coin{AssetID: 60, StringID: "abcd"}); url != "" {
+		t.Errorf("expected no explorer URL for unknown assets, got %q", url)
+	}
+}
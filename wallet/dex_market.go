@@ -0,0 +1,385 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+)
+
+// maxDexTrades is the number of the most recent trades kept for the trade
+// tape of a market.
+const maxDexTrades = 50
+
+// dexTradeCandleDur is the duration of the candles that the trade tape is
+// read from. The smallest candles report the trades of each epoch soonest.
+const dexTradeCandleDur = "5m"
+
+var (
+	// ErrInvalidQuantity is returned for orders with no quantity.
+	ErrInvalidQuantity = errors.New("order quantity must be more than zero")
+	// ErrLotSize is returned for orders with a quantity that is not a
+	// multiple of the lot size of the market.
+	ErrLotSize = errors.New("order quantity must be a multiple of the lot size")
+	// ErrInvalidRate is returned for limit orders with no rate.
+	ErrInvalidRate = errors.New("order rate must be more than zero")
+	// ErrRateStep is returned for limit orders with a rate that is not a
+	// multiple of the rate step of the market.
+	ErrRateStep = errors.New("order rate must be a multiple of the rate step")
+	// ErrNoLiquidity is returned for market orders when the opposite side of
+	// the book is empty.
+	ErrNoLiquidity = errors.New("no orders on the other side of the book")
+	// ErrMarketBuyTooSmall is returned for market buys that cannot buy a lot
+	// at the best sell rate.
+	ErrMarketBuyTooSmall = errors.New("market buy amount is too small to buy a lot")
+)
+
+// DexCore is the part of the dcrdex client core used to trade on a market.
+// It is satisfied by *core.Core.
+type DexCore interface {
+	SyncBook(host string, base, quote uint32) (core.BookFeed, error)
+	Trade(pw []byte, form *core.TradeForm) (*core.Order, error)
+	Cancel(pw []byte, oid dex.Bytes) error
+	Orders(filter *core.OrderFilter) ([]*core.Order, error)
+}
+
+var _ DexCore = (*core.Core)(nil)
+
+// DexOrderForm is an order as entered by the user, in conventional units.
+type DexOrderForm struct {
+	Sell    bool
+	IsLimit bool
+	// Qty is the amount of the base asset to buy or sell, except for market
+	// buys where it is the amount of the quote asset to spend.
+	Qty float64
+	// Rate is the price in quote asset per base asset. It is ignored for
+	// market orders.
+	Rate float64
+}
+
+// DexTrade is a trade made on a market, as reported by its candles.
+type DexTrade struct {
+	// Stamp is the end of the epoch of the trade, in milliseconds.
+	Stamp uint64
+	// Qty is the amount of the base asset traded, in atoms.
+	Qty uint64
+	// Rate is the message rate of the last match of the epoch.
+	Rate uint64
+	// Up is true if the rate is higher than the previous trade.
+	Up bool
+}
+
+// DexMarket keeps the order book and recent trades of a dcrdex market up to
+// date from the core book feed, and places and cancels orders on it.
+type DexMarket struct {
+	core   DexCore
+	host   string
+	market *core.Market
+
+	baseUnits  dex.UnitInfo
+	quoteUnits dex.UnitInfo
+
+	mu       sync.Mutex
+	sells    map[string]*core.MiniOrder
+	buys     map[string]*core.MiniOrder
+	trades   []DexTrade
+	volumes  map[uint64]uint64
+	onUpdate func()
+}
+
+// NewDexMarket returns the market mkt of the DEX at host.
+func NewDexMarket(c DexCore, host string, mkt *core.Market) (*DexMarket, error) {
+	baseInfo, err := asset.Info(mkt.BaseID)
+	if err != nil {
+		return nil, err
+	}
+	quoteInfo, err := asset.Info(mkt.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+	return newDexMarket(c, host, mkt, baseInfo.UnitInfo, quoteInfo.UnitInfo), nil
+}
+
+func newDexMarket(c DexCore, host string, mkt *core.Market, baseUnits, quoteUnits dex.UnitInfo) *DexMarket {
+	return &DexMarket{
+		core:       c,
+		host:       host,
+		market:     mkt,
+		baseUnits:  baseUnits,
+		quoteUnits: quoteUnits,
+		sells:      make(map[string]*core.MiniOrder),
+		buys:       make(map[string]*core.MiniOrder),
+		volumes:    make(map[uint64]uint64),
+	}
+}
+
+// Market returns the market info.
+func (m *DexMarket) Market() *core.Market {
+	return m.market
+}
+
+// Host returns the host of the DEX server of the market.
+func (m *DexMarket) Host() string {
+	return m.host
+}
+
+// BaseUnits and QuoteUnits return the unit info of the assets of the market.
+func (m *DexMarket) BaseUnits() dex.UnitInfo {
+	return m.baseUnits
+}
+
+func (m *DexMarket) QuoteUnits() dex.UnitInfo {
+	return m.quoteUnits
+}
+
+// Sync subscribes to the order book and candles of the market and applies
+// their updates until ctx is cancelled. onUpdate is called after each update.
+func (m *DexMarket) Sync(ctx context.Context, onUpdate func()) error {
+	feed, err := m.core.SyncBook(m.host, m.market.BaseID, m.market.QuoteID)
+	if err != nil {
+		return err
+	}
+	if err = feed.Candles(dexTradeCandleDur); err != nil {
+		log.Errorf("Error subscribing to %s candles: %v", m.market.Name, err)
+	}
+
+	m.mu.Lock()
+	m.onUpdate = onUpdate
+	m.mu.Unlock()
+
+	go func() {
+		defer feed.Close()
+		for {
+			select {
+			case update, ok := <-feed.Next():
+				if !ok {
+					return
+				}
+				m.handleBookUpdate(update)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// handleBookUpdate applies an update of the book feed.
+func (m *DexMarket) handleBookUpdate(update *core.BookUpdate) {
+	m.mu.Lock()
+	switch update.Action {
+	case core.FreshBookAction:
+		if payload, ok := update.Payload.(*core.MarketOrderBook); ok && payload.Book != nil {
+			m.sells = make(map[string]*core.MiniOrder)
+			m.buys = make(map[string]*core.MiniOrder)
+			for _, ord := range payload.Book.Sells {
+				m.sells[ord.Token] = ord
+			}
+			for _, ord := range payload.Book.Buys {
+				m.buys[ord.Token] = ord
+			}
+		}
+	case core.BookOrderAction:
+		if ord, ok := update.Payload.(*core.MiniOrder); ok {
+			if ord.Sell {
+				m.sells[ord.Token] = ord
+			} else {
+				m.buys[ord.Token] = ord
+			}
+		}
+	case core.UnbookOrderAction:
+		if ord, ok := update.Payload.(*core.MiniOrder); ok {
+			delete(m.sells, ord.Token)
+			delete(m.buys, ord.Token)
+		}
+	case core.UpdateRemainingAction:
+		if remainder, ok := update.Payload.(*core.RemainderUpdate); ok {
+			for _, side := range []map[string]*core.MiniOrder{m.sells, m.buys} {
+				if ord, ok := side[remainder.Token]; ok {
+					ord.Qty = remainder.Qty
+					ord.QtyAtomic = remainder.QtyAtomic
+				}
+			}
+		}
+	case core.FreshCandlesAction:
+		// The volumes of the existing candles are the starting point of the
+		// trade tape.
+		if payload, ok := update.Payload.(*core.CandlesPayload); ok && payload.Dur == dexTradeCandleDur {
+			for _, candle := range payload.Candles {
+				m.volumes[candle.StartStamp] = candle.MatchVolume
+			}
+		}
+	case core.CandleUpdateAction:
+		if payload, ok := update.Payload.(core.CandleUpdate); ok && payload.Dur == dexTradeCandleDur && payload.Candle != nil {
+			m.addTrade(payload.Candle.StartStamp, payload.Candle.EndStamp, payload.Candle.MatchVolume, payload.Candle.EndRate)
+		}
+	}
+	onUpdate := m.onUpdate
+	m.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate()
+	}
+}
+
+// addTrade records the volume matched since the last update of the candle
+// starting at start as a trade.
+func (m *DexMarket) addTrade(start, end, volume, rate uint64) {
+	if volume <= m.volumes[start] {
+		return
+	}
+	traded := volume - m.volumes[start]
+	m.volumes[start] = volume
+
+	trade := DexTrade{Stamp: end, Qty: traded, Rate: rate}
+	if len(m.trades) > 0 {
+		trade.Up = rate >= m.trades[0].Rate
+	}
+	m.trades = append([]DexTrade{trade}, m.trades...)
+	if len(m.trades) > maxDexTrades {
+		m.trades = m.trades[:maxDexTrades]
+	}
+}
+
+// Book returns the sell orders from the lowest rate and the buy orders from
+// the highest rate.
+func (m *DexMarket) Book() (sells, buys []*core.MiniOrder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ord := range m.sells {
+		sells = append(sells, ord)
+	}
+	for _, ord := range m.buys {
+		buys = append(buys, ord)
+	}
+	sort.Slice(sells, func(i, j int) bool {
+		return sells[i].MsgRate < sells[j].MsgRate
+	})
+	sort.Slice(buys, func(i, j int) bool {
+		return buys[i].MsgRate > buys[j].MsgRate
+	})
+	return sells, buys
+}
+
+// Trades returns the recent trades of the market, newest first.
+func (m *DexMarket) Trades() []DexTrade {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DexTrade(nil), m.trades...)
+}
+
+// toAtoms converts an amount in conventional units to atoms.
+func toAtoms(amount float64, units dex.UnitInfo) uint64 {
+	return uint64(math.Round(amount * float64(units.Conventional.ConversionFactor)))
+}
+
+// MsgRate converts a rate in conventional units to the message rate encoding.
+func (m *DexMarket) MsgRate(rate float64) uint64 {
+	return uint64(math.Round(rate * calc.RateEncodingFactor *
+		float64(m.quoteUnits.Conventional.ConversionFactor) / float64(m.baseUnits.Conventional.ConversionFactor)))
+}
+
+// ConventionalRate converts a message rate to conventional units.
+func (m *DexMarket) ConventionalRate(msgRate uint64) float64 {
+	return calc.ConventionalRate(msgRate, m.baseUnits, m.quoteUnits)
+}
+
+// TradeForm validates the order against the lot size and rate step of the
+// market and returns the form to submit it.
+func (m *DexMarket) TradeForm(form DexOrderForm) (*core.TradeForm, error) {
+	tradeForm := &core.TradeForm{
+		Host:    m.host,
+		IsLimit: form.IsLimit,
+		Sell:    form.Sell,
+		Base:    m.market.BaseID,
+		Quote:   m.market.QuoteID,
+		// Limit orders remain on the book until filled or cancelled.
+		TifNow: !form.IsLimit,
+	}
+
+	if form.Qty <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	if !form.IsLimit && !form.Sell {
+		// Market buys spend an amount of the quote asset that must buy at
+		// least a lot at the best sell rate, with the buffer required by the
+		// server.
+		tradeForm.Qty = toAtoms(form.Qty, m.quoteUnits)
+		sells, _ := m.Book()
+		if len(sells) == 0 {
+			return nil, ErrNoLiquidity
+		}
+		minQty := calc.BaseToQuote(sells[0].MsgRate, m.market.LotSize)
+		if float64(tradeForm.Qty) < float64(minQty)*m.market.MarketBuyBuffer {
+			return nil, ErrMarketBuyTooSmall
+		}
+		return tradeForm, nil
+	}
+
+	tradeForm.Qty = toAtoms(form.Qty, m.baseUnits)
+	if m.market.LotSize > 0 && tradeForm.Qty%m.market.LotSize != 0 {
+		return nil, ErrLotSize
+	}
+
+	if !form.IsLimit {
+		if _, buys := m.Book(); len(buys) == 0 {
+			return nil, ErrNoLiquidity
+		}
+		return tradeForm, nil
+	}
+
+	if form.Rate <= 0 {
+		return nil, ErrInvalidRate
+	}
+	tradeForm.Rate = m.MsgRate(form.Rate)
+	if m.market.RateStep > 0 && tradeForm.Rate%m.market.RateStep != 0 {
+		return nil, ErrRateStep
+	}
+	return tradeForm, nil
+}
+
+// PlaceOrder validates and submits the order.
+func (m *DexMarket) PlaceOrder(pw []byte, form DexOrderForm) (*core.Order, error) {
+	tradeForm, err := m.TradeForm(form)
+	if err != nil {
+		return nil, err
+	}
+	return m.core.Trade(pw, tradeForm)
+}
+
+// OpenOrders returns the orders of the market that are still in an epoch or
+// on the book.
+func (m *DexMarket) OpenOrders() ([]*core.Order, error) {
+	orders, err := m.core.Orders(&core.OrderFilter{
+		Hosts:    []string{m.host},
+		Assets:   []uint32{m.market.BaseID, m.market.QuoteID},
+		Statuses: []order.OrderStatus{order.OrderStatusEpoch, order.OrderStatusBooked},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The filter matches orders with either asset, which includes the
+	// orders of other markets.
+	var open []*core.Order
+	for _, ord := range orders {
+		if ord.BaseID == m.market.BaseID && ord.QuoteID == m.market.QuoteID {
+			open = append(open, ord)
+		}
+	}
+	return open, nil
+}
+
+// CancelOrder cancels the order with the ID.
+func (m *DexMarket) CancelOrder(pw []byte, id dex.Bytes) error {
+	return m.core.Cancel(pw, id)
+}
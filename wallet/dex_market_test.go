@@ -0,0 +1,261 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/candles"
+	"decred.org/dcrdex/dex/msgjson"
+)
+
+type testBookFeed struct {
+	updates chan *core.BookUpdate
+	candles []string
+}
+
+func (f *testBookFeed) Next() <-chan *core.BookUpdate { return f.updates }
+func (f *testBookFeed) Close()                        {}
+func (f *testBookFeed) Candles(dur string) error {
+	f.candles = append(f.candles, dur)
+	return nil
+}
+
+type testDexCore struct {
+	feed        *testBookFeed
+	trades      []*core.TradeForm
+	cancelled   []dex.Bytes
+	orders      []*core.Order
+	filter      *core.OrderFilter
+	ordersCalls int
+}
+
+func (c *testDexCore) SyncBook(host string, base, quote uint32) (core.BookFeed, error) {
+	return c.feed, nil
+}
+
+func (c *testDexCore) Trade(pw []byte, form *core.TradeForm) (*core.Order, error) {
+	c.trades = append(c.trades, form)
+	return &core.Order{Host: form.Host, BaseID: form.Base, QuoteID: form.Quote}, nil
+}
+
+func (c *testDexCore) Cancel(pw []byte, oid dex.Bytes) error {
+	c.cancelled = append(c.cancelled, oid)
+	return nil
+}
+
+// Orders returns the orders after the offset order, up to filter.N orders.
+func (c *testDexCore) Orders(filter *core.OrderFilter) ([]*core.Order, error) {
+	c.filter = filter
+	c.ordersCalls++
+	orders := c.orders
+	for i, ord := range orders {
+		if len(filter.Offset) > 0 && bytes.Equal(ord.ID, filter.Offset) {
+			orders = orders[i+1:]
+			break
+		}
+	}
+	if filter.N > 0 && len(orders) > filter.N {
+		orders = orders[:filter.N]
+	}
+	return orders, nil
+}
+
+const (
+	testDcrID = 42
+	testBtcID = 0
+)
+
+// newTestDexMarket returns a DCR/BTC market with a lot size of 1 DCR and a
+// rate step of 0.00001 BTC.
+func newTestDexMarket(c DexCore) *DexMarket {
+	units := dex.UnitInfo{Conventional: dex.Denomination{ConversionFactor: 1e8}}
+	return newDexMarket(c, "dex.test", &core.Market{
+		Name:            "dcr_btc",
+		BaseID:          testDcrID,
+		QuoteID:         testBtcID,
+		LotSize:         1e8,
+		RateStep:        1000,
+		MarketBuyBuffer: 1.25,
+	}, units, units)
+}
+
+func testMiniOrder(token string, sell bool, qty, msgRate uint64) *core.MiniOrder {
+	return &core.MiniOrder{Token: token, Sell: sell, QtyAtomic: qty, MsgRate: msgRate}
+}
+
+func TestDexMarketBook(t *testing.T) {
+	m := newTestDexMarket(&testDexCore{})
+
+	m.handleBookUpdate(&core.BookUpdate{
+		Action: core.FreshBookAction,
+		Payload: &core.MarketOrderBook{Book: &core.OrderBook{
+			Sells: []*core.MiniOrder{testMiniOrder("s2", true, 2e8, 1200000), testMiniOrder("s1", true, 1e8, 1100000)},
+			Buys:  []*core.MiniOrder{testMiniOrder("b1", false, 1e8, 900000)},
+		}},
+	})
+	m.handleBookUpdate(&core.BookUpdate{Action: core.BookOrderAction, Payload: testMiniOrder("b2", false, 3e8, 1000000)})
+	m.handleBookUpdate(&core.BookUpdate{Action: core.UnbookOrderAction, Payload: testMiniOrder("s2", true, 0, 0)})
+	m.handleBookUpdate(&core.BookUpdate{Action: core.UpdateRemainingAction, Payload: &core.RemainderUpdate{Token: "b1", QtyAtomic: 5e7}})
+
+	sells, buys := m.Book()
+	if len(sells) != 1 || sells[0].Token != "s1" {
+		t.Fatalf("expected only sell s1 to remain, got %+v", sells)
+	}
+	if len(buys) != 2 || buys[0].Token != "b2" || buys[1].Token != "b1" {
+		t.Fatalf("expected buys b2 then b1, got %+v", buys)
+	}
+	if buys[1].QtyAtomic != 5e7 {
+		t.Errorf("expected b1 remaining quantity 5e7, got %d", buys[1].QtyAtomic)
+	}
+}
+
+func TestDexMarketTrades(t *testing.T) {
+	m := newTestDexMarket(&testDexCore{})
+
+	m.handleBookUpdate(&core.BookUpdate{
+		Action: core.FreshCandlesAction,
+		Payload: &core.CandlesPayload{
+			Dur:     dexTradeCandleDur,
+			Candles: []msgjson.Candle{{StartStamp: 1000, MatchVolume: 5e8}},
+		},
+	})
+	candleUpdate := func(dur string, start, volume, rate uint64) {
+		m.handleBookUpdate(&core.BookUpdate{
+			Action: core.CandleUpdateAction,
+			Payload: core.CandleUpdate{
+				Dur:    dur,
+				Candle: &candles.Candle{StartStamp: start, EndStamp: start + 100, MatchVolume: volume, EndRate: rate},
+			},
+		})
+	}
+	candleUpdate(dexTradeCandleDur, 1000, 7e8, 1000000)
+	// Candles of other durations report the same trades.
+	candleUpdate("24h", 0, 9e8, 1000000)
+	// Updates without new matches are not trades.
+	candleUpdate(dexTradeCandleDur, 1000, 7e8, 1000000)
+	candleUpdate(dexTradeCandleDur, 2000, 1e8, 900000)
+
+	trades := m.Trades()
+	if len(trades) != 2 {
+		t.Fatalf("expected 2 trades, got %+v", trades)
+	}
+	if trades[0].Qty != 1e8 || trades[0].Rate != 900000 || trades[0].Stamp != 2100 || trades[0].Up {
+		t.Errorf("unexpected newest trade %+v", trades[0])
+	}
+	if trades[1].Qty != 2e8 || trades[1].Rate != 1000000 {
+		t.Errorf("expected the volume delta of the existing candle, got %+v", trades[1])
+	}
+}
+
+func TestDexMarketTradeForm(t *testing.T) {
+	m := newTestDexMarket(&testDexCore{})
+
+	tests := []struct {
+		name string
+		form DexOrderForm
+		err  error
+	}{
+		{"no quantity", DexOrderForm{Sell: true, IsLimit: true, Rate: 0.01}, ErrInvalidQuantity},
+		{"partial lot", DexOrderForm{Sell: true, IsLimit: true, Qty: 1.5, Rate: 0.01}, ErrLotSize},
+		{"no rate", DexOrderForm{IsLimit: true, Qty: 2}, ErrInvalidRate},
+		{"off rate step", DexOrderForm{IsLimit: true, Qty: 2, Rate: 0.010005}, ErrRateStep},
+		{"limit", DexOrderForm{IsLimit: true, Qty: 2, Rate: 0.01}, nil},
+		{"market sell without buys", DexOrderForm{Sell: true, Qty: 1}, ErrNoLiquidity},
+		{"market buy without sells", DexOrderForm{Qty: 1}, ErrNoLiquidity},
+	}
+	for _, test := range tests {
+		if _, err := m.TradeForm(test.form); err != test.err {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	form, err := m.TradeForm(DexOrderForm{IsLimit: true, Qty: 2, Rate: 0.01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Qty != 2e8 || form.Rate != 1e6 || form.TifNow || form.Sell {
+		t.Errorf("unexpected limit buy form %+v", form)
+	}
+
+	// A lot at the best sell rate costs 0.01 BTC, 0.0125 BTC with the market
+	// buy buffer.
+	m.handleBookUpdate(&core.BookUpdate{Action: core.BookOrderAction, Payload: testMiniOrder("s1", true, 1e8, 1e6)})
+	if _, err = m.TradeForm(DexOrderForm{Qty: 0.012}); err != ErrMarketBuyTooSmall {
+		t.Errorf("expected %v, got %v", ErrMarketBuyTooSmall, err)
+	}
+	form, err = m.TradeForm(DexOrderForm{Qty: 0.0125})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Qty != 1250000 || !form.TifNow {
+		t.Errorf("unexpected market buy form %+v", form)
+	}
+}
+
+func TestDexMarketOrders(t *testing.T) {
+	c := &testDexCore{
+		orders: []*core.Order{
+			{BaseID: testDcrID, QuoteID: testBtcID, ID: dex.Bytes{1}},
+			{BaseID: testBtcID, QuoteID: testDcrID, ID: dex.Bytes{2}},
+			{BaseID: testDcrID, QuoteID: 60, ID: dex.Bytes{3}},
+		},
+	}
+	m := newTestDexMarket(c)
+
+	open, err := m.OpenOrders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 || !bytes.Equal(open[0].ID, dex.Bytes{1}) {
+		t.Errorf("expected only the orders of the market, got %+v", open)
+	}
+	if len(c.filter.Hosts) != 1 || c.filter.Hosts[0] != "dex.test" || len(c.filter.Statuses) != 2 {
+		t.Errorf("unexpected order filter %+v", c.filter)
+	}
+
+	if _, err = m.PlaceOrder(nil, DexOrderForm{Sell: true, IsLimit: true, Qty: 1.5, Rate: 0.01}); err != ErrLotSize {
+		t.Errorf("expected %v, got %v", ErrLotSize, err)
+	}
+	if _, err = m.PlaceOrder(nil, DexOrderForm{Sell: true, IsLimit: true, Qty: 1, Rate: 0.01}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.trades) != 1 || c.trades[0].Host != "dex.test" || c.trades[0].Base != testDcrID {
+		t.Errorf("expected the order to be placed, got %+v", c.trades)
+	}
+
+	if err = m.CancelOrder(nil, dex.Bytes{1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.cancelled) != 1 || !bytes.Equal(c.cancelled[0], dex.Bytes{1}) {
+		t.Errorf("expected the order to be cancelled, got %+v", c.cancelled)
+	}
+}
+
+func TestDexMarketSync(t *testing.T) {
+	c := &testDexCore{feed: &testBookFeed{updates: make(chan *core.BookUpdate)}}
+	m := newTestDexMarket(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updated := make(chan struct{}, 1)
+	if err := m.Sync(ctx, func() { updated <- struct{}{} }); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.feed.candles) != 1 || c.feed.candles[0] != dexTradeCandleDur {
+		t.Errorf("expected a subscription to the %s candles, got %v", dexTradeCandleDur, c.feed.candles)
+	}
+
+	c.feed.updates <- &core.BookUpdate{Action: core.BookOrderAction, Payload: testMiniOrder("s1", true, 1e8, 1e6)}
+	select {
+	case <-updated:
+	case <-time.After(time.Second):
+		t.Fatal("expected an update notification")
+	}
+	if sells, _ := m.Book(); len(sells) != 1 {
+		t.Errorf("expected the booked order, got %+v", sells)
+	}
+}
@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"context"
+	"sync"
+
+	"decred.org/dcrdex/client/core"
+)
+
+// DexNotificationSource is the part of the dcrdex client core providing the
+// notifications. It is satisfied by *core.Core. The dcrdex client never
+// releases the channels returned by NotificationFeed, so it must only be
+// called once for the lifetime of the app.
+type DexNotificationSource interface {
+	NotificationFeed() <-chan core.Notification
+}
+
+var _ DexNotificationSource = (*core.Core)(nil)
+
+// DexNotifier reads the notification feed of the DEX client and forwards the
+// notifications to its listeners.
+type DexNotifier struct {
+	mu        sync.Mutex
+	listeners map[string]func(core.Notification)
+
+	startOnce sync.Once
+}
+
+// NewDexNotifier returns a notifier without listeners.
+func NewDexNotifier() *DexNotifier {
+	return &DexNotifier{
+		listeners: make(map[string]func(core.Notification)),
+	}
+}
+
+// Start subscribes to the notifications of the client and forwards them until
+// ctx is canceled. The notifier only subscribes once.
+func (n *DexNotifier) Start(ctx context.Context, c DexNotificationSource) {
+	n.startOnce.Do(func() {
+		feed := c.NotificationFeed()
+		go func() {
+			for {
+				select {
+				case note := <-feed:
+					n.notify(note)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	})
+}
+
+func (n *DexNotifier) notify(note core.Notification) {
+	n.mu.Lock()
+	listeners := make([]func(core.Notification), 0, len(n.listeners))
+	for _, listener := range n.listeners {
+		listeners = append(listeners, listener)
+	}
+	n.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(note)
+	}
+}
+
+// AddListener calls listener with the notifications of the client until
+// RemoveListener is called with the same id. A listener added with the id of
+// another listener replaces it. Listeners are called from the goroutine
+// reading the feed and must not block.
+func (n *DexNotifier) AddListener(id string, listener func(core.Notification)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.listeners[id] = listener
+}
+
+// RemoveListener removes the listener added with the id.
+func (n *DexNotifier) RemoveListener(id string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.listeners, id)
+}
+
+// DexNotifier returns the notifier of the DEX client notifications.
+func (wal *Wallet) DexNotifier() *DexNotifier {
+	wal.dexNotifierOnce.Do(func() {
+		wal.dexNotifier = NewDexNotifier()
+	})
+	return wal.dexNotifier
+}
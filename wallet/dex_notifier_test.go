@@ -0,0 +1,56 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
+)
+
+type testDexFeed struct {
+	feed  chan core.Notification
+	calls int
+}
+
+func (f *testDexFeed) NotificationFeed() <-chan core.Notification {
+	f.calls++
+	return f.feed
+}
+
+func TestDexNotifier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := &testDexFeed{feed: make(chan core.Notification)}
+	n := NewDexNotifier()
+	n.Start(ctx, source)
+	n.Start(ctx, source)
+	if source.calls != 1 {
+		t.Fatalf("expected a single subscription, got %d", source.calls)
+	}
+
+	received := make(chan core.Notification, 1)
+	n.AddListener("test", func(note core.Notification) {
+		received <- note
+	})
+	note := db.NewNotification(core.NoteTypeMatch, "", "subject", "details", db.Data)
+	source.feed <- &note
+	select {
+	case got := <-received:
+		if got.Subject() != "subject" {
+			t.Errorf("unexpected notification %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the notification was not forwarded")
+	}
+
+	n.RemoveListener("test")
+	source.feed <- &note
+	select {
+	case <-received:
+		t.Error("a removed listener was notified")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
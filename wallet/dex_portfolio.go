@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+)
+
+// bittrexAPIHost is the host of the API used for the USD rates of the assets.
+const bittrexAPIHost = "https://api.bittrex.com"
+
+// rateRequestTimeout is the timeout of the requests of the USD rates.
+const rateRequestTimeout = 30 * time.Second
+
+// DexWallets is the part of the dcrdex client core used to read the asset
+// wallets. It is satisfied by *core.Core.
+type DexWallets interface {
+	Wallets() []*core.WalletState
+	NewDepositAddress(assetID uint32) (string, error)
+}
+
+var _ DexWallets = (*core.Core)(nil)
+
+// DexAssetBalance is the balance of an asset wallet of the DEX client, in the
+// atoms of the asset.
+type DexAssetBalance struct {
+	AssetID   uint32
+	Symbol    string
+	Units     dex.UnitInfo
+	Available uint64
+	Locked    uint64
+	Immature  uint64
+	// Address is the deposit address of the wallet.
+	Address string
+	Running bool
+}
+
+// Total returns the available, locked and immature balance.
+func (b *DexAssetBalance) Total() uint64 {
+	return b.Available + b.Locked + b.Immature
+}
+
+// ToCoin converts an amount in atoms to the conventional unit of the asset.
+func (b *DexAssetBalance) ToCoin(atoms uint64) float64 {
+	return float64(atoms) / float64(b.Units.Conventional.ConversionFactor)
+}
+
+// DexPortfolio returns the balances of the asset wallets of the DEX client,
+// ordered by symbol.
+func DexPortfolio(c DexWallets) []*DexAssetBalance {
+	wallets := c.Wallets()
+	balances := make([]*DexAssetBalance, 0, len(wallets))
+	for _, w := range wallets {
+		balance := &DexAssetBalance{
+			AssetID: w.AssetID,
+			Symbol:  w.Symbol,
+			Units:   DexUnitInfo(w.AssetID),
+			Address: w.Address,
+			Running: w.Running,
+		}
+		if w.Balance != nil && w.Balance.Balance != nil {
+			balance.Available = w.Balance.Available
+			balance.Locked = w.Balance.Locked
+			balance.Immature = w.Balance.Immature
+		}
+		balances = append(balances, balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Symbol < balances[j].Symbol
+	})
+	return balances
+}
+
+// DexPortfolioUSD returns the USD value of the balances for which a rate is
+// known. rates maps the asset IDs to their USD rate. The symbols of the assets
+// without a rate are returned, their balance is not included in the total.
+func DexPortfolioUSD(balances []*DexAssetBalance, rates map[uint32]float64) (total float64, missing []string) {
+	for _, balance := range balances {
+		rate, ok := rates[balance.AssetID]
+		if !ok {
+			if balance.Total() > 0 {
+				missing = append(missing, balance.Symbol)
+			}
+			continue
+		}
+		total += balance.ToCoin(balance.Total()) * rate
+	}
+	return total, missing
+}
+
+// fetchUSDRate returns the last trade rate of the asset on its USDT market.
+func fetchUSDRate(ctx context.Context, client *http.Client, host, symbol string) (float64, error) {
+	url := fmt.Sprintf("%s/v3/markets/%s-USDT/ticker", host, strings.ToUpper(symbol))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("no USD rate for %s: %s", symbol, resp.Status)
+	}
+
+	var ticker struct {
+		LastTradeRate string `json:"lastTradeRate"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ticker); err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(ticker.LastTradeRate, 64)
+}
+
+// DexPortfolioRates returns the USD rates of the assets of the balances. The
+// assets without a rate are left out.
+func DexPortfolioRates(ctx context.Context, balances []*DexAssetBalance) map[uint32]float64 {
+	client := &http.Client{Timeout: rateRequestTimeout}
+	rates := make(map[uint32]float64, len(balances))
+	for _, balance := range balances {
+		rate, err := fetchUSDRate(ctx, client, bittrexAPIHost, balance.Symbol)
+		if err != nil {
+			log.Errorf("error fetching the USD rate of %s: %v", balance.Symbol, err)
+			continue
+		}
+		rates[balance.AssetID] = rate
+	}
+	return rates
+}
@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/asset/btc"
+	"decred.org/dcrdex/client/asset/dcr"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
+)
+
+type testDexWallets []*core.WalletState
+
+func (w testDexWallets) Wallets() []*core.WalletState {
+	return w
+}
+
+func (w testDexWallets) NewDepositAddress(assetID uint32) (string, error) {
+	return "", nil
+}
+
+func TestDexPortfolio(t *testing.T) {
+	wallets := testDexWallets{
+		{AssetID: dcr.BipID, Symbol: "dcr", Address: "Ds1", Balance: &core.WalletBalance{
+			Balance: &db.Balance{Balance: asset.Balance{Available: 3e8, Locked: 1e8, Immature: 2e8}},
+		}},
+		{AssetID: btc.BipID, Symbol: "btc", Address: "bc1"},
+	}
+	balances := DexPortfolio(wallets)
+	if len(balances) != 2 || balances[0].Symbol != "btc" || balances[1].Symbol != "dcr" {
+		t.Fatalf("unexpected balances %+v", balances)
+	}
+	if balances[0].Total() != 0 {
+		t.Errorf("expected no btc balance, got %d", balances[0].Total())
+	}
+	if balances[1].Total() != 6e8 || balances[1].ToCoin(balances[1].Available) != 3 || balances[1].Address != "Ds1" {
+		t.Errorf("unexpected dcr balance %+v", balances[1])
+	}
+
+	balances[0].Available = 1e7
+	total, missing := DexPortfolioUSD(balances, map[uint32]float64{dcr.BipID: 20})
+	if total != 120 || !reflect.DeepEqual(missing, []string{"btc"}) {
+		t.Errorf("unexpected USD value %v, missing %v", total, missing)
+	}
+}
+
+func TestFetchUSDRate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/markets/DCR-USDT/ticker" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"symbol":"DCR-USDT","lastTradeRate":"25.50"}`))
+	}))
+	defer server.Close()
+
+	rate, err := fetchUSDRate(context.Background(), server.Client(), server.URL, "dcr")
+	if err != nil || rate != 25.5 {
+		t.Fatalf("unexpected rate %v, error %v", rate, err)
+	}
+	if _, err := fetchUSDRate(context.Background(), server.Client(), server.URL, "xyz"); err == nil {
+		t.Fatal("expected an error for an unknown market")
+	}
+}
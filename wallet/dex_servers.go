@@ -0,0 +1,244 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// dexServersConfigKey is the config key of the DEX server settings.
+const dexServersConfigKey = "dex_servers"
+
+// DexAccounts is the part of the dcrdex client core used to manage the DEX
+// accounts. It is satisfied by *core.Core.
+type DexAccounts interface {
+	Exchanges() map[string]*core.Exchange
+	AccountExport(pw []byte, host string) (*core.Account, error)
+	AccountImport(pw []byte, acct core.Account) error
+	AccountDisable(pw []byte, host string) error
+}
+
+var _ DexAccounts = (*core.Core)(nil)
+
+// DexServerSettings are the app settings of the registered DEX servers.
+type DexServerSettings struct {
+	// Active is the host of the server used for trading.
+	Active string `json:"active"`
+	// Disabled are the hosts of the servers that are not used for trading.
+	// Their accounts are kept by dcrdex.
+	Disabled []string `json:"disabled"`
+}
+
+// ReadDexServerSettings returns the saved DEX server settings.
+func ReadDexServerSettings(mw *dcrlibwallet.MultiWallet) DexServerSettings {
+	var settings DexServerSettings
+	mw.ReadUserConfigValue(dexServersConfigKey, &settings)
+	return settings
+}
+
+// SaveDexServerSettings saves the DEX server settings.
+func SaveDexServerSettings(mw *dcrlibwallet.MultiWallet, settings DexServerSettings) {
+	mw.SaveUserConfigValue(dexServersConfigKey, settings)
+}
+
+// IsDisabled returns true if the server at host is disabled.
+func (s *DexServerSettings) IsDisabled(host string) bool {
+	for _, disabled := range s.Disabled {
+		if disabled == host {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDisabled disables or enables the server at host. A disabled server is
+// no longer the active server.
+func (s *DexServerSettings) SetDisabled(host string, disabled bool) {
+	if s.IsDisabled(host) == disabled {
+		return
+	}
+	if !disabled {
+		for i, h := range s.Disabled {
+			if h == host {
+				s.Disabled = append(s.Disabled[:i], s.Disabled[i+1:]...)
+				return
+			}
+		}
+	}
+	s.Disabled = append(s.Disabled, host)
+	sort.Strings(s.Disabled)
+	if s.Active == host {
+		s.Active = ""
+	}
+}
+
+// Forget removes the settings of the server at host.
+func (s *DexServerSettings) Forget(host string) {
+	s.SetDisabled(host, false)
+	if s.Active == host {
+		s.Active = ""
+	}
+}
+
+// SortDexServers returns the exchanges sorted by host.
+func SortDexServers(exchanges map[string]*core.Exchange) []*core.Exchange {
+	servers := make([]*core.Exchange, 0, len(exchanges))
+	for _, exchange := range exchanges {
+		servers = append(servers, exchange)
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Host < servers[j].Host
+	})
+	return servers
+}
+
+// ActiveDexServer returns the active server if it is registered and enabled,
+// otherwise the first enabled server by host. It returns nil if there is no
+// enabled server.
+func ActiveDexServer(exchanges map[string]*core.Exchange, settings DexServerSettings) *core.Exchange {
+	if exchange, ok := exchanges[settings.Active]; ok && !settings.IsDisabled(settings.Active) {
+		return exchange
+	}
+	for _, exchange := range SortDexServers(exchanges) {
+		if !settings.IsDisabled(exchange.Host) {
+			return exchange
+		}
+	}
+	return nil
+}
+
+// DexCertAccounts is the part of the dcrdex client core used to replace the
+// certificate of an account. It is satisfied by *core.Core.
+type DexCertAccounts interface {
+	DexAccounts
+	GetDEXConfig(dexAddr string, certI interface{}) (*core.Exchange, error)
+}
+
+var _ DexCertAccounts = (*core.Core)(nil)
+
+// DexAccountBackupError is returned when an account was disabled and could
+// not be imported again. The keys of the account are kept in the file at
+// Path.
+type DexAccountBackupError struct {
+	Path string
+	Err  error
+}
+
+func (e *DexAccountBackupError) Error() string {
+	return fmt.Sprintf("%v, the account keys are saved in %s", e.Err, e.Path)
+}
+
+func (e *DexAccountBackupError) Unwrap() error {
+	return e.Err
+}
+
+// ReplaceDexCert replaces the TLS certificate of the account at host. dcrdex
+// has no way to update the certificate of an account, so the account is
+// exported, disabled and imported again with the new certificate. The server
+// is first reached with the new certificate, and the account keys are written
+// to a backup file that is only kept if the account can't be imported again.
+// The original account is imported again if the new certificate is rejected.
+func (wal *Wallet) ReplaceDexCert(c DexCertAccounts, pw []byte, host string, cert []byte) error {
+	if _, err := c.GetDEXConfig(host, cert); err != nil {
+		return fmt.Errorf("error connecting to %s with the new certificate: %v", host, err)
+	}
+
+	acct, err := c.AccountExport(pw, host)
+	if err != nil {
+		return err
+	}
+	backup, err := wal.writeExport(dexAccountFileName(host, "backup"), func(out io.Writer) error {
+		return WriteDexAccount(out, acct)
+	})
+	if err != nil {
+		return err
+	}
+	if err = c.AccountDisable(pw, host); err != nil {
+		os.Remove(backup)
+		return err
+	}
+
+	updated := *acct
+	updated.Cert = fmt.Sprintf("%x", cert)
+	if err = c.AccountImport(pw, updated); err != nil {
+		if restoreErr := c.AccountImport(pw, *acct); restoreErr != nil {
+			err = fmt.Errorf("%v, and restoring the account failed: %v", err, restoreErr)
+			return &DexAccountBackupError{Path: backup, Err: err}
+		}
+		os.Remove(backup)
+		return err
+	}
+	os.Remove(backup)
+	return nil
+}
+
+// dexAccountFileName returns the name of an account file of the server at
+// host.
+func dexAccountFileName(host, kind string) string {
+	fileHost := strings.NewReplacer(":", "-", "/", "-").Replace(host)
+	return fmt.Sprintf("dex-%s-%s-%s.json", kind, fileHost, time.Now().Format("20060102-150405"))
+}
+
+// WriteDexAccount writes the account keys to out as JSON.
+func WriteDexAccount(out io.Writer, acct *core.Account) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(acct)
+}
+
+// ReadDexAccount reads account keys written by WriteDexAccount.
+func ReadDexAccount(in io.Reader) (*core.Account, error) {
+	acct := new(core.Account)
+	if err := json.NewDecoder(in).Decode(acct); err != nil {
+		return nil, err
+	}
+	if acct.Host == "" || acct.PrivKey == "" || acct.DEXPubKey == "" {
+		return nil, errors.New("not a DEX account file")
+	}
+	return acct, nil
+}
+
+// ExportDexAccount writes the keys of the account at host to a file in the
+// exports directory and returns the file path. The keys give full control of
+// the account, the file must be kept private.
+func (wal *Wallet) ExportDexAccount(c DexAccounts, pw []byte, host string) (string, error) {
+	acct, err := c.AccountExport(pw, host)
+	if err != nil {
+		return "", err
+	}
+
+	return wal.writeExport(dexAccountFileName(host, "account"), func(out io.Writer) error {
+		return WriteDexAccount(out, acct)
+	})
+}
+
+// ImportDexAccount imports the account keys from the file at path and
+// returns the host of the account.
+func ImportDexAccount(c DexAccounts, pw []byte, path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("%s does not exist", path)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	acct, err := ReadDexAccount(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	if _, ok := c.Exchanges()[acct.Host]; ok {
+		return "", fmt.Errorf("an account for %s is already registered", acct.Host)
+	}
+	return acct.Host, c.AccountImport(pw, *acct)
+}
@@ -0,0 +1,194 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrdex/client/core"
+)
+
+type testDexAccounts struct {
+	accounts  map[string]core.Account
+	exchanges map[string]*core.Exchange
+	// rejectCert is a certificate that fails the account import.
+	rejectCert string
+	// unreachableCert is a certificate the server can't be reached with.
+	unreachableCert string
+	// failImports fails all account imports.
+	failImports bool
+}
+
+func newTestDexAccounts(hosts ...string) *testDexAccounts {
+	c := &testDexAccounts{
+		accounts:  make(map[string]core.Account),
+		exchanges: make(map[string]*core.Exchange),
+	}
+	for _, host := range hosts {
+		c.accounts[host] = core.Account{Host: host, PrivKey: "aa", DEXPubKey: "bb", Cert: "cc", FeeProofSig: "dd", FeeProofStamp: 1}
+		c.exchanges[host] = &core.Exchange{Host: host}
+	}
+	return c
+}
+
+func (c *testDexAccounts) Exchanges() map[string]*core.Exchange {
+	return c.exchanges
+}
+
+func (c *testDexAccounts) AccountExport(pw []byte, host string) (*core.Account, error) {
+	acct, ok := c.accounts[host]
+	if !ok {
+		return nil, errors.New("unknown host")
+	}
+	return &acct, nil
+}
+
+func (c *testDexAccounts) AccountImport(pw []byte, acct core.Account) error {
+	if acct.Cert == c.rejectCert || c.failImports {
+		return errors.New("account not verified")
+	}
+	c.accounts[acct.Host] = acct
+	c.exchanges[acct.Host] = &core.Exchange{Host: acct.Host}
+	return nil
+}
+
+func (c *testDexAccounts) GetDEXConfig(dexAddr string, certI interface{}) (*core.Exchange, error) {
+	if string(certI.([]byte)) == c.unreachableCert {
+		return nil, errors.New("certificate signed by unknown authority")
+	}
+	return &core.Exchange{Host: dexAddr}, nil
+}
+
+func (c *testDexAccounts) AccountDisable(pw []byte, host string) error {
+	delete(c.accounts, host)
+	delete(c.exchanges, host)
+	return nil
+}
+
+func TestActiveDexServer(t *testing.T) {
+	exchanges := newTestDexAccounts("a.dex", "b.dex", "c.dex").exchanges
+
+	var settings DexServerSettings
+	if server := ActiveDexServer(exchanges, settings); server.Host != "a.dex" {
+		t.Errorf("expected the first server by default, got %s", server.Host)
+	}
+
+	settings.Active = "b.dex"
+	if server := ActiveDexServer(exchanges, settings); server.Host != "b.dex" {
+		t.Errorf("expected the active server, got %s", server.Host)
+	}
+
+	settings.SetDisabled("b.dex", true)
+	settings.SetDisabled("a.dex", true)
+	if settings.Active != "" {
+		t.Errorf("expected the disabled server not to remain active")
+	}
+	if server := ActiveDexServer(exchanges, settings); server.Host != "c.dex" {
+		t.Errorf("expected the first enabled server, got %s", server.Host)
+	}
+
+	settings.SetDisabled("c.dex", true)
+	if server := ActiveDexServer(exchanges, settings); server != nil {
+		t.Errorf("expected no server when all are disabled, got %s", server.Host)
+	}
+
+	settings.SetDisabled("a.dex", false)
+	settings.Forget("b.dex")
+	if len(settings.Disabled) != 1 || settings.Disabled[0] != "c.dex" {
+		t.Errorf("unexpected disabled servers %v", settings.Disabled)
+	}
+}
+
+func TestReplaceDexCert(t *testing.T) {
+	dir, err := os.MkdirTemp("", "godcr-dex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	wal := &Wallet{Root: dir, Net: "testnet3"}
+	c := newTestDexAccounts("a.dex")
+	if err := wal.ReplaceDexCert(c, nil, "a.dex", []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	acct := c.accounts["a.dex"]
+	if acct.Cert != "6e6577" || acct.FeeProofSig != "dd" {
+		t.Errorf("expected the account with the new cert and fee proof, got %+v", acct)
+	}
+
+	// The account is left untouched when the server can't be reached with
+	// the new certificate.
+	c.unreachableCert = "unknown"
+	if err := wal.ReplaceDexCert(c, nil, "a.dex", []byte("unknown")); err == nil {
+		t.Fatal("expected the unreachable server to fail the replacement")
+	}
+	if acct := c.accounts["a.dex"]; acct.Cert != "6e6577" {
+		t.Errorf("expected the account to be untouched, got %+v", acct)
+	}
+
+	// The account is restored when the new certificate is rejected.
+	c.rejectCert = "626164"
+	if err := wal.ReplaceDexCert(c, nil, "a.dex", []byte("bad")); err == nil {
+		t.Fatal("expected the bad certificate to be rejected")
+	}
+	if acct := c.accounts["a.dex"]; acct.Cert != "6e6577" {
+		t.Errorf("expected the account to be restored, got %+v", acct)
+	}
+	exports := filepath.Join(dir, "testnet3", exportsDir)
+	if files, _ := os.ReadDir(exports); len(files) != 0 {
+		t.Errorf("expected no backup to be kept, got %d files", len(files))
+	}
+
+	// The backup is kept when the account can't be restored.
+	c.failImports = true
+	err = wal.ReplaceDexCert(c, nil, "a.dex", []byte("new"))
+	var backupErr *DexAccountBackupError
+	if !errors.As(err, &backupErr) {
+		t.Fatalf("expected a backup error, got %v", err)
+	}
+	f, err := os.Open(backupErr.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if backup, err := ReadDexAccount(f); err != nil || backup.Host != "a.dex" || backup.Cert != "6e6577" {
+		t.Errorf("expected the backup of the account, got %+v, %v", backup, err)
+	}
+}
+
+func TestDexAccountExportImport(t *testing.T) {
+	dir, err := os.MkdirTemp("", "godcr-dex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	wal := &Wallet{Root: dir, Net: "testnet3"}
+	c := newTestDexAccounts("a.dex:7232")
+	path, err := wal.ExportDexAccount(c, nil, "a.dex:7232")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(path) != filepath.Join(dir, "testnet3", exportsDir) {
+		t.Errorf("unexpected export path %s", path)
+	}
+
+	if _, err = ImportDexAccount(c, nil, path); err == nil {
+		t.Error("expected the import of a registered account to fail")
+	}
+
+	other := newTestDexAccounts()
+	host, err := ImportDexAccount(other, nil, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "a.dex:7232" || other.accounts[host] != c.accounts[host] {
+		t.Errorf("expected the exported account to be imported, got %+v", other.accounts)
+	}
+
+	if _, err = ReadDexAccount(bytes.NewReader([]byte(`{"host": "a.dex"}`))); err == nil {
+		t.Error("expected an account without keys to be rejected")
+	}
+}
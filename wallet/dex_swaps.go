@@ -0,0 +1,329 @@
+package wallet
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+)
+
+// DexSwapSource is the part of the dcrdex client core used to monitor the
+// swaps. It is satisfied by *core.Core.
+type DexSwapSource interface {
+	Exchanges() map[string]*core.Exchange
+	Network() dex.Network
+}
+
+var _ DexSwapSource = (*core.Core)(nil)
+
+// DexRefunder is implemented by DEX clients that refund a swap on request.
+// The dcrdex client refunds swaps automatically once their lock time expires
+// and does not implement it.
+type DexRefunder interface {
+	RefundMatch(pw []byte, orderID, matchID dex.Bytes) error
+}
+
+// DexSwapStage is the step of a swap from the point of view of the user.
+type DexSwapStage int
+
+const (
+	// DexSwapSending is the stage of a swap waiting for the swap of the
+	// user to be sent.
+	DexSwapSending DexSwapStage = iota
+	// DexSwapWaiting is the stage of a swap waiting for the swap or the
+	// redeem of the counterparty.
+	DexSwapWaiting
+	// DexSwapRedeeming is the stage of a swap waiting for the redeem of the
+	// user to be sent.
+	DexSwapRedeeming
+	// DexSwapComplete is the stage of a swap redeemed by the user.
+	DexSwapComplete
+	// DexSwapRefunded is the stage of a swap refunded to the user.
+	DexSwapRefunded
+)
+
+// DexRefundStatus is the status of the refund of the swap of the user.
+type DexRefundStatus int
+
+const (
+	// DexRefundNotNeeded is the status of a swap that is not sent or
+	// that was redeemed.
+	DexRefundNotNeeded DexRefundStatus = iota
+	// DexRefundLocked is the status of a swap that can be refunded after
+	// its lock time if the counterparty does not redeem it.
+	DexRefundLocked
+	// DexRefundDue is the status of a swap past its lock time, which is
+	// refunded automatically by the client.
+	DexRefundDue
+	// DexRefunded is the status of a refunded swap.
+	DexRefunded
+)
+
+// DexSwap is an active match of an order of the user.
+type DexSwap struct {
+	Host  string
+	Order *core.Order
+	Match *core.Match
+	Stage DexSwapStage
+	// LockTime is the time after which the swap of the user can be
+	// refunded, and CounterLockTime the time after which the swap of the
+	// counterparty can be refunded.
+	LockTime        time.Time
+	CounterLockTime time.Time
+	Refund          DexRefundStatus
+}
+
+// dexSwapStage returns the stage of the match for the user.
+func dexSwapStage(match *core.Match) DexSwapStage {
+	if match.Refund != nil {
+		return DexSwapRefunded
+	}
+	if match.Status == order.MatchComplete {
+		return DexSwapComplete
+	}
+	if match.Side == order.Maker {
+		switch match.Status {
+		case order.NewlyMatched:
+			return DexSwapSending
+		case order.MakerSwapCast:
+			return DexSwapWaiting
+		case order.TakerSwapCast:
+			return DexSwapRedeeming
+		default:
+			return DexSwapComplete
+		}
+	}
+	switch match.Status {
+	case order.MakerSwapCast:
+		return DexSwapSending
+	case order.MakerRedeemed:
+		return DexSwapRedeeming
+	default:
+		return DexSwapWaiting
+	}
+}
+
+// newDexSwap returns the swap of a match. The lock times of the swap
+// contracts are set from the match time, the maker's contract is locked for
+// lockTimeMaker and the taker's for lockTimeTaker.
+func newDexSwap(host string, ord *core.Order, match *core.Match, lockTimeMaker, lockTimeTaker time.Duration, now time.Time) *DexSwap {
+	matchTime := dexStampTime(match.Stamp)
+	swap := &DexSwap{
+		Host:            host,
+		Order:           ord,
+		Match:           match,
+		Stage:           dexSwapStage(match),
+		LockTime:        matchTime.Add(lockTimeTaker),
+		CounterLockTime: matchTime.Add(lockTimeMaker),
+	}
+	if match.Side == order.Maker {
+		swap.LockTime, swap.CounterLockTime = swap.CounterLockTime, swap.LockTime
+	}
+
+	switch {
+	case match.Refund != nil:
+		swap.Refund = DexRefunded
+	case match.Swap == nil || match.Redeem != nil || match.CounterRedeem != nil || match.Status == order.MatchComplete:
+		swap.Refund = DexRefundNotNeeded
+	case now.Before(swap.LockTime):
+		swap.Refund = DexRefundLocked
+	default:
+		swap.Refund = DexRefundDue
+	}
+	return swap
+}
+
+// activeDexSwaps returns the active matches of the orders of the user,
+// oldest first.
+func activeDexSwaps(exchanges map[string]*core.Exchange, network dex.Network, now time.Time) []*DexSwap {
+	lockTimeMaker, lockTimeTaker := dex.LockTimeMaker(network), dex.LockTimeTaker(network)
+	var swaps []*DexSwap
+	for host, exchange := range exchanges {
+		for _, mkt := range exchange.Markets {
+			for _, ord := range mkt.Orders {
+				for _, match := range ord.Matches {
+					if match.Active && !match.IsCancel {
+						swaps = append(swaps, newDexSwap(host, ord, match, lockTimeMaker, lockTimeTaker, now))
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(swaps, func(i, j int) bool {
+		return swaps[i].Match.Stamp < swaps[j].Match.Stamp
+	})
+	return swaps
+}
+
+// DexSwapEventType is the type of a swap event.
+type DexSwapEventType int
+
+const (
+	// DexSwapSent is the event of the swap of the user being sent.
+	DexSwapSent DexSwapEventType = iota
+	// DexSwapRedeemed is the event of the user redeeming the swap of the
+	// counterparty.
+	DexSwapRedeemed
+	// DexSwapRefundDue is the event of the lock time of an unredeemed swap
+	// of the user expiring.
+	DexSwapRefundDue
+	// DexSwapRefundSent is the event of the swap of the user being
+	// refunded.
+	DexSwapRefundSent
+	// DexSwapRevoked is the event of a match being revoked by the server.
+	DexSwapRevoked
+	// DexSwapFailed is the event of an error of the client with a swap.
+	DexSwapFailed
+)
+
+// DexSwapEvent is a change of a swap, or an error of the client. Swap is nil
+// for errors, which are described by Subject and Details.
+type DexSwapEvent struct {
+	Type    DexSwapEventType
+	Swap    *DexSwap
+	Subject string
+	Details string
+}
+
+// dexSwapState is the state of a swap compared between updates.
+type dexSwapState struct {
+	sent, redeemed, revoked bool
+	refund                  DexRefundStatus
+}
+
+func newDexSwapState(swap *DexSwap) dexSwapState {
+	return dexSwapState{
+		sent:     swap.Match.Swap != nil,
+		redeemed: swap.Match.Redeem != nil,
+		revoked:  swap.Match.Revoked,
+		refund:   swap.Refund,
+	}
+}
+
+// dexSwapEvents returns the events of the changes of a swap since its
+// previous state.
+func dexSwapEvents(prev dexSwapState, swap *DexSwap) []DexSwapEvent {
+	state := newDexSwapState(swap)
+	var events []DexSwapEvent
+	add := func(changed bool, eventType DexSwapEventType) {
+		if changed {
+			events = append(events, DexSwapEvent{Type: eventType, Swap: swap})
+		}
+	}
+	add(state.sent && !prev.sent, DexSwapSent)
+	add(state.redeemed && !prev.redeemed, DexSwapRedeemed)
+	add(state.refund == DexRefundDue && prev.refund != DexRefundDue, DexSwapRefundDue)
+	add(state.refund == DexRefunded && prev.refund != DexRefunded, DexSwapRefundSent)
+	add(state.revoked && !prev.revoked, DexSwapRevoked)
+	return events
+}
+
+// DexSwapMonitor keeps track of the active swaps and reports their changes.
+type DexSwapMonitor struct {
+	mu      sync.Mutex
+	swaps   []*DexSwap
+	states  map[string]dexSwapState
+	updated bool
+
+	runOnce sync.Once
+}
+
+// NewDexSwapMonitor returns a monitor without swaps.
+func NewDexSwapMonitor() *DexSwapMonitor {
+	return &DexSwapMonitor{
+		states: make(map[string]dexSwapState),
+	}
+}
+
+// update reads the active swaps and returns the events of the changes since
+// the previous update. No events are returned by the first update.
+func (m *DexSwapMonitor) update(exchanges map[string]*core.Exchange, network dex.Network, now time.Time) []DexSwapEvent {
+	swaps := activeDexSwaps(exchanges, network, now)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var events []DexSwapEvent
+	states := make(map[string]dexSwapState, len(swaps))
+	for _, swap := range swaps {
+		id := swap.Match.MatchID.String()
+		if m.updated {
+			// The zero state is the state of a new match.
+			events = append(events, dexSwapEvents(m.states[id], swap)...)
+		}
+		states[id] = newDexSwapState(swap)
+	}
+	m.swaps, m.states, m.updated = swaps, states, true
+	return events
+}
+
+// Swaps returns the active swaps read by the last update of the monitor.
+func (m *DexSwapMonitor) Swaps() []*DexSwap {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.swaps
+}
+
+// isDexSwapFailure returns true for the notifications of the client reporting
+// a problem with an order or a swap.
+func isDexSwapFailure(n core.Notification) bool {
+	return (n.Type() == core.NoteTypeMatch || n.Type() == core.NoteTypeOrder) && n.Severity() >= db.WarningLevel
+}
+
+// dexSwapMonitorID is the id of the listener of the monitor.
+const dexSwapMonitorID = "dex_swap_monitor"
+
+// Start updates the swaps when the client reports a change of an order or a
+// match, and every interval to follow the lock times, until ctx is canceled.
+// onEvent is called with the changes of the swaps and the errors of the
+// client with the swaps. The monitor is only started once.
+func (m *DexSwapMonitor) Start(ctx context.Context, c DexSwapSource, notifier *DexNotifier, interval time.Duration, onEvent func(DexSwapEvent)) {
+	m.runOnce.Do(func() {
+		// The updates of the notifications and of the ticker are
+		// serialized so that the events are reported in order.
+		var updateMu sync.Mutex
+		update := func() {
+			updateMu.Lock()
+			defer updateMu.Unlock()
+			for _, event := range m.update(c.Exchanges(), c.Network(), time.Now()) {
+				onEvent(event)
+			}
+		}
+		update()
+
+		notifier.AddListener(dexSwapMonitorID, func(n core.Notification) {
+			if isDexSwapFailure(n) {
+				onEvent(DexSwapEvent{Type: DexSwapFailed, Subject: n.Subject(), Details: n.Details()})
+			}
+			if n.Type() == core.NoteTypeMatch || n.Type() == core.NoteTypeOrder {
+				update()
+			}
+		})
+
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			defer notifier.RemoveListener(dexSwapMonitorID)
+			for {
+				select {
+				case <-ticker.C:
+					update()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	})
+}
+
+// DexSwapMonitor returns the monitor of the DEX swaps.
+func (wal *Wallet) DexSwapMonitor() *DexSwapMonitor {
+	wal.dexSwapMonitorOnce.Do(func() {
+		wal.dexSwapMonitor = NewDexSwapMonitor()
+	})
+	return wal.dexSwapMonitor
+}
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+)
+
+func TestDexSwapStage(t *testing.T) {
+	tests := []struct {
+		side   order.MatchSide
+		status order.MatchStatus
+		stage  DexSwapStage
+	}{
+		{order.Maker, order.NewlyMatched, DexSwapSending},
+		{order.Maker, order.MakerSwapCast, DexSwapWaiting},
+		{order.Maker, order.TakerSwapCast, DexSwapRedeeming},
+		{order.Maker, order.MakerRedeemed, DexSwapComplete},
+		{order.Taker, order.NewlyMatched, DexSwapWaiting},
+		{order.Taker, order.MakerSwapCast, DexSwapSending},
+		{order.Taker, order.TakerSwapCast, DexSwapWaiting},
+		{order.Taker, order.MakerRedeemed, DexSwapRedeeming},
+		{order.Taker, order.MatchComplete, DexSwapComplete},
+	}
+	for _, test := range tests {
+		match := &core.Match{Side: test.side, Status: test.status}
+		if stage := dexSwapStage(match); stage != test.stage {
+			t.Errorf("%s %s: expected stage %d, got %d", test.side, test.status, test.stage, stage)
+		}
+	}
+
+	match := &core.Match{Side: order.Taker, Status: order.TakerSwapCast, Refund: new(core.Coin)}
+	if stage := dexSwapStage(match); stage != DexSwapRefunded {
+		t.Errorf("expected a refunded swap, got %d", stage)
+	}
+}
+
+func TestNewDexSwap(t *testing.T) {
+	matchTime := time.Unix(1600000000, 0)
+	match := &core.Match{
+		Side:   order.Taker,
+		Status: order.TakerSwapCast,
+		Stamp:  uint64(matchTime.UnixMilli()),
+		Swap:   new(core.Coin),
+	}
+	makerLock, takerLock := 20*time.Hour, 8*time.Hour
+
+	swap := newDexSwap("dex", nil, match, makerLock, takerLock, matchTime.Add(time.Hour))
+	if !swap.LockTime.Equal(matchTime.Add(takerLock)) || !swap.CounterLockTime.Equal(matchTime.Add(makerLock)) {
+		t.Errorf("unexpected taker lock times %v and %v", swap.LockTime, swap.CounterLockTime)
+	}
+	if swap.Refund != DexRefundLocked {
+		t.Errorf("expected a locked refund, got %d", swap.Refund)
+	}
+
+	swap = newDexSwap("dex", nil, match, makerLock, takerLock, matchTime.Add(takerLock))
+	if swap.Refund != DexRefundDue {
+		t.Errorf("expected a due refund, got %d", swap.Refund)
+	}
+
+	match.Side = order.Maker
+	swap = newDexSwap("dex", nil, match, makerLock, takerLock, matchTime.Add(takerLock))
+	if !swap.LockTime.Equal(matchTime.Add(makerLock)) || swap.Refund != DexRefundLocked {
+		t.Errorf("unexpected maker lock time %v and refund %d", swap.LockTime, swap.Refund)
+	}
+
+	match.CounterRedeem = new(core.Coin)
+	if swap = newDexSwap("dex", nil, match, makerLock, takerLock, matchTime.Add(makerLock)); swap.Refund != DexRefundNotNeeded {
+		t.Errorf("expected no refund for a redeemed swap, got %d", swap.Refund)
+	}
+}
+
+func TestDexSwapMonitorUpdate(t *testing.T) {
+	matchTime := time.Now()
+	match := &core.Match{
+		MatchID: dex.Bytes{1},
+		Active:  true,
+		Side:    order.Maker,
+		Status:  order.NewlyMatched,
+		Stamp:   uint64(matchTime.UnixMilli()),
+	}
+	exchanges := map[string]*core.Exchange{
+		"dex": {Markets: map[string]*core.Market{
+			"dcr_btc": {Orders: []*core.Order{{Matches: []*core.Match{
+				match,
+				{MatchID: dex.Bytes{2}, Active: true, IsCancel: true},
+				{MatchID: dex.Bytes{3}},
+			}}}},
+		}},
+	}
+
+	m := NewDexSwapMonitor()
+	if events := m.update(exchanges, dex.Simnet, matchTime); len(events) != 0 {
+		t.Errorf("expected no events on the first update, got %d", len(events))
+	}
+	if swaps := m.Swaps(); len(swaps) != 1 || swaps[0].Match != match {
+		t.Fatalf("expected the active match only, got %d swaps", len(swaps))
+	}
+
+	match.Status, match.Swap = order.MakerSwapCast, new(core.Coin)
+	events := m.update(exchanges, dex.Simnet, matchTime)
+	if len(events) != 1 || events[0].Type != DexSwapSent {
+		t.Errorf("expected a swap sent event, got %+v", events)
+	}
+
+	lockTime := matchTime.Add(dex.LockTimeMaker(dex.Simnet))
+	events = m.update(exchanges, dex.Simnet, lockTime)
+	if len(events) != 1 || events[0].Type != DexSwapRefundDue {
+		t.Errorf("expected a refund due event, got %+v", events)
+	}
+	if events = m.update(exchanges, dex.Simnet, lockTime); len(events) != 0 {
+		t.Errorf("expected no events without changes, got %+v", events)
+	}
+
+	match.Refund = new(core.Coin)
+	events = m.update(exchanges, dex.Simnet, lockTime)
+	if len(events) != 1 || events[0].Type != DexSwapRefundSent {
+		t.Errorf("expected a refund sent event, got %+v", events)
+	}
+}
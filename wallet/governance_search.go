@@ -0,0 +1,347 @@
+package wallet
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	SearchKindProposal       = "proposal"
+	SearchKindTreasuryPolicy = "treasury_policy"
+
+	searchDateFormat = "2006-01-02"
+)
+
+// Field weights used to rank the search results. A match on a proposal name
+// or token is more relevant than a match in the proposal body.
+const (
+	tokenWeight  = 8
+	nameWeight   = 4
+	authorWeight = 3
+	bodyWeight   = 1
+)
+
+// usdAmountRegex matches the USD amounts mentioned in a proposal body, e.g.
+// $12,500 or $ 40000.50.
+var usdAmountRegex = regexp.MustCompile(`\$\s?(\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?`)
+
+// SearchDocument is a proposal or treasury policy indexed for search.
+type SearchDocument struct {
+	Kind     string
+	Token    string
+	Name     string
+	Author   string
+	Body     string
+	Category int32
+	// Timestamp is the publish time of the proposal, 0 if unknown.
+	Timestamp int64
+	// Amount is the largest USD amount mentioned in the proposal body, 0 if
+	// the body isn't available locally or doesn't mention any amount.
+	Amount float64
+}
+
+// SearchQuery holds the search terms and filters. Zero valued filters are
+// ignored.
+type SearchQuery struct {
+	Text       string
+	Author     string
+	Token      string
+	Kinds      []string
+	Categories []int32
+	From, To   time.Time
+	MinAmount  float64
+	MaxAmount  float64
+}
+
+// SearchResult is a document matching a query along with its relevance.
+type SearchResult struct {
+	Token string
+	Kind  string
+	Score float64
+}
+
+// ParseSearchQuery builds a query from the text typed in a search editor.
+// Besides plain search terms, the text may contain the author:, token:,
+// after:, before:, min: and max: filters, e.g.
+// "marketing author:richard after:2021-01-01 max:50000".
+func ParseSearchQuery(text string) SearchQuery {
+	var q SearchQuery
+	var terms []string
+	for _, field := range strings.Fields(text) {
+		i := strings.Index(field, ":")
+		if i <= 0 || i == len(field)-1 {
+			terms = append(terms, field)
+			continue
+		}
+
+		key, value := strings.ToLower(field[:i]), field[i+1:]
+		switch key {
+		case "author":
+			q.Author = value
+		case "token":
+			q.Token = value
+		case "after", "from":
+			if t, err := time.ParseInLocation(searchDateFormat, value, time.Local); err == nil {
+				q.From = t
+				continue
+			}
+			terms = append(terms, field)
+		case "before", "to":
+			if t, err := time.ParseInLocation(searchDateFormat, value, time.Local); err == nil {
+				// Include the whole day.
+				q.To = t.Add(24*time.Hour - time.Nanosecond)
+				continue
+			}
+			terms = append(terms, field)
+		case "min", "max":
+			amount, err := strconv.ParseFloat(strings.TrimPrefix(value, "$"), 64)
+			if err != nil {
+				terms = append(terms, field)
+				continue
+			}
+			if key == "min" {
+				q.MinAmount = amount
+			} else {
+				q.MaxAmount = amount
+			}
+		default:
+			terms = append(terms, field)
+		}
+	}
+	q.Text = strings.Join(terms, " ")
+	return q
+}
+
+// IsEmpty returns true if the query has no search terms and no filters.
+func (q SearchQuery) IsEmpty() bool {
+	return strings.TrimSpace(q.Text) == "" && q.Author == "" && q.Token == "" && len(q.Kinds) == 0 &&
+		len(q.Categories) == 0 && q.From.IsZero() && q.To.IsZero() && q.MinAmount == 0 && q.MaxAmount == 0
+}
+
+type indexedDocument struct {
+	SearchDocument
+	// termFreqs maps each term of the document to its weighted frequency.
+	termFreqs map[string]float64
+}
+
+// SearchIndex is an in-memory full-text index of the locally synced
+// proposals and treasury policies.
+type SearchIndex struct {
+	mu sync.RWMutex
+	// docs is keyed by document kind and token.
+	docs map[string]*indexedDocument
+	// docFreqs maps each term to the number of documents containing it.
+	docFreqs map[string]int
+}
+
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		docs:     make(map[string]*indexedDocument),
+		docFreqs: make(map[string]int),
+	}
+}
+
+// tokenize splits text into lower case words.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// largestUSDAmount returns the largest USD amount mentioned in text.
+func largestUSDAmount(text string) float64 {
+	var largest float64
+	for _, match := range usdAmountRegex.FindAllStringSubmatch(text, -1) {
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+		if err == nil && amount > largest {
+			largest = amount
+		}
+	}
+	return largest
+}
+
+// Add indexes doc, replacing any document of the same kind and token.
+func (idx *SearchIndex) Add(doc SearchDocument) {
+	termFreqs := make(map[string]float64)
+	addTerms := func(text string, weight float64) {
+		for _, term := range tokenize(text) {
+			termFreqs[term] += weight
+		}
+	}
+	addTerms(doc.Token, tokenWeight)
+	addTerms(doc.Name, nameWeight)
+	addTerms(doc.Author, authorWeight)
+	addTerms(doc.Body, bodyWeight)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	key := doc.Kind + ":" + doc.Token
+	idx.remove(key)
+	idx.docs[key] = &indexedDocument{SearchDocument: doc, termFreqs: termFreqs}
+	for term := range termFreqs {
+		idx.docFreqs[term]++
+	}
+}
+
+func (idx *SearchIndex) remove(key string) {
+	old, ok := idx.docs[key]
+	if !ok {
+		return
+	}
+	for term := range old.termFreqs {
+		if idx.docFreqs[term]--; idx.docFreqs[term] <= 0 {
+			delete(idx.docFreqs, term)
+		}
+	}
+	delete(idx.docs, key)
+}
+
+// AddProposals indexes the proposals. The proposal body is only indexed if
+// its description was downloaded.
+func (idx *SearchIndex) AddProposals(proposals []dcrlibwallet.Proposal) {
+	for _, proposal := range proposals {
+		timestamp := proposal.PublishedAt
+		if timestamp == 0 {
+			timestamp = proposal.Timestamp
+		}
+		idx.Add(SearchDocument{
+			Kind:      SearchKindProposal,
+			Token:     proposal.Token,
+			Name:      proposal.Name,
+			Author:    proposal.Username,
+			Body:      proposal.IndexFile,
+			Category:  proposal.Category,
+			Timestamp: timestamp,
+			Amount:    largestUSDAmount(proposal.IndexFile),
+		})
+	}
+}
+
+// AddTreasuryPolicies indexes the treasury spending policies, using the Pi
+// key as token.
+func (idx *SearchIndex) AddTreasuryPolicies(policies []*dcrlibwallet.TreasuryKeyPolicy) {
+	for _, policy := range policies {
+		idx.Add(SearchDocument{
+			Kind:  SearchKindTreasuryPolicy,
+			Token: policy.PiKey,
+			Body:  policy.Policy,
+		})
+	}
+}
+
+// matchesFilters returns true if the document satisfies the query filters.
+func (doc *indexedDocument) matchesFilters(q SearchQuery) bool {
+	if len(q.Kinds) > 0 && !containsString(q.Kinds, doc.Kind) {
+		return false
+	}
+	if len(q.Categories) > 0 {
+		found := false
+		for _, category := range q.Categories {
+			found = found || category == doc.Category
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.Author != "" && !strings.Contains(strings.ToLower(doc.Author), strings.ToLower(q.Author)) {
+		return false
+	}
+	if q.Token != "" && !strings.HasPrefix(strings.ToLower(doc.Token), strings.ToLower(q.Token)) {
+		return false
+	}
+	// Documents without a date or amount never match date or amount
+	// filters.
+	if !q.From.IsZero() && (doc.Timestamp == 0 || doc.Timestamp < q.From.Unix()) {
+		return false
+	}
+	if !q.To.IsZero() && (doc.Timestamp == 0 || doc.Timestamp > q.To.Unix()) {
+		return false
+	}
+	if q.MinAmount > 0 && doc.Amount < q.MinAmount {
+		return false
+	}
+	if q.MaxAmount > 0 && (doc.Amount == 0 || doc.Amount > q.MaxAmount) {
+		return false
+	}
+	return true
+}
+
+// termScore returns the weighted frequency of the terms of the document
+// that match the query term. The last query term also matches as a prefix so
+// results are found while the user is typing.
+func (doc *indexedDocument) termScore(idx *SearchIndex, queryTerm string, prefix bool) float64 {
+	var score float64
+	for term, freq := range doc.termFreqs {
+		if term == queryTerm || (prefix && strings.HasPrefix(term, queryTerm)) {
+			idf := math.Log(1 + float64(len(idx.docs))/float64(idx.docFreqs[term]))
+			score += freq * idf
+		}
+	}
+	return score
+}
+
+// Search returns the documents matching every search term and filter of the
+// query, the most relevant first. An empty query returns no results.
+func (idx *SearchIndex) Search(q SearchQuery) []SearchResult {
+	if q.IsEmpty() {
+		return nil
+	}
+	terms := tokenize(q.Text)
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var results []SearchResult
+	for _, doc := range idx.docs {
+		if !doc.matchesFilters(q) {
+			continue
+		}
+
+		score := 0.0
+		for i, term := range terms {
+			termScore := doc.termScore(idx, term, i == len(terms)-1)
+			if termScore == 0 {
+				score = 0
+				break
+			}
+			score += termScore
+		}
+		if len(terms) > 0 && score == 0 {
+			continue
+		}
+
+		results = append(results, SearchResult{Token: doc.Token, Kind: doc.Kind, Score: score})
+	}
+
+	// Order by relevance then newest first.
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		ti := idx.docs[results[i].Kind+":"+results[i].Token].Timestamp
+		tj := idx.docs[results[j].Kind+":"+results[j].Token].Timestamp
+		if ti != tj {
+			return ti > tj
+		}
+		return results[i].Token < results[j].Token
+	})
+	return results
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func testSearchIndex() *SearchIndex {
+	idx := NewSearchIndex()
+	idx.AddProposals([]dcrlibwallet.Proposal{
+		{
+			Token:       "a1b2c3",
+			Name:        "Decred Marketing Campaign 2022",
+			Username:    "richard",
+			Category:    dcrlibwallet.ProposalCategoryApproved,
+			PublishedAt: time.Date(2022, 1, 15, 0, 0, 0, 0, time.Local).Unix(),
+			IndexFile:   "We request a budget of $120,000 for marketing in Asia.",
+		},
+		{
+			Token:       "d4e5f6",
+			Name:        "Lightning Network Development",
+			Username:    "alice",
+			Category:    dcrlibwallet.ProposalCategoryActive,
+			PublishedAt: time.Date(2022, 6, 1, 0, 0, 0, 0, time.Local).Unix(),
+			IndexFile:   "Development work, marketing excluded. Total $45,000.",
+		},
+		{
+			Token:       "f7a8b9",
+			Name:        "Community events",
+			Username:    "bob",
+			Category:    dcrlibwallet.ProposalCategoryRejected,
+			PublishedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.Local).Unix(),
+		},
+	})
+	idx.AddTreasuryPolicies([]*dcrlibwallet.TreasuryKeyPolicy{
+		{PiKey: "03f6e7041f1cf51ee10e0a01cd2b0385ce3cd9debaabb2296f7e9dee9329da946c", Policy: "yes"},
+	})
+	return idx
+}
+
+func resultTokens(results []SearchResult) []string {
+	tokens := make([]string, len(results))
+	for i, result := range results {
+		tokens[i] = result.Token
+	}
+	return tokens
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	q := ParseSearchQuery("marketing author:richard after:2022-01-01 before:2022-12-31 min:$1000 max:50000 foo:bar")
+	if q.Text != "marketing foo:bar" || q.Author != "richard" || q.MinAmount != 1000 || q.MaxAmount != 50000 {
+		t.Fatalf("unexpected query %+v", q)
+	}
+	if q.From.Year() != 2022 || q.From.YearDay() != 1 || q.To.Year() != 2022 || q.To.YearDay() != 365 {
+		t.Fatalf("unexpected date range %v - %v", q.From, q.To)
+	}
+	if !ParseSearchQuery("  ").IsEmpty() {
+		t.Fatal("expected blank query to be empty")
+	}
+}
+
+func TestSearchIndexRanking(t *testing.T) {
+	idx := testSearchIndex()
+
+	// A match in the name ranks above a match in the body only.
+	tokens := resultTokens(idx.Search(SearchQuery{Text: "marketing"}))
+	if len(tokens) != 2 || tokens[0] != "a1b2c3" || tokens[1] != "d4e5f6" {
+		t.Fatalf("unexpected results %v", tokens)
+	}
+
+	// Every term must match and the last term matches as a prefix.
+	tokens = resultTokens(idx.Search(SearchQuery{Text: "lightning dev"}))
+	if len(tokens) != 1 || tokens[0] != "d4e5f6" {
+		t.Fatalf("unexpected results %v", tokens)
+	}
+
+	if results := idx.Search(SearchQuery{Text: "nonexistent"}); len(results) != 0 {
+		t.Fatalf("expected no results, got %v", resultTokens(results))
+	}
+}
+
+func TestSearchIndexFilters(t *testing.T) {
+	idx := testSearchIndex()
+
+	tests := []struct {
+		name     string
+		query    string
+		kinds    []string
+		expected []string
+	}{
+		{"author", "author:ali", nil, []string{"d4e5f6"}},
+		{"token", "token:f7", []string{SearchKindProposal}, []string{"f7a8b9"}},
+		{"date range", "after:2022-01-01 before:2022-03-01", nil, []string{"a1b2c3"}},
+		{"amount range", "min:50000", nil, []string{"a1b2c3"}},
+		{"max amount excludes unknown amounts", "max:50000", nil, []string{"d4e5f6"}},
+		{"treasury policy", "yes", []string{SearchKindTreasuryPolicy}, []string{"03f6e7041f1cf51ee10e0a01cd2b0385ce3cd9debaabb2296f7e9dee9329da946c"}},
+	}
+	for _, test := range tests {
+		q := ParseSearchQuery(test.query)
+		q.Kinds = test.kinds
+		tokens := resultTokens(idx.Search(q))
+		if len(tokens) != len(test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, tokens)
+			continue
+		}
+		for i := range tokens {
+			if tokens[i] != test.expected[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected, tokens)
+			}
+		}
+	}
+
+	q := SearchQuery{Text: "marketing", Categories: []int32{dcrlibwallet.ProposalCategoryActive}}
+	if tokens := resultTokens(idx.Search(q)); len(tokens) != 1 || tokens[0] != "d4e5f6" {
+		t.Fatalf("unexpected results for category filter %v", tokens)
+	}
+}
+
+func TestSearchIndexReplace(t *testing.T) {
+	idx := testSearchIndex()
+	idx.AddProposals([]dcrlibwallet.Proposal{{Token: "a1b2c3", Name: "Renamed proposal"}})
+
+	if results := idx.Search(SearchQuery{Text: "campaign"}); len(results) != 0 {
+		t.Fatalf("expected replaced proposal not to match old name, got %v", resultTokens(results))
+	}
+	if tokens := resultTokens(idx.Search(SearchQuery{Text: "renamed"})); len(tokens) != 1 || tokens[0] != "a1b2c3" {
+		t.Fatalf("unexpected results %v", tokens)
+	}
+}
@@ -0,0 +1,263 @@
+package wallet
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"golang.org/x/net/idna"
+)
+
+// trustedLinkDomainsConfigKey is the multiwallet config key under which the
+// domains whose links are opened without confirmation are saved.
+const trustedLinkDomainsConfigKey = "trusted_link_domains"
+
+// knownLinkDomains are domains often linked to from proposals, which
+// phishing links are likely to imitate.
+var knownLinkDomains = []string{
+	"decred.org", "github.com", "reddit.com", "twitter.com", "medium.com",
+	"youtube.com", "google.com", "matrix.to", "discord.gg",
+}
+
+// confusables maps characters to the ASCII letters they are easily mistaken
+// for. Sequences of ASCII letters that look like another letter are listed
+// too.
+var confusables = strings.NewReplacer(
+	// Cyrillic
+	"а", "a", "в", "b", "е", "e", "к", "k", "м", "m", "н", "h", "о", "o",
+	"р", "p", "с", "c", "т", "t", "у", "y", "х", "x", "і", "i", "ј", "j",
+	"ѕ", "s", "ԁ", "d", "ԛ", "q", "ԝ", "w", "ӏ", "l",
+	// Greek
+	"α", "a", "ε", "e", "ι", "i", "κ", "k", "ν", "v", "ο", "o", "ρ", "p",
+	"τ", "t", "υ", "u", "χ", "x",
+	// ASCII
+	"0", "o", "1", "l", "3", "e", "5", "s", "rn", "m", "vv", "w", "cl", "d",
+	"-", "",
+)
+
+// LinkInfo describes a link and the reasons it may not lead where it
+// appears to.
+type LinkInfo struct {
+	// URL is the link with its host name and escaped characters decoded.
+	URL string
+	// Host is the host name of the link in unicode.
+	Host string
+
+	// UnsafeScheme is true for links that are not http or https links.
+	UnsafeScheme bool
+	// UserInfo is true for links with text before an @ in the host, which
+	// can make the link appear to be for another domain.
+	UserInfo bool
+	// Punycode is true if the host name is an encoded internationalized
+	// domain name.
+	Punycode bool
+	// NonASCII is true if the host name has characters that can be
+	// mistaken for ASCII letters.
+	NonASCII bool
+	// Lookalike is the known domain that the host imitates, if any.
+	Lookalike string
+}
+
+// Suspicious returns true if the link may not lead where it appears to.
+func (info *LinkInfo) Suspicious() bool {
+	return info.UnsafeScheme || info.UserInfo || info.Punycode || info.NonASCII || info.Lookalike != ""
+}
+
+// InspectLink decodes the link for display and checks it for tricks used
+// to disguise phishing links. The host name is compared with the known
+// domains and the trusted domains.
+func InspectLink(rawURL string, trusted []string) (*LinkInfo, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" && u.Opaque == "" && u.Path == "" {
+		return nil, errors.New("link has no address")
+	}
+
+	info := &LinkInfo{
+		UnsafeScheme: u.Scheme != "http" && u.Scheme != "https",
+		UserInfo:     u.User != nil,
+	}
+
+	asciiHost := strings.ToLower(u.Hostname())
+	for _, label := range strings.Split(asciiHost, ".") {
+		if strings.HasPrefix(label, "xn--") {
+			info.Punycode = true
+		}
+	}
+
+	info.Host = asciiHost
+	if host, err := idna.ToUnicode(asciiHost); err == nil {
+		info.Host = host
+	}
+	for _, r := range info.Host {
+		if r > unicode.MaxASCII {
+			info.NonASCII = true
+			break
+		}
+	}
+	info.Lookalike = lookalikeDomain(info.Host, append(append([]string{}, knownLinkDomains...), trusted...))
+
+	decoded := *u
+	if decoded.Host != "" {
+		decoded.Host = info.Host
+		if port := u.Port(); port != "" {
+			decoded.Host = net.JoinHostPort(info.Host, port)
+		}
+	}
+	info.URL = decoded.String()
+	if unescaped, err := url.PathUnescape(info.URL); err == nil {
+		info.URL = unescaped
+	}
+
+	return info, nil
+}
+
+// lookalikeDomain returns the domain that the host imitates, or an empty
+// string if the host belongs to the domains or doesn't resemble any of them.
+func lookalikeDomain(host string, domains []string) string {
+	if host == "" {
+		return ""
+	}
+	for _, domain := range domains {
+		if hostInDomain(host, domain) {
+			return ""
+		}
+	}
+
+	labels := strings.Split(host, ".")
+	registered := host
+	if len(labels) > 2 {
+		registered = strings.Join(labels[len(labels)-2:], ".")
+	}
+
+	for _, domain := range domains {
+		switch {
+		// The domain is used as a subdomain, e.g. decred.org.example.com.
+		case strings.HasPrefix(host, domain+"."):
+			return domain
+		case skeleton(registered) == skeleton(domain):
+			return domain
+		// A letter is added, removed or replaced. Short domains are left out
+		// as many legitimate domains differ from them by one letter.
+		case len(domain) >= 8 && editDistance(registered, domain) == 1:
+			return domain
+		}
+	}
+	return ""
+}
+
+// skeleton replaces the characters of the domain that are easily mistaken
+// for others with the characters they look like.
+func skeleton(domain string) string {
+	// Replacing twice catches sequences formed by the first replacement.
+	return confusables.Replace(confusables.Replace(strings.ToLower(domain)))
+}
+
+// editDistance returns the number of characters that must be inserted,
+// deleted or substituted to change a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func hostInDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// IsTrustedLinkHost returns true if the host belongs to one of the trusted
+// domains.
+func IsTrustedLinkHost(trusted []string, host string) bool {
+	for _, domain := range trusted {
+		if hostInDomain(host, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeLinkDomain returns the domain in the form it is saved in, or an
+// error if it is not a valid domain name.
+func NormalizeLinkDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if u, err := url.Parse(domain); err == nil && u.Host != "" {
+		domain = u.Hostname()
+	}
+	domain = strings.TrimPrefix(domain, "www.")
+
+	unicodeDomain, err := idna.Lookup.ToUnicode(domain)
+	if err != nil || !strings.Contains(unicodeDomain, ".") {
+		return "", errors.New("invalid domain name")
+	}
+	return unicodeDomain, nil
+}
+
+// TrustedLinkDomains returns the domains whose links are opened without
+// confirmation.
+func TrustedLinkDomains(mw *dcrlibwallet.MultiWallet) []string {
+	var domains []string
+	mw.ReadUserConfigValue(trustedLinkDomainsConfigKey, &domains)
+	return domains
+}
+
+// TrustLinkDomain adds the domain to the trusted domains.
+func TrustLinkDomain(mw *dcrlibwallet.MultiWallet, domain string) error {
+	domain, err := NormalizeLinkDomain(domain)
+	if err != nil {
+		return err
+	}
+
+	domains := TrustedLinkDomains(mw)
+	for _, trusted := range domains {
+		if trusted == domain {
+			return nil
+		}
+	}
+	domains = append(domains, domain)
+	sort.Strings(domains)
+	mw.SaveUserConfigValue(trustedLinkDomainsConfigKey, domains)
+	return nil
+}
+
+// UntrustLinkDomain removes the domain from the trusted domains.
+func UntrustLinkDomain(mw *dcrlibwallet.MultiWallet, domain string) {
+	domains := TrustedLinkDomains(mw)
+	for i, trusted := range domains {
+		if trusted == domain {
+			domains = append(domains[:i], domains[i+1:]...)
+			mw.SaveUserConfigValue(trustedLinkDomainsConfigKey, domains)
+			return
+		}
+	}
+}
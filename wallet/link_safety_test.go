@@ -0,0 +1,81 @@
+package wallet
+
+import "testing"
+
+func TestInspectLink(t *testing.T) {
+	tests := []struct {
+		link       string
+		url        string
+		suspicious bool
+		lookalike  string
+	}{
+		{link: "https://proposals.decred.org/record/abc", url: "https://proposals.decred.org/record/abc"},
+		{link: "https://example.com/a%20b", url: "https://example.com/a b"},
+		{link: "https://xn--dcred-zwe.org/", url: "https://dеcred.org/", suspicious: true, lookalike: "decred.org"},
+		{link: "https://decrred.org", url: "https://decrred.org", suspicious: true, lookalike: "decred.org"},
+		{link: "https://g1thub.com/decred", url: "https://g1thub.com/decred", suspicious: true, lookalike: "github.com"},
+		{link: "https://decred.org.example.com", url: "https://decred.org.example.com", suspicious: true, lookalike: "decred.org"},
+		{link: "https://decred.org@example.com/", url: "https://decred.org@example.com/", suspicious: true},
+		{link: "javascript:alert(1)", url: "javascript:alert(1)", suspicious: true},
+	}
+
+	for _, test := range tests {
+		info, err := InspectLink(test.link, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.link, err)
+			continue
+		}
+		if info.URL != test.url {
+			t.Errorf("%s: expected decoded url %q, got %q", test.link, test.url, info.URL)
+		}
+		if info.Suspicious() != test.suspicious {
+			t.Errorf("%s: expected suspicious %v, got %+v", test.link, test.suspicious, info)
+		}
+		if info.Lookalike != test.lookalike {
+			t.Errorf("%s: expected lookalike %q, got %q", test.link, test.lookalike, info.Lookalike)
+		}
+	}
+
+	// Trusted domains are compared with the host too.
+	info, err := InspectLink("https://dcrdata.decred.org", []string{"dcrdata.org"})
+	if err != nil || info.Suspicious() {
+		t.Fatalf("unexpected result %+v, %v", info, err)
+	}
+	info, err = InspectLink("https://dcrdata.0rg.io", []string{"dcrdata.org"})
+	if err != nil || info.Lookalike != "" {
+		t.Fatalf("unexpected result %+v, %v", info, err)
+	}
+}
+
+func TestTrustedLinkHost(t *testing.T) {
+	trusted := []string{"decred.org"}
+	for host, expected := range map[string]bool{
+		"decred.org":           true,
+		"proposals.decred.org": true,
+		"notdecred.org":        false,
+		"decred.org.evil.com":  false,
+	} {
+		if IsTrustedLinkHost(trusted, host) != expected {
+			t.Errorf("%s: expected trusted %v", host, expected)
+		}
+	}
+}
+
+func TestNormalizeLinkDomain(t *testing.T) {
+	for domain, expected := range map[string]string{
+		" Decred.org ":             "decred.org",
+		"https://www.github.com/x": "github.com",
+		"xn--dcred-zwe.org":        "dеcred.org",
+	} {
+		normalized, err := NormalizeLinkDomain(domain)
+		if err != nil || normalized != expected {
+			t.Errorf("%q: expected %q, got %q, %v", domain, expected, normalized, err)
+		}
+	}
+
+	for _, domain := range []string{"", "localhost", "bad domain.org"} {
+		if _, err := NormalizeLinkDomain(domain); err == nil {
+			t.Errorf("%q: expected error", domain)
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// mixerHistoryDir is the directory, in the network data directory,
+	// where the mixer history of each wallet is saved as <wallet id>.json.
+	mixerHistoryDir = "mixer_history"
+
+	// mixerCheckpointInterval is how often the progress of a running mixer
+	// session is saved.
+	mixerCheckpointInterval = 5 * time.Minute
+)
+
+var (
+	// errMixerStoppedUnexpectedly is recorded as the failure of mixer
+	// sessions that ended without the user stopping the mixer.
+	errMixerStoppedUnexpectedly = errors.New("mixer stopped unexpectedly")
+
+	// errMixerInterrupted is recorded as the failure of mixer sessions that
+	// were still running when the app was closed.
+	errMixerInterrupted = errors.New("mixer interrupted by the app closing")
+)
+
+var (
+	// mixerHistoryMu serializes the updates of the mixer history files,
+	// which are made from the mixer notification listener and the privacy
+	// page.
+	mixerHistoryMu sync.Mutex
+	// mixerHistoryUpdates counts the updates of the mixer history files so
+	// pages can tell when to read the history again.
+	mixerHistoryUpdates uint64
+)
+
+// MixerHistoryUpdates returns the number of updates made to the mixer
+// histories since the app started.
+func MixerHistoryUpdates() uint64 {
+	mixerHistoryMu.Lock()
+	defer mixerHistoryMu.Unlock()
+	return mixerHistoryUpdates
+}
+
+// MixerStopReason is who stopped the account mixer.
+type MixerStopReason int
+
+const (
+	// MixerStoppedByUser indicates that the user stopped the mixer.
+	MixerStoppedByUser MixerStopReason = iota
+	// MixerStoppedBySchedule indicates that a mixer schedule stopped the
+	// mixer, as its mixing window closed or its goal was reached.
+	MixerStoppedBySchedule
+)
+
+// MixerSession is a run of the account mixer, from the time it was started
+// to the time it ended.
+type MixerSession struct {
+	Start int64 `json:"start"`
+	// End is 0 while the mixer is running, or if the app was closed before
+	// the mixer ended.
+	End int64 `json:"end,omitempty"`
+	// Checkpoint is the last time the progress of the running session was
+	// saved. A session interrupted by the app closing ends at its last
+	// checkpoint.
+	Checkpoint    int64 `json:"checkpoint,omitempty"`
+	StoppedByUser bool  `json:"stopped_by_user,omitempty"`
+	// StoppedBySchedule is true if a mixer schedule stopped the mixer.
+	StoppedBySchedule bool `json:"stopped_by_schedule,omitempty"`
+	// FailedToStart is true if the mixer did not start. The session has the
+	// same start and end time.
+	FailedToStart bool `json:"failed_to_start,omitempty"`
+	// UnmixedAtStart and UnmixedAtEnd are the total balance of the unmixed
+	// account, in atoms.
+	UnmixedAtStart int64 `json:"unmixed_at_start"`
+	UnmixedAtEnd   int64 `json:"unmixed_at_end,omitempty"`
+	// MixedTxs are the hashes of the mixed transactions produced.
+	MixedTxs []string `json:"mixed_txs,omitempty"`
+	// Denominations maps the denominations mixed, in atoms, to the number of
+	// mixed outputs produced.
+	Denominations map[int64]int64 `json:"denominations,omitempty"`
+	Failure       string          `json:"failure,omitempty"`
+}
+
+// Running returns true if the session has not ended.
+func (s *MixerSession) Running() bool {
+	return s.End == 0
+}
+
+// Amount returns the total amount mixed in the session, in atoms.
+func (s *MixerSession) Amount() int64 {
+	var amount int64
+	for denomination, outputs := range s.Denominations {
+		amount += denomination * outputs
+	}
+	return amount
+}
+
+// Duration returns the time the mixer ran in the session. Running sessions
+// are measured up to now.
+func (s *MixerSession) Duration(now time.Time) time.Duration {
+	end := s.End
+	if end == 0 {
+		end = now.Unix()
+	}
+	return time.Duration(end-s.Start) * time.Second
+}
+
+// MixerHistory is the log of the mixer sessions of a wallet, oldest first.
+type MixerHistory struct {
+	Sessions []*MixerSession `json:"sessions"`
+}
+
+// current returns the session of the running mixer, nil if no session is
+// running.
+func (h *MixerHistory) current() *MixerSession {
+	if len(h.Sessions) == 0 {
+		return nil
+	}
+	if session := h.Sessions[len(h.Sessions)-1]; session.Running() {
+		return session
+	}
+	return nil
+}
+
+// DenominationAmount is the number of outputs mixed of a denomination.
+type DenominationAmount struct {
+	Denomination int64
+	Outputs      int64
+}
+
+// Amount returns the total amount of the outputs, in atoms.
+func (d DenominationAmount) Amount() int64 {
+	return d.Denomination * d.Outputs
+}
+
+// MixerSummary sums the mixer sessions of a wallet.
+type MixerSummary struct {
+	Sessions int
+	Duration time.Duration
+	MixedTxs int
+	Amount   int64
+	// Denominations are sorted from the largest denomination.
+	Denominations []DenominationAmount
+	Failures      int
+}
+
+// Summary sums the sessions in the history. Running sessions are measured up
+// to now.
+func (h *MixerHistory) Summary(now time.Time) MixerSummary {
+	var summary MixerSummary
+	denominations := make(map[int64]int64)
+	for _, session := range h.Sessions {
+		summary.Sessions++
+		summary.Duration += session.Duration(now)
+		summary.MixedTxs += len(session.MixedTxs)
+		summary.Amount += session.Amount()
+		for denomination, outputs := range session.Denominations {
+			denominations[denomination] += outputs
+		}
+		if session.Failure != "" {
+			summary.Failures++
+		}
+	}
+
+	for denomination, outputs := range denominations {
+		summary.Denominations = append(summary.Denominations, DenominationAmount{
+			Denomination: denomination,
+			Outputs:      outputs,
+		})
+	}
+	sort.Slice(summary.Denominations, func(i, j int) bool {
+		return summary.Denominations[i].Denomination > summary.Denominations[j].Denomination
+	})
+	return summary
+}
+
+// recordMixed records the mixed transactions produced between the start and
+// the end of the session.
+func (s *MixerSession) recordMixed(mixed []dcrlibwallet.Transaction) {
+	s.MixedTxs = nil
+	s.Denominations = make(map[int64]int64)
+	for _, tx := range mixed {
+		if tx.Timestamp < s.Start || tx.Timestamp > s.End {
+			continue
+		}
+		s.MixedTxs = append(s.MixedTxs, tx.Hash)
+		if tx.MixDenomination > 0 {
+			s.Denominations[tx.MixDenomination] += int64(tx.MixCount)
+		}
+	}
+}
+
+// checkpoint saves the progress of the running session.
+func (h *MixerHistory) checkpoint(unmixed int64, now time.Time) {
+	if session := h.current(); session != nil {
+		session.Checkpoint = now.Unix()
+		session.UnmixedAtEnd = unmixed
+	}
+}
+
+// endInterrupted ends the session left running, if any. The mixer was
+// interrupted by the app closing so the session is rebuilt up to its last
+// checkpoint from the mixed transactions.
+func (h *MixerHistory) endInterrupted(mixed []dcrlibwallet.Transaction) {
+	session := h.current()
+	if session == nil {
+		return
+	}
+
+	if session.Checkpoint > session.Start {
+		session.End = session.Checkpoint
+	} else {
+		session.End = session.Start
+		session.UnmixedAtEnd = session.UnmixedAtStart
+	}
+	session.recordMixed(mixed)
+	session.Failure = errMixerInterrupted.Error()
+}
+
+// start begins a new session.
+func (h *MixerHistory) start(unmixed int64, mixed []dcrlibwallet.Transaction, now time.Time) {
+	h.endInterrupted(mixed)
+	h.Sessions = append(h.Sessions, &MixerSession{
+		Start:          now.Unix(),
+		UnmixedAtStart: unmixed,
+	})
+}
+
+// end ends the running session, recording the mixed transactions produced
+// since it started.
+func (h *MixerHistory) end(unmixed int64, mixed []dcrlibwallet.Transaction, now time.Time) {
+	session := h.current()
+	if session == nil {
+		return
+	}
+
+	session.End = now.Unix()
+	session.UnmixedAtEnd = unmixed
+	session.recordMixed(mixed)
+	if !session.StoppedByUser && !session.StoppedBySchedule {
+		session.Failure = errMixerStoppedUnexpectedly.Error()
+	}
+}
+
+// failedToStart records a session that failed to start.
+func (h *MixerHistory) failedToStart(unmixed int64, mixed []dcrlibwallet.Transaction, err error, now time.Time) {
+	h.endInterrupted(mixed)
+	h.Sessions = append(h.Sessions, &MixerSession{
+		Start:          now.Unix(),
+		End:            now.Unix(),
+		UnmixedAtStart: unmixed,
+		UnmixedAtEnd:   unmixed,
+		FailedToStart:  true,
+		Failure:        err.Error(),
+	})
+}
+
+func (wal *Wallet) mixerHistoryPath(walletID int) string {
+	return filepath.Join(wal.Root, wal.Net, mixerHistoryDir, fmt.Sprintf("%d.json", walletID))
+}
+
+// MixerHistory returns the mixer sessions of the wallet.
+func (wal *Wallet) MixerHistory(walletID int) (*MixerHistory, error) {
+	mixerHistoryMu.Lock()
+	defer mixerHistoryMu.Unlock()
+	return wal.readMixerHistory(walletID)
+}
+
+func (wal *Wallet) readMixerHistory(walletID int) (*MixerHistory, error) {
+	history := new(MixerHistory)
+	data, err := ioutil.ReadFile(wal.mixerHistoryPath(walletID))
+	if os.IsNotExist(err) {
+		return history, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return history, json.Unmarshal(data, history)
+}
+
+// updateMixerHistory applies update to the mixer history of the wallet and
+// saves it.
+func (wal *Wallet) updateMixerHistory(walletID int, update func(*MixerHistory)) error {
+	mixerHistoryMu.Lock()
+	defer mixerHistoryMu.Unlock()
+
+	history, err := wal.readMixerHistory(walletID)
+	if err != nil {
+		return err
+	}
+	update(history)
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	path := wal.mixerHistoryPath(walletID)
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	mixerHistoryUpdates++
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// unmixedBalance returns the total balance of the unmixed account of the
+// wallet.
+func unmixedBalance(w *dcrlibwallet.Wallet) int64 {
+	balance, err := w.GetAccountBalance(w.UnmixedAccountNumber())
+	if err != nil {
+		log.Errorf("[%d] Error reading unmixed balance: %v", w.ID, err)
+		return 0
+	}
+	return balance.Total
+}
+
+// mixedTxs returns the mixed transactions of the wallet, newest first.
+func mixedTxs(w *dcrlibwallet.Wallet) ([]dcrlibwallet.Transaction, error) {
+	return w.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterMixed, true)
+}
+
+// RecordMixerStarted starts a mixer session for the wallet. The progress of
+// the session is saved every mixerCheckpointInterval until it ends.
+func (wal *Wallet) RecordMixerStarted(w *dcrlibwallet.Wallet) error {
+	mixed, err := mixedTxs(w)
+	if err != nil {
+		return err
+	}
+	unmixed := unmixedBalance(w)
+	err = wal.updateMixerHistory(w.ID, func(history *MixerHistory) {
+		history.start(unmixed, mixed, time.Now())
+	})
+	if err != nil {
+		return err
+	}
+	wal.startMixerCheckpoints(w)
+	return nil
+}
+
+// startMixerCheckpoints saves the progress of the running mixer session of
+// the wallet every mixerCheckpointInterval until stopMixerCheckpoints is
+// called.
+func (wal *Wallet) startMixerCheckpoints(w *dcrlibwallet.Wallet) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wal.mixerCheckpointsMu.Lock()
+	if stop, ok := wal.mixerCheckpoints[w.ID]; ok {
+		stop()
+	}
+	if wal.mixerCheckpoints == nil {
+		wal.mixerCheckpoints = make(map[int]context.CancelFunc)
+	}
+	wal.mixerCheckpoints[w.ID] = cancel
+	wal.mixerCheckpointsMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(mixerCheckpointInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				unmixed := unmixedBalance(w)
+				err := wal.updateMixerHistory(w.ID, func(history *MixerHistory) {
+					history.checkpoint(unmixed, time.Now())
+				})
+				if err != nil {
+					log.Errorf("[%d] Error saving mixer progress: %v", w.ID, err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (wal *Wallet) stopMixerCheckpoints(walletID int) {
+	wal.mixerCheckpointsMu.Lock()
+	defer wal.mixerCheckpointsMu.Unlock()
+	if stop, ok := wal.mixerCheckpoints[walletID]; ok {
+		stop()
+		delete(wal.mixerCheckpoints, walletID)
+	}
+}
+
+// RecordMixerStopping marks the running mixer session of the wallet as
+// stopped for reason, before the mixer is stopped.
+func (wal *Wallet) RecordMixerStopping(walletID int, reason MixerStopReason) error {
+	return wal.updateMixerHistory(walletID, func(history *MixerHistory) {
+		if session := history.current(); session != nil {
+			session.StoppedByUser = reason == MixerStoppedByUser
+			session.StoppedBySchedule = reason == MixerStoppedBySchedule
+		}
+	})
+}
+
+// RecordMixerEnded ends the running mixer session of the wallet with the
+// mixed transactions produced and the unmixed balance remaining.
+func (wal *Wallet) RecordMixerEnded(w *dcrlibwallet.Wallet) error {
+	wal.stopMixerCheckpoints(w.ID)
+	mixed, err := mixedTxs(w)
+	if err != nil {
+		return err
+	}
+	unmixed := unmixedBalance(w)
+	return wal.updateMixerHistory(w.ID, func(history *MixerHistory) {
+		history.end(unmixed, mixed, time.Now())
+	})
+}
+
+// RecordMixerStartFailed records that the mixer of the wallet failed to
+// start with err.
+func (wal *Wallet) RecordMixerStartFailed(w *dcrlibwallet.Wallet, err error) error {
+	mixed, txErr := mixedTxs(w)
+	if txErr != nil {
+		return txErr
+	}
+	unmixed := unmixedBalance(w)
+	return wal.updateMixerHistory(w.ID, func(history *MixerHistory) {
+		history.failedToStart(unmixed, mixed, err, time.Now())
+	})
+}
+
+// RecordMixerInterrupted ends the mixer session of the wallet left running
+// when the app was closed, if any. It must only be called while the mixer of
+// the wallet is stopped.
+func (wal *Wallet) RecordMixerInterrupted(w *dcrlibwallet.Wallet) error {
+	history, err := wal.MixerHistory(w.ID)
+	if err != nil || history.current() == nil {
+		return err
+	}
+
+	mixed, err := mixedTxs(w)
+	if err != nil {
+		return err
+	}
+	return wal.updateMixerHistory(w.ID, func(history *MixerHistory) {
+		history.endInterrupted(mixed)
+	})
+}
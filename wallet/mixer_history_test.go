@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestMixerHistorySessions(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	history := new(MixerHistory)
+
+	history.start(500, nil, start)
+	if history.current() == nil {
+		t.Fatal("expected a running session")
+	}
+
+	mixed := []dcrlibwallet.Transaction{
+		// Before the session.
+		{Hash: "a", Timestamp: start.Unix() - 1, MixDenomination: 100, MixCount: 1},
+		{Hash: "b", Timestamp: start.Unix() + 10, MixDenomination: 100, MixCount: 2},
+		{Hash: "c", Timestamp: start.Unix() + 20, MixDenomination: 50, MixCount: 3},
+		// After the session.
+		{Hash: "d", Timestamp: start.Unix() + 7200, MixDenomination: 100, MixCount: 1},
+	}
+	history.Sessions[0].StoppedByUser = true
+	history.end(200, mixed, start.Add(time.Hour))
+
+	session := history.Sessions[0]
+	if session.Running() || history.current() != nil {
+		t.Fatal("expected the session to end")
+	}
+	if !reflect.DeepEqual(session.MixedTxs, []string{"b", "c"}) {
+		t.Errorf("expected mixed txs b and c, got %v", session.MixedTxs)
+	}
+	if want := map[int64]int64{100: 2, 50: 3}; !reflect.DeepEqual(session.Denominations, want) {
+		t.Errorf("expected denominations %v, got %v", want, session.Denominations)
+	}
+	if session.Amount() != 350 || session.UnmixedAtEnd != 200 || session.Failure != "" {
+		t.Errorf("unexpected session %+v", session)
+	}
+
+	// A session ended without the user stopping it failed.
+	history.start(200, nil, start.Add(2*time.Hour))
+	history.end(200, nil, start.Add(3*time.Hour))
+	if history.Sessions[1].Failure != errMixerStoppedUnexpectedly.Error() {
+		t.Errorf("expected an unexpected stop, got %q", history.Sessions[1].Failure)
+	}
+
+	// A session left running when the app closed is interrupted by the next
+	// session.
+	history.start(200, nil, start.Add(4*time.Hour))
+	history.start(200, nil, start.Add(5*time.Hour))
+	if s := history.Sessions[2]; s.Running() || s.Failure != errMixerInterrupted.Error() {
+		t.Errorf("expected an interrupted session, got %+v", s)
+	}
+
+	history.failedToStart(200, nil, errors.New("no mixable output"), start.Add(6*time.Hour))
+	if s := history.Sessions[3]; s.Running() || s.Failure != errMixerInterrupted.Error() {
+		t.Errorf("expected an interrupted session, got %+v", s)
+	}
+	if s := history.Sessions[4]; s.Running() || !s.FailedToStart || s.Failure != "no mixable output" {
+		t.Errorf("expected a failed session, got %+v", s)
+	}
+}
+
+func TestMixerHistoryInterruptedCheckpoint(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	history := new(MixerHistory)
+	history.start(500, nil, start)
+	history.checkpoint(400, start.Add(10*time.Minute))
+	history.checkpoint(300, start.Add(20*time.Minute))
+
+	// The app closed after the last checkpoint, the session is rebuilt up
+	// to the checkpoint when the next session starts.
+	mixed := []dcrlibwallet.Transaction{
+		{Hash: "a", Timestamp: start.Unix() + 60, MixDenomination: 100, MixCount: 1},
+		{Hash: "b", Timestamp: start.Unix() + 1200, MixDenomination: 100, MixCount: 1},
+		{Hash: "c", Timestamp: start.Unix() + 1500, MixDenomination: 100, MixCount: 1},
+	}
+	history.start(250, mixed, start.Add(time.Hour))
+
+	session := history.Sessions[0]
+	if session.Running() || session.End != start.Unix()+1200 || session.Failure != errMixerInterrupted.Error() {
+		t.Fatalf("expected the session to end at its last checkpoint, got %+v", session)
+	}
+	if !reflect.DeepEqual(session.MixedTxs, []string{"a", "b"}) || session.Amount() != 200 || session.UnmixedAtEnd != 300 {
+		t.Errorf("expected the progress up to the checkpoint, got %+v", session)
+	}
+}
+
+func TestMixerHistorySummary(t *testing.T) {
+	history := &MixerHistory{Sessions: []*MixerSession{
+		{Start: 0, End: 3600, MixedTxs: []string{"a", "b"}, Denominations: map[int64]int64{100: 2, 50: 1}},
+		{Start: 4000, End: 4000, Failure: "failed"},
+		{Start: 5000, MixedTxs: []string{"c"}, Denominations: map[int64]int64{100: 1}},
+	}}
+
+	summary := history.Summary(time.Unix(6000, 0))
+	want := MixerSummary{
+		Sessions: 3,
+		Duration: 3600*time.Second + 1000*time.Second,
+		MixedTxs: 3,
+		Amount:   350,
+		Denominations: []DenominationAmount{
+			{Denomination: 100, Outputs: 3},
+			{Denomination: 50, Outputs: 1},
+		},
+		Failures: 1,
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("expected %+v, got %+v", want, summary)
+	}
+}
+
+func TestMixerHistoryPersistence(t *testing.T) {
+	wal := &Wallet{Root: t.TempDir(), Net: "testnet3"}
+
+	history, err := wal.MixerHistory(1)
+	if err != nil || len(history.Sessions) != 0 {
+		t.Fatalf("expected an empty history, got %v, %v", history, err)
+	}
+
+	err = wal.updateMixerHistory(1, func(h *MixerHistory) {
+		h.start(100, nil, time.Unix(1600000000, 0))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = wal.RecordMixerStopping(1, MixerStoppedBySchedule); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err = wal.MixerHistory(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Sessions) != 1 || !history.Sessions[0].StoppedBySchedule || history.Sessions[0].UnmixedAtStart != 100 {
+		t.Errorf("expected the saved session, got %+v", history.Sessions)
+	}
+	if other, _ := wal.MixerHistory(2); len(other.Sessions) != 0 {
+		t.Errorf("expected the history to be per wallet, got %+v", other.Sessions)
+	}
+}
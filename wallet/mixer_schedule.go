@@ -0,0 +1,538 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// MixerScheduleConfigKey is the wallet config key under which the mixer
+	// schedule is saved.
+	MixerScheduleConfigKey = "mixer_schedule"
+
+	// mixerScheduleInterval is how often a running mixer schedule is
+	// evaluated.
+	mixerScheduleInterval = time.Minute
+)
+
+// MixerSchedule describes when a scheduled mixing session stops and the
+// hours of the day mixing is allowed. A zero value disables the
+// corresponding condition.
+type MixerSchedule struct {
+	// TargetAmount stops the session once this amount, in atoms, has been
+	// mixed.
+	TargetAmount int64 `json:"target_amount"`
+	// StopWhenEmpty stops the session once the unmixed account has nothing
+	// left to mix.
+	StopWhenEmpty bool `json:"stop_when_empty"`
+	// TimeBudget stops the session once the mixer has run for this long.
+	TimeBudget time.Duration `json:"time_budget"`
+	// WindowStart and WindowEnd define the hours of the day (0-23, local
+	// time) within which the mixer runs. The end hour is exclusive and the
+	// range may wrap around midnight. The window is disabled if both are
+	// equal.
+	WindowStart int `json:"window_start"`
+	WindowEnd   int `json:"window_end"`
+}
+
+// HasGoal returns true if any condition stops the session.
+func (s MixerSchedule) HasGoal() bool {
+	return s.TargetAmount > 0 || s.StopWhenEmpty || s.TimeBudget > 0
+}
+
+// HasWindow returns true if mixing is restricted to hours of the day.
+func (s MixerSchedule) HasWindow() bool {
+	return s.WindowStart != s.WindowEnd
+}
+
+// ReadMixerSchedule returns the mixer schedule saved for the wallet.
+func ReadMixerSchedule(w *dcrlibwallet.Wallet) MixerSchedule {
+	var schedule MixerSchedule
+	_ = w.ReadUserConfigValue(MixerScheduleConfigKey, &schedule)
+	return schedule
+}
+
+// SaveMixerSchedule saves the mixer schedule for the wallet.
+func SaveMixerSchedule(w *dcrlibwallet.Wallet, schedule MixerSchedule) {
+	w.SaveUserConfigValue(MixerScheduleConfigKey, schedule)
+}
+
+// Mixer starts and stops the account mixer of a wallet and reports what it
+// has mixed.
+type Mixer interface {
+	// VerifyPassphrase returns an error if the wallet can't be unlocked with
+	// passphrase.
+	VerifyPassphrase(passphrase []byte) error
+	StartMixer(passphrase []byte) error
+	StopMixer(reason MixerStopReason) error
+	IsMixing() bool
+	// UnmixedBalance returns the total balance of the unmixed account.
+	UnmixedBalance() (int64, error)
+	// MixedSince returns the amount mixed after since, in atoms.
+	MixedSince(since time.Time) (int64, error)
+}
+
+// MixerScheduleState identifies the state of a scheduled mixing session.
+type MixerScheduleState int
+
+const (
+	// ScheduleIdle indicates that no session has been started.
+	ScheduleIdle MixerScheduleState = iota
+	// ScheduleMixing indicates that the mixer is running for the session.
+	ScheduleMixing
+	// ScheduleWaiting indicates that the mixer is stopped until the next
+	// mixing window.
+	ScheduleWaiting
+	// ScheduleTargetReached indicates that the target amount was mixed.
+	ScheduleTargetReached
+	// ScheduleEmpty indicates that the unmixed account has nothing left to
+	// mix.
+	ScheduleEmpty
+	// ScheduleBudgetUsed indicates that the time budget was used up.
+	ScheduleBudgetUsed
+	// ScheduleCancelled indicates that the user stopped the session.
+	ScheduleCancelled
+	// ScheduleAuthFailed indicates that the mixer could not be started
+	// because the passphrase is no longer valid.
+	ScheduleAuthFailed
+)
+
+// Finished returns true if the session has ended.
+func (s MixerScheduleState) Finished() bool {
+	return s >= ScheduleTargetReached
+}
+
+// MixerScheduleProgress reports the progress of a scheduled mixing session
+// against its goal.
+type MixerScheduleProgress struct {
+	State    MixerScheduleState
+	Schedule MixerSchedule
+	Started  time.Time
+	// Mixed is the amount mixed since the session started, in atoms.
+	Mixed int64
+	// Elapsed is the time the mixer has run in the session.
+	Elapsed  time.Duration
+	Unmixed  int64
+	LastErr  error
+	Finished time.Time
+}
+
+// Fraction returns the progress of the session towards the closest of its
+// goals, from 0 to 1. It is 0 if the session has no measurable goal.
+func (p MixerScheduleProgress) Fraction() float64 {
+	if p.State == ScheduleTargetReached || p.State == ScheduleEmpty || p.State == ScheduleBudgetUsed {
+		return 1
+	}
+
+	var fraction float64
+	if p.Schedule.TargetAmount > 0 {
+		fraction = float64(p.Mixed) / float64(p.Schedule.TargetAmount)
+	}
+	if p.Schedule.TimeBudget > 0 {
+		if f := float64(p.Elapsed) / float64(p.Schedule.TimeBudget); f > fraction {
+			fraction = f
+		}
+	}
+	if p.Schedule.StopWhenEmpty && p.Mixed+p.Unmixed > 0 {
+		if f := float64(p.Mixed) / float64(p.Mixed+p.Unmixed); f > fraction {
+			fraction = f
+		}
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	return fraction
+}
+
+// MixerScheduler runs the account mixer of a wallet according to a
+// MixerSchedule. It keeps the passphrase in memory while a session runs so
+// the mixer can be restarted in each mixing window.
+type MixerScheduler struct {
+	mu sync.Mutex
+
+	mixer Mixer
+	clock Clock
+
+	progress MixerScheduleProgress
+	// session identifies the current session so that an evaluation made
+	// without holding mu is dropped if the session ended in the meantime.
+	session      uint64
+	passphrase   []byte
+	lastTick     time.Time
+	cancel       context.CancelFunc
+	onUpdate     func()
+	onAuthFailed func()
+}
+
+// NewMixerScheduler returns a scheduler that runs mixer. If clock is nil, the
+// system clock is used.
+func NewMixerScheduler(mixer Mixer, clock Clock) *MixerScheduler {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &MixerScheduler{
+		mixer: mixer,
+		clock: clock,
+	}
+}
+
+// Progress returns the progress of the current or last session.
+func (s *MixerScheduler) Progress() MixerScheduleProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.progress
+}
+
+// IsRunning returns true if a session is in progress.
+func (s *MixerScheduler) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.isRunning()
+}
+
+func (s *MixerScheduler) isRunning() bool {
+	return s.progress.State == ScheduleMixing || s.progress.State == ScheduleWaiting
+}
+
+// Begin starts a session with the schedule and evaluates it immediately.
+// The mixer is started with passphrase whenever the schedule allows it.
+func (s *MixerScheduler) Begin(schedule MixerSchedule, passphrase []byte) error {
+	if !schedule.HasGoal() {
+		return errors.New("mixer schedule has no goal")
+	}
+
+	// The passphrase is checked even if the mixer doesn't start until the
+	// mixing window opens.
+	if err := s.mixer.VerifyPassphrase(passphrase); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.isRunning() {
+		s.mu.Unlock()
+		return errors.New("scheduled mixing already running")
+	}
+	now := s.clock.Now()
+	s.progress = MixerScheduleProgress{
+		State:    ScheduleWaiting,
+		Schedule: schedule,
+		Started:  now,
+	}
+	s.session++
+	s.passphrase = passphrase
+	s.lastTick = now
+	s.mu.Unlock()
+
+	s.Tick()
+
+	// A start error ends the session before it runs.
+	progress := s.Progress()
+	if progress.State == ScheduleAuthFailed {
+		return progress.LastErr
+	}
+	if progress.LastErr != nil && !progress.State.Finished() && !s.mixer.IsMixing() {
+		s.mu.Lock()
+		s.finish(ScheduleCancelled)
+		s.mu.Unlock()
+		return progress.LastErr
+	}
+	return nil
+}
+
+// Cancel stops the session and the mixer.
+func (s *MixerScheduler) Cancel() {
+	s.mu.Lock()
+	if !s.isRunning() {
+		s.mu.Unlock()
+		return
+	}
+	s.finish(ScheduleCancelled)
+	s.mu.Unlock()
+
+	s.stopMixer(MixerStoppedByUser)
+}
+
+// finish ends the session in state. The caller stops the mixer once mu is
+// released.
+func (s *MixerScheduler) finish(state MixerScheduleState) {
+	s.progress.State = state
+	s.progress.Finished = s.clock.Now()
+	s.passphrase = nil
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+// stopMixer stops the mixer if it runs. It must be called without holding
+// mu.
+func (s *MixerScheduler) stopMixer(reason MixerStopReason) {
+	if !s.mixer.IsMixing() {
+		return
+	}
+	if err := s.mixer.StopMixer(reason); err != nil {
+		s.mu.Lock()
+		s.progress.LastErr = err
+		s.mu.Unlock()
+	}
+}
+
+// Tick evaluates the schedule: it ends the session once a goal is reached
+// and starts or stops the mixer as the mixing window opens or closes. The
+// mixer is queried, started and stopped without holding mu so the progress
+// can be read and the session cancelled meanwhile.
+func (s *MixerScheduler) Tick() {
+	s.mu.Lock()
+	if !s.isRunning() {
+		s.mu.Unlock()
+		return
+	}
+	session, started := s.session, s.progress.Started
+	s.mu.Unlock()
+
+	mixing := s.mixer.IsMixing()
+	mixed, mixedErr := s.mixer.MixedSince(started)
+	unmixed, unmixedErr := s.mixer.UnmixedBalance()
+
+	s.mu.Lock()
+	if !s.isRunning() || s.session != session {
+		s.mu.Unlock()
+		return
+	}
+
+	now := s.clock.Now()
+	if mixing {
+		s.progress.Elapsed += now.Sub(s.lastTick)
+	}
+	s.lastTick = now
+
+	schedule := s.progress.Schedule
+	if mixedErr == nil {
+		s.progress.Mixed = mixed
+	} else {
+		s.progress.LastErr = mixedErr
+	}
+	if unmixedErr == nil {
+		s.progress.Unmixed = unmixed
+	} else {
+		s.progress.LastErr = unmixedErr
+	}
+
+	var stop bool
+	switch {
+	case schedule.TargetAmount > 0 && s.progress.Mixed >= schedule.TargetAmount:
+		s.finish(ScheduleTargetReached)
+		stop = true
+	case schedule.TimeBudget > 0 && s.progress.Elapsed >= schedule.TimeBudget:
+		s.finish(ScheduleBudgetUsed)
+		stop = true
+	case schedule.StopWhenEmpty && unmixedErr == nil && unmixed <= 0:
+		s.finish(ScheduleEmpty)
+		stop = true
+	case schedule.HasWindow() && !withinHours(schedule.WindowStart, schedule.WindowEnd, now):
+		s.progress.State = ScheduleWaiting
+		stop = true
+	}
+	passphrase := s.passphrase
+	s.mu.Unlock()
+
+	if stop {
+		s.stopMixer(MixerStoppedBySchedule)
+		return
+	}
+
+	var err error
+	if !mixing {
+		err = s.mixer.StartMixer(passphrase)
+	}
+
+	s.mu.Lock()
+	if !s.isRunning() || s.session != session {
+		// The session was cancelled while the mixer started.
+		s.mu.Unlock()
+		if err == nil && !mixing {
+			s.stopMixer(MixerStoppedByUser)
+		}
+		return
+	}
+
+	switch {
+	case err == nil:
+		s.progress.LastErr = nil
+		s.progress.State = ScheduleMixing
+	case err.Error() == dcrlibwallet.ErrNoMixableOutput && schedule.StopWhenEmpty:
+		s.finish(ScheduleEmpty)
+	case err.Error() == dcrlibwallet.ErrInvalidPassphrase:
+		// Retrying can't succeed, the session ends until the user starts
+		// it again.
+		s.progress.LastErr = err
+		s.finish(ScheduleAuthFailed)
+	default:
+		s.progress.LastErr = err
+		s.progress.State = ScheduleWaiting
+	}
+	s.mu.Unlock()
+}
+
+// Run evaluates the schedule every interval until the session ends or ctx
+// is cancelled.
+func (s *MixerScheduler) Run(ctx context.Context, interval time.Duration) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.cancel = cancel
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.Tick()
+			s.notifyUpdate()
+			if !s.IsRunning() {
+				if s.Progress().State == ScheduleAuthFailed {
+					s.notifyAuthFailed()
+				}
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// SetOnUpdate sets the function called after each evaluation of the running
+// schedule, replacing any previous one.
+func (s *MixerScheduler) SetOnUpdate(onUpdate func()) {
+	s.mu.Lock()
+	s.onUpdate = onUpdate
+	s.mu.Unlock()
+}
+
+// SetOnAuthFailed sets the function called when a running session ends
+// because the mixer rejected the passphrase, replacing any previous one.
+func (s *MixerScheduler) SetOnAuthFailed(onAuthFailed func()) {
+	s.mu.Lock()
+	s.onAuthFailed = onAuthFailed
+	s.mu.Unlock()
+}
+
+func (s *MixerScheduler) notifyUpdate() {
+	s.mu.Lock()
+	onUpdate := s.onUpdate
+	s.mu.Unlock()
+	if onUpdate != nil {
+		onUpdate()
+	}
+}
+
+func (s *MixerScheduler) notifyAuthFailed() {
+	s.mu.Lock()
+	onAuthFailed := s.onAuthFailed
+	s.mu.Unlock()
+	if onAuthFailed != nil {
+		onAuthFailed()
+	}
+}
+
+// walletMixer is the Mixer of a dcrlibwallet wallet.
+type walletMixer struct {
+	wal    *Wallet
+	wallet *dcrlibwallet.Wallet
+}
+
+func (m *walletMixer) VerifyPassphrase(passphrase []byte) error {
+	if err := m.wallet.UnlockWallet(passphrase); err != nil {
+		return err
+	}
+	m.wallet.LockWallet()
+	return nil
+}
+
+func (m *walletMixer) StartMixer(passphrase []byte) error {
+	return m.wal.multi.StartAccountMixer(m.wallet.ID, string(passphrase))
+}
+
+func (m *walletMixer) StopMixer(reason MixerStopReason) error {
+	if err := m.wal.RecordMixerStopping(m.wallet.ID, reason); err != nil {
+		log.Errorf("[%d] Error recording mixer stop: %v", m.wallet.ID, err)
+	}
+	return m.wal.multi.StopAccountMixer(m.wallet.ID)
+}
+
+func (m *walletMixer) IsMixing() bool {
+	return m.wallet.IsAccountMixerActive()
+}
+
+func (m *walletMixer) UnmixedBalance() (int64, error) {
+	balance, err := m.wallet.GetAccountBalance(m.wallet.UnmixedAccountNumber())
+	if err != nil {
+		return 0, err
+	}
+	return balance.Total, nil
+}
+
+func (m *walletMixer) MixedSince(since time.Time) (int64, error) {
+	txs, err := m.wallet.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterMixed, true)
+	if err != nil {
+		return 0, err
+	}
+	var mixed int64
+	for _, tx := range txs {
+		if tx.Timestamp < since.Unix() {
+			// The transactions are sorted newest first.
+			break
+		}
+		mixed += tx.MixDenomination * int64(tx.MixCount)
+	}
+	return mixed, nil
+}
+
+// MixerScheduler returns the mixer scheduler for the wallet with the
+// specified ID, creating it if necessary.
+func (wal *Wallet) MixerScheduler(walletID int) (*MixerScheduler, error) {
+	wal.mixerSchedulersMu.Lock()
+	defer wal.mixerSchedulersMu.Unlock()
+
+	if s, ok := wal.mixerSchedulers[walletID]; ok {
+		return s, nil
+	}
+
+	w := wal.multi.WalletWithID(walletID)
+	if w == nil {
+		return nil, errors.New(dcrlibwallet.ErrNotExist)
+	}
+
+	s := NewMixerScheduler(&walletMixer{wal: wal, wallet: w}, nil)
+	if wal.mixerSchedulers == nil {
+		wal.mixerSchedulers = make(map[int]*MixerScheduler)
+	}
+	wal.mixerSchedulers[walletID] = s
+	return s, nil
+}
+
+// StartScheduledMixing begins a mixing session for the wallet with its saved
+// schedule and evaluates the schedule in the background until the session
+// ends. onAuthFailed is called if the session ends because the passphrase
+// was rejected.
+func (wal *Wallet) StartScheduledMixing(walletID int, passphrase []byte, onAuthFailed func()) error {
+	s, err := wal.MixerScheduler(walletID)
+	if err != nil {
+		return err
+	}
+	s.SetOnAuthFailed(onAuthFailed)
+
+	w := wal.multi.WalletWithID(walletID)
+	if err = s.Begin(ReadMixerSchedule(w), passphrase); err != nil {
+		return err
+	}
+	go s.Run(context.Background(), mixerScheduleInterval)
+	return nil
+}
@@ -0,0 +1,272 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+type testMixer struct {
+	clock    *testClock
+	mixing   bool
+	starts   int
+	startErr error
+	passErr  error
+	// stops are the reasons the mixer was stopped for.
+	stops   []MixerStopReason
+	unmixed int64
+	// mixes are the amounts mixed, by the time they were mixed.
+	mixes map[time.Time]int64
+}
+
+func (m *testMixer) VerifyPassphrase(passphrase []byte) error {
+	return m.passErr
+}
+
+func (m *testMixer) StartMixer(passphrase []byte) error {
+	if m.startErr != nil {
+		return m.startErr
+	}
+	m.mixing = true
+	m.starts++
+	return nil
+}
+
+func (m *testMixer) StopMixer(reason MixerStopReason) error {
+	m.mixing = false
+	m.stops = append(m.stops, reason)
+	return nil
+}
+
+func (m *testMixer) IsMixing() bool {
+	return m.mixing
+}
+
+func (m *testMixer) UnmixedBalance() (int64, error) {
+	return m.unmixed, nil
+}
+
+func (m *testMixer) MixedSince(since time.Time) (int64, error) {
+	var mixed int64
+	for t, amount := range m.mixes {
+		if !t.Before(since) {
+			mixed += amount
+		}
+	}
+	return mixed, nil
+}
+
+// mix records amount as mixed now, moving it out of the unmixed balance.
+func (m *testMixer) mix(amount int64) {
+	m.mixes[m.clock.now] += amount
+	m.unmixed -= amount
+}
+
+func newTestScheduler(hour int) (*MixerScheduler, *testMixer, *testClock) {
+	clock := &testClock{now: time.Date(2022, 8, 1, hour, 0, 0, 0, time.Local)}
+	mixer := &testMixer{clock: clock, unmixed: 50e8, mixes: make(map[time.Time]int64)}
+	return NewMixerScheduler(mixer, clock), mixer, clock
+}
+
+func TestMixerScheduleTargetAmount(t *testing.T) {
+	s, mixer, clock := newTestScheduler(12)
+	if err := s.Begin(MixerSchedule{TargetAmount: 10e8}, []byte("pass")); err != nil {
+		t.Fatal(err)
+	}
+	if !mixer.mixing || s.Progress().State != ScheduleMixing {
+		t.Fatalf("expected the mixer to start, got state %v", s.Progress().State)
+	}
+
+	clock.advance(time.Minute)
+	mixer.mix(4e8)
+	s.Tick()
+	if p := s.Progress(); p.Mixed != 4e8 || p.Fraction() != 0.4 {
+		t.Fatalf("expected 40%% progress, got %d mixed, %v", p.Mixed, p.Fraction())
+	}
+
+	clock.advance(time.Minute)
+	mixer.mix(6e8)
+	s.Tick()
+	if p := s.Progress(); p.State != ScheduleTargetReached || p.Fraction() != 1 {
+		t.Fatalf("expected the target to be reached, got state %v", p.State)
+	}
+	if mixer.mixing {
+		t.Error("expected the mixer to stop once the target is reached")
+	}
+	if !reflect.DeepEqual(mixer.stops, []MixerStopReason{MixerStoppedBySchedule}) {
+		t.Errorf("expected the schedule to stop the mixer, got %v", mixer.stops)
+	}
+}
+
+func TestMixerScheduleStopWhenEmpty(t *testing.T) {
+	s, mixer, clock := newTestScheduler(12)
+	if err := s.Begin(MixerSchedule{StopWhenEmpty: true}, []byte("pass")); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.advance(time.Minute)
+	mixer.mix(25e8)
+	s.Tick()
+	if p := s.Progress(); p.State != ScheduleMixing || p.Fraction() != 0.5 {
+		t.Fatalf("expected 50%% progress, got state %v, %v", p.State, p.Fraction())
+	}
+
+	clock.advance(time.Minute)
+	mixer.mix(25e8)
+	s.Tick()
+	if p := s.Progress(); p.State != ScheduleEmpty {
+		t.Fatalf("expected the session to end with the account empty, got state %v", p.State)
+	}
+	if mixer.mixing {
+		t.Error("expected the mixer to stop once the account is empty")
+	}
+
+	// Starting with nothing to mix ends the session immediately.
+	s, mixer, _ = newTestScheduler(12)
+	mixer.startErr = errors.New(dcrlibwallet.ErrNoMixableOutput)
+	if err := s.Begin(MixerSchedule{StopWhenEmpty: true}, []byte("pass")); err != nil {
+		t.Fatal(err)
+	}
+	if p := s.Progress(); p.State != ScheduleEmpty {
+		t.Fatalf("expected the session to end with no mixable output, got state %v", p.State)
+	}
+}
+
+func TestMixerScheduleTimeBudget(t *testing.T) {
+	s, mixer, clock := newTestScheduler(12)
+	if err := s.Begin(MixerSchedule{TimeBudget: time.Hour}, []byte("pass")); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.advance(30 * time.Minute)
+	s.Tick()
+	if p := s.Progress(); p.Elapsed != 30*time.Minute || p.Fraction() != 0.5 {
+		t.Fatalf("expected half the budget used, got %v", p.Elapsed)
+	}
+
+	clock.advance(30 * time.Minute)
+	s.Tick()
+	if p := s.Progress(); p.State != ScheduleBudgetUsed {
+		t.Fatalf("expected the budget to be used, got state %v", p.State)
+	}
+	if mixer.mixing {
+		t.Error("expected the mixer to stop once the budget is used")
+	}
+}
+
+func TestMixerScheduleWindow(t *testing.T) {
+	// The window opens at 22:00 and closes at 02:00.
+	s, mixer, clock := newTestScheduler(20)
+	schedule := MixerSchedule{TimeBudget: 5 * time.Hour, WindowStart: 22, WindowEnd: 2}
+	if err := s.Begin(schedule, []byte("pass")); err != nil {
+		t.Fatal(err)
+	}
+	if mixer.mixing || s.Progress().State != ScheduleWaiting {
+		t.Fatal("expected the mixer to wait for the window")
+	}
+
+	clock.advance(2 * time.Hour)
+	s.Tick()
+	if !mixer.mixing || s.Progress().State != ScheduleMixing {
+		t.Fatal("expected the mixer to start when the window opens")
+	}
+
+	for i := 0; i < 4; i++ {
+		clock.advance(time.Hour)
+		s.Tick()
+	}
+	if mixer.mixing || s.Progress().State != ScheduleWaiting {
+		t.Fatal("expected the mixer to stop when the window closes")
+	}
+	if p := s.Progress(); p.Elapsed != 4*time.Hour {
+		t.Fatalf("expected 4 hours of mixing, got %v", p.Elapsed)
+	}
+
+	// Time outside the window does not count against the budget.
+	clock.advance(20 * time.Hour)
+	s.Tick()
+	if p := s.Progress(); p.Elapsed != 4*time.Hour || !mixer.mixing {
+		t.Fatalf("expected the mixer to restart with 4 hours used, got %v", p.Elapsed)
+	}
+
+	clock.advance(time.Hour)
+	s.Tick()
+	if p := s.Progress(); p.State != ScheduleBudgetUsed {
+		t.Fatalf("expected the budget to be used, got state %v", p.State)
+	}
+}
+
+func TestMixerScheduleCancel(t *testing.T) {
+	s, mixer, _ := newTestScheduler(12)
+	if err := s.Begin(MixerSchedule{StopWhenEmpty: true}, []byte("pass")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Begin(MixerSchedule{StopWhenEmpty: true}, []byte("pass")); err == nil {
+		t.Error("expected an error beginning a second session")
+	}
+
+	s.Cancel()
+	if p := s.Progress(); p.State != ScheduleCancelled || mixer.mixing {
+		t.Fatalf("expected the session to be cancelled, got state %v", p.State)
+	}
+	if !reflect.DeepEqual(mixer.stops, []MixerStopReason{MixerStoppedByUser}) {
+		t.Errorf("expected the user to stop the mixer, got %v", mixer.stops)
+	}
+	s.Tick()
+	if mixer.starts != 1 {
+		t.Errorf("expected a cancelled session not to restart the mixer, got %d starts", mixer.starts)
+	}
+}
+
+func TestMixerScheduleStartError(t *testing.T) {
+	s, mixer, _ := newTestScheduler(12)
+	mixer.startErr = errors.New(dcrlibwallet.ErrInvalidPassphrase)
+	if err := s.Begin(MixerSchedule{TargetAmount: 1e8}, []byte("wrong")); err == nil {
+		t.Fatal("expected the start error to be returned")
+	}
+	if s.IsRunning() {
+		t.Error("expected the session to end when the mixer fails to start")
+	}
+
+	if err := s.Begin(MixerSchedule{}, []byte("pass")); err == nil {
+		t.Error("expected an error beginning a session with no goal")
+	}
+}
+
+func TestMixerScheduleAuthFailed(t *testing.T) {
+	// A wrong passphrase is rejected even outside the mixing window.
+	s, mixer, clock := newTestScheduler(20)
+	schedule := MixerSchedule{TargetAmount: 1e8, WindowStart: 22, WindowEnd: 2}
+	mixer.passErr = errors.New(dcrlibwallet.ErrInvalidPassphrase)
+	if err := s.Begin(schedule, []byte("wrong")); err == nil {
+		t.Fatal("expected the wrong passphrase to be rejected")
+	}
+	if s.IsRunning() {
+		t.Fatal("expected no session with a wrong passphrase")
+	}
+
+	// The passphrase rejected when the window opens ends the session
+	// rather than retrying every tick.
+	mixer.passErr = nil
+	if err := s.Begin(schedule, []byte("pass")); err != nil {
+		t.Fatal(err)
+	}
+	var authFailed int
+	s.SetOnAuthFailed(func() { authFailed++ })
+	mixer.startErr = errors.New(dcrlibwallet.ErrInvalidPassphrase)
+	clock.advance(2 * time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.Run(ctx, time.Millisecond)
+	if p := s.Progress(); p.State != ScheduleAuthFailed || p.LastErr == nil {
+		t.Fatalf("expected the session to end with the passphrase rejected, got state %v", p.State)
+	}
+	if authFailed != 1 {
+		t.Errorf("expected the user to be notified once, got %d notifications", authFailed)
+	}
+}
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const politeiaRequestTimeout = 60 * time.Second
+
+// postPoliteia sends the JSON encoded request to the politeia API route and
+// decodes the reply into reply.
+func postPoliteia(ctx context.Context, client *http.Client, host, route string, request, reply interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, host+route, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%d response from politeia", resp.StatusCode)
+	}
+
+	return json.Unmarshal(respBytes, reply)
+}
@@ -0,0 +1,167 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	cmv1 "github.com/decred/politeia/politeiawww/api/comments/v1"
+)
+
+const (
+	CommentSortNewest = "newest"
+	CommentSortTop    = "top"
+
+	// proposalCommentsDir is the directory, in the network data directory,
+	// where the comments of the proposals are cached.
+	proposalCommentsDir = "proposal_comments"
+)
+
+// ProposalComment is a comment on a proposal. Replies is only set on the
+// comments returned by CommentThreads.
+type ProposalComment struct {
+	ID        uint32 `json:"id"`
+	ParentID  uint32 `json:"parent_id"`
+	Author    string `json:"author"`
+	Comment   string `json:"comment"`
+	Timestamp int64  `json:"timestamp"`
+	Upvotes   uint64 `json:"upvotes"`
+	Downvotes uint64 `json:"downvotes"`
+	Deleted   bool   `json:"deleted,omitempty"`
+
+	Replies []*ProposalComment `json:"-"`
+}
+
+// Score returns the number of upvotes minus the number of downvotes.
+func (c *ProposalComment) Score() int64 {
+	return int64(c.Upvotes) - int64(c.Downvotes)
+}
+
+// ProposalComments are the comments of a proposal as last fetched from
+// politeia.
+type ProposalComments struct {
+	Token     string            `json:"token"`
+	FetchedAt time.Time         `json:"fetched_at"`
+	Comments  []ProposalComment `json:"comments"`
+}
+
+// fetchProposalComments requests the comments of the proposal from the
+// politeia server at host.
+func fetchProposalComments(ctx context.Context, client *http.Client, host, token string) ([]ProposalComment, error) {
+	var reply cmv1.CommentsReply
+	err := postPoliteia(ctx, client, host, cmv1.APIRoute+cmv1.RouteComments, cmv1.Comments{Token: token}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]ProposalComment, 0, len(reply.Comments))
+	for _, c := range reply.Comments {
+		comments = append(comments, ProposalComment{
+			ID:        c.CommentID,
+			ParentID:  c.ParentID,
+			Author:    c.Username,
+			Comment:   c.Comment,
+			Timestamp: c.Timestamp,
+			Upvotes:   c.Upvotes,
+			Downvotes: c.Downvotes,
+			Deleted:   c.Deleted,
+		})
+	}
+	return comments, nil
+}
+
+func (wal *Wallet) proposalCommentsPath(token string) string {
+	return filepath.Join(wal.Root, wal.Net, proposalCommentsDir, token+".json")
+}
+
+// CachedProposalComments returns the comments of the proposal saved by the
+// last call to FetchProposalComments, nil if the comments have never been
+// fetched.
+func (wal *Wallet) CachedProposalComments(token string) (*ProposalComments, error) {
+	data, err := ioutil.ReadFile(wal.proposalCommentsPath(token))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	comments := new(ProposalComments)
+	if err = json.Unmarshal(data, comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// FetchProposalComments requests the comments of the proposal from politeia
+// and saves them so they can be read offline.
+func (wal *Wallet) FetchProposalComments(ctx context.Context, token string) (*ProposalComments, error) {
+	client := &http.Client{Timeout: politeiaRequestTimeout}
+	fetched, err := fetchProposalComments(ctx, client, wal.politeiaHost(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := &ProposalComments{
+		Token:     token,
+		FetchedAt: time.Now(),
+		Comments:  fetched,
+	}
+	return comments, wal.saveProposalComments(comments)
+}
+
+func (wal *Wallet) saveProposalComments(comments *ProposalComments) error {
+	data, err := json.Marshal(comments)
+	if err != nil {
+		return err
+	}
+	path := wal.proposalCommentsPath(comments.Token)
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// CommentThreads arranges the comments into threads sorted by sortBy, one
+// of CommentSortNewest or CommentSortTop. Replies are sorted the same way.
+// Replies to comments that are missing are shown as top level comments.
+func CommentThreads(comments []ProposalComment, sortBy string) []*ProposalComment {
+	byID := make(map[uint32]*ProposalComment, len(comments))
+	for i := range comments {
+		comment := comments[i]
+		comment.Replies = nil
+		byID[comment.ID] = &comment
+	}
+
+	var threads []*ProposalComment
+	for i := range comments {
+		comment := byID[comments[i].ID]
+		if parent, ok := byID[comment.ParentID]; ok && comment.ParentID != comment.ID {
+			parent.Replies = append(parent.Replies, comment)
+		} else {
+			threads = append(threads, comment)
+		}
+	}
+
+	sortComments(threads, sortBy)
+	return threads
+}
+
+func sortComments(comments []*ProposalComment, sortBy string) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		if sortBy == CommentSortTop && comments[i].Score() != comments[j].Score() {
+			return comments[i].Score() > comments[j].Score()
+		}
+		if comments[i].Timestamp != comments[j].Timestamp {
+			return comments[i].Timestamp > comments[j].Timestamp
+		}
+		return comments[i].ID > comments[j].ID
+	})
+	for _, comment := range comments {
+		sortComments(comment.Replies, sortBy)
+	}
+}
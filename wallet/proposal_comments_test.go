@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cmv1 "github.com/decred/politeia/politeiawww/api/comments/v1"
+)
+
+func TestCommentThreads(t *testing.T) {
+	comments := []ProposalComment{
+		{ID: 1, Timestamp: 100, Upvotes: 1},
+		{ID: 2, Timestamp: 200, Upvotes: 5, Downvotes: 1},
+		{ID: 3, ParentID: 1, Timestamp: 300},
+		{ID: 4, ParentID: 1, Timestamp: 150, Upvotes: 3},
+		{ID: 5, ParentID: 4, Timestamp: 400},
+		// The parent of this reply is missing.
+		{ID: 6, ParentID: 42, Timestamp: 50},
+	}
+
+	ids := func(comments []*ProposalComment) []uint32 {
+		ids := make([]uint32, 0, len(comments))
+		for _, c := range comments {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+	equal := func(a, b []uint32) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	newest := CommentThreads(comments, CommentSortNewest)
+	if got := ids(newest); !equal(got, []uint32{2, 1, 6}) {
+		t.Fatalf("unexpected newest threads %v", got)
+	}
+	if got := ids(newest[1].Replies); !equal(got, []uint32{3, 4}) {
+		t.Fatalf("unexpected newest replies %v", got)
+	}
+	if got := ids(newest[1].Replies[1].Replies); !equal(got, []uint32{5}) {
+		t.Fatalf("unexpected nested replies %v", got)
+	}
+
+	top := CommentThreads(comments, CommentSortTop)
+	if got := ids(top); !equal(got, []uint32{2, 1, 6}) {
+		t.Fatalf("unexpected top threads %v", got)
+	}
+	if got := ids(top[1].Replies); !equal(got, []uint32{4, 3}) {
+		t.Fatalf("unexpected top replies %v", got)
+	}
+
+	// Building threads must not modify the comments.
+	if comments[0].Replies != nil {
+		t.Fatal("expected source comments to be left unchanged")
+	}
+}
+
+func TestFetchProposalComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != cmv1.APIRoute+cmv1.RouteComments {
+			http.NotFound(w, r)
+			return
+		}
+		var req cmv1.Comments
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token != "abc" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(cmv1.CommentsReply{Comments: []cmv1.Comment{
+			{CommentID: 1, Username: "alice", Comment: "**hi**", Timestamp: 100, Upvotes: 2},
+			{CommentID: 2, ParentID: 1, Username: "bob", Timestamp: 200, Deleted: true},
+		}})
+	}))
+	defer server.Close()
+
+	comments, err := fetchProposalComments(context.Background(), server.Client(), server.URL, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 || comments[0].Author != "alice" || comments[0].Score() != 2 || !comments[1].Deleted || comments[1].ParentID != 1 {
+		t.Fatalf("unexpected comments %+v", comments)
+	}
+
+	if _, err = fetchProposalComments(context.Background(), server.Client(), server.URL, "missing"); err == nil {
+		t.Fatal("expected error for bad response")
+	}
+}
+
+func TestProposalCommentsCache(t *testing.T) {
+	wal := &Wallet{Root: t.TempDir(), Net: "testnet3"}
+
+	comments, err := wal.CachedProposalComments("abc")
+	if err != nil || comments != nil {
+		t.Fatalf("expected no cached comments, got %v, %v", comments, err)
+	}
+
+	saved := &ProposalComments{Token: "abc", Comments: []ProposalComment{{ID: 1, Author: "alice", Comment: "hi"}}}
+	if err = wal.saveProposalComments(saved); err != nil {
+		t.Fatal(err)
+	}
+	comments, err = wal.CachedProposalComments("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments.Comments) != 1 || comments.Comments[0].Author != "alice" {
+		t.Fatalf("unexpected cached comments %+v", comments)
+	}
+}
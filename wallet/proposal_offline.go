@@ -0,0 +1,425 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	piv1 "github.com/decred/politeia/politeiawww/api/pi/v1"
+	rcv1 "github.com/decred/politeia/politeiawww/api/records/v1"
+	"github.com/gomarkdown/markdown"
+	mdhtml "github.com/gomarkdown/markdown/html"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// offlineProposalsDir is the directory, in the network data directory,
+	// where the proposals downloaded for offline reading are saved. Each
+	// proposal is saved as <token>.json and its attachments in the <token>
+	// directory.
+	offlineProposalsDir = "offline_proposals"
+
+	ProposalExportMarkdown = "md"
+	ProposalExportHTML     = "html"
+)
+
+// ProposalAttachment is a file attached to a proposal, other than its index
+// and metadata files.
+type ProposalAttachment struct {
+	Name string `json:"name"`
+	MIME string `json:"mime"`
+	Size int    `json:"size"`
+}
+
+// OfflineProposal is a proposal with its body, metadata and attachments
+// saved for offline reading.
+type OfflineProposal struct {
+	Proposal     dcrlibwallet.Proposal  `json:"proposal"`
+	Body         string                 `json:"body"`
+	Metadata     *piv1.ProposalMetadata `json:"metadata,omitempty"`
+	Attachments  []ProposalAttachment   `json:"attachments"`
+	DownloadedAt time.Time              `json:"downloaded_at"`
+
+	// attachments holds the content of the attachments until they are saved
+	// or exported.
+	attachments map[string][]byte
+}
+
+// proposalRecord is the latest version of a proposal record as returned by
+// politeia.
+type proposalRecord struct {
+	body        string
+	metadata    *piv1.ProposalMetadata
+	attachments []ProposalAttachment
+	files       map[string][]byte
+}
+
+// fetchProposalRecord requests the latest version of the proposal and its
+// files from the politeia server at host.
+func fetchProposalRecord(ctx context.Context, client *http.Client, host, token string) (*proposalRecord, error) {
+	var reply rcv1.DetailsReply
+	err := postPoliteia(ctx, client, host, rcv1.APIRoute+rcv1.RouteDetails, rcv1.Details{Token: token}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &proposalRecord{files: make(map[string][]byte)}
+	var hasIndex bool
+	for _, file := range reply.Record.Files {
+		payload, err := base64.StdEncoding.DecodeString(file.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		switch file.Name {
+		case piv1.FileNameIndexFile:
+			record.body = string(payload)
+			hasIndex = true
+		case piv1.FileNameProposalMetadata:
+			metadata := new(piv1.ProposalMetadata)
+			if err = json.Unmarshal(payload, metadata); err != nil {
+				return nil, err
+			}
+			record.metadata = metadata
+		case piv1.FileNameVoteMetadata:
+		default:
+			name := attachmentName(file.Name)
+			if name == "" {
+				log.Warnf("Skipping proposal %s attachment with invalid name %q", token, file.Name)
+				continue
+			}
+			record.attachments = append(record.attachments, ProposalAttachment{
+				Name: name,
+				MIME: file.MIME,
+				Size: len(payload),
+			})
+			record.files[name] = payload
+		}
+	}
+	if !hasIndex {
+		return nil, errors.New("proposal has no index file")
+	}
+	return record, nil
+}
+
+// attachmentName returns the name of the attachment file stripped of any
+// directory, or an empty string if the name cannot be used as a file name.
+func attachmentName(name string) string {
+	name = filepath.Base(filepath.Clean("/" + strings.ReplaceAll(name, "\\", "/")))
+	if name == "/" || name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
+
+func (wal *Wallet) offlineProposalPath(token string) string {
+	return filepath.Join(wal.Root, wal.Net, offlineProposalsDir, token+".json")
+}
+
+func (wal *Wallet) offlineAttachmentsDir(token string) string {
+	return filepath.Join(wal.Root, wal.Net, offlineProposalsDir, token)
+}
+
+// OfflineProposal returns the proposal saved for offline reading, nil if the
+// proposal has not been downloaded.
+func (wal *Wallet) OfflineProposal(token string) (*OfflineProposal, error) {
+	data, err := ioutil.ReadFile(wal.offlineProposalPath(token))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	proposal := new(OfflineProposal)
+	if err = json.Unmarshal(data, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// OfflineProposals returns the proposals saved for offline reading, most
+// recently updated first.
+func (wal *Wallet) OfflineProposals() ([]*OfflineProposal, error) {
+	paths, err := filepath.Glob(filepath.Join(wal.Root, wal.Net, offlineProposalsDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]*OfflineProposal, 0, len(paths))
+	for _, path := range paths {
+		proposal, err := wal.OfflineProposal(strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			log.Errorf("Error reading offline proposal %s: %v", path, err)
+			continue
+		}
+		proposals = append(proposals, proposal)
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].Proposal.Timestamp > proposals[j].Proposal.Timestamp
+	})
+	return proposals, nil
+}
+
+// fetchOfflineProposal requests the body, metadata and attachments of the
+// proposal from politeia.
+func (wal *Wallet) fetchOfflineProposal(ctx context.Context, proposal *dcrlibwallet.Proposal) (*OfflineProposal, error) {
+	client := &http.Client{Timeout: politeiaRequestTimeout}
+	record, err := fetchProposalRecord(ctx, client, wal.politeiaHost(), proposal.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OfflineProposal{
+		Proposal:     *proposal,
+		Body:         record.body,
+		Metadata:     record.metadata,
+		Attachments:  record.attachments,
+		DownloadedAt: time.Now(),
+		attachments:  record.files,
+	}, nil
+}
+
+// DownloadProposal requests the proposal from politeia and saves it with its
+// attachments for offline reading, replacing any copy saved before.
+func (wal *Wallet) DownloadProposal(ctx context.Context, proposal *dcrlibwallet.Proposal) (*OfflineProposal, error) {
+	offline, err := wal.fetchOfflineProposal(ctx, proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	return offline, wal.saveOfflineProposal(offline)
+}
+
+func (wal *Wallet) saveOfflineProposal(offline *OfflineProposal) error {
+	dir := wal.offlineAttachmentsDir(offline.Proposal.Token)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for name, data := range offline.attachments {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(offline)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(wal.offlineProposalPath(offline.Proposal.Token), data, 0600)
+}
+
+// DeleteOfflineProposal removes the proposal and its attachments saved for
+// offline reading.
+func (wal *Wallet) DeleteOfflineProposal(token string) error {
+	if err := os.RemoveAll(wal.offlineAttachmentsDir(token)); err != nil {
+		return err
+	}
+	err := os.Remove(wal.offlineProposalPath(token))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// attachment returns the content of the named attachment, read from the
+// offline copy if it was not fetched with the proposal.
+func (wal *Wallet) attachment(offline *OfflineProposal, name string) ([]byte, error) {
+	if data, ok := offline.attachments[name]; ok {
+		return data, nil
+	}
+	return ioutil.ReadFile(filepath.Join(wal.offlineAttachmentsDir(offline.Proposal.Token), name))
+}
+
+// ExportProposal writes the proposal with its metadata and attachments to a
+// standalone file in format, one of ProposalExportMarkdown or
+// ProposalExportHTML, and returns the path of the file. The offline copy is
+// used if it is of the current version of the proposal.
+func (wal *Wallet) ExportProposal(ctx context.Context, proposal *dcrlibwallet.Proposal, format string) (string, error) {
+	if format != ProposalExportMarkdown && format != ProposalExportHTML {
+		return "", fmt.Errorf("unknown proposal export format %q", format)
+	}
+
+	offline, err := wal.OfflineProposal(proposal.Token)
+	if err != nil {
+		return "", err
+	}
+	if offline == nil || offline.Proposal.Version != proposal.Version {
+		if offline, err = wal.fetchOfflineProposal(ctx, proposal); err != nil {
+			return "", err
+		}
+	}
+
+	files := make(map[string][]byte, len(offline.Attachments))
+	for _, attachment := range offline.Attachments {
+		if files[attachment.Name], err = wal.attachment(offline, attachment.Name); err != nil {
+			return "", err
+		}
+	}
+
+	name := fmt.Sprintf("proposal-%s-%s.%s", proposal.Token, time.Now().Format("20060102-150405"), format)
+	return wal.writeExport(name, func(out io.Writer) error {
+		if format == ProposalExportHTML {
+			return WriteProposalHTML(out, offline, files)
+		}
+		return WriteProposalMarkdown(out, offline, files)
+	})
+}
+
+// proposalStatus describes the category of the proposal.
+func proposalStatus(category int32) string {
+	switch category {
+	case dcrlibwallet.ProposalCategoryPre:
+		return "In discussion"
+	case dcrlibwallet.ProposalCategoryActive:
+		return "Voting"
+	case dcrlibwallet.ProposalCategoryApproved:
+		return "Approved"
+	case dcrlibwallet.ProposalCategoryRejected:
+		return "Rejected"
+	case dcrlibwallet.ProposalCategoryAbandoned:
+		return "Abandoned"
+	default:
+		return "Unknown"
+	}
+}
+
+// proposalHeader returns the metadata of the proposal as name and value
+// pairs, in the order they are exported.
+func proposalHeader(offline *OfflineProposal) [][2]string {
+	p := offline.Proposal
+	formatTime := func(unix int64) string {
+		return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+	}
+
+	header := [][2]string{
+		{"Title", p.Name},
+		{"Token", p.Token},
+		{"Author", p.Username},
+		{"Version", p.Version},
+		{"Status", proposalStatus(p.Category)},
+		{"Published", formatTime(p.PublishedAt)},
+		{"Updated", formatTime(p.Timestamp)},
+	}
+	if p.Category != dcrlibwallet.ProposalCategoryPre {
+		header = append(header,
+			[2]string{"Yes votes", fmt.Sprint(p.YesVotes)},
+			[2]string{"No votes", fmt.Sprint(p.NoVotes)},
+			[2]string{"Eligible tickets", fmt.Sprint(p.EligibleTickets)},
+		)
+	}
+	if m := offline.Metadata; m != nil {
+		if m.Amount > 0 {
+			header = append(header, [2]string{"Amount", fmt.Sprintf("$%d.%02d", m.Amount/100, m.Amount%100)})
+		}
+		if m.StartDate > 0 {
+			header = append(header, [2]string{"Start date", formatTime(m.StartDate)})
+		}
+		if m.EndDate > 0 {
+			header = append(header, [2]string{"End date", formatTime(m.EndDate)})
+		}
+		if m.Domain != "" {
+			header = append(header, [2]string{"Domain", m.Domain})
+		}
+	}
+	return header
+}
+
+func dataURI(attachment ProposalAttachment, data []byte) string {
+	return "data:" + attachment.MIME + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// isImage returns true if the attachment is an image with a MIME type that
+// is safe to embed in a data URI.
+func isImage(attachment ProposalAttachment) bool {
+	subtype := strings.TrimPrefix(attachment.MIME, "image/")
+	if subtype == attachment.MIME || subtype == "" {
+		return false
+	}
+	for _, r := range subtype {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '+' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteProposalMarkdown writes the proposal as a Markdown document headed by
+// its metadata as front matter. Image attachments are embedded at the end.
+func WriteProposalMarkdown(out io.Writer, offline *OfflineProposal, files map[string][]byte) error {
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, field := range proposalHeader(offline) {
+		fmt.Fprintf(&b, "%s: %q\n", strings.ToLower(strings.ReplaceAll(field[0], " ", "_")), field[1])
+	}
+	if len(offline.Attachments) > 0 {
+		b.WriteString("attachments:\n")
+		for _, attachment := range offline.Attachments {
+			fmt.Fprintf(&b, "  - %q\n", attachment.Name)
+		}
+	}
+	b.WriteString("---\n\n")
+
+	b.WriteString(strings.TrimSpace(offline.Body))
+	b.WriteString("\n")
+
+	for _, attachment := range offline.Attachments {
+		if isImage(attachment) {
+			fmt.Fprintf(&b, "\n![%s](%s)\n", attachment.Name, dataURI(attachment, files[attachment.Name]))
+		}
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// WriteProposalHTML writes the proposal as a standalone HTML document headed
+// by a table of its metadata. Raw HTML in the proposal body is dropped and
+// image attachments are embedded at the end.
+func WriteProposalHTML(out io.Writer, offline *OfflineProposal, files map[string][]byte) error {
+	renderer := mdhtml.NewRenderer(mdhtml.RendererOptions{
+		Flags: mdhtml.CommonFlags | mdhtml.SkipHTML | mdhtml.Safelink,
+	})
+	body := markdown.ToHTML([]byte(offline.Body), nil, renderer)
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(offline.Proposal.Name))
+	b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n<table>\n", html.EscapeString(offline.Proposal.Name))
+	for _, field := range proposalHeader(offline) {
+		fmt.Fprintf(&b, "<tr><th>%s</th><td>%s</td></tr>\n", html.EscapeString(field[0]), html.EscapeString(field[1]))
+	}
+	b.WriteString("</table>\n<hr>\n")
+	b.Write(body)
+
+	if len(offline.Attachments) > 0 {
+		b.WriteString("<hr>\n<h2>Attachments</h2>\n")
+		for _, attachment := range offline.Attachments {
+			name := html.EscapeString(attachment.Name)
+			if isImage(attachment) {
+				fmt.Fprintf(&b, "<figure><img src=\"%s\" alt=\"%s\"><figcaption>%s</figcaption></figure>\n",
+					dataURI(attachment, files[attachment.Name]), name, name)
+			} else {
+				fmt.Fprintf(&b, "<p>%s</p>\n", name)
+			}
+		}
+	}
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
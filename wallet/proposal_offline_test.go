@@ -0,0 +1,150 @@
+package wallet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	piv1 "github.com/decred/politeia/politeiawww/api/pi/v1"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func testOfflineProposal() *OfflineProposal {
+	return &OfflineProposal{
+		Proposal: dcrlibwallet.Proposal{
+			Token:       "abc123",
+			Name:        "Fund <things>",
+			Username:    "alice",
+			Version:     "2",
+			Category:    dcrlibwallet.ProposalCategoryApproved,
+			PublishedAt: 1600000000,
+			Timestamp:   1600086400,
+			YesVotes:    10,
+			NoVotes:     2,
+		},
+		Body:     "# Plan\n\nDo **things**.\n\n<script>alert(1)</script>\n",
+		Metadata: &piv1.ProposalMetadata{Amount: 1234567, Domain: "development"},
+		Attachments: []ProposalAttachment{
+			{Name: "chart.png", MIME: "image/png"},
+			{Name: "budget.txt", MIME: "text/plain"},
+		},
+	}
+}
+
+func TestAttachmentName(t *testing.T) {
+	tests := map[string]string{
+		"chart.png":        "chart.png",
+		"../../etc/passwd": "passwd",
+		"..\\secret.txt":   "secret.txt",
+		"dir/image.jpg":    "image.jpg",
+		"..":               "",
+		"":                 "",
+	}
+	for name, want := range tests {
+		if got := attachmentName(name); got != want {
+			t.Errorf("%q: expected %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestIsImage(t *testing.T) {
+	tests := map[string]bool{
+		"image/png":            true,
+		"image/svg+xml":        true,
+		"text/plain":           false,
+		"image/":               false,
+		"image/png\"onerror=x": false,
+	}
+	for mime, want := range tests {
+		if got := isImage(ProposalAttachment{MIME: mime}); got != want {
+			t.Errorf("%q: expected %v, got %v", mime, want, got)
+		}
+	}
+}
+
+func TestWriteProposalMarkdown(t *testing.T) {
+	files := map[string][]byte{"chart.png": []byte("png"), "budget.txt": []byte("txt")}
+
+	var buf bytes.Buffer
+	if err := WriteProposalMarkdown(&buf, testOfflineProposal(), files); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"---\ntitle: \"Fund <things>\"\ntoken: \"abc123\"\n",
+		"status: \"Approved\"\n",
+		"published: \"2020-09-13T12:26:40Z\"\n",
+		"amount: \"$12345.67\"\n",
+		"domain: \"development\"\n",
+		"attachments:\n  - \"chart.png\"\n  - \"budget.txt\"\n---\n\n# Plan",
+		"![chart.png](data:image/png;base64,cG5n)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "data:text/plain") {
+		t.Errorf("expected only images to be embedded, got:\n%s", out)
+	}
+}
+
+func TestWriteProposalHTML(t *testing.T) {
+	files := map[string][]byte{"chart.png": []byte("png"), "budget.txt": []byte("txt")}
+
+	var buf bytes.Buffer
+	if err := WriteProposalHTML(&buf, testOfflineProposal(), files); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<title>Fund &lt;things&gt;</title>",
+		"<tr><th>Author</th><td>alice</td></tr>",
+		"<tr><th>Yes votes</th><td>10</td></tr>",
+		"<strong>things</strong>",
+		"<img src=\"data:image/png;base64,cG5n\" alt=\"chart.png\">",
+		"<p>budget.txt</p>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected html to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<script>") {
+		t.Errorf("expected raw html to be dropped, got:\n%s", out)
+	}
+}
+
+func TestOfflineProposals(t *testing.T) {
+	wal := &Wallet{Root: t.TempDir(), Net: "testnet3"}
+
+	offline, err := wal.OfflineProposal("abc123")
+	if err != nil || offline != nil {
+		t.Fatalf("expected no offline proposal, got %v, %v", offline, err)
+	}
+
+	proposal := testOfflineProposal()
+	proposal.attachments = map[string][]byte{"chart.png": []byte("png")}
+	if err = wal.saveOfflineProposal(proposal); err != nil {
+		t.Fatal(err)
+	}
+
+	proposals, err := wal.OfflineProposals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 1 || proposals[0].Proposal.Token != "abc123" || proposals[0].Body != proposal.Body {
+		t.Fatalf("expected the saved proposal, got %+v", proposals)
+	}
+	data, err := wal.attachment(proposals[0], "chart.png")
+	if err != nil || string(data) != "png" {
+		t.Errorf("expected the saved attachment, got %q, %v", data, err)
+	}
+
+	if err = wal.DeleteOfflineProposal("abc123"); err != nil {
+		t.Fatal(err)
+	}
+	if proposals, _ = wal.OfflineProposals(); len(proposals) != 0 {
+		t.Errorf("expected no offline proposals after delete, got %d", len(proposals))
+	}
+}
@@ -0,0 +1,214 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rcv1 "github.com/decred/politeia/politeiawww/api/records/v1"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// proposalVersionsDir is the directory, in the network data directory,
+	// where the fetched proposal versions are cached. Versions never change
+	// once published so they are cached indefinitely.
+	proposalVersionsDir = "proposal_versions"
+
+	// seenProposalVersionsConfigKey is the multiwallet config key under
+	// which the version of each proposal last opened by the user is saved.
+	seenProposalVersionsConfigKey = "seen_proposal_versions"
+
+	proposalIndexFile = "index.md"
+)
+
+// ProposalVersion is the markdown body of a version of a proposal.
+type ProposalVersion struct {
+	Token     string `json:"token"`
+	Version   uint32 `json:"version"`
+	Timestamp int64  `json:"timestamp"`
+	Body      string `json:"body"`
+}
+
+// fetchProposalVersion requests the specified version of the proposal from
+// the politeia server at host.
+func fetchProposalVersion(ctx context.Context, client *http.Client, host, token string, version uint32) (*ProposalVersion, error) {
+	var reply rcv1.DetailsReply
+	err := postPoliteia(ctx, client, host, rcv1.APIRoute+rcv1.RouteDetails, rcv1.Details{Token: token, Version: version}, &reply)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range reply.Record.Files {
+		if file.Name != proposalIndexFile {
+			continue
+		}
+		body, err := base64.StdEncoding.DecodeString(file.Payload)
+		if err != nil {
+			return nil, err
+		}
+		return &ProposalVersion{
+			Token:     token,
+			Version:   reply.Record.Version,
+			Timestamp: reply.Record.Timestamp,
+			Body:      string(body),
+		}, nil
+	}
+	return nil, errors.New("proposal version has no index file")
+}
+
+func (wal *Wallet) proposalVersionsPath(token string) string {
+	return filepath.Join(wal.Root, wal.Net, proposalVersionsDir, token+".json")
+}
+
+func (wal *Wallet) cachedProposalVersions(token string) (map[uint32]*ProposalVersion, error) {
+	versions := make(map[uint32]*ProposalVersion)
+	data, err := ioutil.ReadFile(wal.proposalVersionsPath(token))
+	if os.IsNotExist(err) {
+		return versions, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return versions, json.Unmarshal(data, &versions)
+}
+
+func (wal *Wallet) saveProposalVersions(token string, versions map[uint32]*ProposalVersion) error {
+	data, err := json.Marshal(versions)
+	if err != nil {
+		return err
+	}
+	path := wal.proposalVersionsPath(token)
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// ProposalVersion returns the specified version of the proposal, from the
+// local cache if it has been fetched before.
+func (wal *Wallet) ProposalVersion(ctx context.Context, token string, version uint32) (*ProposalVersion, error) {
+	versions, err := wal.cachedProposalVersions(token)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := versions[version]; ok {
+		return cached, nil
+	}
+
+	client := &http.Client{Timeout: politeiaRequestTimeout}
+	fetched, err := fetchProposalVersion(ctx, client, wal.politeiaHost(), token, version)
+	if err != nil {
+		return nil, err
+	}
+
+	versions[version] = fetched
+	return fetched, wal.saveProposalVersions(token, versions)
+}
+
+// DiffOp is the change made to a line between two versions of a text.
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffAdded
+	DiffRemoved
+)
+
+// DiffLine is a line of a line-level diff.
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines returns the line-level diff from old to new. Removed lines are
+// listed before the lines added in their place.
+func DiffLines(old, new string) []DiffLine {
+	a, b := strings.Split(old, "\n"), strings.Split(new, "\n")
+
+	// Lines shared at the start and end are kept out of the LCS table.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	// lcs[i][j] is the length of the longest common subsequence of ma[i:]
+	// and mb[j:].
+	lcs := make([][]int32, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			diff = append(diff, DiffLine{Op: DiffEqual, Text: ma[i]})
+			i++
+			j++
+		case j == len(mb) || (i < len(ma) && lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, DiffLine{Op: DiffRemoved, Text: ma[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{Op: DiffAdded, Text: mb[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: line})
+	}
+	return diff
+}
+
+// SeenProposalVersions returns the version of each proposal last opened by
+// the user, keyed by token.
+func SeenProposalVersions(mw *dcrlibwallet.MultiWallet) map[string]string {
+	seen := make(map[string]string)
+	mw.ReadUserConfigValue(seenProposalVersionsConfigKey, &seen)
+	return seen
+}
+
+// MarkProposalSeen saves the current version of the proposal as seen by the
+// user.
+func MarkProposalSeen(mw *dcrlibwallet.MultiWallet, proposal *dcrlibwallet.Proposal) {
+	seen := SeenProposalVersions(mw)
+	if seen[proposal.Token] == proposal.Version {
+		return
+	}
+	seen[proposal.Token] = proposal.Version
+	mw.SaveUserConfigValue(seenProposalVersionsConfigKey, seen)
+}
+
+// ProposalChangedSinceSeen returns true if the proposal was opened by the
+// user and has been edited since.
+func ProposalChangedSinceSeen(seen map[string]string, proposal *dcrlibwallet.Proposal) bool {
+	version, ok := seen[proposal.Token]
+	return ok && version != proposal.Version
+}
@@ -0,0 +1,119 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	rcv1 "github.com/decred/politeia/politeiawww/api/records/v1"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		expected []DiffLine
+	}{
+		{
+			name: "unchanged",
+			old:  "a\nb",
+			new:  "a\nb",
+			expected: []DiffLine{
+				{DiffEqual, "a"}, {DiffEqual, "b"},
+			},
+		},
+		{
+			name: "line changed",
+			old:  "a\nb\nc",
+			new:  "a\nB\nc",
+			expected: []DiffLine{
+				{DiffEqual, "a"}, {DiffRemoved, "b"}, {DiffAdded, "B"}, {DiffEqual, "c"},
+			},
+		},
+		{
+			name: "lines added and removed",
+			old:  "# Title\nintro\nbudget: 10\nend",
+			new:  "# Title\nbudget: 10\nmilestones\nend",
+			expected: []DiffLine{
+				{DiffEqual, "# Title"}, {DiffRemoved, "intro"}, {DiffEqual, "budget: 10"},
+				{DiffAdded, "milestones"}, {DiffEqual, "end"},
+			},
+		},
+		{
+			name: "appended",
+			old:  "a",
+			new:  "a\nb\nc",
+			expected: []DiffLine{
+				{DiffEqual, "a"}, {DiffAdded, "b"}, {DiffAdded, "c"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if diff := DiffLines(test.old, test.new); !reflect.DeepEqual(diff, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, diff)
+		}
+	}
+}
+
+func TestFetchProposalVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rcv1.Details
+		if r.URL.Path != rcv1.APIRoute+rcv1.RouteDetails || json.NewDecoder(r.Body).Decode(&req) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(rcv1.DetailsReply{Record: rcv1.Record{
+			Version:   req.Version,
+			Timestamp: 100,
+			Files: []rcv1.File{
+				{Name: "proposalmetadata.json", Payload: base64.StdEncoding.EncodeToString([]byte("{}"))},
+				{Name: "index.md", Payload: base64.StdEncoding.EncodeToString([]byte("# Version 1"))},
+			},
+		}})
+	}))
+	defer server.Close()
+
+	version, err := fetchProposalVersion(context.Background(), server.Client(), server.URL, "abc", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Version != 1 || version.Body != "# Version 1" || version.Token != "abc" {
+		t.Fatalf("unexpected version %+v", version)
+	}
+}
+
+func TestProposalVersionCache(t *testing.T) {
+	wal := &Wallet{Root: t.TempDir(), Net: "testnet3"}
+	cached := map[uint32]*ProposalVersion{2: {Token: "abc", Version: 2, Body: "cached"}}
+	if err := wal.saveProposalVersions("abc", cached); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cached versions are returned without a request to politeia.
+	version, err := wal.ProposalVersion(context.Background(), "abc", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Body != "cached" {
+		t.Fatalf("unexpected version %+v", version)
+	}
+}
+
+func TestProposalChangedSinceSeen(t *testing.T) {
+	seen := map[string]string{"abc": "1"}
+	if ProposalChangedSinceSeen(seen, &dcrlibwallet.Proposal{Token: "abc", Version: "1"}) {
+		t.Error("expected unchanged proposal not to be flagged")
+	}
+	if !ProposalChangedSinceSeen(seen, &dcrlibwallet.Proposal{Token: "abc", Version: "2"}) {
+		t.Error("expected edited proposal to be flagged")
+	}
+	if ProposalChangedSinceSeen(seen, &dcrlibwallet.Proposal{Token: "def", Version: "2"}) {
+		t.Error("expected proposal never opened not to be flagged")
+	}
+}
@@ -0,0 +1,184 @@
+package wallet
+
+import (
+	www "github.com/decred/politeia/politeiawww/api/www/v1"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// ProposalEventType identifies a change to a watched proposal that can be
+// reported to the user.
+type ProposalEventType string
+
+const (
+	ProposalVersionUpdated    ProposalEventType = "version_updated"
+	ProposalVoteMilestone     ProposalEventType = "vote_milestone"
+	ProposalQuorumReached     ProposalEventType = "quorum_reached"
+	ProposalPassThresholdMet  ProposalEventType = "pass_threshold_met"
+	ProposalPassThresholdLost ProposalEventType = "pass_threshold_lost"
+	ProposalVoteResult        ProposalEventType = "vote_result"
+)
+
+// watchedProposalsConfigKey is the multiwallet config key under which the
+// watched proposals are saved. The multiwallet config is stored per network
+// so each network has its own watchlist.
+const watchedProposalsConfigKey = "watched_proposals"
+
+// proposalVoteMilestones are the turnout percentages, of the eligible
+// tickets, reported for watched proposals.
+var proposalVoteMilestones = []int32{25, 50, 75}
+
+// ProposalEvent describes a change to a watched proposal.
+type ProposalEvent struct {
+	Type     ProposalEventType
+	Proposal *dcrlibwallet.Proposal
+	// Milestone is the turnout percentage reached, only set for
+	// ProposalVoteMilestone events.
+	Milestone int32
+}
+
+// watchedProposalState is the state of a watched proposal last reported to
+// the user.
+type watchedProposalState struct {
+	Version       string `json:"version"`
+	Milestone     int32  `json:"milestone"`
+	QuorumReached bool   `json:"quorum_reached"`
+	Passing       bool   `json:"passing"`
+	Finished      bool   `json:"finished"`
+}
+
+func newWatchedProposalState(proposal *dcrlibwallet.Proposal) *watchedProposalState {
+	return &watchedProposalState{
+		Version:       proposal.Version,
+		Milestone:     proposalMilestone(proposal),
+		QuorumReached: proposalQuorumReached(proposal),
+		Passing:       proposalPassing(proposal),
+		Finished:      proposal.VoteStatus == int32(www.PropVoteStatusFinished),
+	}
+}
+
+// proposalMilestone returns the highest vote milestone reached by the
+// proposal, 0 if none.
+func proposalMilestone(proposal *dcrlibwallet.Proposal) int32 {
+	if proposal.EligibleTickets == 0 {
+		return 0
+	}
+
+	turnout := (proposal.YesVotes + proposal.NoVotes) * 100 / proposal.EligibleTickets
+	var milestone int32
+	for _, m := range proposalVoteMilestones {
+		if turnout >= m {
+			milestone = m
+		}
+	}
+	return milestone
+}
+
+func proposalQuorumReached(proposal *dcrlibwallet.Proposal) bool {
+	votes := proposal.YesVotes + proposal.NoVotes
+	return votes > 0 && votes*100 >= proposal.EligibleTickets*proposal.QuorumPercentage
+}
+
+func proposalPassing(proposal *dcrlibwallet.Proposal) bool {
+	votes := proposal.YesVotes + proposal.NoVotes
+	return votes > 0 && proposal.YesVotes*100 >= votes*proposal.PassPercentage
+}
+
+// proposalTransitions returns the events that occurred since prev was
+// recorded and updates prev to the current state of the proposal.
+func proposalTransitions(prev *watchedProposalState, proposal *dcrlibwallet.Proposal) []ProposalEvent {
+	if prev.Finished {
+		return nil
+	}
+
+	current := newWatchedProposalState(proposal)
+	var events []ProposalEvent
+	event := func(eventType ProposalEventType) {
+		events = append(events, ProposalEvent{Type: eventType, Proposal: proposal})
+	}
+
+	if current.Version != prev.Version {
+		event(ProposalVersionUpdated)
+	}
+	if current.Milestone > prev.Milestone {
+		events = append(events, ProposalEvent{Type: ProposalVoteMilestone, Proposal: proposal, Milestone: current.Milestone})
+	}
+	if current.QuorumReached && !prev.QuorumReached {
+		event(ProposalQuorumReached)
+	}
+	// The final result is reported instead of the last threshold change.
+	if current.Finished {
+		event(ProposalVoteResult)
+	} else if current.Passing != prev.Passing {
+		if current.Passing {
+			event(ProposalPassThresholdMet)
+		} else {
+			event(ProposalPassThresholdLost)
+		}
+	}
+
+	*prev = *current
+	return events
+}
+
+func readWatchedProposals(mw *dcrlibwallet.MultiWallet) map[string]*watchedProposalState {
+	watched := make(map[string]*watchedProposalState)
+	mw.ReadUserConfigValue(watchedProposalsConfigKey, &watched)
+	return watched
+}
+
+// WatchedProposals returns the tokens of the watched proposals.
+func WatchedProposals(mw *dcrlibwallet.MultiWallet) []string {
+	watched := readWatchedProposals(mw)
+	tokens := make([]string, 0, len(watched))
+	for token := range watched {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// IsProposalWatched returns true if the proposal with the specified token is
+// on the watchlist.
+func IsProposalWatched(mw *dcrlibwallet.MultiWallet, token string) bool {
+	_, ok := readWatchedProposals(mw)[token]
+	return ok
+}
+
+// WatchProposal adds the proposal to the watchlist. Only changes that occur
+// after the proposal is watched are reported.
+func WatchProposal(mw *dcrlibwallet.MultiWallet, proposal *dcrlibwallet.Proposal) {
+	watched := readWatchedProposals(mw)
+	watched[proposal.Token] = newWatchedProposalState(proposal)
+	mw.SaveUserConfigValue(watchedProposalsConfigKey, watched)
+}
+
+// UnwatchProposal removes the proposal with the specified token from the
+// watchlist.
+func UnwatchProposal(mw *dcrlibwallet.MultiWallet, token string) {
+	watched := readWatchedProposals(mw)
+	delete(watched, token)
+	mw.SaveUserConfigValue(watchedProposalsConfigKey, watched)
+}
+
+// ProposalEvents compares the locally synced data of every watched proposal
+// with the state last reported and returns an event for each change that
+// has not been reported yet. Reported states are saved so that events are not
+// repeated after a restart. Proposals that fail to load are checked again on
+// the next call.
+func ProposalEvents(mw *dcrlibwallet.MultiWallet) []ProposalEvent {
+	watched := readWatchedProposals(mw)
+
+	var events []ProposalEvent
+	for token, state := range watched {
+		proposal, err := mw.Politeia.GetProposalRaw(token)
+		if err != nil {
+			log.Errorf("error loading watched proposal %s: %v", token, err)
+			continue
+		}
+		events = append(events, proposalTransitions(state, proposal)...)
+	}
+
+	if len(events) > 0 {
+		mw.SaveUserConfigValue(watchedProposalsConfigKey, watched)
+	}
+	return events
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func eventTypes(events []ProposalEvent) []ProposalEventType {
+	types := make([]ProposalEventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestProposalTransitions(t *testing.T) {
+	proposal := &dcrlibwallet.Proposal{
+		Token:            "abc",
+		Version:          "1",
+		EligibleTickets:  1000,
+		QuorumPercentage: 20,
+		PassPercentage:   60,
+	}
+	state := newWatchedProposalState(proposal)
+
+	steps := []struct {
+		name     string
+		update   func(p *dcrlibwallet.Proposal)
+		expected []ProposalEventType
+	}{
+		{"no change", func(p *dcrlibwallet.Proposal) {}, nil},
+		{"new version", func(p *dcrlibwallet.Proposal) { p.Version = "2" }, []ProposalEventType{ProposalVersionUpdated}},
+		{"votes below quorum", func(p *dcrlibwallet.Proposal) { p.VoteStatus, p.YesVotes, p.NoVotes = 3, 50, 100 }, nil},
+		{"milestone and quorum reached", func(p *dcrlibwallet.Proposal) { p.YesVotes, p.NoVotes = 150, 120 },
+			[]ProposalEventType{ProposalVoteMilestone, ProposalQuorumReached}},
+		{"pass threshold met", func(p *dcrlibwallet.Proposal) { p.YesVotes = 200 }, []ProposalEventType{ProposalPassThresholdMet}},
+		{"pass threshold lost", func(p *dcrlibwallet.Proposal) { p.NoVotes = 300 }, []ProposalEventType{ProposalVoteMilestone, ProposalPassThresholdLost}},
+		{"vote finished", func(p *dcrlibwallet.Proposal) { p.VoteStatus, p.YesVotes = 4, 500 },
+			[]ProposalEventType{ProposalVoteMilestone, ProposalVoteResult}},
+		{"finished proposals are not reported again", func(p *dcrlibwallet.Proposal) { p.Version = "3" }, nil},
+	}
+
+	for _, step := range steps {
+		step.update(proposal)
+		events := proposalTransitions(state, proposal)
+		if types := eventTypes(events); !reflect.DeepEqual(types, step.expected) && (len(types) > 0 || len(step.expected) > 0) {
+			t.Fatalf("%s: expected events %v, got %v", step.name, step.expected, types)
+		}
+	}
+}
+
+func TestProposalVoteMilestone(t *testing.T) {
+	proposal := &dcrlibwallet.Proposal{EligibleTickets: 1000, YesVotes: 400, NoVotes: 120}
+	state := &watchedProposalState{Milestone: 25}
+
+	events := proposalTransitions(state, proposal)
+	if len(events) == 0 || events[0].Type != ProposalVoteMilestone || events[0].Milestone != 50 {
+		t.Fatalf("expected 50%% milestone event, got %+v", events)
+	}
+	if state.Milestone != 50 {
+		t.Fatalf("expected state milestone to be updated, got %d", state.Milestone)
+	}
+}
@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"strings"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// roundAmountUnit is the granularity, in atoms, below which a payment amount
+// is considered round. Round payments are easily told apart from the change
+// of the transaction.
+const roundAmountUnit = 1e6
+
+// SpendWarningKind identifies a privacy risk of a transaction about to be
+// sent.
+type SpendWarningKind int
+
+const (
+	// SpendMergesMixedInputs indicates that the transaction may spend mixed
+	// and unmixed outputs together, linking the mixed outputs to the
+	// unmixed ones.
+	SpendMergesMixedInputs SpendWarningKind = iota
+	// SpendTraceableChange indicates that the transaction creates a change
+	// output that can be told apart from the payment.
+	SpendTraceableChange
+	// SpendAddressReused indicates that the destination address was used by
+	// a previous transaction.
+	SpendAddressReused
+	// SpendAmountRepeated indicates that a previous payment sent the same
+	// amount.
+	SpendAmountRepeated
+)
+
+// SpendWarning is a privacy risk of a transaction about to be sent.
+type SpendWarning struct {
+	Kind SpendWarningKind
+	// TxHash and Timestamp identify the previous transaction that used the
+	// destination address or sent the same amount.
+	TxHash    string
+	Timestamp int64
+}
+
+// SpendPlan describes a transaction about to be sent.
+type SpendPlan struct {
+	// Address is the destination address. It is empty for transfers between
+	// the accounts of the wallet, which are not checked for address reuse
+	// or repeated amounts.
+	Address string
+	Amount  int64
+	SendMax bool
+	// FromMixedAccount is true if the transaction spends from the mixed
+	// account, returning its change to the unmixed account.
+	FromMixedAccount bool
+	// Inputs are the outputs the transaction may spend.
+	Inputs []*dcrlibwallet.UnspentOutput
+}
+
+// outputTxHash returns the hash of the transaction of an unspent output.
+func outputTxHash(output *dcrlibwallet.UnspentOutput) string {
+	return strings.Split(output.OutputKey, ":")[0]
+}
+
+// isMixedOutput returns true if the output is a mixed output of a mixed
+// transaction in txs, which are indexed by hash.
+func isMixedOutput(output *dcrlibwallet.UnspentOutput, txs map[string]*dcrlibwallet.Transaction) bool {
+	tx, ok := txs[outputTxHash(output)]
+	return ok && tx.Type == dcrlibwallet.TxTypeMixed && output.Amount == tx.MixDenomination
+}
+
+// CheckSpend returns the privacy risks of the planned transaction given the
+// transaction history of the wallet.
+func CheckSpend(plan SpendPlan, history []dcrlibwallet.Transaction) []SpendWarning {
+	txs := make(map[string]*dcrlibwallet.Transaction, len(history))
+	for i := range history {
+		txs[history[i].Hash] = &history[i]
+	}
+
+	var warnings []SpendWarning
+
+	var mixed, unmixed int
+	for _, input := range plan.Inputs {
+		if isMixedOutput(input, txs) {
+			mixed++
+		} else {
+			unmixed++
+		}
+	}
+	// Inputs are selected by the wallet from all the outputs of the account,
+	// so mixed and unmixed outputs may be merged as soon as the account holds
+	// both.
+	if mixed > 0 && unmixed > 0 {
+		warnings = append(warnings, SpendWarning{Kind: SpendMergesMixedInputs})
+	}
+
+	if !plan.SendMax && (plan.FromMixedAccount || plan.Amount%roundAmountUnit == 0) {
+		warnings = append(warnings, SpendWarning{Kind: SpendTraceableChange})
+	}
+
+	if plan.Address == "" {
+		return warnings
+	}
+
+	var reused, repeated *dcrlibwallet.Transaction
+	for i := range history {
+		tx := &history[i]
+		for _, output := range tx.Outputs {
+			if reused == nil && output.Address == plan.Address {
+				reused = tx
+			}
+			// Only payments to addresses outside the wallet are compared.
+			if repeated == nil && tx.Direction == dcrlibwallet.TxDirectionSent &&
+				output.AccountNumber == -1 && output.Amount == plan.Amount {
+				repeated = tx
+			}
+		}
+	}
+	if reused != nil {
+		warnings = append(warnings, SpendWarning{Kind: SpendAddressReused, TxHash: reused.Hash, Timestamp: reused.Timestamp})
+	}
+	if repeated != nil && !plan.SendMax {
+		warnings = append(warnings, SpendWarning{Kind: SpendAmountRepeated, TxHash: repeated.Hash, Timestamp: repeated.Timestamp})
+	}
+
+	return warnings
+}
+
+// SpendWarnings returns the privacy risks of sending amount to address from
+// the account of w. address is empty for transfers between the accounts of
+// the wallet.
+func (wal *Wallet) SpendWarnings(w *dcrlibwallet.Wallet, account int32, address string, amount int64, sendMax bool) ([]SpendWarning, error) {
+	inputs, err := w.UnspentOutputs(account)
+	if err != nil {
+		return nil, err
+	}
+	history, err := w.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterAll, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return CheckSpend(SpendPlan{
+		Address:          address,
+		Amount:           amount,
+		SendMax:          sendMax,
+		FromMixedAccount: w.ReadBoolConfigValueForKey(dcrlibwallet.AccountMixerConfigSet, false) && account == w.MixedAccountNumber(),
+		Inputs:           inputs,
+	}, history), nil
+}
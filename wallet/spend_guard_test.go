@@ -0,0 +1,112 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func testSpendHistory() []dcrlibwallet.Transaction {
+	return []dcrlibwallet.Transaction{
+		{
+			Hash:            "mix",
+			Type:            dcrlibwallet.TxTypeMixed,
+			MixDenomination: 2e8,
+			MixCount:        2,
+			Outputs: []*dcrlibwallet.TxOutput{
+				{Index: 0, Amount: 2e8, Address: "Dsmixed1", AccountNumber: 1},
+				{Index: 1, Amount: 2e8, Address: "Dsmixed2", AccountNumber: 1},
+				{Index: 2, Amount: 12345678, Address: "Dschange", AccountNumber: 2},
+			},
+		},
+		{
+			Hash:      "payment",
+			Type:      dcrlibwallet.TxTypeRegular,
+			Direction: dcrlibwallet.TxDirectionSent,
+			Timestamp: 1600000000,
+			Outputs: []*dcrlibwallet.TxOutput{
+				{Index: 0, Amount: 123456789, Address: "Dspaid", AccountNumber: -1},
+				{Index: 1, Amount: 98765432, Address: "Dsownchange", AccountNumber: 2},
+			},
+		},
+	}
+}
+
+func spendWarningKinds(warnings []SpendWarning) map[SpendWarningKind]SpendWarning {
+	kinds := make(map[SpendWarningKind]SpendWarning)
+	for _, warning := range warnings {
+		kinds[warning.Kind] = warning
+	}
+	return kinds
+}
+
+func TestCheckSpendMergedInputs(t *testing.T) {
+	mixed := &dcrlibwallet.UnspentOutput{OutputKey: "mix:0", Amount: 2e8}
+	change := &dcrlibwallet.UnspentOutput{OutputKey: "mix:2", Amount: 12345678}
+	received := &dcrlibwallet.UnspentOutput{OutputKey: "other:0", Amount: 2e8}
+
+	tests := []struct {
+		name   string
+		inputs []*dcrlibwallet.UnspentOutput
+		merged bool
+	}{
+		{"mixed only", []*dcrlibwallet.UnspentOutput{mixed}, false},
+		{"unmixed only", []*dcrlibwallet.UnspentOutput{change, received}, false},
+		{"mixed and change", []*dcrlibwallet.UnspentOutput{mixed, change}, true},
+		// Outputs of the mix denomination outside of mixed transactions are
+		// not mixed.
+		{"mixed and received", []*dcrlibwallet.UnspentOutput{mixed, received}, true},
+	}
+	for _, test := range tests {
+		warnings := CheckSpend(SpendPlan{Amount: 1, SendMax: true, Inputs: test.inputs}, testSpendHistory())
+		if _, ok := spendWarningKinds(warnings)[SpendMergesMixedInputs]; ok != test.merged {
+			t.Errorf("%s: expected merge warning %v, got %v", test.name, test.merged, ok)
+		}
+	}
+}
+
+func TestCheckSpendTraceableChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		plan      SpendPlan
+		traceable bool
+	}{
+		{"round amount", SpendPlan{Amount: 5e8}, true},
+		{"odd amount", SpendPlan{Amount: 512345678}, false},
+		{"from mixed account", SpendPlan{Amount: 512345678, FromMixedAccount: true}, true},
+		{"send max", SpendPlan{Amount: 5e8, SendMax: true, FromMixedAccount: true}, false},
+	}
+	for _, test := range tests {
+		warnings := CheckSpend(test.plan, nil)
+		if _, ok := spendWarningKinds(warnings)[SpendTraceableChange]; ok != test.traceable {
+			t.Errorf("%s: expected change warning %v, got %v", test.name, test.traceable, ok)
+		}
+	}
+}
+
+func TestCheckSpendHistory(t *testing.T) {
+	kinds := spendWarningKinds(CheckSpend(SpendPlan{Address: "Dspaid", Amount: 123456789}, testSpendHistory()))
+	reused, ok := kinds[SpendAddressReused]
+	if !ok || reused.TxHash != "payment" || reused.Timestamp != 1600000000 {
+		t.Errorf("expected the address reuse by the payment, got %+v", reused)
+	}
+	if repeated, ok := kinds[SpendAmountRepeated]; !ok || repeated.TxHash != "payment" {
+		t.Errorf("expected the amount repeated by the payment, got %+v", repeated)
+	}
+
+	// Addresses and amounts of the wallet's own outputs count as reused but
+	// not as repeated payments.
+	kinds = spendWarningKinds(CheckSpend(SpendPlan{Address: "Dsmixed1", Amount: 98765432}, testSpendHistory()))
+	if _, ok := kinds[SpendAddressReused]; !ok {
+		t.Error("expected a wallet address to count as reused")
+	}
+	if _, ok := kinds[SpendAmountRepeated]; ok {
+		t.Error("expected change outputs not to count as payments")
+	}
+
+	// Transfers between accounts are not checked against the history.
+	kinds = spendWarningKinds(CheckSpend(SpendPlan{Amount: 123456789}, testSpendHistory()))
+	if len(kinds) != 0 {
+		t.Errorf("expected no warnings for an account transfer, got %+v", kinds)
+	}
+}
@@ -0,0 +1,265 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"decred.org/dcrwallet/v2/wallet/txrules"
+	"decred.org/dcrwallet/v2/wallet/txsizes"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// TicketBuyerRulesConfigKey is the wallet config key under which the
+	// ticket buyer rules are saved.
+	TicketBuyerRulesConfigKey = "tb_rules"
+
+	ticketBuyerPurchasesConfigKey    = "tb_rule_purchases"
+	ticketBuyerWindowPricesConfigKey = "tb_rule_window_prices"
+)
+
+// RuleTicketBuyer purchases tickets for a wallet whenever a block is
+// attached, as permitted by the ticket buyer rules saved for the wallet.
+// It uses the account, VSP and balance to maintain from the wallet's
+// automatic ticket buyer config.
+type RuleTicketBuyer struct {
+	mu sync.Mutex
+
+	multi  *dcrlibwallet.MultiWallet
+	wallet *dcrlibwallet.Wallet
+	engine *TicketBuyerRuleEngine
+
+	// savedWindow is the latest price window whose ticket price is saved.
+	savedWindow int32
+
+	cfg        *dcrlibwallet.TicketBuyerConfig
+	vspPubKey  []byte
+	vspFee     float64
+	passphrase []byte
+	running    bool
+	buying     bool
+}
+
+// RuleTicketBuyer returns the rule-based ticket buyer for the wallet with
+// the specified ID, creating it if necessary.
+func (wal *Wallet) RuleTicketBuyer(walletID int) (*RuleTicketBuyer, error) {
+	wal.ticketBuyersMu.Lock()
+	defer wal.ticketBuyersMu.Unlock()
+
+	if tb, ok := wal.ticketBuyers[walletID]; ok {
+		return tb, nil
+	}
+
+	w := wal.multi.WalletWithID(walletID)
+	if w == nil {
+		return nil, errors.New(dcrlibwallet.ErrNotExist)
+	}
+
+	windowSize := int32(w.Internal().ChainParams().StakeDiffWindowSize)
+	tb := &RuleTicketBuyer{
+		multi:  wal.multi,
+		wallet: w,
+		engine: NewTicketBuyerRuleEngine(ReadTicketBuyerRules(w), nil, w, windowSize),
+	}
+
+	var purchases []TicketPurchaseRecord
+	if err := w.ReadUserConfigValue(ticketBuyerPurchasesConfigKey, &purchases); err == nil {
+		tb.engine.SetPurchases(purchases)
+	}
+	tb.savedWindow = -1
+	var windowPrices []TicketWindowPrice
+	if err := w.ReadUserConfigValue(ticketBuyerWindowPricesConfigKey, &windowPrices); err == nil && len(windowPrices) > 0 {
+		tb.engine.SetWindowPrices(windowPrices)
+		tb.savedWindow = windowPrices[len(windowPrices)-1].Window
+	}
+
+	if wal.ticketBuyers == nil {
+		wal.ticketBuyers = make(map[int]*RuleTicketBuyer)
+	}
+	wal.ticketBuyers[walletID] = tb
+	return tb, nil
+}
+
+// ReadTicketBuyerRules returns the ticket buyer rules saved for the wallet.
+func ReadTicketBuyerRules(w *dcrlibwallet.Wallet) TicketBuyerRules {
+	var rules TicketBuyerRules
+	_ = w.ReadUserConfigValue(TicketBuyerRulesConfigKey, &rules)
+	return rules
+}
+
+// SaveTicketBuyerRules saves the ticket buyer rules for the wallet.
+func SaveTicketBuyerRules(w *dcrlibwallet.Wallet, rules TicketBuyerRules) {
+	w.SaveUserConfigValue(TicketBuyerRulesConfigKey, rules)
+}
+
+func (tb *RuleTicketBuyer) listenerID() string {
+	return fmt.Sprintf("%s_rule_ticket_buyer_%d", syncID, tb.wallet.ID)
+}
+
+// Start validates the passphrase and starts purchasing tickets on every new
+// block.
+func (tb *RuleTicketBuyer) Start(passphrase []byte) error {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if tb.running {
+		return errors.New("ticket buyer already running")
+	}
+
+	cfg := tb.wallet.AutoTicketsBuyerConfig()
+	if cfg.VspHost == "" {
+		return errors.New("ticket buyer config not set for this wallet")
+	}
+	if cfg.BalanceToMaintain < 0 {
+		return errors.New("negative balance to maintain in ticket buyer config")
+	}
+
+	var vspPubKey []byte
+	var vspFee float64
+	for _, vsp := range tb.multi.KnownVSPs() {
+		if vsp.Host == cfg.VspHost && vsp.VspInfoResponse != nil {
+			vspPubKey, vspFee = vsp.PubKey, vsp.FeePercentage
+			break
+		}
+	}
+	if vspPubKey == nil {
+		return fmt.Errorf("no vsp info for %s", cfg.VspHost)
+	}
+
+	// Validate the passphrase.
+	if err := tb.wallet.UnlockWallet(passphrase); err != nil {
+		return err
+	}
+	tb.wallet.LockWallet()
+
+	tb.engine.SetRules(ReadTicketBuyerRules(tb.wallet))
+	if err := tb.multi.AddTxAndBlockNotificationListener(tb, true, tb.listenerID()); err != nil {
+		return err
+	}
+
+	tb.cfg = cfg
+	tb.vspPubKey = vspPubKey
+	tb.vspFee = vspFee
+	tb.passphrase = passphrase
+	tb.running = true
+	return nil
+}
+
+// Stop stops purchasing tickets.
+func (tb *RuleTicketBuyer) Stop() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if !tb.running {
+		return
+	}
+
+	tb.multi.RemoveTxAndBlockNotificationListener(tb.listenerID())
+	tb.passphrase = nil
+	tb.running = false
+}
+
+// IsRunning returns true if the ticket buyer is running.
+func (tb *RuleTicketBuyer) IsRunning() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.running
+}
+
+// Log returns the most recent rule evaluations, newest first.
+func (tb *RuleTicketBuyer) Log() []RuleEvaluation {
+	return tb.engine.Log()
+}
+
+// OnTransaction satisfies the dcrlibwallet TxAndBlockNotificationListener
+// interface.
+func (tb *RuleTicketBuyer) OnTransaction(transaction string) {}
+
+// OnTransactionConfirmed satisfies the dcrlibwallet
+// TxAndBlockNotificationListener interface.
+func (tb *RuleTicketBuyer) OnTransactionConfirmed(walletID int, hash string, blockHeight int32) {}
+
+// OnBlockAttached evaluates the ticket buyer rules and purchases the tickets
+// they allow.
+func (tb *RuleTicketBuyer) OnBlockAttached(walletID int, blockHeight int32) {
+	if walletID != tb.wallet.ID || !tb.multi.IsSynced() {
+		return
+	}
+
+	tb.mu.Lock()
+	if !tb.running || tb.buying {
+		tb.mu.Unlock()
+		return
+	}
+	tb.buying = true
+	cfg, vspPubKey, vspFee, passphrase := tb.cfg, tb.vspPubKey, tb.vspFee, tb.passphrase
+	tb.mu.Unlock()
+
+	defer func() {
+		tb.mu.Lock()
+		tb.buying = false
+		tb.mu.Unlock()
+	}()
+
+	evaluation := tb.engine.Evaluate(func(ticketPrice int64) int {
+		balance, err := tb.wallet.GetAccountBalance(cfg.PurchaseAccount)
+		if err != nil || ticketPrice <= 0 {
+			return 0
+		}
+		spendable := balance.Spendable - cfg.BalanceToMaintain
+		if spendable <= 0 {
+			return 0
+		}
+		w := tb.wallet.Internal()
+		cost := ticketCost(dcrutil.Amount(ticketPrice), w.RelayFee(), blockHeight, vspFee, w.ChainParams())
+		return int(spendable / int64(cost))
+	})
+	tb.saveWindowPrices()
+	if evaluation.Allowed == 0 {
+		log.Debugf("[%d] Skipping ticket purchase: rule result %d", walletID, evaluation.Result)
+		return
+	}
+
+	hashes, err := tb.wallet.PurchaseTickets(cfg.PurchaseAccount, int32(evaluation.Allowed), cfg.VspHost, vspPubKey, passphrase)
+	if len(hashes) > 0 {
+		log.Infof("[%d] Purchased %d tickets at %d atoms", walletID, len(hashes), evaluation.TicketPrice)
+		tb.engine.RecordPurchase(len(hashes), blockHeight)
+		tb.wallet.SaveUserConfigValue(ticketBuyerPurchasesConfigKey, tb.engine.Purchases())
+	}
+	if err != nil {
+		log.Errorf("[%d] Ticket purchasing failed: %v", walletID, err)
+	}
+}
+
+// ticketCost returns the ticket price plus the fee paid to the VSP and an
+// estimate of the transaction fees paid for a ticket.
+func ticketCost(ticketPrice, relayFee dcrutil.Amount, height int32, vspFeePercent float64, params *chaincfg.Params) dcrutil.Amount {
+	// The ticket redeems a P2PKH output of the split transaction and has a
+	// stake submission, a commitment and a stake change output. The fee
+	// transaction redeems a P2PKH output and pays the VSP with change.
+	ticketSize := txsizes.EstimateSerializeSizeFromScriptSizes([]int{txsizes.RedeemP2PKHSigScriptSize},
+		[]int{txsizes.P2PKHPkScriptSize + 1, txsizes.TicketCommitmentScriptSize, txsizes.P2PKHPkScriptSize + 1}, 0)
+	feeTxSize := txsizes.EstimateSerializeSizeFromScriptSizes([]int{txsizes.RedeemP2PKHSigScriptSize},
+		[]int{txsizes.P2PKHPkScriptSize}, txsizes.P2PKHPkScriptSize)
+	txFees := txrules.FeeForSerializeSize(relayFee, txsizes.P2PKHOutputSize+ticketSize+feeTxSize)
+
+	// SPV wallets assume DCP0010 is active.
+	vspFee := txrules.StakePoolTicketFee(ticketPrice, relayFee, height, vspFeePercent, params, true)
+	return ticketPrice + vspFee + txFees
+}
+
+// saveWindowPrices saves the window prices of the rule engine when it has
+// seen a new price window so that the moving average of the ticket price
+// survives restarts. It is only called by OnBlockAttached, which doesn't run
+// concurrently.
+func (tb *RuleTicketBuyer) saveWindowPrices() {
+	prices := tb.engine.WindowPrices()
+	if len(prices) == 0 || prices[len(prices)-1].Window == tb.savedWindow {
+		return
+	}
+	tb.wallet.SaveUserConfigValue(ticketBuyerWindowPricesConfigKey, prices)
+	tb.savedWindow = prices[len(prices)-1].Window
+}
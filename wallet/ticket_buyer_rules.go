@@ -0,0 +1,378 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// maxRuleEvaluationLogSize is the number of rule evaluations kept in the
+// ticket buyer rule engine's log.
+const maxRuleEvaluationLogSize = 50
+
+// TicketBuyerRules are additional rules that restrict when and how many
+// tickets the automatic ticket buyer may purchase. A zero value disables the
+// corresponding rule.
+type TicketBuyerRules struct {
+	// MaxTicketPrice is the highest ticket price, in atoms, at which tickets
+	// may be purchased.
+	MaxTicketPrice int64 `json:"max_ticket_price"`
+	// MaxTicketsPerDay is the maximum number of tickets purchased in the
+	// last 24 hours.
+	MaxTicketsPerDay int `json:"max_tickets_per_day"`
+	// MaxTicketsPerWindow is the maximum number of tickets purchased in a
+	// single ticket price window.
+	MaxTicketsPerWindow int `json:"max_tickets_per_window"`
+	// ActiveHoursStart and ActiveHoursEnd define the hours of the day
+	// (0-23, local time) within which tickets may be purchased. The end
+	// hour is exclusive and the range may wrap around midnight. The rule is
+	// disabled if both are equal.
+	ActiveHoursStart int `json:"active_hours_start"`
+	ActiveHoursEnd   int `json:"active_hours_end"`
+	// PauseAbovePercent pauses purchases while the ticket price is more
+	// than this percentage above the moving average of the ticket price
+	// over the last AveragePeriod price windows.
+	PauseAbovePercent float64 `json:"pause_above_percent"`
+	AveragePeriod     int     `json:"average_period"`
+}
+
+// IsSet returns true if any of the rules is enabled.
+func (r TicketBuyerRules) IsSet() bool {
+	return r.MaxTicketPrice > 0 || r.MaxTicketsPerDay > 0 || r.MaxTicketsPerWindow > 0 ||
+		r.ActiveHoursStart != r.ActiveHoursEnd || (r.PauseAbovePercent > 0 && r.AveragePeriod > 0)
+}
+
+// RuleResult identifies the outcome of a ticket buyer rule evaluation.
+type RuleResult int
+
+const (
+	// RulesPassed indicates that tickets may be purchased.
+	RulesPassed RuleResult = iota
+	// RuleLowBalance indicates that no ticket is affordable.
+	RuleLowBalance
+	// RulePriceAboveMax indicates that the ticket price is above the
+	// maximum ticket price.
+	RulePriceAboveMax
+	// RuleOutsideActiveHours indicates that the current time is outside
+	// the active purchase hours.
+	RuleOutsideActiveHours
+	// RuleDailyLimitReached indicates that the maximum number of tickets
+	// per day has been purchased.
+	RuleDailyLimitReached
+	// RuleWindowLimitReached indicates that the maximum number of tickets
+	// per price window has been purchased.
+	RuleWindowLimitReached
+	// RulePriceAboveAverage indicates that the ticket price has risen
+	// above its moving average.
+	RulePriceAboveAverage
+	// RulePriceUnavailable indicates that the ticket price could not be
+	// fetched.
+	RulePriceUnavailable
+)
+
+// Clock provides the current time to the ticket buyer rule engine.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// TicketPriceFeed provides the current ticket price and the block height at
+// which it applies. It is satisfied by *dcrlibwallet.Wallet.
+type TicketPriceFeed interface {
+	TicketPrice() (*dcrlibwallet.TicketPriceResponse, error)
+}
+
+// RuleEvaluation records the outcome of a single rule evaluation.
+type RuleEvaluation struct {
+	Time        time.Time
+	TicketPrice int64
+	// Allowed is the number of tickets that may be purchased.
+	Allowed int
+	Result  RuleResult
+}
+
+// TicketPurchaseRecord records tickets purchased by the rule-based ticket
+// buyer.
+type TicketPurchaseRecord struct {
+	Time   time.Time `json:"time"`
+	Window int32     `json:"window"`
+	Count  int       `json:"count"`
+}
+
+// TicketWindowPrice records the ticket price of a price window.
+type TicketWindowPrice struct {
+	Window int32 `json:"window"`
+	Price  int64 `json:"price"`
+}
+
+// TicketBuyerRuleEngine decides how many tickets the automatic ticket buyer
+// may purchase based on a set of TicketBuyerRules.
+type TicketBuyerRuleEngine struct {
+	mu sync.Mutex
+
+	rules      TicketBuyerRules
+	clock      Clock
+	priceFeed  TicketPriceFeed
+	windowSize int32
+
+	// windowPrices holds the ticket price of each price window seen,
+	// oldest first, and lastWindow is the most recent price window.
+	windowPrices []TicketWindowPrice
+	lastWindow   int32
+
+	purchases []TicketPurchaseRecord
+	log       []RuleEvaluation
+}
+
+// NewTicketBuyerRuleEngine returns a rule engine that reads the ticket price
+// from priceFeed. The windowSize is the number of blocks in a ticket price
+// window. If clock is nil, the system clock is used.
+func NewTicketBuyerRuleEngine(rules TicketBuyerRules, clock Clock, priceFeed TicketPriceFeed, windowSize int32) *TicketBuyerRuleEngine {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &TicketBuyerRuleEngine{
+		rules:      rules,
+		clock:      clock,
+		priceFeed:  priceFeed,
+		windowSize: windowSize,
+		lastWindow: -1,
+	}
+}
+
+// SetRules replaces the rules used by the engine.
+func (e *TicketBuyerRuleEngine) SetRules(rules TicketBuyerRules) {
+	e.mu.Lock()
+	e.rules = rules
+	e.mu.Unlock()
+}
+
+// SetPurchases restores the purchases previously recorded by the engine.
+func (e *TicketBuyerRuleEngine) SetPurchases(purchases []TicketPurchaseRecord) {
+	e.mu.Lock()
+	e.purchases = purchases
+	e.mu.Unlock()
+}
+
+// Purchases returns the purchases recorded in the last 24 hours.
+func (e *TicketBuyerRuleEngine) Purchases() []TicketPurchaseRecord {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.prunePurchases()
+	return append([]TicketPurchaseRecord(nil), e.purchases...)
+}
+
+// SetWindowPrices restores the window prices previously recorded by the
+// engine.
+func (e *TicketBuyerRuleEngine) SetWindowPrices(prices []TicketWindowPrice) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.windowPrices = prices
+	e.lastWindow = -1
+	if len(prices) > 0 {
+		e.lastWindow = prices[len(prices)-1].Window
+	}
+}
+
+// WindowPrices returns the ticket prices of the price windows used to
+// compute the moving average of the ticket price, oldest first.
+func (e *TicketBuyerRuleEngine) WindowPrices() []TicketWindowPrice {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]TicketWindowPrice(nil), e.windowPrices...)
+}
+
+// Log returns the most recent rule evaluations, newest first.
+func (e *TicketBuyerRuleEngine) Log() []RuleEvaluation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	evaluations := make([]RuleEvaluation, len(e.log))
+	for i := range e.log {
+		evaluations[i] = e.log[len(e.log)-1-i]
+	}
+	return evaluations
+}
+
+// Evaluate checks the rules against the current time and ticket price and
+// returns the number of tickets that may be purchased, up to affordable.
+func (e *TicketBuyerRuleEngine) Evaluate(affordable func(ticketPrice int64) int) RuleEvaluation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	evaluation := e.evaluate(affordable)
+	e.log = append(e.log, evaluation)
+	if len(e.log) > maxRuleEvaluationLogSize {
+		e.log = e.log[len(e.log)-maxRuleEvaluationLogSize:]
+	}
+	return evaluation
+}
+
+func (e *TicketBuyerRuleEngine) evaluate(affordable func(ticketPrice int64) int) RuleEvaluation {
+	now := e.clock.Now()
+	evaluation := RuleEvaluation{Time: now}
+
+	price, err := e.priceFeed.TicketPrice()
+	if err != nil {
+		evaluation.Result = RulePriceUnavailable
+		return evaluation
+	}
+	evaluation.TicketPrice = price.TicketPrice
+	window := e.window(price.Height)
+
+	if window != e.lastWindow {
+		e.windowPrices = append(e.windowPrices, TicketWindowPrice{Window: window, Price: price.TicketPrice})
+		if len(e.windowPrices) > e.rules.AveragePeriod+1 {
+			e.windowPrices = e.windowPrices[len(e.windowPrices)-e.rules.AveragePeriod-1:]
+		}
+		e.lastWindow = window
+	}
+	average := e.movingAverage()
+
+	rules := e.rules
+	if rules.MaxTicketPrice > 0 && price.TicketPrice > rules.MaxTicketPrice {
+		evaluation.Result = RulePriceAboveMax
+		return evaluation
+	}
+
+	if !rules.withinActiveHours(now) {
+		evaluation.Result = RuleOutsideActiveHours
+		return evaluation
+	}
+
+	if rules.PauseAbovePercent > 0 && average > 0 &&
+		float64(price.TicketPrice) > average*(1+rules.PauseAbovePercent/100) {
+		evaluation.Result = RulePriceAboveAverage
+		return evaluation
+	}
+
+	allowed := affordable(price.TicketPrice)
+	e.prunePurchases()
+
+	if rules.MaxTicketsPerDay > 0 {
+		remaining := rules.MaxTicketsPerDay - e.purchasedSince(now.Add(-24*time.Hour), -1)
+		if remaining <= 0 {
+			evaluation.Result = RuleDailyLimitReached
+			return evaluation
+		}
+		if allowed > remaining {
+			allowed = remaining
+		}
+	}
+
+	if rules.MaxTicketsPerWindow > 0 {
+		remaining := rules.MaxTicketsPerWindow - e.purchasedSince(time.Time{}, window)
+		if remaining <= 0 {
+			evaluation.Result = RuleWindowLimitReached
+			return evaluation
+		}
+		if allowed > remaining {
+			allowed = remaining
+		}
+	}
+
+	if allowed <= 0 {
+		evaluation.Result = RuleLowBalance
+		return evaluation
+	}
+
+	evaluation.Allowed = allowed
+	evaluation.Result = RulesPassed
+	return evaluation
+}
+
+// RecordPurchase records count tickets purchased in the price window
+// that applies at the specified block height.
+func (e *TicketBuyerRuleEngine) RecordPurchase(count int, height int32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.purchases = append(e.purchases, TicketPurchaseRecord{
+		Time:   e.clock.Now(),
+		Window: e.window(height),
+		Count:  count,
+	})
+}
+
+func (e *TicketBuyerRuleEngine) window(height int32) int32 {
+	if e.windowSize <= 0 {
+		return 0
+	}
+	// TicketPrice reports the price of the next block.
+	return (height + 1) / e.windowSize
+}
+
+// movingAverage returns the average ticket price of the price windows
+// preceding the current window or 0 if not enough windows have been seen.
+func (e *TicketBuyerRuleEngine) movingAverage() float64 {
+	if e.rules.AveragePeriod <= 0 || len(e.windowPrices) < 2 {
+		return 0
+	}
+
+	// The last recorded price is for the current window.
+	previous := e.windowPrices[:len(e.windowPrices)-1]
+	if len(previous) > e.rules.AveragePeriod {
+		previous = previous[len(previous)-e.rules.AveragePeriod:]
+	}
+
+	var total int64
+	for _, price := range previous {
+		total += price.Price
+	}
+	return float64(total) / float64(len(previous))
+}
+
+// purchasedSince returns the number of tickets purchased after since. If
+// window is not negative, only tickets purchased in that window are counted.
+func (e *TicketBuyerRuleEngine) purchasedSince(since time.Time, window int32) int {
+	var count int
+	for _, purchase := range e.purchases {
+		if purchase.Time.Before(since) {
+			continue
+		}
+		if window >= 0 && purchase.Window != window {
+			continue
+		}
+		count += purchase.Count
+	}
+	return count
+}
+
+// prunePurchases drops purchase records that no rule needs anymore.
+func (e *TicketBuyerRuleEngine) prunePurchases() {
+	cutoff := e.clock.Now().Add(-24 * time.Hour)
+	purchases := e.purchases[:0]
+	for _, purchase := range e.purchases {
+		if purchase.Time.After(cutoff) || purchase.Window == e.lastWindow {
+			purchases = append(purchases, purchase)
+		}
+	}
+	e.purchases = purchases
+}
+
+func (r TicketBuyerRules) withinActiveHours(t time.Time) bool {
+	return withinHours(r.ActiveHoursStart, r.ActiveHoursEnd, t)
+}
+
+// withinHours returns true if the hour of t is from start up to, but not
+// including, end. The range may wrap around midnight and includes every hour
+// if start and end are equal.
+func withinHours(start, end int, t time.Time) bool {
+	if start == end {
+		return true
+	}
+
+	hour := t.Hour()
+	if start < end {
+		return hour >= start && hour < end
+	}
+	// the hours wrap around midnight.
+	return hour >= start || hour < end
+}
@@ -0,0 +1,227 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const testWindowSize = 144
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type testPriceFeed struct {
+	price  int64
+	height int32
+	err    error
+}
+
+func (f *testPriceFeed) TicketPrice() (*dcrlibwallet.TicketPriceResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &dcrlibwallet.TicketPriceResponse{TicketPrice: f.price, Height: f.height}, nil
+}
+
+// nextWindow moves the feed to the first block of the next price window.
+func (f *testPriceFeed) nextWindow(price int64) {
+	f.height += testWindowSize
+	f.price = price
+}
+
+func affordable(n int) func(int64) int {
+	return func(int64) int { return n }
+}
+
+func newTestEngine(rules TicketBuyerRules) (*TicketBuyerRuleEngine, *testClock, *testPriceFeed) {
+	clock := &testClock{now: time.Date(2022, time.August, 1, 12, 0, 0, 0, time.Local)}
+	feed := &testPriceFeed{price: 100e8, height: 1000}
+	return NewTicketBuyerRuleEngine(rules, clock, feed, testWindowSize), clock, feed
+}
+
+func TestRuleEngineNoRules(t *testing.T) {
+	engine, _, _ := newTestEngine(TicketBuyerRules{})
+
+	evaluation := engine.Evaluate(affordable(3))
+	if evaluation.Result != RulesPassed || evaluation.Allowed != 3 {
+		t.Fatalf("expected 3 tickets allowed, got %d (result %d)", evaluation.Allowed, evaluation.Result)
+	}
+
+	evaluation = engine.Evaluate(affordable(0))
+	if evaluation.Result != RuleLowBalance || evaluation.Allowed != 0 {
+		t.Fatalf("expected low balance result, got %d (allowed %d)", evaluation.Result, evaluation.Allowed)
+	}
+}
+
+func TestRuleEngineMaxTicketPrice(t *testing.T) {
+	engine, _, feed := newTestEngine(TicketBuyerRules{MaxTicketPrice: 150e8})
+
+	if evaluation := engine.Evaluate(affordable(1)); evaluation.Result != RulesPassed {
+		t.Fatalf("expected purchase below price cap, got result %d", evaluation.Result)
+	}
+
+	feed.nextWindow(151e8)
+	if evaluation := engine.Evaluate(affordable(1)); evaluation.Result != RulePriceAboveMax {
+		t.Fatalf("expected price cap result, got %d", evaluation.Result)
+	}
+}
+
+func TestRuleEngineActiveHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int
+		hour       int
+		want       RuleResult
+	}{
+		{"inside", 9, 17, 12, RulesPassed},
+		{"before", 9, 17, 8, RuleOutsideActiveHours},
+		{"end is exclusive", 9, 17, 17, RuleOutsideActiveHours},
+		{"wraps midnight, late", 22, 6, 23, RulesPassed},
+		{"wraps midnight, early", 22, 6, 3, RulesPassed},
+		{"wraps midnight, outside", 22, 6, 12, RuleOutsideActiveHours},
+	}
+
+	for _, test := range tests {
+		engine, clock, _ := newTestEngine(TicketBuyerRules{ActiveHoursStart: test.start, ActiveHoursEnd: test.end})
+		clock.now = time.Date(2022, time.August, 1, test.hour, 30, 0, 0, time.Local)
+		if evaluation := engine.Evaluate(affordable(1)); evaluation.Result != test.want {
+			t.Errorf("%s: expected result %d, got %d", test.name, test.want, evaluation.Result)
+		}
+	}
+}
+
+func TestRuleEngineDailyLimit(t *testing.T) {
+	engine, clock, feed := newTestEngine(TicketBuyerRules{MaxTicketsPerDay: 5})
+
+	evaluation := engine.Evaluate(affordable(3))
+	if evaluation.Allowed != 3 {
+		t.Fatalf("expected 3 tickets allowed, got %d", evaluation.Allowed)
+	}
+	engine.RecordPurchase(3, feed.height)
+
+	clock.advance(time.Hour)
+	evaluation = engine.Evaluate(affordable(10))
+	if evaluation.Allowed != 2 {
+		t.Fatalf("expected remaining 2 tickets allowed, got %d", evaluation.Allowed)
+	}
+	engine.RecordPurchase(2, feed.height)
+
+	clock.advance(time.Hour)
+	if evaluation = engine.Evaluate(affordable(10)); evaluation.Result != RuleDailyLimitReached {
+		t.Fatalf("expected daily limit result, got %d", evaluation.Result)
+	}
+
+	// The first purchase drops out of the last 24 hours.
+	clock.advance(22*time.Hour + time.Minute)
+	if evaluation = engine.Evaluate(affordable(10)); evaluation.Allowed != 3 {
+		t.Fatalf("expected 3 tickets allowed after a day, got %d", evaluation.Allowed)
+	}
+}
+
+func TestRuleEngineWindowLimit(t *testing.T) {
+	engine, _, feed := newTestEngine(TicketBuyerRules{MaxTicketsPerWindow: 2})
+
+	engine.Evaluate(affordable(2))
+	engine.RecordPurchase(2, feed.height)
+
+	feed.height++
+	if evaluation := engine.Evaluate(affordable(2)); evaluation.Result != RuleWindowLimitReached {
+		t.Fatalf("expected window limit result, got %d", evaluation.Result)
+	}
+
+	feed.nextWindow(feed.price)
+	if evaluation := engine.Evaluate(affordable(5)); evaluation.Allowed != 2 {
+		t.Fatalf("expected 2 tickets allowed in the next window, got %d", evaluation.Allowed)
+	}
+}
+
+func TestRuleEnginePriceAboveAverage(t *testing.T) {
+	engine, _, feed := newTestEngine(TicketBuyerRules{PauseAbovePercent: 10, AveragePeriod: 3})
+
+	for _, price := range []int64{100e8, 110e8, 90e8} {
+		feed.nextWindow(price)
+		if evaluation := engine.Evaluate(affordable(1)); evaluation.Result != RulesPassed {
+			t.Fatalf("expected purchase at price %d, got result %d", price, evaluation.Result)
+		}
+	}
+
+	// The average of the last 3 windows is 100 DCR.
+	feed.nextWindow(111e8)
+	if evaluation := engine.Evaluate(affordable(1)); evaluation.Result != RulePriceAboveAverage {
+		t.Fatalf("expected price above average result, got %d", evaluation.Result)
+	}
+
+	// Evaluating again in the same window doesn't count the price twice.
+	feed.height++
+	if evaluation := engine.Evaluate(affordable(1)); evaluation.Result != RulePriceAboveAverage {
+		t.Fatalf("expected price above average result, got %d", evaluation.Result)
+	}
+
+	feed.nextWindow(105e8)
+	if evaluation := engine.Evaluate(affordable(1)); evaluation.Result != RulesPassed {
+		t.Fatalf("expected purchase below threshold, got result %d", evaluation.Result)
+	}
+}
+
+func TestRuleEngineRestoredWindowPrices(t *testing.T) {
+	rules := TicketBuyerRules{PauseAbovePercent: 10, AveragePeriod: 3}
+	engine, _, feed := newTestEngine(rules)
+	for _, price := range []int64{100e8, 110e8, 90e8} {
+		feed.nextWindow(price)
+		engine.Evaluate(affordable(1))
+	}
+
+	// Restart the engine in the same window.
+	restarted := NewTicketBuyerRuleEngine(rules, nil, feed, testWindowSize)
+	restarted.SetWindowPrices(engine.WindowPrices())
+	feed.height++
+	if evaluation := restarted.Evaluate(affordable(1)); evaluation.Result != RulesPassed {
+		t.Fatalf("expected purchase at the restored window price, got result %d", evaluation.Result)
+	}
+	if prices := restarted.WindowPrices(); len(prices) != 3 {
+		t.Fatalf("expected the current window price to be recorded once, got %+v", prices)
+	}
+
+	// The average of the restored windows is 100 DCR, the price of the
+	// last window alone would pause purchases.
+	feed.nextWindow(105e8)
+	if evaluation := restarted.Evaluate(affordable(1)); evaluation.Result != RulesPassed {
+		t.Fatalf("expected purchase below the restored average threshold, got result %d", evaluation.Result)
+	}
+}
+
+func TestRuleEngineLog(t *testing.T) {
+	engine, clock, feed := newTestEngine(TicketBuyerRules{MaxTicketPrice: 150e8})
+
+	engine.Evaluate(affordable(1))
+	clock.advance(time.Minute)
+	feed.err = errors.New("not synced")
+	engine.Evaluate(affordable(1))
+
+	evaluations := engine.Log()
+	if len(evaluations) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(evaluations))
+	}
+	if evaluations[0].Result != RulePriceUnavailable || evaluations[1].Result != RulesPassed {
+		t.Fatalf("expected newest log entry first, got results %d, %d", evaluations[0].Result, evaluations[1].Result)
+	}
+
+	for i := 0; i < maxRuleEvaluationLogSize; i++ {
+		engine.Evaluate(affordable(1))
+	}
+	if len(engine.Log()) != maxRuleEvaluationLogSize {
+		t.Fatalf("expected log to be capped at %d entries, got %d", maxRuleEvaluationLogSize, len(engine.Log()))
+	}
+}
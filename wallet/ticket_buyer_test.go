@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"testing"
+
+	"decred.org/dcrwallet/v2/wallet/txrules"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+func TestTicketCost(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	const price, height = dcrutil.Amount(100e8), 700000
+
+	withoutVSPFee := ticketCost(price, txrules.DefaultRelayFeePerKb, height, 0, params)
+	txFees := withoutVSPFee - price
+	// The ticket, fee and split transactions take less than 1 kB.
+	if txFees <= 0 || txFees > txrules.DefaultRelayFeePerKb {
+		t.Fatalf("unexpected transaction fees %v", txFees)
+	}
+
+	vspFee := ticketCost(price, txrules.DefaultRelayFeePerKb, height, 2, params) - withoutVSPFee
+	want := txrules.StakePoolTicketFee(price, txrules.DefaultRelayFeePerKb, height, 2, params, true)
+	if vspFee <= 0 || vspFee != want {
+		t.Fatalf("expected a VSP fee of %v, got %v", want, vspFee)
+	}
+
+	// A balance of exactly two ticket prices doesn't cover the fees of two
+	// tickets.
+	if n := int64(2*price) / int64(price+vspFee+txFees); n != 1 {
+		t.Errorf("expected 1 affordable ticket, got %d", n)
+	}
+}
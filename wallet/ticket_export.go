@@ -0,0 +1,261 @@
+package wallet
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	TicketExportCSV  = "csv"
+	TicketExportJSON = "json"
+
+	exportsDir = "exports"
+)
+
+// TicketRecord holds the accounting data of a ticket. Amounts are in atoms.
+type TicketRecord struct {
+	Hash           string    `json:"hash"`
+	WalletID       int       `json:"wallet_id"`
+	WalletName     string    `json:"wallet_name"`
+	Status         string    `json:"status"`
+	PurchaseTime   time.Time `json:"purchase_time"`
+	PurchaseHeight int32     `json:"purchase_height"`
+	Price          int64     `json:"price"`
+	TxFee          int64     `json:"tx_fee"`
+
+	VSP          string `json:"vsp,omitempty"`
+	VSPFeeTxHash string `json:"vsp_fee_tx_hash,omitempty"`
+	VSPFee       int64  `json:"vsp_fee"`
+	VSPFeeStatus string `json:"vsp_fee_status,omitempty"`
+
+	// SpenderType is "vote" or "revocation" if the ticket was spent.
+	SpenderType        string     `json:"spender_type,omitempty"`
+	SpenderHash        string     `json:"spender_hash,omitempty"`
+	SpenderTime        *time.Time `json:"spender_time,omitempty"`
+	SpenderHeight      int32      `json:"spender_height,omitempty"`
+	Reward             int64      `json:"reward"`
+	DaysToVoteOrRevoke int32      `json:"days_to_vote_or_revoke,omitempty"`
+}
+
+var ticketCSVHeader = []string{
+	"hash", "wallet_id", "wallet_name", "status", "purchase_time", "purchase_height", "price", "tx_fee",
+	"vsp", "vsp_fee_tx_hash", "vsp_fee", "vsp_fee_status",
+	"spender_type", "spender_hash", "spender_time", "spender_height", "reward", "days_to_vote_or_revoke",
+}
+
+// Statuses of the spent tickets and of the tickets without a known status,
+// in addition to the dcrlibwallet ticket statuses.
+const (
+	TicketStatusVoted   = "voted"
+	TicketStatusRevoked = "revoked"
+	TicketStatusUnknown = "unknown"
+)
+
+// TicketStatus returns the status of the ticket purchase tx, spent by the
+// spender tx if it is not nil.
+func TicketStatus(w *dcrlibwallet.Wallet, tx, spender *dcrlibwallet.Transaction) string {
+	switch {
+	case spender != nil && spender.Type == dcrlibwallet.TxTypeVote:
+		return TicketStatusVoted
+	case spender != nil:
+		return TicketStatusRevoked
+	case w.TxMatchesFilter(tx, dcrlibwallet.TxFilterLive):
+		return dcrlibwallet.TicketStatusLive
+	case w.TxMatchesFilter(tx, dcrlibwallet.TxFilterImmature):
+		return dcrlibwallet.TicketStatusImmature
+	case w.TxMatchesFilter(tx, dcrlibwallet.TxFilterUnmined):
+		return dcrlibwallet.TicketStatusUnmined
+	case w.TxMatchesFilter(tx, dcrlibwallet.TxFilterExpired):
+		return dcrlibwallet.TicketStatusExpired
+	default:
+		return TicketStatusUnknown
+	}
+}
+
+// ticketRecord builds the record of the ticket purchase tx.
+func ticketRecord(ctx context.Context, w *dcrlibwallet.Wallet, tx *dcrlibwallet.Transaction) (*TicketRecord, error) {
+	spender, err := w.TicketSpender(tx.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &TicketRecord{
+		Hash:           tx.Hash,
+		WalletID:       w.ID,
+		WalletName:     w.Name,
+		Status:         TicketStatus(w, tx, spender),
+		PurchaseTime:   time.Unix(tx.Timestamp, 0).UTC(),
+		PurchaseHeight: tx.BlockHeight,
+		Price:          tx.Amount,
+		TxFee:          tx.Fee,
+	}
+
+	if spender != nil {
+		spenderTime := time.Unix(spender.Timestamp, 0).UTC()
+		record.SpenderType = "revocation"
+		if spender.Type == dcrlibwallet.TxTypeVote {
+			record.SpenderType = "vote"
+		}
+		record.SpenderHash = spender.Hash
+		record.SpenderTime = &spenderTime
+		record.SpenderHeight = spender.BlockHeight
+		record.Reward = spender.VoteReward
+		record.DaysToVoteOrRevoke = spender.DaysToVoteOrRevoke
+	}
+
+	ticketHash, err := chainhash.NewHashFromStr(tx.Hash)
+	if err != nil {
+		return nil, err
+	}
+	// Tickets purchased without a VSP have no VSP info.
+	if ticketInfo, err := w.Internal().VSPTicketInfo(ctx, ticketHash); err == nil {
+		record.VSP = ticketInfo.Host
+		record.VSPFeeStatus = dcrlibwallet.VSPFeeStatus(ticketInfo.FeeTxStatus).String()
+		if ticketInfo.FeeHash != (chainhash.Hash{}) {
+			record.VSPFeeTxHash = ticketInfo.FeeHash.String()
+			if feeTx, err := w.GetTransactionRaw(record.VSPFeeTxHash); err == nil {
+				record.VSPFee = feeTx.Amount
+			}
+		}
+	}
+
+	return record, nil
+}
+
+// TicketRecords returns the records of all the tickets purchased by the
+// wallet, newest first.
+func TicketRecords(ctx context.Context, w *dcrlibwallet.Wallet) ([]*TicketRecord, error) {
+	txs, err := w.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterTickets, true)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*TicketRecord, 0, len(txs))
+	for i := range txs {
+		record, err := ticketRecord(ctx, w, &txs[i])
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ReadTicketRecord returns the record of the ticket with the specified hash.
+func ReadTicketRecord(ctx context.Context, w *dcrlibwallet.Wallet, hash string) (*TicketRecord, error) {
+	tx, err := w.GetTransactionRaw(hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type != dcrlibwallet.TxTypeTicketPurchase {
+		return nil, fmt.Errorf("%s is not a ticket purchase", hash)
+	}
+	return ticketRecord(ctx, w, tx)
+}
+
+// WriteTicketsCSV writes the records to out in CSV format, one ticket per
+// row after a header row.
+func WriteTicketsCSV(out io.Writer, records []*TicketRecord) error {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	itoa := func(i int64) string {
+		return strconv.FormatInt(i, 10)
+	}
+
+	writer := csv.NewWriter(out)
+	if err := writer.Write(ticketCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := []string{
+			r.Hash, strconv.Itoa(r.WalletID), r.WalletName, r.Status, formatTime(&r.PurchaseTime),
+			itoa(int64(r.PurchaseHeight)), itoa(r.Price), itoa(r.TxFee),
+			r.VSP, r.VSPFeeTxHash, itoa(r.VSPFee), r.VSPFeeStatus,
+			r.SpenderType, r.SpenderHash, formatTime(r.SpenderTime), itoa(int64(r.SpenderHeight)), itoa(r.Reward),
+			itoa(int64(r.DaysToVoteOrRevoke)),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteTicketsJSON writes the records to out as an indented JSON array.
+func WriteTicketsJSON(out io.Writer, records []*TicketRecord) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// ExportTicketReport writes the report of the ticket with the hash to a file
+// in the exports directory and returns the file path.
+func (wal *Wallet) ExportTicketReport(hash, report string) (string, error) {
+	name := fmt.Sprintf("ticket-%s-%s.txt", hash, time.Now().Format("20060102-150405"))
+	return wal.writeExport(name, func(out io.Writer) error {
+		_, err := io.WriteString(out, report)
+		return err
+	})
+}
+
+// ExportTickets writes the records of all the tickets purchased by the wallet
+// to a file in the exports directory and returns the file path. format is
+// either TicketExportCSV or TicketExportJSON.
+func (wal *Wallet) ExportTickets(ctx context.Context, w *dcrlibwallet.Wallet, format string) (string, error) {
+	var write func(io.Writer, []*TicketRecord) error
+	switch format {
+	case TicketExportCSV:
+		write = WriteTicketsCSV
+	case TicketExportJSON:
+		write = WriteTicketsJSON
+	default:
+		return "", fmt.Errorf("unsupported export format %q", format)
+	}
+
+	records, err := TicketRecords(ctx, w)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("tickets-%d-%s.%s", w.ID, time.Now().Format("20060102-150405"), format)
+	return wal.writeExport(name, func(out io.Writer) error {
+		return write(out, records)
+	})
+}
+
+// writeExport creates the file name in the exports directory and writes its
+// content using write.
+func (wal *Wallet) writeExport(name string, write func(io.Writer) error) (string, error) {
+	dir := filepath.Join(wal.Root, wal.Net, exportsDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, name)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	if err = write(file); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+	return path, file.Close()
+}
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTicketRecords() []*TicketRecord {
+	voteTime := time.Date(2022, 3, 10, 8, 0, 0, 0, time.UTC)
+	return []*TicketRecord{
+		{
+			Hash:               "aa",
+			WalletID:           1,
+			WalletName:         "default",
+			Status:             "voted",
+			PurchaseTime:       time.Date(2022, 2, 1, 12, 30, 0, 0, time.UTC),
+			PurchaseHeight:     640000,
+			Price:              15000000000,
+			TxFee:              2980,
+			VSP:                "https://vsp.example.org",
+			VSPFeeTxHash:       "bb",
+			VSPFee:             1500000,
+			VSPFeeStatus:       "confirmed",
+			SpenderType:        "vote",
+			SpenderHash:        "cc",
+			SpenderTime:        &voteTime,
+			SpenderHeight:      646000,
+			Reward:             15000000,
+			DaysToVoteOrRevoke: 37,
+		},
+		{
+			Hash:           "dd",
+			WalletID:       1,
+			WalletName:     "default, savings",
+			Status:         "live",
+			PurchaseTime:   time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+			PurchaseHeight: 650000,
+			Price:          16000000000,
+			TxFee:          2980,
+		},
+	}
+}
+
+func TestWriteTicketsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTicketsCSV(&buf, testTicketRecords()); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d rows", len(rows))
+	}
+
+	row := make(map[string]string)
+	for i, column := range rows[0] {
+		row[column] = rows[1][i]
+	}
+	expected := map[string]string{
+		"hash":          "aa",
+		"purchase_time": "2022-02-01T12:30:00Z",
+		"price":         "15000000000",
+		"vsp_fee":       "1500000",
+		"spender_type":  "vote",
+		"spender_time":  "2022-03-10T08:00:00Z",
+		"reward":        "15000000",
+	}
+	for column, value := range expected {
+		if row[column] != value {
+			t.Errorf("expected %s %q, got %q", column, value, row[column])
+		}
+	}
+
+	if rows[2][2] != "default, savings" || rows[2][14] != "" {
+		t.Errorf("unexpected row for unspent ticket: %v", rows[2])
+	}
+}
+
+func TestWriteTicketsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTicketsJSON(&buf, testTicketRecords()); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []*TicketRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Reward != 15000000 || !records[0].SpenderTime.Equal(*testTicketRecords()[0].SpenderTime) {
+		t.Fatalf("unexpected records %+v", records)
+	}
+	if records[1].SpenderTime != nil || strings.Contains(buf.String(), `"spender_hash": ""`) {
+		t.Fatal("expected spender fields to be omitted for unspent ticket")
+	}
+}
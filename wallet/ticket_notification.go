@@ -0,0 +1,267 @@
+package wallet
+
+import (
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// TicketEventType identifies a ticket lifecycle transition that can be
+// reported to the user.
+type TicketEventType string
+
+const (
+	TicketPurchased     TicketEventType = "purchased"
+	TicketMatured       TicketEventType = "matured"
+	TicketVoted         TicketEventType = "voted"
+	TicketMissed        TicketEventType = "missed"
+	TicketExpired       TicketEventType = "expired"
+	TicketRevoked       TicketEventType = "revoked"
+	TicketVSPFeeErrored TicketEventType = "vsp_fee_errored"
+)
+
+// TicketEventTypes lists every ticket event type in the order they occur in
+// a ticket's lifecycle.
+var TicketEventTypes = []TicketEventType{
+	TicketPurchased,
+	TicketMatured,
+	TicketVoted,
+	TicketMissed,
+	TicketExpired,
+	TicketRevoked,
+	TicketVSPFeeErrored,
+}
+
+// ticketNotificationStateConfigKey is the wallet config key under which the
+// last reported status of every ticket is saved.
+const ticketNotificationStateConfigKey = "ticket_notification_state"
+
+// ticket states recorded in the wallet config.
+const (
+	ticketStateUnmined  = "unmined"
+	ticketStateImmature = "immature"
+	ticketStateLive     = "live"
+	ticketStateVoted    = "voted"
+	ticketStateMissed   = "missed"
+	ticketStateExpired  = "expired"
+	ticketStateRevoked  = "revoked"
+)
+
+// ConfigKey returns the wallet config key that enables desktop notifications
+// for this event type.
+func (t TicketEventType) ConfigKey() string {
+	return "ticket_notification_" + string(t)
+}
+
+// TicketEvent describes a lifecycle transition of a single ticket.
+type TicketEvent struct {
+	Type       TicketEventType
+	WalletID   int
+	TicketHash string
+	// Reward is the vote reward, only set for TicketVoted events.
+	Reward int64
+}
+
+type ticketNotificationState struct {
+	Status     string `json:"status"`
+	FeeErrored bool   `json:"fee_errored"`
+}
+
+// isFinal returns true if the ticket can no longer change state.
+func (s *ticketNotificationState) isFinal() bool {
+	switch s.Status {
+	case ticketStateVoted, ticketStateMissed, ticketStateRevoked:
+		return true
+	}
+	return false
+}
+
+// ticketWallet is the part of a dcrlibwallet wallet used to follow the state
+// of its tickets. It is satisfied by *dcrlibwallet.Wallet.
+type ticketWallet interface {
+	ReadUserConfigValue(key string, valueOut interface{}) error
+	SaveUserConfigValue(key string, value interface{})
+	GetTransactionRaw(txHash string) (*dcrlibwallet.Transaction, error)
+	GetTransactionsRaw(offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error)
+	TicketSpender(ticketHash string) (*dcrlibwallet.Transaction, error)
+	TxMatchesFilter(tx *dcrlibwallet.Transaction, txFilter int32) bool
+}
+
+var _ ticketWallet = (*dcrlibwallet.Wallet)(nil)
+
+// ticketNetwork is the part of the multiwallet providing the ticket
+// parameters of the network and the VSP info of the tickets. It is satisfied
+// by *dcrlibwallet.MultiWallet.
+type ticketNetwork interface {
+	TicketMaturity() int32
+	TicketExpiry() int32
+	VSPTicketInfo(walletID int, hash string) (*dcrlibwallet.VSPTicketInfo, error)
+}
+
+var _ ticketNetwork = (*dcrlibwallet.MultiWallet)(nil)
+
+// TicketEvents compares the current status of every ticket in the wallet
+// with the status last reported and returns an event for each transition
+// that has not been reported yet. Reported statuses are saved in the wallet
+// config so that events are not repeated after a restart. The first call for
+// a wallet only records the status of its existing tickets.
+func TicketEvents(mw *dcrlibwallet.MultiWallet, wal *dcrlibwallet.Wallet) ([]TicketEvent, error) {
+	return ticketEvents(mw, wal, wal.ID, nil, true)
+}
+
+// TicketEventsForTxs is like TicketEvents but only checks the tickets that
+// can still change state and the tickets among the transactions with the
+// txHashes. The first call for a wallet checks every ticket.
+func TicketEventsForTxs(mw *dcrlibwallet.MultiWallet, wal *dcrlibwallet.Wallet, txHashes ...string) ([]TicketEvent, error) {
+	return ticketEvents(mw, wal, wal.ID, txHashes, false)
+}
+
+func ticketEvents(net ticketNetwork, wal ticketWallet, walletID int, txHashes []string, all bool) ([]TicketEvent, error) {
+	states := make(map[string]*ticketNotificationState)
+	seeding := wal.ReadUserConfigValue(ticketNotificationStateConfigKey, &states) != nil
+
+	var tickets []*dcrlibwallet.Transaction
+	if all || seeding {
+		txs, err := wal.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterTickets, true)
+		if err != nil {
+			return nil, err
+		}
+		for i := range txs {
+			tickets = append(tickets, &txs[i])
+		}
+	} else {
+		checked := make(map[string]bool)
+		for hash, state := range states {
+			if !state.isFinal() {
+				checked[hash] = true
+			}
+		}
+		for _, hash := range txHashes {
+			if _, ok := states[hash]; !ok {
+				checked[hash] = true
+			}
+		}
+		for hash := range checked {
+			tx, err := wal.GetTransactionRaw(hash)
+			if err != nil || tx.Type != dcrlibwallet.TxTypeTicketPurchase {
+				continue
+			}
+			tickets = append(tickets, tx)
+		}
+	}
+
+	var events []TicketEvent
+	for _, ticket := range tickets {
+		prev, ok := states[ticket.Hash]
+		if !ok {
+			prev = &ticketNotificationState{}
+			states[ticket.Hash] = prev
+		}
+		if prev.isFinal() {
+			continue
+		}
+
+		status, spender, err := ticketState(net, wal, ticket)
+		if err != nil {
+			return nil, err
+		}
+
+		var feeErrored bool
+		if !prev.FeeErrored && (status == ticketStateUnmined || status == ticketStateImmature) {
+			// Tickets not purchased through a VSP have no VSP ticket info.
+			info, err := net.VSPTicketInfo(walletID, ticket.Hash)
+			feeErrored = err == nil && info.FeeTxStatus == dcrlibwallet.VSPFeeProcessErrored
+		}
+
+		if !seeding {
+			for _, eventType := range ticketTransitions(prev.Status, status) {
+				event := TicketEvent{
+					Type:       eventType,
+					WalletID:   walletID,
+					TicketHash: ticket.Hash,
+				}
+				if eventType == TicketVoted && spender != nil {
+					event.Reward = spender.VoteReward
+				}
+				events = append(events, event)
+			}
+			if feeErrored {
+				events = append(events, TicketEvent{
+					Type:       TicketVSPFeeErrored,
+					WalletID:   walletID,
+					TicketHash: ticket.Hash,
+				})
+			}
+		}
+
+		prev.Status = status
+		prev.FeeErrored = prev.FeeErrored || feeErrored
+	}
+
+	wal.SaveUserConfigValue(ticketNotificationStateConfigKey, states)
+	return events, nil
+}
+
+// ticketState returns the current state of the ticket and the transaction
+// that spent it, if any.
+func ticketState(net ticketNetwork, wal ticketWallet, ticket *dcrlibwallet.Transaction) (string, *dcrlibwallet.Transaction, error) {
+	spender, err := wal.TicketSpender(ticket.Hash)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if spender != nil {
+		if spender.Type == dcrlibwallet.TxTypeVote {
+			return ticketStateVoted, spender, nil
+		}
+
+		// A ticket revoked before reaching its expiry height was selected to
+		// vote but missed the vote.
+		expiryHeight := ticket.BlockHeight + net.TicketMaturity() + net.TicketExpiry()
+		if spender.BlockHeight > 0 && spender.BlockHeight <= expiryHeight {
+			return ticketStateMissed, spender, nil
+		}
+		return ticketStateRevoked, spender, nil
+	}
+
+	switch {
+	case wal.TxMatchesFilter(ticket, dcrlibwallet.TxFilterUnmined):
+		return ticketStateUnmined, nil, nil
+	case wal.TxMatchesFilter(ticket, dcrlibwallet.TxFilterImmature):
+		return ticketStateImmature, nil, nil
+	case wal.TxMatchesFilter(ticket, dcrlibwallet.TxFilterExpired):
+		return ticketStateExpired, nil, nil
+	default:
+		return ticketStateLive, nil, nil
+	}
+}
+
+// ticketTransitions returns the events that occurred while a ticket moved
+// from the prev state to the cur state. An empty prev state indicates a
+// ticket that has not been seen before.
+func ticketTransitions(prev, cur string) []TicketEventType {
+	if prev == cur {
+		return nil
+	}
+
+	var events []TicketEventType
+	if prev == "" {
+		events = append(events, TicketPurchased)
+	}
+
+	switch cur {
+	case ticketStateLive:
+		events = append(events, TicketMatured)
+	case ticketStateVoted:
+		events = append(events, TicketVoted)
+	case ticketStateMissed:
+		events = append(events, TicketMissed)
+	case ticketStateExpired:
+		events = append(events, TicketExpired)
+	case ticketStateRevoked:
+		if prev != ticketStateExpired {
+			events = append(events, TicketExpired)
+		}
+		events = append(events, TicketRevoked)
+	}
+
+	return events
+}
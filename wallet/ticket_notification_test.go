@@ -0,0 +1,212 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+type testTicketNetwork struct {
+	feeErrored map[string]bool
+}
+
+func (n *testTicketNetwork) TicketMaturity() int32 {
+	return 16
+}
+
+func (n *testTicketNetwork) TicketExpiry() int32 {
+	return 6144
+}
+
+func (n *testTicketNetwork) VSPTicketInfo(walletID int, hash string) (*dcrlibwallet.VSPTicketInfo, error) {
+	if n.feeErrored[hash] {
+		return &dcrlibwallet.VSPTicketInfo{FeeTxStatus: dcrlibwallet.VSPFeeProcessErrored}, nil
+	}
+	return nil, errors.New("no VSP ticket info")
+}
+
+type testTicketWallet struct {
+	// config holds the saved config values, it is shared between the
+	// wallets of a restart.
+	config   map[string][]byte
+	tickets  map[string]*dcrlibwallet.Transaction
+	spenders map[string]*dcrlibwallet.Transaction
+	// filters maps the ticket hashes to the filter they match.
+	filters map[string]int32
+	// lookups counts the tickets read.
+	lookups int
+}
+
+func newTestTicketWallet(config map[string][]byte) *testTicketWallet {
+	return &testTicketWallet{
+		config:   config,
+		tickets:  make(map[string]*dcrlibwallet.Transaction),
+		spenders: make(map[string]*dcrlibwallet.Transaction),
+		filters:  make(map[string]int32),
+	}
+}
+
+func (w *testTicketWallet) addTicket(hash string, filter int32) {
+	w.tickets[hash] = &dcrlibwallet.Transaction{Hash: hash, Type: dcrlibwallet.TxTypeTicketPurchase, BlockHeight: 100}
+	w.filters[hash] = filter
+}
+
+func (w *testTicketWallet) ReadUserConfigValue(key string, valueOut interface{}) error {
+	data, ok := w.config[key]
+	if !ok {
+		return errors.New("not found")
+	}
+	return json.Unmarshal(data, valueOut)
+}
+
+func (w *testTicketWallet) SaveUserConfigValue(key string, value interface{}) {
+	w.config[key], _ = json.Marshal(value)
+}
+
+func (w *testTicketWallet) GetTransactionRaw(txHash string) (*dcrlibwallet.Transaction, error) {
+	w.lookups++
+	tx, ok := w.tickets[txHash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return tx, nil
+}
+
+func (w *testTicketWallet) GetTransactionsRaw(offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error) {
+	txs := make([]dcrlibwallet.Transaction, 0, len(w.tickets))
+	for _, tx := range w.tickets {
+		w.lookups++
+		txs = append(txs, *tx)
+	}
+	return txs, nil
+}
+
+func (w *testTicketWallet) TicketSpender(ticketHash string) (*dcrlibwallet.Transaction, error) {
+	return w.spenders[ticketHash], nil
+}
+
+func (w *testTicketWallet) TxMatchesFilter(tx *dcrlibwallet.Transaction, txFilter int32) bool {
+	return w.filters[tx.Hash] == txFilter
+}
+
+func ticketEventTypes(events []TicketEvent) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.TicketHash + ":" + string(event.Type)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func TestTicketTransitions(t *testing.T) {
+	tests := []struct {
+		prev, cur string
+		want      []TicketEventType
+	}{
+		{"", ticketStateUnmined, []TicketEventType{TicketPurchased}},
+		{"", ticketStateLive, []TicketEventType{TicketPurchased, TicketMatured}},
+		{ticketStateUnmined, ticketStateImmature, nil},
+		{ticketStateImmature, ticketStateLive, []TicketEventType{TicketMatured}},
+		{ticketStateLive, ticketStateLive, nil},
+		{ticketStateLive, ticketStateVoted, []TicketEventType{TicketVoted}},
+		{ticketStateLive, ticketStateMissed, []TicketEventType{TicketMissed}},
+		{ticketStateLive, ticketStateExpired, []TicketEventType{TicketExpired}},
+		{ticketStateLive, ticketStateRevoked, []TicketEventType{TicketExpired, TicketRevoked}},
+		{ticketStateExpired, ticketStateRevoked, []TicketEventType{TicketRevoked}},
+	}
+	for _, test := range tests {
+		if got := ticketTransitions(test.prev, test.cur); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ticketTransitions(%q, %q) = %v, want %v", test.prev, test.cur, got, test.want)
+		}
+	}
+}
+
+func TestTicketState(t *testing.T) {
+	net := &testTicketNetwork{}
+	tests := []struct {
+		name    string
+		filter  int32
+		spender *dcrlibwallet.Transaction
+		want    string
+	}{
+		{"unmined", dcrlibwallet.TxFilterUnmined, nil, ticketStateUnmined},
+		{"immature", dcrlibwallet.TxFilterImmature, nil, ticketStateImmature},
+		{"expired", dcrlibwallet.TxFilterExpired, nil, ticketStateExpired},
+		{"live", dcrlibwallet.TxFilterLive, nil, ticketStateLive},
+		{"voted", dcrlibwallet.TxFilterLive, &dcrlibwallet.Transaction{Type: dcrlibwallet.TxTypeVote, BlockHeight: 500}, ticketStateVoted},
+		{"missed", dcrlibwallet.TxFilterLive, &dcrlibwallet.Transaction{Type: dcrlibwallet.TxTypeRevocation, BlockHeight: 500}, ticketStateMissed},
+		{"revoked", dcrlibwallet.TxFilterExpired, &dcrlibwallet.Transaction{Type: dcrlibwallet.TxTypeRevocation, BlockHeight: 7000}, ticketStateRevoked},
+	}
+	for _, test := range tests {
+		wal := newTestTicketWallet(make(map[string][]byte))
+		wal.addTicket(test.name, test.filter)
+		if test.spender != nil {
+			wal.spenders[test.name] = test.spender
+		}
+		got, _, err := ticketState(net, wal, wal.tickets[test.name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%s: got state %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTicketEventsAcrossRestarts(t *testing.T) {
+	net := &testTicketNetwork{feeErrored: map[string]bool{"b": true}}
+	config := make(map[string][]byte)
+
+	// The first call only records the existing tickets.
+	wal := newTestTicketWallet(config)
+	wal.addTicket("a", dcrlibwallet.TxFilterLive)
+	events, err := ticketEvents(net, wal, 1, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no events for the existing tickets, got %v", ticketEventTypes(events))
+	}
+
+	// A new ticket and a vote are reported once.
+	wal.addTicket("b", dcrlibwallet.TxFilterUnmined)
+	wal.spenders["a"] = &dcrlibwallet.Transaction{Type: dcrlibwallet.TxTypeVote, BlockHeight: 500, VoteReward: 10}
+	events, err = ticketEvents(net, wal, 1, []string{"b"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a:voted", "b:purchased", "b:vsp_fee_errored"}
+	if got := ticketEventTypes(events); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got events %v, want %v", got, want)
+	}
+
+	// The reported events are not repeated after a restart, even when every
+	// ticket is checked.
+	restarted := newTestTicketWallet(config)
+	restarted.tickets, restarted.spenders, restarted.filters = wal.tickets, wal.spenders, wal.filters
+	events, err = ticketEvents(net, restarted, 1, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no repeated events after a restart, got %v", ticketEventTypes(events))
+	}
+
+	// Only the tickets that can still change are read again.
+	restarted.lookups = 0
+	restarted.filters["b"] = dcrlibwallet.TxFilterLive
+	events, err = ticketEvents(net, restarted, 1, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ticketEventTypes(events); !reflect.DeepEqual(got, []string{"b:matured"}) {
+		t.Errorf("got events %v, want the maturity of b", got)
+	}
+	if restarted.lookups != 1 {
+		t.Errorf("expected only the live ticket to be read, read %d tickets", restarted.lookups)
+	}
+}
@@ -0,0 +1,569 @@
+package wallet
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/decred/dcrd/blockchain/stake/v4"
+	"github.com/decred/dcrd/blockchain/standalone/v2"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/dcrlibwallet/utils"
+)
+
+const (
+	dcrdataMainnetHost = "https://dcrdata.decred.org"
+	dcrdataTestnetHost = "https://testnet.decred.org"
+
+	treasuryBalancePath = "/api/treasury/balance"
+	treasuryIOPath      = "/api/treasury/io/month"
+	dcrdataTxPath       = "/api/tx/"
+	treasuryVotesPath   = "/api/treasury/votes/"
+	treasuryTxsPath     = "/api/treasury/txs"
+
+	// tspendsPageSize is the number of tspends requested at a time.
+	tspendsPageSize = 100
+
+	dcrdataRequestTimeout = 30 * time.Second
+
+	// treasuryHistoryFile is the file, in the network data directory, where
+	// the treasury history is cached.
+	treasuryHistoryFile = "treasury_history.json"
+
+	TSpendStatusVoting   = "voting"
+	TSpendStatusApproved = "approved"
+	TSpendStatusExpired  = "expired"
+)
+
+// TreasuryBalance is the state of the treasury. Amounts are in atoms.
+type TreasuryBalance struct {
+	Balance    int64 `json:"balance"`
+	Added      int64 `json:"added"`
+	Spent      int64 `json:"spent"`
+	SpendCount int64 `json:"spend_count"`
+	Immature   int64 `json:"immature"`
+}
+
+// TreasuryBalancePoint is the treasury balance at the end of a month along
+// with the amounts received and spent during the month, in atoms.
+type TreasuryBalancePoint struct {
+	Time     time.Time `json:"time"`
+	Received int64     `json:"received"`
+	Sent     int64     `json:"sent"`
+	Balance  int64     `json:"balance"`
+}
+
+// TSpendRecord is a treasury spend and the votes of the tickets of the
+// wallets on it. Yes and No are the number of tickets that voted for and against the
+// tspend, Abstain the number of tickets that voted during the voting window
+// of the tspend without voting on it.
+type TSpendRecord struct {
+	Hash        string    `json:"hash"`
+	Amount      int64     `json:"amount"`
+	Expiry      uint32    `json:"expiry"`
+	BlockHeight int64     `json:"block_height,omitempty"`
+	Time        time.Time `json:"time,omitempty"`
+	Status      string    `json:"status"`
+
+	Yes     int `json:"yes"`
+	No      int `json:"no"`
+	Abstain int `json:"abstain"`
+
+	// Network is the tally of the votes of all the tickets of the network,
+	// nil if it couldn't be fetched.
+	Network *TSpendTally `json:"network,omitempty"`
+}
+
+// TSpendTally is the number of votes of the network on a tspend.
+type TSpendTally struct {
+	Yes int64 `json:"yesvotes"`
+	No  int64 `json:"novotes"`
+}
+
+// YesFraction returns the fraction of the votes that voted yes.
+func (t *TSpendTally) YesFraction() float64 {
+	if t.Yes+t.No == 0 {
+		return 0
+	}
+	return float64(t.Yes) / float64(t.Yes+t.No)
+}
+
+// Votes returns the number of tickets that voted during the voting window
+// of the tspend.
+func (r *TSpendRecord) Votes() int {
+	return r.Yes + r.No + r.Abstain
+}
+
+// Fractions returns the fractions of the tickets that voted yes, no and
+// abstained.
+func (r *TSpendRecord) Fractions() (yes, no, abstain float64) {
+	total := float64(r.Votes())
+	if total == 0 {
+		return 0, 0, 0
+	}
+	return float64(r.Yes) / total, float64(r.No) / total, float64(r.Abstain) / total
+}
+
+// TreasuryHistory is the treasury data as last fetched.
+type TreasuryHistory struct {
+	FetchedAt      time.Time              `json:"fetched_at"`
+	Balance        TreasuryBalance        `json:"balance"`
+	BalanceHistory []TreasuryBalancePoint `json:"balance_history"`
+	// TSpends are sorted newest first.
+	TSpends []*TSpendRecord `json:"tspends"`
+}
+
+func (wal *Wallet) dcrdataHost() string {
+	if wal.Net == dcrlibwallet.Testnet3 {
+		return dcrdataTestnetHost
+	}
+	return dcrdataMainnetHost
+}
+
+// getDcrdata requests the path from the dcrdata server at host and decodes
+// the JSON response into reply.
+func getDcrdata(ctx context.Context, client *http.Client, host, path string, reply interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%d response from dcrdata", resp.StatusCode)
+	}
+
+	return json.Unmarshal(respBytes, reply)
+}
+
+// chartTime is a chart time sent by dcrdata either as a unix time or as an
+// RFC3339 string.
+type chartTime time.Time
+
+func (t *chartTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		unix, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return err
+		}
+		*t = chartTime(time.Unix(unix, 0).UTC())
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*t = chartTime(parsed)
+	return nil
+}
+
+// treasuryIO is the amount received and spent by the treasury in each
+// period, in DCR.
+type treasuryIO struct {
+	Time     []chartTime `json:"time"`
+	Received []float64   `json:"received"`
+	Sent     []float64   `json:"sent"`
+}
+
+// balanceHistory returns the balance of the treasury at the end of each
+// period.
+func balanceHistory(flows *treasuryIO) ([]TreasuryBalancePoint, error) {
+	if len(flows.Received) != len(flows.Time) || len(flows.Sent) != len(flows.Time) {
+		return nil, fmt.Errorf("treasury history has %d times, %d received and %d sent amounts",
+			len(flows.Time), len(flows.Received), len(flows.Sent))
+	}
+
+	points := make([]TreasuryBalancePoint, len(flows.Time))
+	var balance int64
+	for i := range flows.Time {
+		received, err := dcrutil.NewAmount(flows.Received[i])
+		if err != nil {
+			return nil, err
+		}
+		sent, err := dcrutil.NewAmount(flows.Sent[i])
+		if err != nil {
+			return nil, err
+		}
+		balance += int64(received) - int64(sent)
+		points[i] = TreasuryBalancePoint{
+			Time:     time.Time(flows.Time[i]),
+			Received: int64(received),
+			Sent:     int64(sent),
+			Balance:  balance,
+		}
+	}
+	return points, nil
+}
+
+// ticketVote is the treasury votes of a vote cast by a ticket of the
+// wallets, mapping tspend hashes to the vote choice.
+type ticketVote struct {
+	Height int64
+	TSpend map[string]stake.TreasuryVoteT
+}
+
+// treasuryVotes returns the tspend votes found in the final output of the
+// vote tx, nil if the vote doesn't vote on any tspend.
+func treasuryVotes(tx *dcrlibwallet.Transaction) (map[string]stake.TreasuryVoteT, error) {
+	txBytes, err := hex.DecodeString(tx.Hex)
+	if err != nil {
+		return nil, err
+	}
+	msgTx := new(wire.MsgTx)
+	if err = msgTx.FromBytes(txBytes); err != nil {
+		return nil, err
+	}
+	if len(msgTx.TxOut) == 0 {
+		return nil, nil
+	}
+
+	// Votes without treasury votes don't have the TV output.
+	tuples, err := stake.GetSSGenTreasuryVotes(msgTx.TxOut[len(msgTx.TxOut)-1].PkScript)
+	if err != nil {
+		return nil, nil
+	}
+	votes := make(map[string]stake.TreasuryVoteT, len(tuples))
+	for _, tuple := range tuples {
+		votes[tuple.Hash.String()] = tuple.Vote
+	}
+	return votes, nil
+}
+
+// walletTicketVotes returns the votes cast by the tickets of the wallets.
+func walletTicketVotes(mw *dcrlibwallet.MultiWallet) ([]ticketVote, error) {
+	var votes []ticketVote
+	for _, w := range mw.AllWallets() {
+		txs, err := w.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterVoted, true)
+		if err != nil {
+			return nil, err
+		}
+		for i := range txs {
+			if txs[i].BlockHeight <= 0 {
+				continue
+			}
+			tspendVotes, err := treasuryVotes(&txs[i])
+			if err != nil {
+				return nil, fmt.Errorf("error reading vote %s: %v", txs[i].Hash, err)
+			}
+			votes = append(votes, ticketVote{Height: int64(txs[i].BlockHeight), TSpend: tspendVotes})
+		}
+	}
+	return votes, nil
+}
+
+// tallyTSpendVotes counts the votes of the tickets on the tspend. Tickets
+// that voted inside the voting window of the tspend without voting on it
+// abstained.
+func tallyTSpendVotes(record *TSpendRecord, votes []ticketVote, params *chaincfg.Params) {
+	record.Yes, record.No, record.Abstain = 0, 0, 0
+	for _, vote := range votes {
+		choice, voted := vote.TSpend[record.Hash]
+		switch {
+		case voted && choice == stake.TreasuryVoteYes:
+			record.Yes++
+		case voted && choice == stake.TreasuryVoteNo:
+			record.No++
+		case standalone.InsideTSpendWindow(vote.Height, record.Expiry,
+			params.TreasuryVoteInterval, params.TreasuryVoteIntervalMultiplier):
+			record.Abstain++
+		}
+	}
+}
+
+// tspendStatus returns the status of the tspend at the best block height.
+func tspendStatus(record *TSpendRecord, bestHeight int64) string {
+	if record.BlockHeight > 0 {
+		return TSpendStatusApproved
+	}
+	if bestHeight >= int64(record.Expiry) {
+		return TSpendStatusExpired
+	}
+	return TSpendStatusVoting
+}
+
+// dcrdataTx is the part of a dcrdata transaction needed to describe a tspend.
+type dcrdataTx struct {
+	Expiry uint32 `json:"expiry"`
+	Vout   []struct {
+		Value float64 `json:"value"`
+	} `json:"vout"`
+	Block *struct {
+		BlockHeight int64 `json:"blockheight"`
+		Time        int64 `json:"time"`
+	} `json:"block"`
+}
+
+// fetchTSpend requests the amount, expiry and block of the tspend.
+func fetchTSpend(ctx context.Context, client *http.Client, host, hash string) (*TSpendRecord, error) {
+	var tx dcrdataTx
+	if err := getDcrdata(ctx, client, host, dcrdataTxPath+hash, &tx); err != nil {
+		return nil, err
+	}
+
+	record := &TSpendRecord{Hash: hash, Expiry: tx.Expiry}
+	// The first output of a tspend commits to the amount spent and pays
+	// nothing.
+	for i := 1; i < len(tx.Vout); i++ {
+		amount, err := dcrutil.NewAmount(tx.Vout[i].Value)
+		if err != nil {
+			return nil, err
+		}
+		record.Amount += int64(amount)
+	}
+	if tx.Block != nil && tx.Block.BlockHeight > 0 {
+		record.BlockHeight = tx.Block.BlockHeight
+		record.Time = time.Unix(tx.Block.Time, 0)
+	}
+	return record, nil
+}
+
+// fetchTSpendHashes requests the hashes of all the tspends known to dcrdata.
+func fetchTSpendHashes(ctx context.Context, client *http.Client, host string) ([]string, error) {
+	var hashes []string
+	for offset := 0; ; offset += tspendsPageSize {
+		var txs []struct {
+			TxID string `json:"txid"`
+		}
+		path := fmt.Sprintf("%s?type=tspend&count=%d&offset=%d", treasuryTxsPath, tspendsPageSize, offset)
+		if err := getDcrdata(ctx, client, host, path, &txs); err != nil {
+			return nil, err
+		}
+		for _, tx := range txs {
+			hashes = append(hashes, tx.TxID)
+		}
+		if len(txs) < tspendsPageSize {
+			return hashes, nil
+		}
+	}
+}
+
+// tspendRecords returns the tspends known to dcrdata, along with the tspends
+// still in the mempool that the tickets of the wallets voted on, with the
+// votes of the tickets tallied. The details and network tallies of the
+// known records are reused.
+func tspendRecords(ctx context.Context, client *http.Client, host string, votes []ticketVote,
+	known map[string]*TSpendRecord, bestHeight int64, params *chaincfg.Params) ([]*TSpendRecord, error) {
+
+	hashes, err := fetchTSpendHashes(ctx, client, host)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tspends: %v", err)
+	}
+	seen := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		seen[hash] = true
+	}
+	for _, vote := range votes {
+		for hash := range vote.TSpend {
+			if !seen[hash] {
+				seen[hash] = true
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+
+	records := make([]*TSpendRecord, 0, len(hashes))
+	for _, hash := range hashes {
+		record, ok := known[hash]
+		if !ok {
+			if record, err = fetchTSpend(ctx, client, host, hash); err != nil {
+				return nil, fmt.Errorf("error fetching tspend %s: %v", hash, err)
+			}
+		}
+		record.Status = tspendStatus(record, bestHeight)
+		tallyTSpendVotes(record, votes, params)
+		if !ok || record.Network == nil {
+			// The votes of the tickets of the wallets are shown without the
+			// network tally if it can't be fetched.
+			if record.Network, err = fetchTSpendTally(ctx, client, host, hash); err != nil {
+				log.Errorf("Error fetching the votes on tspend %s: %v", hash, err)
+			}
+		}
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Expiry > records[j].Expiry
+	})
+	return records, nil
+}
+
+// fetchTSpendTally requests the votes of the network on the tspend.
+func fetchTSpendTally(ctx context.Context, client *http.Client, host, hash string) (*TSpendTally, error) {
+	tally := new(TSpendTally)
+	if err := getDcrdata(ctx, client, host, treasuryVotesPath+hash, tally); err != nil {
+		return nil, err
+	}
+	return tally, nil
+}
+
+func (wal *Wallet) treasuryHistoryPath() string {
+	return filepath.Join(wal.Root, wal.Net, treasuryHistoryFile)
+}
+
+// CachedTreasuryHistory returns the treasury history saved by the last call
+// to FetchTreasuryHistory, nil if the history has never been fetched.
+func (wal *Wallet) CachedTreasuryHistory() (*TreasuryHistory, error) {
+	data, err := ioutil.ReadFile(wal.treasuryHistoryPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	history := new(TreasuryHistory)
+	if err = json.Unmarshal(data, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// FetchTreasuryHistory requests the treasury balance history from dcrdata
+// and tallies the votes of the tickets of the wallets on every tspend, along
+// with the votes of the network. The history is saved so it
+// can be read offline.
+func (wal *Wallet) FetchTreasuryHistory(ctx context.Context) (*TreasuryHistory, error) {
+	params, err := utils.ChainParams(wal.multi.NetType())
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: dcrdataRequestTimeout}
+	host := wal.dcrdataHost()
+
+	history := &TreasuryHistory{FetchedAt: time.Now()}
+	if err = getDcrdata(ctx, client, host, treasuryBalancePath, &history.Balance); err != nil {
+		return nil, fmt.Errorf("error fetching treasury balance: %v", err)
+	}
+
+	var flows treasuryIO
+	if err = getDcrdata(ctx, client, host, treasuryIOPath, &flows); err != nil {
+		return nil, fmt.Errorf("error fetching treasury history: %v", err)
+	}
+	if history.BalanceHistory, err = balanceHistory(&flows); err != nil {
+		return nil, err
+	}
+
+	votes, err := walletTicketVotes(wal.multi)
+	if err != nil {
+		return nil, err
+	}
+
+	// Details and tallies of tspends that were mined or expired can't
+	// change.
+	known := make(map[string]*TSpendRecord)
+	if cached, err := wal.CachedTreasuryHistory(); err == nil && cached != nil {
+		for _, record := range cached.TSpends {
+			if record.Status != TSpendStatusVoting {
+				known[record.Hash] = record
+			}
+		}
+	}
+
+	bestHeight := int64(wal.multi.GetBestBlock().Height)
+	history.TSpends, err = tspendRecords(ctx, client, host, votes, known, bestHeight, params)
+	if err != nil {
+		return nil, err
+	}
+	return history, wal.saveTreasuryHistory(history)
+}
+
+func (wal *Wallet) saveTreasuryHistory(history *TreasuryHistory) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	path := wal.treasuryHistoryPath()
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func formatFraction(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
+
+// WriteTreasuryHistoryCSV writes the balance history and the tspends to out
+// in CSV format, as two tables separated by an empty line. Amounts are in
+// atoms.
+func WriteTreasuryHistoryCSV(out io.Writer, history *TreasuryHistory) error {
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"month", "received", "sent", "balance"})
+	for _, point := range history.BalanceHistory {
+		writer.Write([]string{
+			point.Time.UTC().Format("2006-01"),
+			strconv.FormatInt(point.Received, 10),
+			strconv.FormatInt(point.Sent, 10),
+			strconv.FormatInt(point.Balance, 10),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(out, "\n"); err != nil {
+		return err
+	}
+
+	writer.Write([]string{"tspend", "amount", "expiry", "block_height", "time", "status",
+		"yes", "no", "abstain", "yes_fraction", "no_fraction", "abstain_fraction", "network_yes", "network_no"})
+	for _, r := range history.TSpends {
+		var minedTime string
+		if !r.Time.IsZero() {
+			minedTime = r.Time.UTC().Format(time.RFC3339)
+		}
+		yes, no, abstain := r.Fractions()
+		var networkYes, networkNo string
+		if r.Network != nil {
+			networkYes = strconv.FormatInt(r.Network.Yes, 10)
+			networkNo = strconv.FormatInt(r.Network.No, 10)
+		}
+		writer.Write([]string{
+			r.Hash,
+			strconv.FormatInt(r.Amount, 10),
+			strconv.FormatUint(uint64(r.Expiry), 10),
+			strconv.FormatInt(r.BlockHeight, 10),
+			minedTime,
+			r.Status,
+			strconv.Itoa(r.Yes), strconv.Itoa(r.No), strconv.Itoa(r.Abstain),
+			formatFraction(yes), formatFraction(no), formatFraction(abstain),
+			networkYes, networkNo,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportTreasuryHistory writes the treasury history to a CSV file in the
+// exports directory and returns the file path.
+func (wal *Wallet) ExportTreasuryHistory(history *TreasuryHistory) (string, error) {
+	name := fmt.Sprintf("treasury-%s.csv", time.Now().Format("20060102-150405"))
+	return wal.writeExport(name, func(out io.Writer) error {
+		return WriteTreasuryHistoryCSV(out, history)
+	})
+}
@@ -0,0 +1,253 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/blockchain/stake/v4"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestBalanceHistory(t *testing.T) {
+	var flows treasuryIO
+	data := `{"time":["2021-06-01T00:00:00Z",1625097600],"received":[1000.5,250],"sent":[0,300.25]}`
+	if err := json.Unmarshal([]byte(data), &flows); err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := balanceHistory(&flows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TreasuryBalancePoint{
+		{Time: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Received: 100050000000, Sent: 0, Balance: 100050000000},
+		{Time: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), Received: 25000000000, Sent: 30025000000, Balance: 95025000000},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("expected %d points, got %d", len(want), len(points))
+	}
+	for i := range want {
+		if !points[i].Time.Equal(want[i].Time) || points[i].Received != want[i].Received ||
+			points[i].Sent != want[i].Sent || points[i].Balance != want[i].Balance {
+			t.Errorf("point %d: expected %+v, got %+v", i, want[i], points[i])
+		}
+	}
+
+	flows.Sent = flows.Sent[:1]
+	if _, err := balanceHistory(&flows); err == nil {
+		t.Error("expected an error for mismatched series")
+	}
+}
+
+func testVoteTx(t *testing.T, votes []stake.TreasuryVoteTuple) *dcrlibwallet.Transaction {
+	t.Helper()
+	data := []byte{'T', 'V'}
+	for _, vote := range votes {
+		data = append(data, vote.Hash[:]...)
+		data = append(data, byte(vote.Vote))
+	}
+	script := append([]byte{0x6a, byte(len(data))}, data...)
+
+	msgTx := wire.NewMsgTx()
+	msgTx.AddTxOut(wire.NewTxOut(0, []byte{0x6a, 0x02, 0x01, 0x00}))
+	msgTx.AddTxOut(wire.NewTxOut(0, script))
+	txBytes, err := msgTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dcrlibwallet.Transaction{Hex: hex.EncodeToString(txBytes)}
+}
+
+func TestTreasuryVotes(t *testing.T) {
+	yesHash := chainhash.Hash{1}
+	noHash := chainhash.Hash{2}
+	tx := testVoteTx(t, []stake.TreasuryVoteTuple{
+		{Hash: yesHash, Vote: stake.TreasuryVoteYes},
+		{Hash: noHash, Vote: stake.TreasuryVoteNo},
+	})
+
+	votes, err := treasuryVotes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if votes[yesHash.String()] != stake.TreasuryVoteYes || votes[noHash.String()] != stake.TreasuryVoteNo || len(votes) != 2 {
+		t.Errorf("unexpected votes %v", votes)
+	}
+
+	// A vote without treasury votes.
+	msgTx := wire.NewMsgTx()
+	msgTx.AddTxOut(wire.NewTxOut(0, []byte{0x6a, 0x02, 0x01, 0x00}))
+	txBytes, _ := msgTx.Bytes()
+	votes, err = treasuryVotes(&dcrlibwallet.Transaction{Hex: hex.EncodeToString(txBytes)})
+	if err != nil || votes != nil {
+		t.Errorf("expected no votes, got %v, %v", votes, err)
+	}
+}
+
+func TestTallyTSpendVotes(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	hash := chainhash.Hash{1}.String()
+	other := chainhash.Hash{2}.String()
+	// The voting window of the tspend is 860544 to 864000.
+	record := &TSpendRecord{Hash: hash, Expiry: 864002}
+
+	votes := []ticketVote{
+		{Height: 861000, TSpend: map[string]stake.TreasuryVoteT{hash: stake.TreasuryVoteYes}},
+		{Height: 861100, TSpend: map[string]stake.TreasuryVoteT{hash: stake.TreasuryVoteYes, other: stake.TreasuryVoteNo}},
+		{Height: 862000, TSpend: map[string]stake.TreasuryVoteT{hash: stake.TreasuryVoteNo}},
+		// Abstained inside the window.
+		{Height: 863000, TSpend: map[string]stake.TreasuryVoteT{other: stake.TreasuryVoteYes}},
+		{Height: 863500},
+		// Outside the window.
+		{Height: 850000},
+		{Height: 870000, TSpend: map[string]stake.TreasuryVoteT{other: stake.TreasuryVoteYes}},
+	}
+
+	tallyTSpendVotes(record, votes, params)
+	if record.Yes != 2 || record.No != 1 || record.Abstain != 2 {
+		t.Fatalf("expected 2 yes, 1 no and 2 abstain votes, got %d, %d and %d", record.Yes, record.No, record.Abstain)
+	}
+	yes, no, abstain := record.Fractions()
+	if yes != 0.4 || no != 0.2 || abstain != 0.4 {
+		t.Errorf("unexpected fractions %v, %v, %v", yes, no, abstain)
+	}
+
+	empty := &TSpendRecord{}
+	if yes, no, abstain := empty.Fractions(); yes != 0 || no != 0 || abstain != 0 {
+		t.Error("expected zero fractions without votes")
+	}
+}
+
+func TestTSpendStatus(t *testing.T) {
+	tests := []struct {
+		record     TSpendRecord
+		bestHeight int64
+		want       string
+	}{
+		{TSpendRecord{Expiry: 864002, BlockHeight: 863000}, 870000, TSpendStatusApproved},
+		{TSpendRecord{Expiry: 864002}, 862000, TSpendStatusVoting},
+		{TSpendRecord{Expiry: 864002}, 864002, TSpendStatusExpired},
+	}
+	for i, test := range tests {
+		if got := tspendStatus(&test.record, test.bestHeight); got != test.want {
+			t.Errorf("test %d: expected %s, got %s", i, test.want, got)
+		}
+	}
+}
+
+func TestWriteTreasuryHistoryCSV(t *testing.T) {
+	history := &TreasuryHistory{
+		BalanceHistory: []TreasuryBalancePoint{
+			{Time: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Received: 100, Sent: 0, Balance: 100},
+		},
+		TSpends: []*TSpendRecord{
+			{Hash: "aa", Amount: 50, Expiry: 864002, BlockHeight: 863000, Time: time.Date(2021, 7, 2, 3, 4, 5, 0, time.UTC),
+				Status: TSpendStatusApproved, Yes: 3, No: 1, Network: &TSpendTally{Yes: 4000, No: 1000}},
+			{Hash: "bb", Amount: 20, Expiry: 870002, Status: TSpendStatusVoting, Yes: 1},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTreasuryHistoryCSV(&buf, history); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"month,received,sent,balance",
+		"2021-06,100,0,100",
+		"",
+		"tspend,amount,expiry,block_height,time,status,yes,no,abstain,yes_fraction,no_fraction,abstain_fraction,network_yes,network_no",
+		"aa,50,864002,863000,2021-07-02T03:04:05Z,approved,3,1,0,0.7500,0.2500,0.0000,4000,1000",
+		"bb,20,870002,0,,voting,1,0,0,1.0000,0.0000,0.0000,,",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestFetchTSpendTally(t *testing.T) {
+	hash := chainhash.Hash{1}.String()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != treasuryVotesPath+hash {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"hash":%q,"expiry":864002,"votestart":860544,"voteend":864000,"yesvotes":4000,"novotes":1000}`, hash)
+	}))
+	defer server.Close()
+
+	tally, err := fetchTSpendTally(context.Background(), server.Client(), server.URL, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tally.Yes != 4000 || tally.No != 1000 || tally.YesFraction() != 0.8 {
+		t.Errorf("unexpected tally %+v", tally)
+	}
+
+	if _, err = fetchTSpendTally(context.Background(), server.Client(), server.URL, chainhash.Hash{2}.String()); err == nil {
+		t.Error("expected an error for an unknown tspend")
+	}
+}
+
+func TestTSpendRecords(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	// abstained is mined and only abstaining votes fall inside its voting
+	// window. voted is still in the mempool.
+	abstained := chainhash.Hash{1}.String()
+	voted := chainhash.Hash{2}.String()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case treasuryTxsPath:
+			if r.URL.Query().Get("type") != "tspend" || r.URL.Query().Get("offset") != "0" {
+				t.Errorf("unexpected tspends request %s", r.URL)
+			}
+			fmt.Fprintf(w, `[{"txid":%q}]`, abstained)
+		case dcrdataTxPath + abstained:
+			fmt.Fprint(w, `{"expiry":864002,"vout":[{"value":0},{"value":10},{"value":5}],"block":{"blockheight":863500,"time":1625097600}}`)
+		case dcrdataTxPath + voted:
+			fmt.Fprint(w, `{"expiry":870050,"vout":[{"value":0},{"value":1}]}`)
+		case treasuryVotesPath + abstained:
+			fmt.Fprint(w, `{"yesvotes":4000,"novotes":1000}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	votes := []ticketVote{
+		{Height: 861000},
+		{Height: 863000, TSpend: map[string]stake.TreasuryVoteT{voted: stake.TreasuryVoteYes}},
+		{Height: 868000, TSpend: map[string]stake.TreasuryVoteT{voted: stake.TreasuryVoteNo}},
+	}
+	records, err := tspendRecords(context.Background(), server.Client(), server.URL, votes, nil, 869000, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Hash != voted || records[1].Hash != abstained {
+		t.Fatalf("expected both tspends newest first, got %+v", records)
+	}
+
+	record := records[1]
+	if record.Yes != 0 || record.No != 0 || record.Abstain != 2 {
+		t.Errorf("expected 2 abstain votes on the listed tspend, got %d, %d and %d", record.Yes, record.No, record.Abstain)
+	}
+	if record.Amount != 15e8 || record.Status != TSpendStatusApproved || record.Network == nil || record.Network.Yes != 4000 {
+		t.Errorf("unexpected listed tspend %+v", record)
+	}
+
+	record = records[0]
+	if record.No != 1 || record.Status != TSpendStatusVoting || record.Network != nil {
+		t.Errorf("unexpected mempool tspend %+v", record)
+	}
+}
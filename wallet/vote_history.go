@@ -0,0 +1,241 @@
+package wallet
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	www "github.com/decred/politeia/politeiawww/api/www/v1"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// proposalVotesConfigKey is the wallet config key under which the votes
+	// cast from this wallet are saved along with their time.
+	proposalVotesConfigKey = "proposal_votes"
+	// proposalVoteAuditConfigKey is the wallet config key under which the
+	// vote audit of finished proposals is cached, as it can no longer
+	// change.
+	proposalVoteAuditConfigKey = "proposal_vote_audit"
+
+	VoteOutcomeVoting   = "voting"
+	VoteOutcomeApproved = "approved"
+	VoteOutcomeRejected = "rejected"
+)
+
+// savedVote is a vote cast from this wallet or found by an audit.
+type savedVote struct {
+	Bit string `json:"bit"`
+	// Time is the unix time the vote was cast from this wallet, 0 if the
+	// vote was cast elsewhere.
+	Time int64 `json:"time,omitempty"`
+}
+
+// VoteRecord is the vote of a ticket on a proposal.
+type VoteRecord struct {
+	ProposalToken string
+	ProposalName  string
+	Ticket        string
+	// Choice is dcrlibwallet.VoteBitYes or dcrlibwallet.VoteBitNo, empty if
+	// the ticket was eligible but didn't vote.
+	Choice string
+	// VoteTime is zero if the vote wasn't cast from this wallet.
+	VoteTime time.Time
+	Outcome  string
+}
+
+// Voted returns true if the ticket voted on the proposal.
+func (r *VoteRecord) Voted() bool {
+	return r.Choice != ""
+}
+
+// ChoiceString returns the vote choice as "yes" or "no", empty if the ticket
+// didn't vote.
+func (r *VoteRecord) ChoiceString() string {
+	switch r.Choice {
+	case dcrlibwallet.VoteBitYes:
+		return "yes"
+	case dcrlibwallet.VoteBitNo:
+		return "no"
+	}
+	return ""
+}
+
+func proposalOutcome(proposal *dcrlibwallet.Proposal) string {
+	switch proposal.VoteStatus {
+	case int32(www.PropVoteStatusStarted):
+		return VoteOutcomeVoting
+	case int32(www.PropVoteStatusFinished):
+		if proposal.VoteApproved {
+			return VoteOutcomeApproved
+		}
+		return VoteOutcomeRejected
+	}
+	return ""
+}
+
+// RecordProposalVotes saves the time the votes were cast from the wallet.
+func RecordProposalVotes(w *dcrlibwallet.Wallet, token string, votes []*dcrlibwallet.ProposalVote) {
+	saved := make(map[string]map[string]savedVote)
+	w.ReadUserConfigValue(proposalVotesConfigKey, &saved)
+
+	if saved[token] == nil {
+		saved[token] = make(map[string]savedVote)
+	}
+	now := time.Now().Unix()
+	for _, vote := range votes {
+		saved[token][vote.Ticket.Hash] = savedVote{Bit: vote.Bit, Time: now}
+	}
+	w.SaveUserConfigValue(proposalVotesConfigKey, saved)
+}
+
+// auditedTicket is the vote of an eligible ticket found by an audit. Bit is
+// empty if the ticket didn't vote.
+type auditedTicket struct {
+	Ticket string `json:"ticket"`
+	Bit    string `json:"bit"`
+}
+
+// mergeVoteRecords builds the vote records of a proposal from the audited
+// tickets and the votes cast from this wallet. Audited tickets take
+// precedence for the choice while the local votes provide the vote time.
+func mergeVoteRecords(proposal *dcrlibwallet.Proposal, audited []auditedTicket, local map[string]savedVote) []*VoteRecord {
+	outcome := proposalOutcome(proposal)
+	newRecord := func(ticket, bit string) *VoteRecord {
+		record := &VoteRecord{
+			ProposalToken: proposal.Token,
+			ProposalName:  proposal.Name,
+			Ticket:        ticket,
+			Choice:        bit,
+			Outcome:       outcome,
+		}
+		if vote, ok := local[ticket]; ok && vote.Time > 0 {
+			record.VoteTime = time.Unix(vote.Time, 0)
+			if record.Choice == "" {
+				// The audit predates the vote.
+				record.Choice = vote.Bit
+			}
+		}
+		return record
+	}
+
+	seen := make(map[string]bool)
+	records := make([]*VoteRecord, 0, len(audited)+len(local))
+	for _, ticket := range audited {
+		seen[ticket.Ticket] = true
+		records = append(records, newRecord(ticket.Ticket, ticket.Bit))
+	}
+	for ticket, vote := range local {
+		if !seen[ticket] {
+			records = append(records, newRecord(ticket, vote.Bit))
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Ticket < records[j].Ticket
+	})
+	return records
+}
+
+// auditProposal requests the vote details of the proposal for the wallet.
+func auditProposal(mw *dcrlibwallet.MultiWallet, w *dcrlibwallet.Wallet, token string) ([]auditedTicket, error) {
+	details, err := mw.Politeia.ProposalVoteDetailsRaw(w.ID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	audited := make([]auditedTicket, 0, len(details.Votes)+len(details.EligibleTickets))
+	for _, vote := range details.Votes {
+		audited = append(audited, auditedTicket{Ticket: vote.Ticket.Hash, Bit: vote.Bit})
+	}
+	for _, ticket := range details.EligibleTickets {
+		audited = append(audited, auditedTicket{Ticket: ticket.Hash})
+	}
+	return audited, nil
+}
+
+// ProposalVoteHistory returns the vote of every ticket of the wallet on the
+// proposals whose vote has started, newest proposals first. If audit is
+// true, the vote details of the proposals that haven't been audited are
+// requested from politeia to include the votes cast elsewhere and the
+// eligible tickets that didn't vote. Otherwise only the votes cast from this
+// wallet and the cached audits are returned.
+func ProposalVoteHistory(ctx context.Context, mw *dcrlibwallet.MultiWallet, w *dcrlibwallet.Wallet, audit bool) ([]*VoteRecord, error) {
+	proposals, err := mw.Politeia.GetProposalsRaw(dcrlibwallet.ProposalCategoryAll, 0, 0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	local := make(map[string]map[string]savedVote)
+	w.ReadUserConfigValue(proposalVotesConfigKey, &local)
+	audits := make(map[string][]auditedTicket)
+	w.ReadUserConfigValue(proposalVoteAuditConfigKey, &audits)
+
+	var records []*VoteRecord
+	auditsUpdated := false
+	for i := range proposals {
+		proposal := &proposals[i]
+		if proposal.VoteStatus != int32(www.PropVoteStatusStarted) && proposal.VoteStatus != int32(www.PropVoteStatusFinished) {
+			continue
+		}
+
+		audited, cached := audits[proposal.Token]
+		if audit && (!cached || proposal.VoteStatus == int32(www.PropVoteStatusStarted)) {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			audited, err = auditProposal(mw, w, proposal.Token)
+			if err != nil {
+				return nil, fmt.Errorf("error auditing proposal %s: %v", proposal.Token, err)
+			}
+			// Only finished proposals are cached as votes on active
+			// proposals may still be cast.
+			if proposal.VoteStatus == int32(www.PropVoteStatusFinished) {
+				audits[proposal.Token] = audited
+				auditsUpdated = true
+			}
+		}
+
+		records = append(records, mergeVoteRecords(proposal, audited, local[proposal.Token])...)
+	}
+
+	if auditsUpdated {
+		w.SaveUserConfigValue(proposalVoteAuditConfigKey, audits)
+	}
+	return records, nil
+}
+
+// WriteVoteHistoryCSV writes the vote records to out in CSV format.
+func WriteVoteHistoryCSV(out io.Writer, records []*VoteRecord) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write([]string{"proposal_token", "proposal_name", "ticket", "choice", "vote_time", "outcome"}); err != nil {
+		return err
+	}
+	for _, r := range records {
+		var voteTime string
+		if !r.VoteTime.IsZero() {
+			voteTime = r.VoteTime.UTC().Format(time.RFC3339)
+		}
+		choice := r.ChoiceString()
+		if choice == "" {
+			choice = "not voted"
+		}
+		if err := writer.Write([]string{r.ProposalToken, r.ProposalName, r.Ticket, choice, voteTime, r.Outcome}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportVoteHistory writes the vote records of the wallet to a CSV file in
+// the exports directory and returns the file path.
+func (wal *Wallet) ExportVoteHistory(w *dcrlibwallet.Wallet, records []*VoteRecord) (string, error) {
+	name := fmt.Sprintf("votes-%d-%s.csv", w.ID, time.Now().Format("20060102-150405"))
+	return wal.writeExport(name, func(out io.Writer) error {
+		return WriteVoteHistoryCSV(out, records)
+	})
+}
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	www "github.com/decred/politeia/politeiawww/api/www/v1"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestMergeVoteRecords(t *testing.T) {
+	proposal := &dcrlibwallet.Proposal{Token: "abc", Name: "Test", VoteStatus: int32(www.PropVoteStatusFinished), VoteApproved: true}
+	voteTime := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	audited := []auditedTicket{
+		{Ticket: "t1", Bit: dcrlibwallet.VoteBitYes},
+		{Ticket: "t2", Bit: dcrlibwallet.VoteBitNo},
+		{Ticket: "t3"},
+		{Ticket: "t4"},
+	}
+	local := map[string]savedVote{
+		"t1": {Bit: dcrlibwallet.VoteBitYes, Time: voteTime.Unix()},
+		// Voted after the audit.
+		"t4": {Bit: dcrlibwallet.VoteBitNo, Time: voteTime.Unix()},
+		// Not returned by the audit.
+		"t5": {Bit: dcrlibwallet.VoteBitYes, Time: voteTime.Unix()},
+	}
+
+	records := mergeVoteRecords(proposal, audited, local)
+	if len(records) != 5 {
+		t.Fatalf("expected 5 records, got %d", len(records))
+	}
+
+	expected := []struct {
+		ticket, choice string
+		timed          bool
+	}{
+		{"t1", "yes", true},
+		{"t2", "no", false},
+		{"t3", "", false},
+		{"t4", "no", true},
+		{"t5", "yes", true},
+	}
+	for i, e := range expected {
+		r := records[i]
+		if r.Ticket != e.ticket || r.ChoiceString() != e.choice || r.VoteTime.IsZero() == e.timed {
+			t.Errorf("record %d: expected %+v, got %+v", i, e, r)
+		}
+		if r.Outcome != VoteOutcomeApproved {
+			t.Errorf("record %d: expected approved outcome, got %q", i, r.Outcome)
+		}
+	}
+	if records[2].Voted() {
+		t.Error("expected ticket without vote not to be reported as voted")
+	}
+}
+
+func TestWriteVoteHistoryCSV(t *testing.T) {
+	records := []*VoteRecord{
+		{ProposalToken: "abc", ProposalName: "Test, with comma", Ticket: "t1", Choice: dcrlibwallet.VoteBitYes,
+			VoteTime: time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC), Outcome: VoteOutcomeApproved},
+		{ProposalToken: "abc", ProposalName: "Test, with comma", Ticket: "t2", Outcome: VoteOutcomeApproved},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteVoteHistoryCSV(&buf, records); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d", len(rows))
+	}
+	if rows[1][1] != "Test, with comma" || rows[1][3] != "yes" || rows[1][4] != "2022-05-01T10:00:00Z" {
+		t.Errorf("unexpected row %v", rows[1])
+	}
+	if rows[2][3] != "not voted" || rows[2][4] != "" {
+		t.Errorf("unexpected row for ticket without vote %v", rows[2])
+	}
+}
@@ -0,0 +1,296 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// CustomVSPsConfigKey is the multiwallet config key under which the
+	// hosts of the VSPs added by the user are saved.
+	CustomVSPsConfigKey = "custom_vsps"
+
+	vspInfoPath       = "/api/v3/vspinfo"
+	vspRequestTimeout = 30 * time.Second
+)
+
+// VSPStatus is the health of a VSP as seen by the last vspinfo request.
+type VSPStatus struct {
+	Host string
+	// Info is the last vspinfo response received from the VSP. It is kept
+	// while the VSP is offline.
+	Info   *dcrlibwallet.VspInfoResponse
+	Online bool
+	// LastSeen is the time of the last successful vspinfo request and
+	// LastChecked the time of the last request.
+	LastSeen    time.Time
+	LastChecked time.Time
+	Err         error
+}
+
+// Checked returns true if the VSP has been polled at least once.
+func (s VSPStatus) Checked() bool {
+	return !s.LastChecked.IsZero()
+}
+
+// VSPMonitor polls the vspinfo endpoint of a set of VSPs.
+type VSPMonitor struct {
+	mu sync.RWMutex
+
+	client   *http.Client
+	network  string
+	hosts    func() []string
+	statuses map[string]*VSPStatus
+}
+
+// NewVSPMonitor returns a monitor for the VSPs returned by hosts. VSPs that
+// don't serve the specified network are reported as offline.
+func NewVSPMonitor(client *http.Client, network string, hosts func() []string) *VSPMonitor {
+	if client == nil {
+		client = &http.Client{Timeout: vspRequestTimeout}
+	}
+	return &VSPMonitor{
+		client:   client,
+		network:  network,
+		hosts:    hosts,
+		statuses: make(map[string]*VSPStatus),
+	}
+}
+
+// FetchVSPInfo requests the vspinfo of the VSP at host and validates the
+// response signature.
+func FetchVSPInfo(ctx context.Context, client *http.Client, host string) (*dcrlibwallet.VspInfoResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(host, "/")+vspInfoPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%d response from server", resp.StatusCode)
+	}
+
+	info := new(dcrlibwallet.VspInfoResponse)
+	if err = json.Unmarshal(respBytes, info); err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(resp.Header.Get("VSP-Server-Signature"))
+	if err != nil {
+		return nil, fmt.Errorf("error validating VSP signature: %v", err)
+	}
+	if len(info.PubKey) != ed25519.PublicKeySize || !ed25519.Verify(info.PubKey, respBytes, sig) {
+		return nil, errors.New("bad signature from VSP")
+	}
+
+	return info, nil
+}
+
+// vspServesNetwork returns true if a VSP reporting vspNetwork serves the
+// network of the wallet. VSPs report testnet3 as testnet.
+func vspServesNetwork(vspNetwork, network string) bool {
+	if network == dcrlibwallet.Testnet3 {
+		network = "testnet"
+	}
+	return vspNetwork == network
+}
+
+// Check polls every monitored VSP.
+func (m *VSPMonitor) Check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, host := range m.hosts() {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			m.CheckHost(ctx, host)
+		}(host)
+	}
+	wg.Wait()
+}
+
+// CheckHost polls the VSP at host and returns its updated status.
+func (m *VSPMonitor) CheckHost(ctx context.Context, host string) VSPStatus {
+	info, err := FetchVSPInfo(ctx, m.client, host)
+	if err == nil && !vspServesNetwork(info.Network, m.network) {
+		err = fmt.Errorf("invalid net %s", info.Network)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status, ok := m.statuses[host]
+	if !ok {
+		status = &VSPStatus{Host: host}
+		m.statuses[host] = status
+	}
+
+	status.LastChecked = time.Now()
+	status.Err = err
+	status.Online = err == nil
+	if err == nil {
+		status.Info = info
+		status.LastSeen = status.LastChecked
+	}
+	return *status
+}
+
+// Run polls the monitored VSPs every interval until ctx is canceled. The
+// onUpdate function, if not nil, is called after every poll.
+func (m *VSPMonitor) Run(ctx context.Context, interval time.Duration, onUpdate func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if onUpdate != nil {
+			onUpdate()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Status returns the last known status of the VSP at host.
+func (m *VSPMonitor) Status(host string) VSPStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if status, ok := m.statuses[host]; ok {
+		return *status
+	}
+	return VSPStatus{Host: host}
+}
+
+// Statuses returns the last known status of every monitored VSP.
+func (m *VSPMonitor) Statuses() []VSPStatus {
+	hosts := m.hosts()
+	statuses := make([]VSPStatus, 0, len(hosts))
+	for _, host := range hosts {
+		statuses = append(statuses, m.Status(host))
+	}
+	return statuses
+}
+
+// IsDown returns true if the last poll of the VSP at host failed.
+func (m *VSPMonitor) IsDown(host string) bool {
+	status := m.Status(host)
+	return status.Checked() && !status.Online
+}
+
+// VSPMonitor returns the monitor of the known and custom VSPs.
+func (wal *Wallet) VSPMonitor() *VSPMonitor {
+	wal.vspMonitorOnce.Do(func() {
+		wal.vspMonitor = NewVSPMonitor(nil, wal.multi.NetType(), wal.monitoredVSPs)
+	})
+	return wal.vspMonitor
+}
+
+// monitoredVSPs returns the custom VSP hosts followed by the hosts of the
+// other known VSPs.
+func (wal *Wallet) monitoredVSPs() []string {
+	hosts := wal.CustomVSPs()
+	seen := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		seen[host] = true
+	}
+
+	for _, vsp := range wal.multi.KnownVSPs() {
+		if !seen[vsp.Host] {
+			seen[vsp.Host] = true
+			hosts = append(hosts, vsp.Host)
+		}
+	}
+	return hosts
+}
+
+// userConfig is the part of the multiwallet config that stores the custom
+// VSP list.
+type userConfig interface {
+	ReadUserConfigValue(key string, valueOut interface{}) error
+	SaveUserConfigValue(key string, value interface{})
+}
+
+// CustomVSPs returns the hosts of the VSPs added by the user.
+func (wal *Wallet) CustomVSPs() []string {
+	return customVSPs(wal.multi)
+}
+
+// AddCustomVSP validates the VSP at host and adds it to the custom VSP list.
+func (wal *Wallet) AddCustomVSP(host string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), vspRequestTimeout)
+	defer cancel()
+	return addCustomVSP(ctx, wal.VSPMonitor().client, wal.multi, wal.multi.NetType(), host)
+}
+
+// RemoveCustomVSP removes the VSP at host from the custom VSP list.
+func (wal *Wallet) RemoveCustomVSP(host string) {
+	removeCustomVSP(wal.multi, host)
+}
+
+func customVSPs(config userConfig) []string {
+	var hosts []string
+	config.ReadUserConfigValue(CustomVSPsConfigKey, &hosts)
+	return hosts
+}
+
+// addCustomVSP adds host to the custom VSP list if the VSP at host serves
+// network.
+func addCustomVSP(ctx context.Context, client *http.Client, config userConfig, network, host string) error {
+	host = strings.TrimSuffix(strings.TrimSpace(host), "/")
+
+	hosts := customVSPs(config)
+	for _, savedHost := range hosts {
+		if savedHost == host {
+			return fmt.Errorf("duplicate host %s", host)
+		}
+	}
+
+	info, err := FetchVSPInfo(ctx, client, host)
+	if err != nil {
+		return err
+	}
+	if !vspServesNetwork(info.Network, network) {
+		return fmt.Errorf("invalid net %s", info.Network)
+	}
+
+	config.SaveUserConfigValue(CustomVSPsConfigKey, append(hosts, host))
+	return nil
+}
+
+func removeCustomVSP(config userConfig, host string) {
+	hosts := customVSPs(config)
+	for i, savedHost := range hosts {
+		if savedHost == host {
+			hosts = append(hosts[:i], hosts[i+1:]...)
+			break
+		}
+	}
+	config.SaveUserConfigValue(CustomVSPsConfigKey, hosts)
+}
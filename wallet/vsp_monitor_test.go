@@ -0,0 +1,216 @@
+package wallet
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// testVSP is an httptest stand-in for a vspd server.
+type testVSP struct {
+	*httptest.Server
+
+	privKey ed25519.PrivateKey
+	info    dcrlibwallet.VspInfoResponse
+	down    bool
+	badSig  bool
+}
+
+func newTestVSP(t *testing.T) *testVSP {
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vsp := &testVSP{
+		privKey: privKey,
+		info: dcrlibwallet.VspInfoResponse{
+			APIVersions:   []int64{3},
+			PubKey:        pubKey,
+			FeePercentage: 2.5,
+			Network:       "testnet",
+			Voting:        10,
+			Voted:         25,
+			Revoked:       1,
+		},
+	}
+	vsp.Server = httptest.NewServer(http.HandlerFunc(vsp.serveHTTP))
+	t.Cleanup(vsp.Close)
+	return vsp
+}
+
+func (v *testVSP) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if v.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.URL.Path != vspInfoPath {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, _ := json.Marshal(v.info)
+	sig := ed25519.Sign(v.privKey, body)
+	if v.badSig {
+		sig[0] ^= 0xff
+	}
+	w.Header().Set("VSP-Server-Signature", base64.StdEncoding.EncodeToString(sig))
+	w.Write(body)
+}
+
+func newTestMonitor(hosts ...string) *VSPMonitor {
+	return NewVSPMonitor(http.DefaultClient, dcrlibwallet.Testnet3, func() []string { return hosts })
+}
+
+func TestFetchVSPInfo(t *testing.T) {
+	vsp := newTestVSP(t)
+
+	info, err := FetchVSPInfo(context.Background(), http.DefaultClient, vsp.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.FeePercentage != 2.5 || info.Voted != 25 || info.Network != "testnet" {
+		t.Fatalf("unexpected vsp info %+v", info)
+	}
+
+	vsp.badSig = true
+	if _, err = FetchVSPInfo(context.Background(), http.DefaultClient, vsp.URL); err == nil {
+		t.Fatal("expected bad signature error")
+	}
+}
+
+func TestVSPMonitorCheck(t *testing.T) {
+	vsp := newTestVSP(t)
+	monitor := newTestMonitor(vsp.URL)
+
+	if status := monitor.Status(vsp.URL); status.Checked() || monitor.IsDown(vsp.URL) {
+		t.Fatal("expected unchecked vsp not to be reported down")
+	}
+
+	monitor.Check(context.Background())
+	status := monitor.Status(vsp.URL)
+	if !status.Online || status.Info == nil || status.Info.FeePercentage != 2.5 || status.LastSeen.IsZero() {
+		t.Fatalf("expected online vsp with info, got %+v", status)
+	}
+	lastSeen := status.LastSeen
+
+	vsp.down = true
+	monitor.Check(context.Background())
+	status = monitor.Status(vsp.URL)
+	if status.Online || status.Err == nil || !monitor.IsDown(vsp.URL) {
+		t.Fatalf("expected offline vsp, got %+v", status)
+	}
+	if status.Info == nil || !status.LastSeen.Equal(lastSeen) {
+		t.Fatal("expected last info and last seen time to be kept while offline")
+	}
+
+	vsp.down = false
+	if status = monitor.CheckHost(context.Background(), vsp.URL); !status.Online {
+		t.Fatalf("expected vsp to be back online, got %v", status.Err)
+	}
+}
+
+func TestVSPMonitorWrongNetwork(t *testing.T) {
+	vsp := newTestVSP(t)
+	vsp.info.Network = "mainnet"
+	monitor := newTestMonitor(vsp.URL)
+
+	if status := monitor.CheckHost(context.Background(), vsp.URL); status.Online || status.Err == nil {
+		t.Fatal("expected vsp on another network to be reported down")
+	}
+}
+
+func TestVSPServesNetwork(t *testing.T) {
+	tests := []struct {
+		vspNetwork, network string
+		want                bool
+	}{
+		{"testnet", dcrlibwallet.Testnet3, true},
+		{"mainnet", dcrlibwallet.Mainnet, true},
+		{"net", dcrlibwallet.Mainnet, false},
+		{"testnet", dcrlibwallet.Mainnet, false},
+		{"mainnet", dcrlibwallet.Testnet3, false},
+	}
+	for _, test := range tests {
+		if got := vspServesNetwork(test.vspNetwork, test.network); got != test.want {
+			t.Errorf("vspServesNetwork(%q, %q) = %v, want %v", test.vspNetwork, test.network, got, test.want)
+		}
+	}
+}
+
+func TestVSPMonitorStatuses(t *testing.T) {
+	vsp1, vsp2 := newTestVSP(t), newTestVSP(t)
+	vsp2.Close()
+	monitor := newTestMonitor(vsp1.URL, vsp2.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := 0
+	monitor.Run(ctx, time.Hour, func() {
+		updates++
+		cancel()
+	})
+	if updates != 1 {
+		t.Fatalf("expected 1 update, got %d", updates)
+	}
+
+	statuses := monitor.Statuses()
+	if len(statuses) != 2 || statuses[0].Host != vsp1.URL || statuses[1].Host != vsp2.URL {
+		t.Fatalf("expected statuses in host order, got %+v", statuses)
+	}
+	if !statuses[0].Online || statuses[1].Online {
+		t.Fatalf("expected only the first vsp online, got %v, %v", statuses[0].Online, statuses[1].Online)
+	}
+}
+
+// testConfig is an in-memory userConfig.
+type testConfig map[string][]byte
+
+func (c testConfig) ReadUserConfigValue(key string, valueOut interface{}) error {
+	if value, ok := c[key]; ok {
+		return json.Unmarshal(value, valueOut)
+	}
+	return nil
+}
+
+func (c testConfig) SaveUserConfigValue(key string, value interface{}) {
+	c[key], _ = json.Marshal(value)
+}
+
+func TestCustomVSPs(t *testing.T) {
+	vsp1, vsp2 := newTestVSP(t), newTestVSP(t)
+	config := make(testConfig)
+	monitor := NewVSPMonitor(http.DefaultClient, dcrlibwallet.Testnet3, func() []string { return customVSPs(config) })
+	ctx := context.Background()
+
+	for _, host := range []string{vsp1.URL, vsp2.URL + "/"} {
+		if err := addCustomVSP(ctx, http.DefaultClient, config, dcrlibwallet.Testnet3, host); err != nil {
+			t.Fatalf("unexpected error adding %s: %v", host, err)
+		}
+	}
+	if err := addCustomVSP(ctx, http.DefaultClient, config, dcrlibwallet.Testnet3, vsp1.URL); err == nil {
+		t.Fatal("expected an error adding a duplicate host")
+	}
+	if err := addCustomVSP(ctx, http.DefaultClient, config, dcrlibwallet.Mainnet, newTestVSP(t).URL); err == nil {
+		t.Fatal("expected an error adding a VSP of another network")
+	}
+
+	monitor.Check(ctx)
+	statuses := monitor.Statuses()
+	if len(statuses) != 2 || statuses[0].Host != vsp1.URL || statuses[1].Host != vsp2.URL {
+		t.Fatalf("expected both custom VSPs to be monitored, got %+v", statuses)
+	}
+
+	removeCustomVSP(config, vsp1.URL)
+	monitor.Check(ctx)
+	statuses = monitor.Statuses()
+	if len(statuses) != 1 || statuses[0].Host != vsp2.URL {
+		t.Fatalf("expected the removed VSP to no longer be monitored, got %+v", statuses)
+	}
+}
@@ -0,0 +1,225 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// maxVSPFee is the highest VSP fee, in atoms, paid when retrying a ticket's
+// fee payment. It matches the limit used by dcrlibwallet when purchasing
+// tickets.
+const maxVSPFee = 0.2e8
+
+// errTicketFeePaid is returned when moving a ticket whose fee was already
+// paid to its VSP.
+var errTicketFeePaid = errors.New("the ticket fee was already paid to its VSP")
+
+// VSPTicketStatus is the status of a ticket at the VSP it is registered
+// with.
+type VSPTicketStatus struct {
+	Hash      string
+	VSP       string
+	FeeTxHash string
+	FeeStatus dcrlibwallet.VSPFeeStatus
+	// Confirmed is true if the VSP has confirmed the ticket. It is only
+	// meaningful if Fetched is true.
+	Confirmed bool
+	// Fetched is true if the status was obtained from the VSP's ticketstatus
+	// endpoint rather than from the wallet database.
+	Fetched bool
+	Voted   bool
+	// Err is the error returned by the VSP, if any.
+	Err error
+}
+
+// HasProblem returns true if the ticket's fee payment failed or the VSP
+// hasn't registered a ticket whose fee is confirmed.
+func (s *VSPTicketStatus) HasProblem() bool {
+	if s.Voted {
+		return false
+	}
+	if s.FeeStatus == dcrlibwallet.VSPFeeProcessErrored {
+		return true
+	}
+	return s.Fetched && !s.Confirmed && s.FeeStatus == dcrlibwallet.VSPFeeProcessConfirmed
+}
+
+// ReadVSPTicketStatus returns the VSP status of the ticket with the specified
+// hash as saved in the wallet database.
+func ReadVSPTicketStatus(ctx context.Context, w *dcrlibwallet.Wallet, hash string) (*VSPTicketStatus, error) {
+	ticketHash, err := chainhash.NewHashFromStr(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketInfo, err := w.Internal().VSPTicketInfo(ctx, ticketHash)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &VSPTicketStatus{
+		Hash:      hash,
+		VSP:       ticketInfo.Host,
+		FeeTxHash: ticketInfo.FeeHash.String(),
+		FeeStatus: dcrlibwallet.VSPFeeStatus(ticketInfo.FeeTxStatus),
+	}
+
+	spender, err := w.TicketSpender(hash)
+	if err != nil {
+		return nil, err
+	}
+	status.Voted = spender != nil && spender.Type == dcrlibwallet.TxTypeVote
+	return status, nil
+}
+
+// fetchVSPTicketStatus queries the ticketstatus endpoint of the VSP the
+// ticket is registered with. The request is signed with the ticket's
+// commitment address so the wallet must be unlocked.
+func fetchVSPTicketStatus(ctx context.Context, w *dcrlibwallet.Wallet, hash string) (*VSPTicketStatus, error) {
+	status, err := ReadVSPTicketStatus(ctx, w, hash)
+	if err != nil || status.Voted {
+		return status, err
+	}
+
+	ticketHash, err := chainhash.NewHashFromStr(hash)
+	if err != nil {
+		return nil, err
+	}
+	ticketInfo, err := w.Internal().VSPTicketInfo(ctx, ticketHash)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := w.VSPClient(ticketInfo.Host, ticketInfo.PubKey)
+	if err != nil {
+		status.Err = err
+		return status, nil
+	}
+	vspStatus, err := client.TicketStatus(ctx, ticketHash)
+	if err != nil {
+		status.Err = err
+		return status, nil
+	}
+
+	setFetchedStatus(status, vspStatus.TicketConfirmed, vspStatus.FeeTxHash, vspStatus.FeeTxStatus)
+	return status, nil
+}
+
+// setFetchedStatus updates status with the reply of the ticketstatus
+// endpoint of the VSP.
+func setFetchedStatus(status *VSPTicketStatus, confirmed bool, feeTxHash, feeTxStatus string) {
+	status.Fetched = true
+	status.Confirmed = confirmed
+	status.FeeTxHash = feeTxHash
+	switch feeTxStatus {
+	case "received":
+		status.FeeStatus = dcrlibwallet.VSPFeeProcessStarted
+	case "broadcast":
+		status.FeeStatus = dcrlibwallet.VSPFeeProcessPaid
+	case "confirmed":
+		status.FeeStatus = dcrlibwallet.VSPFeeProcessConfirmed
+	default:
+		status.FeeStatus = dcrlibwallet.VSPFeeProcessErrored
+	}
+}
+
+// FetchVSPTicketStatuses unlocks the wallet and queries the VSP status of
+// each ticket in hashes. Tickets that are not registered with a VSP are
+// skipped.
+func FetchVSPTicketStatuses(ctx context.Context, w *dcrlibwallet.Wallet, hashes []string, passphrase []byte) (map[string]*VSPTicketStatus, error) {
+	if err := w.UnlockWallet(passphrase); err != nil {
+		return nil, err
+	}
+	defer w.LockWallet()
+
+	statuses := make(map[string]*VSPTicketStatus, len(hashes))
+	for _, hash := range hashes {
+		status, err := fetchVSPTicketStatus(ctx, w, hash)
+		if err != nil {
+			log.Debugf("[%d] No VSP status for ticket %s: %v", w.ID, hash, err)
+			continue
+		}
+		statuses[hash] = status
+	}
+	return statuses, nil
+}
+
+// ticketFeeAccount returns the account that pays the VSP fees of the
+// wallet, the ticket buyer's purchase account if set or the default
+// account.
+func ticketFeeAccount(w *dcrlibwallet.Wallet) uint32 {
+	if w.TicketBuyerConfigIsSet() {
+		return uint32(w.AutoTicketsBuyerConfig().PurchaseAccount)
+	}
+	return dcrlibwallet.DefaultAccountNum
+}
+
+// RetryTicketFee pays the fee of the ticket with the specified hash to the
+// VSP it is registered with, from the purchase account, and returns the
+// status of the ticket at the VSP once the fee is submitted.
+func RetryTicketFee(ctx context.Context, w *dcrlibwallet.Wallet, hash string, passphrase []byte) (*VSPTicketStatus, error) {
+	ticketHash, err := chainhash.NewHashFromStr(hash)
+	if err != nil {
+		return nil, err
+	}
+	ticketInfo, err := w.Internal().VSPTicketInfo(ctx, ticketHash)
+	if err != nil {
+		return nil, err
+	}
+	return processTicket(ctx, w, ticketHash, ticketInfo.Host, ticketInfo.PubKey, passphrase)
+}
+
+// ChangeTicketVSP registers the ticket with the specified hash with another
+// VSP, pays its fee from the purchase account and returns the status of the
+// ticket at the new VSP. Tickets whose fee was paid to their VSP can't be
+// moved.
+func ChangeTicketVSP(ctx context.Context, w *dcrlibwallet.Wallet, hash, vspHost string, vspPubKey []byte, passphrase []byte) (*VSPTicketStatus, error) {
+	ticketHash, err := chainhash.NewHashFromStr(hash)
+	if err != nil {
+		return nil, err
+	}
+	status, err := ReadVSPTicketStatus(ctx, w, hash)
+	if err != nil {
+		return nil, err
+	}
+	if status.FeeStatus == dcrlibwallet.VSPFeeProcessPaid || status.FeeStatus == dcrlibwallet.VSPFeeProcessConfirmed {
+		return nil, errTicketFeePaid
+	}
+	return processTicket(ctx, w, ticketHash, vspHost, vspPubKey, passphrase)
+}
+
+func processTicket(ctx context.Context, w *dcrlibwallet.Wallet, ticketHash *chainhash.Hash, vspHost string, vspPubKey []byte,
+	passphrase []byte) (*VSPTicketStatus, error) {
+
+	client, err := w.VSPClient(vspHost, vspPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("VSP Server instance failed to start: %v", err)
+	}
+
+	if err = w.UnlockWallet(passphrase); err != nil {
+		return nil, err
+	}
+	defer w.LockWallet()
+
+	// The policy type of the VSP client isn't exported by dcrlibwallet, a
+	// struct with the same fields is assignable to it.
+	account := ticketFeeAccount(w)
+	policy := struct {
+		MaxFee     dcrutil.Amount
+		ChangeAcct uint32
+		FeeAcct    uint32
+	}{
+		MaxFee:     maxVSPFee,
+		ChangeAcct: account,
+		FeeAcct:    account,
+	}
+	if err = client.ProcessTicket(ctx, ticketHash, policy); err != nil {
+		return nil, err
+	}
+	return fetchVSPTicketStatus(ctx, w, ticketHash.String())
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestVSPTicketStatusHasProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		status VSPTicketStatus
+		want   bool
+	}{
+		{"fee errored", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessErrored}, true},
+		{"fee started", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessStarted}, false},
+		{"fee paid", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessPaid}, false},
+		{"fee confirmed in wallet", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessConfirmed}, false},
+		{"confirmed by VSP", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessConfirmed, Fetched: true, Confirmed: true}, false},
+		{"not confirmed by VSP", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessConfirmed, Fetched: true}, true},
+		{"fee paid not confirmed by VSP", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessPaid, Fetched: true}, false},
+		{"voted", VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessErrored, Voted: true}, false},
+	}
+	for _, test := range tests {
+		if got := test.status.HasProblem(); got != test.want {
+			t.Errorf("%s: HasProblem() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSetFetchedStatus(t *testing.T) {
+	tests := []struct {
+		feeTxStatus string
+		want        dcrlibwallet.VSPFeeStatus
+	}{
+		{"received", dcrlibwallet.VSPFeeProcessStarted},
+		{"broadcast", dcrlibwallet.VSPFeeProcessPaid},
+		{"confirmed", dcrlibwallet.VSPFeeProcessConfirmed},
+		{"error", dcrlibwallet.VSPFeeProcessErrored},
+		{"", dcrlibwallet.VSPFeeProcessErrored},
+	}
+	for _, test := range tests {
+		status := &VSPTicketStatus{FeeStatus: dcrlibwallet.VSPFeeProcessPaid, FeeTxHash: "old"}
+		setFetchedStatus(status, true, "fee", test.feeTxStatus)
+		if status.FeeStatus != test.want {
+			t.Errorf("fee status %q mapped to %v, want %v", test.feeTxStatus, status.FeeStatus, test.want)
+		}
+		if !status.Fetched || !status.Confirmed || status.FeeTxHash != "fee" {
+			t.Errorf("fee status %q: expected the VSP reply to be recorded, got %+v", test.feeTxStatus, status)
+		}
+	}
+
+	// A ticket whose fee the VSP confirmed without registering it is a
+	// problem.
+	status := &VSPTicketStatus{}
+	setFetchedStatus(status, false, "fee", "confirmed")
+	if !status.HasProblem() {
+		t.Error("expected a confirmed fee of a ticket not confirmed by the VSP to be a problem")
+	}
+}
@@ -3,9 +3,11 @@
 package wallet
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/planetdecred/dcrlibwallet"
@@ -25,6 +27,27 @@ type Wallet struct {
 	version     string
 	logFile     string
 	startUpTime time.Time
+
+	ticketBuyersMu sync.Mutex
+	ticketBuyers   map[int]*RuleTicketBuyer
+
+	mixerSchedulersMu sync.Mutex
+	mixerSchedulers   map[int]*MixerScheduler
+
+	mixerCheckpointsMu sync.Mutex
+	mixerCheckpoints   map[int]context.CancelFunc
+
+	vspMonitorOnce sync.Once
+	vspMonitor     *VSPMonitor
+
+	dexPassMu sync.Mutex
+	dexPass   []byte
+
+	dexSwapMonitorOnce sync.Once
+	dexSwapMonitor     *DexSwapMonitor
+
+	dexNotifierOnce sync.Once
+	dexNotifier     *DexNotifier
 }
 
 // NewWallet initializies an new Wallet instance.
@@ -63,11 +86,7 @@ func (wal *Wallet) StartupTime() time.Time {
 }
 
 func (wal *Wallet) InitMultiWallet() error {
-	politeiaHost := dcrlibwallet.PoliteiaMainnetHost
-	if wal.Net == dcrlibwallet.Testnet3 {
-		politeiaHost = dcrlibwallet.PoliteiaTestnetHost
-	}
-	multiWal, err := dcrlibwallet.NewMultiWallet(wal.Root, "bdb", wal.Net, politeiaHost)
+	multiWal, err := dcrlibwallet.NewMultiWallet(wal.Root, "bdb", wal.Net, wal.politeiaHost())
 	if err != nil {
 		return err
 	}
@@ -76,6 +95,14 @@ func (wal *Wallet) InitMultiWallet() error {
 	return nil
 }
 
+// politeiaHost returns the politeia API host of the wallet's network.
+func (wal *Wallet) politeiaHost() string {
+	if wal.Net == dcrlibwallet.Testnet3 {
+		return dcrlibwallet.PoliteiaTestnetHost
+	}
+	return dcrlibwallet.PoliteiaMainnetHost
+}
+
 func (wal *Wallet) hdPrefix() string {
 	switch wal.Net {
 	case dcrlibwallet.Testnet3: